@@ -81,8 +81,10 @@ func main() {
 		newDeletePositionCmd(store),
 		newListStocksCmd(store),
 		newSetStockPriceCmd(store),
+		newSetStockVolatilityCmd(store),
 		newWorldCmd(store),
 		newSetWorldCmd(store),
+		newSeedDemoSocialCmd(store),
 		newSelectCmd(store),
 	)
 
@@ -546,6 +548,34 @@ func newDeletePositionCmd(store *adminStore) *cobra.Command {
 	}
 }
 
+func newSeedDemoSocialCmd(store *adminStore) *cobra.Command {
+	return &cobra.Command{
+		Use:   "seed-demo-social <user-id> [bots]",
+		Short: "Seed demo bot players that mutually follow a player (requires STANKS_DEV_SEED on the API)",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bots := 0
+			if len(args) > 1 {
+				v, err := strconv.Atoi(strings.TrimSpace(args[1]))
+				if err != nil || v <= 0 {
+					return fmt.Errorf("invalid bot count")
+				}
+				bots = v
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			rows, err := store.SeedDemoSocial(ctx, strings.TrimSpace(args[0]), bots)
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				fmt.Printf("%-14s %-14s invite=%s balance=%s stonky positions+%d\n", row.UserID, row.Username, row.InviteCode, formatMicros(row.BalanceMicros), row.PositionsAdded)
+			}
+			return nil
+		},
+	}
+}
+
 func newListStocksCmd(store *adminStore) *cobra.Command {
 	return &cobra.Command{
 		Use:   "stocks",
@@ -585,6 +615,24 @@ func newSetStockPriceCmd(store *adminStore) *cobra.Command {
 	}
 }
 
+func newSetStockVolatilityCmd(store *adminStore) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-stock-vol <symbol> <calm|normal|wild>",
+		Short: "Set a stock's volatility tier",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			row, err := store.SetStockVolatility(ctx, strings.ToUpper(strings.TrimSpace(args[0])), strings.ToLower(strings.TrimSpace(args[1])))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Stock %s volatility -> %s\n", row.Symbol, game.VolatilityTierName(row.VolatilityBps))
+			return nil
+		},
+	}
+}
+
 func newWorldCmd(store *adminStore) *cobra.Command {
 	return &cobra.Command{
 		Use:   "world",
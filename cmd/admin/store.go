@@ -199,6 +199,14 @@ func (s *adminStore) SetStockPrice(ctx context.Context, symbol string, priceMicr
 	return out, err
 }
 
+func (s *adminStore) SetStockVolatility(ctx context.Context, symbol, tier string) (stockRow, error) {
+	var out stockRow
+	err := s.jsonRequest(ctx, http.MethodPost, "/v1/admin/stocks/"+url.PathEscape(strings.ToUpper(symbol))+"/volatility", map[string]any{
+		"tier": tier,
+	}, &out)
+	return out, err
+}
+
 func (s *adminStore) WorldState(ctx context.Context) (worldRow, error) {
 	var out worldRow
 	err := s.jsonRequest(ctx, http.MethodGet, "/v1/admin/world", nil, &out)
@@ -211,6 +219,24 @@ func (s *adminStore) SetWorldState(ctx context.Context, in worldRow) (worldRow,
 	return out, err
 }
 
+type demoBotRow struct {
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	InviteCode     string `json:"invite_code"`
+	BalanceMicros  int64  `json:"balance_micros"`
+	PositionsAdded int    `json:"positions_added"`
+}
+
+func (s *adminStore) SeedDemoSocial(ctx context.Context, userID string, bots int) ([]demoBotRow, error) {
+	var out struct {
+		Bots []demoBotRow `json:"bots"`
+	}
+	err := s.jsonRequest(ctx, http.MethodPost, "/v1/admin/players/"+url.PathEscape(strings.TrimSpace(userID))+"/demo-social", map[string]any{
+		"bots": bots,
+	}, &out)
+	return out.Bots, err
+}
+
 func (s *adminStore) jsonRequest(ctx context.Context, method, path string, in any, out any) error {
 	var body io.Reader
 	if in != nil {
@@ -440,14 +440,15 @@ func printStocks(rows []stockRow) {
 		fmt.Println("No stocks found.")
 		return
 	}
-	fmt.Printf("%-8s  %-22s  %-12s  %-12s  %-6s\n", "SYMBOL", "NAME", "PRICE", "ANCHOR", "PUBLIC")
+	fmt.Printf("%-8s  %-22s  %-12s  %-12s  %-6s  %-6s\n", "SYMBOL", "NAME", "PRICE", "ANCHOR", "PUBLIC", "VOL")
 	for _, row := range rows {
-		fmt.Printf("%-8s  %-22s  %12s  %12s  %-6t\n",
+		fmt.Printf("%-8s  %-22s  %12s  %12s  %-6t  %-6s\n",
 			row.Symbol,
 			truncate(row.DisplayName, 22),
 			formatMicros(row.CurrentPriceMicros),
 			formatMicros(row.AnchorPriceMicros),
 			row.ListedPublic,
+			game.VolatilityTierName(row.VolatilityBps),
 		)
 	}
 }
@@ -28,6 +28,10 @@ func main() {
 	}
 
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	if err := game.ConfigureSymbolFormat(cfg.Symbol.MinLen, cfg.Symbol.MaxLen, cfg.Symbol.AllowDigits); err != nil {
+		logger.Error("invalid symbol format", "err", err)
+		os.Exit(1)
+	}
 	pool, err := db.Connect(ctx, cfg.DatabaseURL)
 	if err != nil {
 		logger.Error("db connect failed", "err", err)
@@ -41,6 +45,16 @@ func main() {
 
 	authClient := auth.NewClient(pool)
 	gameSvc := game.NewService(pool, logger)
+	if cfg.DatabaseReplica != "" {
+		replica, err := db.ConnectReadOnly(ctx, cfg.DatabaseReplica)
+		if err != nil {
+			logger.Error("db replica connect failed", "err", err)
+			os.Exit(1)
+		}
+		defer replica.Close()
+		gameSvc.UseReadReplica(replica)
+		logger.Info("read replica enabled for read-only views")
+	}
 	adminSvc := admin.NewService(pool)
 
 	seasonID, err := gameSvc.ActiveSeasonID(ctx)
@@ -60,6 +74,7 @@ func main() {
 	}
 
 	server := api.New(cfg, logger, authClient, gameSvc, adminSvc)
+	go server.RunLeaderboardStream(ctx)
 	httpServer := &http.Server{
 		Addr:              cfg.Addr,
 		Handler:           server.Handler(),
@@ -55,6 +55,10 @@ func main() {
 
 	runOnce := strings.EqualFold(strings.TrimSpace(os.Getenv("STANKS_WORKER_RUN_ONCE")), "true")
 	if runOnce {
+		if state, err := svc.MarketState(ctx, seasonID); err == nil && !state.Open {
+			logger.Info("market closed, skipping run-once tick", "season_id", seasonID)
+			return
+		}
 		stocksThisTick := cfg.NewStocksPerTick
 		if cfg.NewStocksEvery > 0 {
 			stocksThisTick = 0
@@ -83,6 +87,15 @@ func main() {
 				logger.Error("season read failed", "err", err)
 				continue
 			}
+			state, err := svc.MarketState(ctx, seasonID)
+			if err != nil {
+				logger.Error("market state read failed", "err", err)
+				continue
+			}
+			if !state.Open {
+				logger.Info("market closed, skipping tick", "season_id", seasonID)
+				continue
+			}
 			stocksThisTick := 0
 			if cfg.NewStocksPerTick > 0 {
 				if cfg.NewStocksEvery <= 0 {
@@ -99,6 +112,19 @@ func main() {
 				lastStocksSpawnAt = time.Now()
 			}
 			logger.Info("market tick complete", "season_id", seasonID)
+			nextSeasonID, err := svc.CompleteSeasonIfTickLimit(ctx, seasonID)
+			if err != nil {
+				logger.Error("season tick limit check failed", "err", err)
+				continue
+			}
+			if nextSeasonID > 0 {
+				logger.Info("season completed on tick limit", "season_id", seasonID, "next_season_id", nextSeasonID)
+				if cfg.StartupSeedStocks {
+					if err := svc.SeedDefaults(ctx, nextSeasonID); err != nil {
+						logger.Error("seed defaults for next season failed", "err", err)
+					}
+				}
+			}
 		}
 	}
 }
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"stanks/internal/game"
+)
+
+// outputFormat selects how renderers that support --csv print.
+type outputFormat int
+
+const (
+	formatTable outputFormat = iota
+	formatCSV
+)
+
+// outputFormatFor maps a --csv flag to a format. CSV is meant for files
+// and spreadsheets, so it also turns colors off for anything else printed.
+func outputFormatFor(asCSV bool) outputFormat {
+	if !asCSV {
+		return formatTable
+	}
+	color.NoColor = true
+	return formatCSV
+}
+
+// writeCSV prints a header and rows as CSV on stdout. Cells must be plain
+// values (see csvMicros and csvShares), never colorized or comma-grouped
+// display strings.
+func writeCSV(header []string, rows [][]string) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+// csvMicros renders micros as a plain decimal stonky amount, e.g. -1234.5.
+func csvMicros(v int64) string {
+	sign := ""
+	u := uint64(v)
+	if v < 0 {
+		sign = "-"
+		u = uint64(-(v + 1)) + 1
+	}
+	per := uint64(game.MicrosPerStonky)
+	whole, frac := u/per, u%per
+	if frac == 0 {
+		return sign + strconv.FormatUint(whole, 10)
+	}
+	return sign + strconv.FormatUint(whole, 10) + "." + strings.TrimRight(fmt.Sprintf("%06d", frac), "0")
+}
+
+// csvShares renders share or fund units as a plain decimal amount.
+func csvShares(units int64) string {
+	return strconv.FormatFloat(game.UnitsToShares(units), 'f', -1, 64)
+}
+
+// csvTime renders a timestamp as RFC 3339 in the display zone.
+func csvTime(t time.Time) string {
+	return t.In(displayLocation).Format(time.RFC3339)
+}
@@ -3,14 +3,20 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math"
+	"net"
+	"net/url"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
+	"stanks/internal/buildinfo"
 	cl "stanks/internal/cli"
 	"stanks/internal/config"
 	"stanks/internal/db"
@@ -38,6 +44,12 @@ func main() {
 
 	cfg := config.LoadCLIFromEnv()
 	apiBase := cfg.APIBaseURL
+	if err := game.ConfigureSymbolFormat(cfg.Symbol.MinLen, cfg.Symbol.MaxLen, cfg.Symbol.AllowDigits); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v; using default symbol format\n", err)
+	}
+	syncq.MaxSize = cfg.SyncQueueMax
+	sessionWarnWithin = time.Duration(cfg.SessionWarnMinutes) * time.Minute
+	configureDisplayPrecision(cfg.DisplayDecimals, cfg.PennyDecimals)
 
 	root := &cobra.Command{
 		Use:           "stk",
@@ -45,22 +57,45 @@ func main() {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 	}
+	quiet := false
+	asJSON := false
+	timezone := cfg.Timezone
+	root.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Disable colors and section banners for pipe-friendly output")
+	root.PersistentFlags().BoolVar(&asJSON, "json", false, "Print raw API JSON instead of tables; errors become {\"error\": ...}")
+	root.PersistentFlags().BoolVar(&noPager, "no-pager", false, "Print long lists straight to the terminal instead of through $PAGER")
+	root.PersistentFlags().StringVar(&timezone, "timezone", timezone, "Zone for printed timestamps: local, UTC, or an IANA name like Europe/Berlin (env STK_TIMEZONE)")
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		configureOutput(quiet, asJSON)
+		return configureTimezone(timezone)
+	}
 
 	root.AddCommand(
 		newTUICmd(&apiBase),
 		newSignupCmd(&apiBase),
 		newLoginCmd(&apiBase),
 		newLogoutCmd(),
+		newWhoamiCmd(),
 		newDashCmd(&apiBase),
 		newWorldCmd(&apiBase),
+		newNewsCmd(&apiBase),
+		newSeasonCmd(&apiBase),
+		newMarketCmd(&apiBase),
 		newRushCmd(&apiBase),
 		newStakesCmd(&apiBase),
+		newCostsCmd(&apiBase),
+		newAutomationCmd(&apiBase),
 		newSyncCmd(&apiBase),
+		newQueueCmd(),
 		newStocksCmd(&apiBase),
+		newHistoryCmd(&apiBase),
+		newAlertsCmd(&apiBase),
 		newFundsCmd(&apiBase),
+		newTradeCmd(&apiBase),
 		newBusinessCmd(&apiBase),
 		newLeaderboardCmd(&apiBase),
 		newFriendsCmd(&apiBase),
+		newDoctorCmd(&apiBase),
+		newVersionCmd(&apiBase),
 	)
 
 	root.RunE = func(cmd *cobra.Command, args []string) error {
@@ -70,15 +105,126 @@ func main() {
 		return cmd.Help()
 	}
 
-	if err := root.Execute(); err != nil {
+	ctx, stop := interruptContext()
+	defer stop()
+	if err := root.ExecuteContext(ctx); err != nil {
+		if ctx.Err() != nil && errors.Is(err, context.Canceled) {
+			msg := cancelledMessage(err)
+			reportError(msg, msg)
+			os.Exit(130)
+		}
 		slog.Error("stk command failed", "err", err)
-		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		reportError("error: "+err.Error(), err.Error())
 		os.Exit(1)
 	}
 }
 
+// reportError prints a fatal error as text on stderr or, under --json, as
+// {"error": msg} on stdout so scripts read a single stream.
+func reportError(text, msg string) {
+	if jsonOutput {
+		_ = printJSON(map[string]string{"error": msg})
+		return
+	}
+	fmt.Fprintln(os.Stderr, text)
+}
+
+// interruptGrace is how long a command gets to wind down after Ctrl-C
+// before stk exits anyway (e.g. while blocked on a prompt).
+const interruptGrace = 2 * time.Second
+
+// interruptContext is cancelled by Ctrl-C or SIGTERM, which aborts any
+// in-flight request. A second signal, or a command that does not return
+// within interruptGrace, exits immediately.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+		time.Sleep(interruptGrace)
+		fmt.Fprintln(os.Stderr, "cancelled")
+		os.Exit(130)
+	}()
+	return ctx, stop
+}
+
+// unknownStatusError is a write cancelled after it may have reached the
+// server, so whether it took effect is unknown.
+type unknownStatusError struct {
+	cmd syncq.Command
+	err error
+}
+
+func (e *unknownStatusError) Error() string {
+	if e.cmd.Path == "/v1/orders" {
+		return "cancelled: order status unknown, check with `stk history`"
+	}
+	return fmt.Sprintf("cancelled: %s %s status unknown, check before retrying", e.cmd.Method, e.cmd.Path)
+}
+
+func (e *unknownStatusError) Unwrap() error { return e.err }
+
+// cancelledMessage is what stk prints for a command stopped by Ctrl-C.
+func cancelledMessage(err error) string {
+	var unknown *unknownStatusError
+	if errors.As(err, &unknown) {
+		return unknown.Error()
+	}
+	return "cancelled"
+}
+
+// sessionWarnWithin is how close to token expiry loadSession starts warning.
+var sessionWarnWithin time.Duration
+
+// loadSession loads the saved session and checks the token's exp claim so an
+// expired login surfaces as a clear message instead of a raw 401.
+func loadSession() (cl.Session, error) {
+	sess, err := cl.LoadSession()
+	if err != nil {
+		return sess, err
+	}
+	left, err := cl.SessionTimeLeft(sess, time.Now())
+	if errors.Is(err, cl.ErrSessionExpired) {
+		if strings.TrimSpace(sess.RefreshToken) != "" {
+			// The client refreshes on the first 401.
+			return sess, nil
+		}
+		return cl.Session{}, err
+	}
+	if err == nil && left < sessionWarnWithin {
+		printWarn(fmt.Sprintf("Session expires in %s; run `stk login` to avoid interruptions.", left.Round(time.Second)))
+	}
+	return sess, nil
+}
+
 func newClient(apiBase *string) *cl.Client {
-	return cl.NewClient(strings.TrimRight(strings.TrimSpace(*apiBase), "/"))
+	client := cl.NewClient(strings.TrimRight(strings.TrimSpace(*apiBase), "/"))
+	client.RefreshAccessToken = func(ctx context.Context) (string, error) {
+		return refreshSession(ctx, client)
+	}
+	return client
+}
+
+// refreshSession exchanges the saved refresh token for a new session and
+// saves it, returning the new access token.
+func refreshSession(ctx context.Context, client *cl.Client) (string, error) {
+	sess, err := cl.LoadSession()
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(sess.RefreshToken) == "" {
+		return "", errors.New("no refresh token saved")
+	}
+	fresh, err := client.Refresh(ctx, sess.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+	sess.AccessToken = fresh.AccessToken
+	sess.RefreshToken = fresh.RefreshToken
+	if err := cl.SaveSession(sess); err != nil {
+		return "", fmt.Errorf("save refreshed session: %w", err)
+	}
+	return fresh.AccessToken, nil
 }
 
 func newSignupCmd(apiBase *string) *cobra.Command {
@@ -98,11 +244,15 @@ func newSignupCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			inviteCode, err := promptOptional("Invite code (required on invite-only servers)")
+			if err != nil {
+				return err
+			}
 
 			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 			defer cancel()
 			client := newClient(apiBase)
-			session, err := client.Signup(ctx, email, password, username)
+			session, err := client.Signup(ctx, email, password, username, strings.ToUpper(inviteCode))
 			if err != nil {
 				return err
 			}
@@ -172,12 +322,27 @@ func newLogoutCmd() *cobra.Command {
 	}
 }
 
-func newDashCmd(apiBase *string) *cobra.Command {
+func newWhoamiCmd() *cobra.Command {
 	return &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the saved login and token expiry",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := cl.LoadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			return renderWhoami(sess, time.Now())
+		},
+	}
+}
+
+func newDashCmd(apiBase *string) *cobra.Command {
+	var asCSV bool
+	cmd := &cobra.Command{
 		Use:   "dash",
 		Short: "Show your dashboard",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -188,20 +353,41 @@ func newDashCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return renderDashboard(out)
+			return render(out, renderDashboard(outputFormatFor(asCSV)))
 		},
 	}
+	cmd.Flags().BoolVar(&asCSV, "csv", false, "Print your stock positions as CSV instead of the dashboard")
+	return cmd
 }
 
-func newSyncCmd(apiBase *string) *cobra.Command {
+func newTradeCmd(apiBase *string) *cobra.Command {
 	return &cobra.Command{
-		Use:   "sync",
-		Short: "Replay locally queued offline writes to cloud",
+		Use:   "trade",
+		Short: "Guided menu for trading stocks, funds, or businesses",
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			market, err := promptChoice("Trade", []string{"stocks", "funds", "business"}, "stocks")
 			if err != nil {
-				return fmt.Errorf("login required: %w", err)
+				return err
+			}
+			switch market {
+			case "funds":
+				return runFundsGuidedFlow(cmd, apiBase)
+			case "business":
+				return runBusinessGuidedFlow(cmd, apiBase)
+			default:
+				return runStocksGuidedFlow(cmd, apiBase)
 			}
+		},
+	}
+}
+
+func newSyncCmd(apiBase *string) *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Replay locally queued offline writes to cloud",
+		RunE: func(cmd *cobra.Command, args []string) error {
 			queue, err := syncq.Load()
 			if err != nil {
 				return err
@@ -210,28 +396,174 @@ func newSyncCmd(apiBase *string) *cobra.Command {
 				printInfo("Sync queue is empty.")
 				return nil
 			}
+			batch := queue
+			if len(batch) > syncq.ReplayBatchSize {
+				batch = queue[:syncq.ReplayBatchSize]
+			}
+			if dryRun {
+				renderSyncDryRun(batch, len(queue))
+				return nil
+			}
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
 			client := newClient(apiBase)
 			ctx, cancel := context.WithTimeout(cmd.Context(), 60*time.Second)
 			defer cancel()
 
+			progress := newSyncProgress(len(batch))
 			remaining := make([]syncq.Command, 0, len(queue))
 			success := 0
-			for _, q := range queue {
+			for i, q := range batch {
+				progress.step(i+1, q)
 				_, err := client.Do(ctx, q.Method, q.Path, sess.AccessToken, q.Body, q.IdempotencyKey)
 				if err != nil {
 					remaining = append(remaining, q)
+					progress.clear()
 					printError(fmt.Sprintf("Sync failed for %s %s: %v", q.Method, q.Path, err))
 					continue
 				}
 				success++
 			}
+			progress.clear()
+			remaining = append(remaining, queue[len(batch):]...)
 			if err := syncq.Save(remaining); err != nil {
 				return err
 			}
 			printSuccess(fmt.Sprintf("Sync complete: replayed=%d remaining=%d", success, len(remaining)))
+			if len(queue) > len(batch) {
+				printInfo(fmt.Sprintf("Replayed in a batch of %d; run `stk sync` again for the rest.", syncq.ReplayBatchSize))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the queued commands the next sync would replay without sending them")
+	return cmd
+}
+
+func newQueueCmd() *cobra.Command {
+	queue := &cobra.Command{
+		Use:   "queue",
+		Short: "Inspect or clear offline writes waiting for `stk sync`",
+	}
+	queue.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List queued offline writes",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			commands, err := syncq.Load()
+			if err != nil {
+				return err
+			}
+			renderQueueList(commands)
+			return nil
+		},
+	})
+	var yes bool
+	clearCmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Drop every queued offline write without sending it",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			commands, err := syncq.Load()
+			if err != nil {
+				return err
+			}
+			if len(commands) == 0 {
+				printInfo("Sync queue is empty.")
+				return nil
+			}
+			if !yes {
+				printWarn(fmt.Sprintf("This drops %d queued write(s) without sending them.", len(commands)))
+				ok, err := promptConfirm("Clear the sync queue", false)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return fmt.Errorf("cancelled")
+				}
+			}
+			if err := syncq.Clear(); err != nil {
+				return err
+			}
+			printSuccess(fmt.Sprintf("Cleared %d queued command(s).", len(commands)))
 			return nil
 		},
 	}
+	clearCmd.Flags().BoolVar(&yes, "yes", false, "Skip the confirmation prompt")
+	queue.AddCommand(clearCmd)
+	return queue
+}
+
+func newMarketCmd(apiBase *string) *cobra.Command {
+	market := &cobra.Command{
+		Use:   "market",
+		Short: "Market-wide history",
+	}
+	market.AddCommand(&cobra.Command{
+		Use:   "regimes",
+		Short: "Show this season's bull, bear, and neutral periods",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			client := newClient(apiBase)
+			out, err := client.RegimeHistory(ctx, sess.AccessToken)
+			if err != nil {
+				return err
+			}
+			return render(out, renderRegimeHistory)
+		},
+	})
+	return market
+}
+
+func newSeasonCmd(apiBase *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "season",
+		Short: "Show the active season's schedule and rules (works without login)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, _ := loadSession()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			client := newClient(apiBase)
+			out, err := client.ActiveSeason(ctx, sess.AccessToken)
+			if err != nil {
+				return err
+			}
+			return render(out, renderSeason)
+		},
+	}
+}
+
+func newNewsCmd(apiBase *string) *cobra.Command {
+	var limit int
+	cmd := &cobra.Command{
+		Use:   "news",
+		Short: "Show the season's news feed (works without login)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit <= 0 || limit > game.MaxNewsLimit {
+				return fmt.Errorf("--limit must be between 1 and %d", game.MaxNewsLimit)
+			}
+			sess, _ := loadSession()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			client := newClient(apiBase)
+			out, err := client.News(ctx, sess.AccessToken, limit)
+			if err != nil {
+				return err
+			}
+			return withPager(func() error { return render(out, renderNews) })
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", game.DefaultNewsLimit, "Number of entries to show")
+	return cmd
 }
 
 func newWorldCmd(apiBase *string) *cobra.Command {
@@ -239,7 +571,7 @@ func newWorldCmd(apiBase *string) *cobra.Command {
 		Use:   "world",
 		Short: "Show the political climate, catalyst, and global markets",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -250,7 +582,50 @@ func newWorldCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return renderWorld(out)
+			return render(out, renderWorld)
+		},
+	}
+}
+
+func newAutomationCmd(apiBase *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "automation",
+		Short: "Show automated operations skipped for lack of funds",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			client := newClient(apiBase)
+			out, err := client.AutomationSkips(ctx, sess.AccessToken)
+			if err != nil {
+				return err
+			}
+			return render(out, renderAutomationSkips)
+		},
+	}
+}
+
+func newCostsCmd(apiBase *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "costs",
+		Short: "Show fees, interest, and business losses paid this season",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			client := newClient(apiBase)
+			out, err := client.MyCosts(ctx, sess.AccessToken)
+			if err != nil {
+				return err
+			}
+			return render(out, renderCosts)
 		},
 	}
 }
@@ -260,7 +635,7 @@ func newRushCmd(apiBase *string) *cobra.Command {
 		Use:   "rush",
 		Short: "Play the high-volatility streak loop",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -271,7 +646,7 @@ func newRushCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return renderRushStatus(out)
+			return render(out, renderRushStatus)
 		},
 	}
 	rush.AddCommand(&cobra.Command{
@@ -279,7 +654,7 @@ func newRushCmd(apiBase *string) *cobra.Command {
 		Short: "Put stonky on the line for streaks and vault drops",
 		Args:  cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -311,7 +686,7 @@ func newRushCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return renderRushPlay(out)
+			return render(out, renderRushPlay)
 		},
 	})
 	return rush
@@ -322,7 +697,7 @@ func newStakesCmd(apiBase *string) *cobra.Command {
 		Use:   "stakes",
 		Short: "View and transfer business stakes",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -333,7 +708,7 @@ func newStakesCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return renderStakes(out)
+			return render(out, renderStakes)
 		},
 	}
 	stakes.AddCommand(&cobra.Command{
@@ -341,7 +716,7 @@ func newStakesCmd(apiBase *string) *cobra.Command {
 		Short: "Give part of your company stake to another player",
 		Args:  cobra.MaximumNArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -378,7 +753,7 @@ func newStakesCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Gave %.2f%% of business %d to %s.", percent, businessID, username))
+			return render(out, renderSimpleOK(fmt.Sprintf("Gave %.2f%% of business %d to %s.", percent, businessID, username)))
 		},
 	})
 	return stakes
@@ -392,125 +767,608 @@ func newStocksCmd(apiBase *string) *cobra.Command {
 	}
 
 	stocks.AddCommand(newStocksListCmd(apiBase))
+	stocks.AddCommand(newStocksHoldingsCmd(apiBase))
+	stocks.AddCommand(newStocksRecentCmd(apiBase))
 	stocks.AddCommand(newStocksBuyCmd(apiBase))
 	stocks.AddCommand(newStocksSellCmd(apiBase))
 	stocks.AddCommand(newStocksCreateCmd(apiBase))
 	stocks.AddCommand(newStocksIPOCmd(apiBase))
+	stocks.AddCommand(newStocksCandlesCmd(apiBase))
+	stocks.AddCommand(newStocksChartCmd(apiBase))
+	stocks.AddCommand(newStocksPriorityCmd(apiBase))
+	stocks.AddCommand(newStocksStopCmd(apiBase))
+	stocks.AddCommand(newStocksOrdersCmd(apiBase))
+	stocks.AddCommand(newStocksWatchCmd(apiBase))
+	stocks.AddCommand(newStocksLiquidationOrderCmd(apiBase))
 
 	return stocks
 }
 
-func newStocksListCmd(apiBase *string) *cobra.Command {
+func newStocksHoldingsCmd(apiBase *string) *cobra.Command {
 	return &cobra.Command{
-		Use:   "list [all|SYMBOL]",
-		Short: "List stocks or inspect one stock",
-		Args:  cobra.MaximumNArgs(1),
+		Use:     "holdings",
+		Short:   "Show just your stock positions and their P/L",
+		Aliases: []string{"positions"},
+		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
 			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 			defer cancel()
 			client := newClient(apiBase)
-
-			if len(args) == 0 {
-				choice, err := promptChoice("View", []string{"market", "all", "symbol"}, "market")
-				if err != nil {
-					return err
-				}
-				switch choice {
-				case "market":
-					out, err := client.ListStocks(ctx, sess.AccessToken, false)
-					if err != nil {
-						return err
-					}
-					return renderStocksList(out)
-				case "all":
-					out, err := client.ListStocks(ctx, sess.AccessToken, true)
-					if err != nil {
-						return err
-					}
-					return renderStocksList(out)
-				default:
-					symbol, err := promptSymbol("Symbol")
-					if err != nil {
-						return err
-					}
-					out, err := client.StockDetail(ctx, sess.AccessToken, symbol)
-					if err != nil {
-						return err
-					}
-					return renderStockDetail(out)
-				}
+			out, err := client.Positions(ctx, sess.AccessToken)
+			if err != nil {
+				return err
 			}
+			return render(out, renderHoldings)
+		},
+	}
+}
 
-			arg := strings.ToUpper(strings.TrimSpace(args[0]))
-			if arg == "ALL" {
-				out, err := client.ListStocks(ctx, sess.AccessToken, true)
-				if err != nil {
-					return err
-				}
-				return renderStocksList(out)
+func newStocksRecentCmd(apiBase *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "recent [ticks]",
+		Short: "List stocks that IPO'd recently (default last 288 ticks)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				printInfo("Viewing as guest; run `stk login` to play.")
 			}
-			if arg == "MARKET" {
-				out, err := client.ListStocks(ctx, sess.AccessToken, false)
-				if err != nil {
-					return err
+			var ticks int64
+			if len(args) > 0 {
+				ticks, err = strconv.ParseInt(strings.TrimSpace(args[0]), 10, 64)
+				if err != nil || ticks <= 0 {
+					return fmt.Errorf("ticks must be a positive whole number")
 				}
-				return renderStocksList(out)
 			}
-			out, err := client.StockDetail(ctx, sess.AccessToken, arg)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			client := newClient(apiBase)
+			out, err := client.RecentIPOs(ctx, sess.AccessToken, ticks)
 			if err != nil {
 				return err
 			}
-			return renderStockDetail(out)
+			return render(out, renderRecentIPOs)
 		},
 	}
 }
 
-func newStocksBuyCmd(apiBase *string) *cobra.Command {
+func newStocksCandlesCmd(apiBase *string) *cobra.Command {
+	var bucket string
 	cmd := &cobra.Command{
-		Use:   "buy [symbol]",
-		Short: "Buy shares",
+		Use:   "candles [SYMBOL]",
+		Short: "Show OHLC candles for a stock",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
 			symbol, err := symbolFromArgsOrPrompt(args)
 			if err != nil {
 				return err
 			}
-			qty, err := promptFloat("Shares to buy", 0)
+			if _, err := game.ParseCandleBucket(bucket); err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			client := newClient(apiBase)
+			out, err := client.StockCandles(ctx, sess.AccessToken, symbol, bucket, 0)
 			if err != nil {
 				return err
 			}
-			return placeOrderCommand(cmd, apiBase, "buy", symbol, qty)
+			return render(out, renderStockCandles(bucket))
 		},
 	}
+	cmd.Flags().StringVar(&bucket, "bucket", "1h", "Candle width, e.g. 5m, 1h, 1d")
 	return cmd
 }
 
-func newStocksSellCmd(apiBase *string) *cobra.Command {
+func newStocksChartCmd(apiBase *string) *cobra.Command {
+	var interval string
+	var count int
 	cmd := &cobra.Command{
-		Use:   "sell [symbol]",
-		Short: "Sell shares",
+		Use:   "chart [SYMBOL]",
+		Short: "Draw an ASCII candlestick chart for a stock",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			symbol, err := symbolFromArgsOrPrompt(args)
+			sess, err := loadSession()
 			if err != nil {
-				return err
+				return fmt.Errorf("login required: %w", err)
+			}
+			symbol, err := symbolFromArgsOrPrompt(args)
+			if err != nil {
+				return err
+			}
+			if _, err := game.ParseCandleBucket(interval); err != nil {
+				return err
+			}
+			if count <= 0 {
+				return fmt.Errorf("--count must be > 0")
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			client := newClient(apiBase)
+			out, err := client.StockCandles(ctx, sess.AccessToken, symbol, interval, count)
+			if err != nil {
+				return err
+			}
+			return render(out, renderCandleChart(interval))
+		},
+	}
+	cmd.Flags().StringVar(&interval, "interval", "1h", "Candle width, e.g. 5m, 1h, 1d")
+	cmd.Flags().IntVar(&count, "count", 40, "Number of candles to draw")
+	return cmd
+}
+
+func newStocksPriorityCmd(apiBase *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "priority [SYMBOL] [priority]",
+		Short: "Set how early a holding is force-sold (-100 protect .. 100 sell first)",
+		Args:  cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			symbol, err := symbolFromArgsOrPrompt(args)
+			if err != nil {
+				return err
+			}
+			var priority int64
+			if len(args) >= 2 {
+				priority, err = strconv.ParseInt(strings.TrimSpace(args[1]), 10, 64)
+				if err != nil {
+					return fmt.Errorf("priority must be a whole number")
+				}
+			} else {
+				priority, err = promptInt64("Priority (-100 protect .. 100 sell first)", game.MinLiquidationPriority)
+				if err != nil {
+					return err
+				}
+			}
+			if priority < game.MinLiquidationPriority || priority > game.MaxLiquidationPriority {
+				return fmt.Errorf("priority must be between %d and %d", game.MinLiquidationPriority, game.MaxLiquidationPriority)
+			}
+			idem := uuid.NewString()
+			path := "/v1/stocks/" + url.PathEscape(symbol) + "/liquidation-priority"
+			body := map[string]any{"priority": priority}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			client := newClient(apiBase)
+			out, err := client.SetLiquidationPriority(ctx, sess.AccessToken, symbol, priority, idem)
+			if err != nil {
+				return queueOnNetworkError(err, syncq.Command{
+					Method:         "POST",
+					Path:           path,
+					Body:           body,
+					IdempotencyKey: idem,
+				})
+			}
+			return render(out, renderSimpleOK(fmt.Sprintf("%s liquidation priority set to %d.", symbol, priority)))
+		},
+	}
+}
+
+func newStocksStopCmd(apiBase *string) *cobra.Command {
+	var shares float64
+	cmd := &cobra.Command{
+		Use:   "stop [SYMBOL] [price]",
+		Short: "Sell a holding at market once its price falls below a trigger (0 clears)",
+		Args:  cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			symbol, err := symbolFromArgsOrPrompt(args)
+			if err != nil {
+				return err
+			}
+			var price float64
+			if len(args) >= 2 {
+				price, err = strconv.ParseFloat(strings.TrimSpace(args[1]), 64)
+				if err != nil || price < 0 {
+					return fmt.Errorf("price must be a number >= 0")
+				}
+			} else {
+				price, err = promptFloat("Trigger price (stonky, 0 clears)", 0)
+				if err != nil {
+					return err
+				}
+			}
+			var qtyUnits int64
+			if shares > 0 {
+				qtyUnits, err = game.SharesToUnits(shares)
+				if err != nil {
+					return err
+				}
+			}
+			triggerMicros := game.StonkyToMicros(price)
+			idem := uuid.NewString()
+			path := "/v1/positions/" + url.PathEscape(symbol) + "/stop"
+			body := map[string]any{"trigger_price_micros": triggerMicros, "quantity_units": qtyUnits}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			client := newClient(apiBase)
+			out, err := client.SetStopLoss(ctx, sess.AccessToken, symbol, triggerMicros, qtyUnits, idem)
+			if err != nil {
+				return queueOnNetworkError(err, syncq.Command{
+					Method:         "POST",
+					Path:           path,
+					Body:           body,
+					IdempotencyKey: idem,
+				})
+			}
+			if triggerMicros == 0 {
+				return render(out, renderSimpleOK(fmt.Sprintf("%s stop-loss cleared.", symbol)))
+			}
+			size := "the whole position"
+			if qtyUnits > 0 {
+				size = fmt.Sprintf("%.4f shares", game.UnitsToShares(qtyUnits))
+			}
+			return render(out, renderSimpleOK(fmt.Sprintf("%s stop-loss set: sells %s below %s stonky.", symbol, size, formatPrice(triggerMicros))))
+		},
+	}
+	cmd.Flags().Float64Var(&shares, "shares", 0, "Shares to sell when triggered (default: the whole position)")
+	return cmd
+}
+
+func newStocksOrdersCmd(apiBase *string) *cobra.Command {
+	var page, limit int
+	var asCSV bool
+	cmd := &cobra.Command{
+		Use:   "orders",
+		Short: "Show your order history with realized P/L, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if page < 1 {
+				return fmt.Errorf("page must be >= 1")
+			}
+			if limit < 1 || limit > game.MaxOrderPageSize {
+				return fmt.Errorf("limit must be between 1 and %d", game.MaxOrderPageSize)
+			}
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			client := newClient(apiBase)
+			out, err := client.ListOrders(ctx, sess.AccessToken, limit, (page-1)*limit)
+			if err != nil {
+				return err
+			}
+			format := outputFormatFor(asCSV)
+			if format == formatCSV {
+				return render(out, renderOrderHistory(format))
+			}
+			return withPager(func() error { return render(out, renderOrderHistory(format)) })
+		},
+	}
+	cmd.Flags().BoolVar(&asCSV, "csv", false, "Print the page as CSV")
+	cmd.Flags().IntVar(&page, "page", 1, "Page to show; 1 is the most recent trades")
+	cmd.Flags().IntVar(&limit, "limit", 20, "Orders per page")
+	return cmd
+}
+
+func newStocksWatchCmd(apiBase *string) *cobra.Command {
+	watch := &cobra.Command{
+		Use:   "watch",
+		Short: "Track symbols on a watchlist without owning them",
+	}
+	watch.AddCommand(&cobra.Command{
+		Use:   "add [symbol]",
+		Short: "Watch a stock from its current price",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			symbol, err := symbolFromArgsOrPrompt(args)
+			if err != nil {
+				return err
+			}
+			symbol = strings.ToUpper(strings.TrimSpace(symbol))
+			idem := uuid.NewString()
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.AddWatch(ctx, sess.AccessToken, symbol, idem)
+			if err != nil {
+				return queueOnNetworkError(err, syncq.Command{
+					Method:         "POST",
+					Path:           "/v1/watchlist",
+					Body:           map[string]any{"symbol": symbol},
+					IdempotencyKey: idem,
+				})
+			}
+			return render(out, renderSimpleOK(fmt.Sprintf("Watching %s.", symbol)))
+		},
+	})
+	watch.AddCommand(&cobra.Command{
+		Use:   "remove [symbol]",
+		Short: "Stop watching a stock",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			symbol, err := symbolFromArgsOrPrompt(args)
+			if err != nil {
+				return err
+			}
+			symbol = strings.ToUpper(strings.TrimSpace(symbol))
+			idem := uuid.NewString()
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.RemoveWatch(ctx, sess.AccessToken, symbol, idem)
+			if err != nil {
+				return queueOnNetworkError(err, syncq.Command{
+					Method:         "DELETE",
+					Path:           "/v1/watchlist/" + url.PathEscape(symbol),
+					IdempotencyKey: idem,
+				})
+			}
+			return render(out, renderSimpleOK(fmt.Sprintf("Stopped watching %s.", symbol)))
+		},
+	})
+	watch.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "Show watched stocks and their move since you added them",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.ListWatch(ctx, sess.AccessToken)
+			if err != nil {
+				return err
+			}
+			return render(out, renderWatchlist)
+		},
+	})
+	return watch
+}
+
+// newHistoryCmd is `stk stocks orders` at the top level, the place to check
+// on an order whose outcome is unknown.
+func newHistoryCmd(apiBase *string) *cobra.Command {
+	cmd := newStocksOrdersCmd(apiBase)
+	cmd.Use = "history"
+	cmd.Short = "Show your order history (same as `stk stocks orders`)"
+	return cmd
+}
+
+func newAlertsCmd(apiBase *string) *cobra.Command {
+	alerts := &cobra.Command{
+		Use:   "alerts",
+		Short: "Show price alerts that fired since you last checked, then clear them",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.TriggeredAlerts(ctx, sess.AccessToken)
+			if err != nil {
+				return err
+			}
+			payload, err := decodeInto[alertsPayload](out)
+			if err != nil {
+				return err
+			}
+			renderTriggeredAlerts(payload.Alerts)
+			if len(payload.Alerts) == 0 {
+				return nil
+			}
+			ids := make([]int64, 0, len(payload.Alerts))
+			for _, a := range payload.Alerts {
+				ids = append(ids, a.ID)
+			}
+			idem := uuid.NewString()
+			if _, err := client.AcknowledgeAlerts(ctx, sess.AccessToken, ids, idem); err != nil {
+				return queueOnNetworkError(err, syncq.Command{
+					Method:         "POST",
+					Path:           "/v1/alerts/ack",
+					Body:           map[string]any{"ids": ids},
+					IdempotencyKey: idem,
+				})
+			}
+			return nil
+		},
+	}
+	alerts.AddCommand(&cobra.Command{
+		Use:   "set [symbol] [above|below] [price]",
+		Short: "Alert when a stock's price reaches a target from above or below",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			symbol := strings.ToUpper(strings.TrimSpace(args[0]))
+			direction := strings.ToLower(strings.TrimSpace(args[1]))
+			if direction != "above" && direction != "below" {
+				return fmt.Errorf("direction must be above or below")
+			}
+			price, err := strconv.ParseFloat(strings.TrimSpace(args[2]), 64)
+			if err != nil || price <= 0 {
+				return fmt.Errorf("price must be a number > 0")
+			}
+			targetMicros := game.StonkyToMicros(price)
+			idem := uuid.NewString()
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.SetPriceAlert(ctx, sess.AccessToken, symbol, direction, targetMicros, idem)
+			if err != nil {
+				return queueOnNetworkError(err, syncq.Command{
+					Method: "POST",
+					Path:   "/v1/alerts",
+					Body: map[string]any{
+						"symbol":              symbol,
+						"direction":           direction,
+						"target_price_micros": targetMicros,
+					},
+					IdempotencyKey: idem,
+				})
+			}
+			return render(out, renderSimpleOK(fmt.Sprintf("Alert set: %s %s %s.", symbol, direction, formatPrice(targetMicros))))
+		},
+	})
+	return alerts
+}
+
+func newStocksLiquidationOrderCmd(apiBase *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "liquidation-order",
+		Short: "Show the order your holdings would be force-sold in",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			client := newClient(apiBase)
+			out, err := client.LiquidationOrder(ctx, sess.AccessToken)
+			if err != nil {
+				return err
+			}
+			return render(out, renderLiquidationOrder)
+		},
+	}
+}
+
+func newStocksListCmd(apiBase *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [all|SYMBOL]",
+		Short: "List stocks or inspect one stock",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			client := newClient(apiBase)
+
+			if len(args) == 0 {
+				choice, err := promptChoice("View", []string{"market", "all", "symbol"}, "market")
+				if err != nil {
+					return err
+				}
+				switch choice {
+				case "market":
+					out, err := client.ListStocks(ctx, sess.AccessToken, false)
+					if err != nil {
+						return err
+					}
+					return withPager(func() error { return render(out, renderStocksList) })
+				case "all":
+					out, err := client.ListStocks(ctx, sess.AccessToken, true)
+					if err != nil {
+						return err
+					}
+					return withPager(func() error { return render(out, renderStocksList) })
+				default:
+					symbol, err := promptSymbol("Symbol")
+					if err != nil {
+						return err
+					}
+					out, err := client.StockDetail(ctx, sess.AccessToken, symbol)
+					if err != nil {
+						return err
+					}
+					return render(out, renderStockDetail)
+				}
+			}
+
+			arg := strings.ToUpper(strings.TrimSpace(args[0]))
+			if arg == "ALL" {
+				out, err := client.ListStocks(ctx, sess.AccessToken, true)
+				if err != nil {
+					return err
+				}
+				return withPager(func() error { return render(out, renderStocksList) })
+			}
+			if arg == "MARKET" {
+				out, err := client.ListStocks(ctx, sess.AccessToken, false)
+				if err != nil {
+					return err
+				}
+				return withPager(func() error { return render(out, renderStocksList) })
+			}
+			out, err := client.StockDetail(ctx, sess.AccessToken, arg)
+			if err != nil {
+				return err
+			}
+			return render(out, renderStockDetail)
+		},
+	}
+}
+
+func newStocksBuyCmd(apiBase *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "buy [symbol]",
+		Short: "Buy shares",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			symbol, err := symbolFromArgsOrPrompt(args)
+			if err != nil {
+				return err
+			}
+			qty, err := promptFloat("Shares to buy", 0)
+			if err != nil {
+				return err
+			}
+			return placeOrderCommand(cmd, apiBase, "buy", symbol, qty, false)
+		},
+	}
+	return cmd
+}
+
+func newStocksSellCmd(apiBase *string) *cobra.Command {
+	var short bool
+	cmd := &cobra.Command{
+		Use:   "sell [symbol]",
+		Short: "Sell shares",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			symbol, err := symbolFromArgsOrPrompt(args)
+			if err != nil {
+				return err
 			}
 			qty, err := promptFloat("Shares to sell", 0)
 			if err != nil {
 				return err
 			}
-			return placeOrderCommand(cmd, apiBase, "sell", symbol, qty)
+			return placeOrderCommand(cmd, apiBase, "sell", symbol, qty, short)
 		},
 	}
+	cmd.Flags().BoolVar(&short, "short", false, "sell shares you do not hold, opening a short (cover it with `stk stocks buy`)")
 	return cmd
 }
 
-func placeOrderCommand(cmd *cobra.Command, apiBase *string, side, symbol string, qty float64) error {
-	sess, err := cl.LoadSession()
+func placeOrderCommand(cmd *cobra.Command, apiBase *string, side, symbol string, qty float64, short bool) error {
+	sess, err := loadSession()
 	if err != nil {
 		return fmt.Errorf("login required: %w", err)
 	}
@@ -518,6 +1376,7 @@ func placeOrderCommand(cmd *cobra.Command, apiBase *string, side, symbol string,
 	if err != nil {
 		return err
 	}
+	warnIfSharesRounded(qty, units)
 	symbol = strings.ToUpper(strings.TrimSpace(symbol))
 	idem := uuid.NewString()
 	body := map[string]any{
@@ -525,6 +1384,9 @@ func placeOrderCommand(cmd *cobra.Command, apiBase *string, side, symbol string,
 		"side":           side,
 		"quantity_units": units,
 	}
+	if short {
+		body["short"] = true
+	}
 
 	client := newClient(apiBase)
 	ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
@@ -538,13 +1400,24 @@ func placeOrderCommand(cmd *cobra.Command, apiBase *string, side, symbol string,
 		if err != nil {
 			return err
 		}
-		notional := orderNotional(detail.CurrentPriceMicros, units)
-		fee := int64(math.Round(float64(notional) * 0.0015))
-		if err := confirmWalletSpend(ctx, client, sess.AccessToken, notional+fee); err != nil {
+		if !detail.ListedPublic {
+			return fmt.Errorf("%s is not listed publicly yet; only listed stocks can be traded (see `stk stocks list`)", symbol)
+		}
+		quote, err := client.QuoteOrder(ctx, sess.AccessToken, symbol, side, units, false)
+		if err != nil {
+			return err
+		}
+		if err := confirmWalletSpend(ctx, client, sess.AccessToken, int64Field(quote, "total_micros")); err != nil {
 			return err
 		}
 	}
-	out, err := client.PlaceOrder(ctx, sess.AccessToken, symbol, side, idem, units)
+	var out map[string]any
+	if short {
+		out, err = client.ShortSell(ctx, sess.AccessToken, symbol, idem, units)
+		side = "short"
+	} else {
+		out, err = client.PlaceOrder(ctx, sess.AccessToken, symbol, side, idem, units)
+	}
 	if err != nil {
 		return queueOnNetworkError(err, syncq.Command{
 			Method:         "POST",
@@ -553,7 +1426,7 @@ func placeOrderCommand(cmd *cobra.Command, apiBase *string, side, symbol string,
 			IdempotencyKey: idem,
 		})
 	}
-	return renderOrderResult(out, side, symbol, qty)
+	return render(out, renderOrderResult(side, symbol))
 }
 
 func newStocksCreateCmd(apiBase *string) *cobra.Command {
@@ -562,7 +1435,7 @@ func newStocksCreateCmd(apiBase *string) *cobra.Command {
 		Short: "Create your own stock for one of your businesses",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -596,7 +1469,7 @@ func newStocksCreateCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Created custom stock %s.", symbol))
+			return render(out, renderSimpleOK(fmt.Sprintf("Created custom stock %s.", symbol)))
 		},
 	}
 	return cmd
@@ -608,7 +1481,7 @@ func newStocksIPOCmd(apiBase *string) *cobra.Command {
 		Short: "List a created stock publicly",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -636,7 +1509,7 @@ func newStocksIPOCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("IPO opened for %s at %s stonky.", symbol, formatMicros(priceMicros)))
+			return render(out, renderSimpleOK(fmt.Sprintf("IPO opened for %s at %s stonky.", symbol, formatMicros(priceMicros))))
 		},
 	}
 	return cmd
@@ -652,18 +1525,68 @@ func newBusinessCmd(apiBase *string) *cobra.Command {
 	business.AddCommand(newBusinessStateCmd(apiBase))
 	business.AddCommand(newBusinessVisibilityCmd(apiBase))
 	business.AddCommand(newBusinessIPOCmd(apiBase))
+	business.AddCommand(newBusinessDelistCmd(apiBase))
 	business.AddCommand(newBusinessEmployeesCmd(apiBase))
 	business.AddCommand(newBusinessMachineryCmd(apiBase))
 	business.AddCommand(newBusinessLoansCmd(apiBase))
 	business.AddCommand(newBusinessStrategyCmd(apiBase))
+	business.AddCommand(newBusinessRevenueModeCmd(apiBase))
+	business.AddCommand(newBusinessClaimCmd(apiBase))
 	business.AddCommand(newBusinessUpgradesCmd(apiBase))
 	business.AddCommand(newBusinessReserveCmd(apiBase))
 	business.AddCommand(newBusinessSellCmd(apiBase))
+	business.AddCommand(newBusinessDeleteCmd(apiBase))
 	return business
 }
 
+func runStocksGuidedFlow(cmd *cobra.Command, apiBase *string) error {
+	sess, err := loadSession()
+	if err != nil {
+		return fmt.Errorf("login required: %w", err)
+	}
+	client := newClient(apiBase)
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	positions, err := client.Positions(ctx, sess.AccessToken)
+	if err != nil {
+		return err
+	}
+	if err := render(positions, renderHoldings); err != nil {
+		return err
+	}
+	stocks, err := client.ListStocks(ctx, sess.AccessToken, false)
+	if err != nil {
+		return err
+	}
+	if err := render(stocks, renderStocksList); err != nil {
+		return err
+	}
+
+	action, err := promptChoice("Stocks action", []string{"buy", "sell", "detail"}, "buy")
+	if err != nil {
+		return err
+	}
+	symbol, err := symbolFromArgsOrPrompt(nil)
+	if err != nil {
+		return err
+	}
+	if action == "detail" {
+		out, err := client.StockDetail(ctx, sess.AccessToken, symbol)
+		if err != nil {
+			return err
+		}
+		return render(out, renderStockDetail)
+	}
+	qty, err := promptFloat("Shares", 0)
+	if err != nil {
+		return err
+	}
+	return placeOrderCommand(cmd, apiBase, action, symbol, qty, false)
+}
+
 func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
-	sess, err := cl.LoadSession()
+	sess, err := loadSession()
 	if err != nil {
 		return fmt.Errorf("login required: %w", err)
 	}
@@ -698,7 +1621,7 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderBusinessCreated(out, name, visibility)
+		return render(out, renderBusinessCreated(name, visibility))
 	case "state":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -708,7 +1631,7 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderBusinessState(out)
+		return render(out, renderBusinessState)
 	case "visibility":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -723,7 +1646,7 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderSimpleOK(out, fmt.Sprintf("Business %d visibility set to %s.", id, visibility))
+		return render(out, renderSimpleOK(fmt.Sprintf("Business %d visibility set to %s.", id, visibility)))
 	case "ipo":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -743,7 +1666,7 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderSimpleOK(out, fmt.Sprintf("Business %d IPO opened as %s at %s stonky.", id, symbol, formatMicros(priceMicros)))
+		return render(out, renderSimpleOK(fmt.Sprintf("Business %d IPO opened as %s at %s stonky.", id, symbol, formatMicros(priceMicros))))
 	case "employees_list":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -753,7 +1676,7 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderBusinessEmployees(out, id)
+		return withPager(func() error { return render(out, renderBusinessEmployees(id)) })
 	case "employees_hire":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -799,7 +1722,7 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderSimpleOK(out, fmt.Sprintf("Trained employee %d in business %d.", employeeID, id))
+		return render(out, renderSimpleOK(fmt.Sprintf("Trained employee %d in business %d.", employeeID, id)))
 	case "machinery_list":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -809,7 +1732,7 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderBusinessMachinery(out, id)
+		return render(out, renderBusinessMachinery(id))
 	case "machinery_buy":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -819,7 +1742,11 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		if err := confirmWalletSpend(ctx, client, sess.AccessToken, machineryCostMicros(machineType)); err != nil {
+		costMicros, err := quoteMachineryCost(ctx, client, sess.AccessToken, id, []map[string]any{{"machine_type": machineType, "count": 1}})
+		if err != nil {
+			return err
+		}
+		if err := confirmWalletSpend(ctx, client, sess.AccessToken, costMicros); err != nil {
 			return err
 		}
 		idem := uuid.NewString()
@@ -827,7 +1754,7 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderSimpleOK(out, fmt.Sprintf("Installed %s for business %d.", machineType, id))
+		return render(out, renderSimpleOK(fmt.Sprintf("Installed %s for business %d.", machineType, id)))
 	case "loans_list":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -837,7 +1764,7 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderBusinessLoans(out, id)
+		return render(out, renderBusinessLoans(id))
 	case "loans_take":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -853,7 +1780,7 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderSimpleOK(out, fmt.Sprintf("Loan drawn for business %d: %s stonky.", id, formatMicros(amountMicros)))
+		return render(out, renderSimpleOK(fmt.Sprintf("Loan drawn for business %d: %s stonky.", id, formatMicros(amountMicros))))
 	case "loans_repay":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -863,16 +1790,20 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
+		loanID, err := promptInt64("Loan ID (0 = oldest first)", 0)
+		if err != nil {
+			return err
+		}
 		amountMicros := game.StonkyToMicros(amount)
 		if err := confirmWalletSpend(ctx, client, sess.AccessToken, amountMicros); err != nil {
 			return err
 		}
 		idem := uuid.NewString()
-		out, err := client.RepayBusinessLoan(ctx, sess.AccessToken, id, amountMicros, idem)
+		out, err := client.RepayBusinessLoan(ctx, sess.AccessToken, id, amountMicros, loanID, idem)
 		if err != nil {
 			return err
 		}
-		return renderSimpleOK(out, fmt.Sprintf("Loan repayment submitted for business %d.", id))
+		return render(out, renderSimpleOK(fmt.Sprintf("Loan repayment submitted for business %d.", id)))
 	case "strategy":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -887,7 +1818,7 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderSimpleOK(out, fmt.Sprintf("Business %d strategy set to %s.", id, strategy))
+		return render(out, renderSimpleOK(fmt.Sprintf("Business %d strategy set to %s.", id, strategy)))
 	case "upgrade":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -923,7 +1854,7 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderSimpleOK(out, fmt.Sprintf("Business %d upgraded: %s.", id, upgrade))
+		return render(out, renderSimpleOK(fmt.Sprintf("Business %d upgraded: %s.", id, upgrade)))
 	case "reserve_deposit":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -942,7 +1873,7 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderSimpleOK(out, fmt.Sprintf("Business %d reserve deposit: %s stonky.", id, formatMicros(amountMicros)))
+		return render(out, renderSimpleOK(fmt.Sprintf("Business %d reserve deposit: %s stonky.", id, formatMicros(amountMicros))))
 	case "reserve_withdraw":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
@@ -958,25 +1889,28 @@ func runBusinessGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderSimpleOK(out, fmt.Sprintf("Business %d reserve withdraw: %s stonky.", id, formatMicros(amountMicros)))
+		return render(out, renderSimpleOK(fmt.Sprintf("Business %d reserve withdraw: %s stonky.", id, formatMicros(amountMicros))))
 	case "sell":
 		id, err := promptInt64("Business ID", 1)
 		if err != nil {
 			return err
 		}
+		if err := confirmBusinessSale(ctx, client, sess.AccessToken, id); err != nil {
+			return err
+		}
 		idem := uuid.NewString()
 		out, err := client.SellBusinessToBank(ctx, sess.AccessToken, id, idem)
 		if err != nil {
 			return err
 		}
-		return renderSimpleOK(out, fmt.Sprintf("Business %d sold to the bank.", id))
+		return render(out, renderSimpleOK(fmt.Sprintf("Business %d sold to the bank.", id)))
 	default:
 		return nil
 	}
 }
 
 func runFundsGuidedFlow(cmd *cobra.Command, apiBase *string) error {
-	sess, err := cl.LoadSession()
+	sess, err := loadSession()
 	if err != nil {
 		return fmt.Errorf("login required: %w", err)
 	}
@@ -994,13 +1928,9 @@ func runFundsGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 		if err != nil {
 			return err
 		}
-		return renderFundsList(out)
+		return render(out, renderFundsList)
 	case "buy", "sell":
-		code, qty, err := fundCodeAndQty(nil)
-		if err != nil {
-			return err
-		}
-		units, err := game.SharesToUnits(qty)
+		code, units, err := fundCodeAndQty(nil)
 		if err != nil {
 			return err
 		}
@@ -1021,13 +1951,18 @@ func runFundsGuidedFlow(cmd *cobra.Command, apiBase *string) error {
 			out, err = client.SellFund(ctx, sess.AccessToken, code, idem, units)
 		}
 		if err != nil {
-			return err
+			return queueOnNetworkError(err, syncq.Command{
+				Method:         "POST",
+				Path:           fmt.Sprintf("/v1/funds/%s/%s", code, action),
+				Body:           map[string]any{"units": units},
+				IdempotencyKey: idem,
+			})
 		}
 		label := "Bought"
 		if action == "sell" {
 			label = "Sold"
 		}
-		return renderSimpleOK(out, fmt.Sprintf("%s %.4f units of %s.", label, qty, code))
+		return render(out, renderFundTrade(fmt.Sprintf("%s %.4f units of %s.", label, game.UnitsToShares(units), code)))
 	default:
 		return nil
 	}
@@ -1039,7 +1974,7 @@ func newBusinessCreateCmd(apiBase *string) *cobra.Command {
 		Short: "Create a business (requires progression)",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1071,7 +2006,7 @@ func newBusinessCreateCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderBusinessCreated(out, name, visibility)
+			return render(out, renderBusinessCreated(name, visibility))
 		},
 	}
 	return cmd
@@ -1083,7 +2018,7 @@ func newBusinessStateCmd(apiBase *string) *cobra.Command {
 		Short: "Show business state",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1098,7 +2033,7 @@ func newBusinessStateCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return renderBusinessState(out)
+			return render(out, renderBusinessState)
 		},
 	}
 }
@@ -1109,7 +2044,7 @@ func newBusinessVisibilityCmd(apiBase *string) *cobra.Command {
 		Short: "Set business visibility",
 		Args:  cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1143,7 +2078,7 @@ func newBusinessVisibilityCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Business %d visibility set to %s.", id, visibility))
+			return render(out, renderSimpleOK(fmt.Sprintf("Business %d visibility set to %s.", id, visibility)))
 		},
 	}
 }
@@ -1154,7 +2089,7 @@ func newBusinessIPOCmd(apiBase *string) *cobra.Command {
 		Short: "List a public business on market",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1190,23 +2125,46 @@ func newBusinessIPOCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Business %d IPO opened as %s at %s stonky.", id, symbol, formatMicros(priceMicros)))
+			return render(out, renderSimpleOK(fmt.Sprintf("Business %d IPO opened as %s at %s stonky.", id, symbol, formatMicros(priceMicros))))
+		},
+	}
+	return cmd
+}
+
+func newBusinessEmployeesCmd(apiBase *string) *cobra.Command {
+	employees := &cobra.Command{
+		Use:   "employees",
+		Short: "Employee operations",
+	}
+	employees.AddCommand(&cobra.Command{
+		Use:   "list [business_id]",
+		Short: "List employees hired by your business",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			businessID, err := int64FromArgOrPrompt(cmd.Context(), apiBase, args, 0, "Business ID")
+			if err != nil {
+				return err
+			}
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.ListBusinessEmployees(ctx, sess.AccessToken, businessID)
+			if err != nil {
+				return err
+			}
+			return withPager(func() error { return render(out, renderBusinessEmployees(businessID)) })
 		},
-	}
-	return cmd
-}
-
-func newBusinessEmployeesCmd(apiBase *string) *cobra.Command {
-	employees := &cobra.Command{
-		Use:   "employees",
-		Short: "Employee operations",
-	}
+	})
 	employees.AddCommand(&cobra.Command{
-		Use:   "list [business_id]",
-		Short: "List employees hired by your business",
-		Args:  cobra.MaximumNArgs(1),
+		Use:   "preview [business_id] [candidate_id]",
+		Short: "Preview revenue/tick and average risk after hiring a candidate",
+		Args:  cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1214,14 +2172,24 @@ func newBusinessEmployeesCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			candidateID, err := int64FromArgOrPrompt(cmd.Context(), apiBase, args, 1, "Candidate ID")
+			if err != nil {
+				return err
+			}
 			client := newClient(apiBase)
 			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 			defer cancel()
-			out, err := client.ListBusinessEmployees(ctx, sess.AccessToken, businessID)
+			out, err := client.PreviewHire(ctx, sess.AccessToken, businessID, candidateID)
 			if err != nil {
 				return err
 			}
-			return renderBusinessEmployees(out, businessID)
+			if err := render(out, renderHirePreview); err != nil {
+				return err
+			}
+			if !jsonOutput {
+				fmt.Println()
+			}
+			return nil
 		},
 	})
 	employees.AddCommand(&cobra.Command{
@@ -1229,7 +2197,7 @@ func newBusinessEmployeesCmd(apiBase *string) *cobra.Command {
 		Short: "Hire one employee using a strategy",
 		Args:  cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1257,7 +2225,7 @@ func newBusinessEmployeesCmd(apiBase *string) *cobra.Command {
 		Short: "Hire multiple candidates in one shot using a strategy",
 		Args:  cobra.MaximumNArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1289,7 +2257,7 @@ func newBusinessEmployeesCmd(apiBase *string) *cobra.Command {
 		Short: "Train a professional to increase output (also raises risk)",
 		Args:  cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1322,7 +2290,7 @@ func newBusinessEmployeesCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Trained employee %d in business %d.", employeeID, businessID))
+			return render(out, renderSimpleOK(fmt.Sprintf("Trained employee %d in business %d.", employeeID, businessID)))
 		},
 	})
 	return employees
@@ -1338,7 +2306,7 @@ func newBusinessMachineryCmd(apiBase *string) *cobra.Command {
 		Short: "List machinery installed in a business",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1353,7 +2321,7 @@ func newBusinessMachineryCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return renderBusinessMachinery(out, businessID)
+			return render(out, renderBusinessMachinery(businessID))
 		},
 	})
 	machinery.AddCommand(&cobra.Command{
@@ -1361,7 +2329,7 @@ func newBusinessMachineryCmd(apiBase *string) *cobra.Command {
 		Short: "Buy or upgrade machinery (assembly_line, robotics_cell, cloud_cluster, bio_reactor, quantum_rig)",
 		Args:  cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1384,7 +2352,11 @@ func newBusinessMachineryCmd(apiBase *string) *cobra.Command {
 			client := newClient(apiBase)
 			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 			defer cancel()
-			if err := confirmWalletSpend(ctx, client, sess.AccessToken, machineryCostMicros(machineType)); err != nil {
+			costMicros, err := quoteMachineryCost(ctx, client, sess.AccessToken, businessID, []map[string]any{body})
+			if err != nil {
+				return err
+			}
+			if err := confirmWalletSpend(ctx, client, sess.AccessToken, costMicros); err != nil {
 				return err
 			}
 			out, err := client.BuyBusinessMachinery(ctx, sess.AccessToken, businessID, machineType, idem)
@@ -1396,12 +2368,99 @@ func newBusinessMachineryCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Installed %s for business %d.", machineType, businessID))
+			return render(out, renderSimpleOK(fmt.Sprintf("Installed %s for business %d.", machineType, businessID)))
+		},
+	})
+	machinery.AddCommand(&cobra.Command{
+		Use:   "buy-batch [business_id] [machine_type[:count]...]",
+		Short: "Buy or upgrade several machinery levels in one transaction",
+		Args:  cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			businessID, err := int64FromArgOrPrompt(cmd.Context(), apiBase, args, 0, "Business ID")
+			if err != nil {
+				return err
+			}
+			var specs []string
+			if len(args) > 1 {
+				specs = args[1:]
+			} else {
+				raw, err := promptRequired("Machines (e.g. assembly_line:3,robotics_cell)")
+				if err != nil {
+					return err
+				}
+				specs = strings.Split(raw, ",")
+			}
+			items, err := parseMachineryBatch(specs)
+			if err != nil {
+				return err
+			}
+			idem := uuid.NewString()
+			path := fmt.Sprintf("/v1/businesses/%d/machinery/buy-batch", businessID)
+			body := map[string]any{"items": items}
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			costMicros, err := quoteMachineryCost(ctx, client, sess.AccessToken, businessID, items)
+			if err != nil {
+				return err
+			}
+			if err := confirmWalletSpend(ctx, client, sess.AccessToken, costMicros); err != nil {
+				return err
+			}
+			out, err := client.BuyBusinessMachineryBatch(ctx, sess.AccessToken, businessID, items, idem)
+			if err != nil {
+				return queueOnNetworkError(err, syncq.Command{
+					Method:         "POST",
+					Path:           path,
+					Body:           body,
+					IdempotencyKey: idem,
+				})
+			}
+			return render(out, renderMachineryBatchResult(businessID))
 		},
 	})
 	return machinery
 }
 
+func parseMachineryBatch(specs []string) ([]map[string]any, error) {
+	counts := map[string]int64{}
+	order := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		spec = strings.ToLower(strings.TrimSpace(spec))
+		if spec == "" {
+			continue
+		}
+		machineType, countRaw, hasCount := strings.Cut(spec, ":")
+		count := int64(1)
+		if hasCount {
+			v, err := strconv.ParseInt(strings.TrimSpace(countRaw), 10, 64)
+			if err != nil || v <= 0 {
+				return nil, fmt.Errorf("invalid count in %q", spec)
+			}
+			count = v
+		}
+		if machineType == "" {
+			return nil, fmt.Errorf("missing machine type in %q", spec)
+		}
+		if _, seen := counts[machineType]; !seen {
+			order = append(order, machineType)
+		}
+		counts[machineType] += count
+	}
+	if len(order) == 0 {
+		return nil, fmt.Errorf("at least one machine type is required")
+	}
+	items := make([]map[string]any, 0, len(order))
+	for _, machineType := range order {
+		items = append(items, map[string]any{"machine_type": machineType, "count": counts[machineType]})
+	}
+	return items, nil
+}
+
 func newBusinessLoansCmd(apiBase *string) *cobra.Command {
 	loans := &cobra.Command{
 		Use:   "loans",
@@ -1412,7 +2471,7 @@ func newBusinessLoansCmd(apiBase *string) *cobra.Command {
 		Short: "List loans and delinquency status for a business",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1427,7 +2486,7 @@ func newBusinessLoansCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return renderBusinessLoans(out, businessID)
+			return render(out, renderBusinessLoans(businessID))
 		},
 	})
 	loans.AddCommand(&cobra.Command{
@@ -1435,7 +2494,7 @@ func newBusinessLoansCmd(apiBase *string) *cobra.Command {
 		Short: "Take a business loan",
 		Args:  cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1471,15 +2530,242 @@ func newBusinessLoansCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Loan drawn for business %d: %s stonky.", businessID, formatMicros(amountMicros)))
+			return render(out, renderSimpleOK(fmt.Sprintf("Loan drawn for business %d: %s stonky.", businessID, formatMicros(amountMicros))))
+		},
+	})
+	loans.AddCommand(&cobra.Command{
+		Use:   "repay [business_id] [stonky] [loan_id]",
+		Short: "Repay business loans (oldest first, or one loan by id)",
+		Args:  cobra.MaximumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			businessID, err := int64FromArgOrPrompt(cmd.Context(), apiBase, args, 0, "Business ID")
+			if err != nil {
+				return err
+			}
+			amount := 0.0
+			if len(args) >= 2 {
+				amount, err = strconv.ParseFloat(strings.TrimSpace(args[1]), 64)
+				if err != nil || amount <= 0 {
+					return fmt.Errorf("repay amount must be a positive number")
+				}
+			} else {
+				amount, err = promptFloat("Repay amount (stonky)", 0)
+				if err != nil {
+					return err
+				}
+			}
+			loanID := int64(0)
+			if len(args) >= 3 {
+				loanID, err = strconv.ParseInt(strings.TrimSpace(args[2]), 10, 64)
+				if err != nil || loanID <= 0 {
+					return fmt.Errorf("loan id must be a positive integer")
+				}
+			}
+			amountMicros := game.StonkyToMicros(amount)
+			idem := uuid.NewString()
+			path := fmt.Sprintf("/v1/businesses/%d/loans/repay", businessID)
+			body := map[string]any{"amount_micros": amountMicros}
+			if loanID > 0 {
+				body["loan_id"] = loanID
+			}
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			if err := confirmWalletSpend(ctx, client, sess.AccessToken, amountMicros); err != nil {
+				return err
+			}
+			out, err := client.RepayBusinessLoan(ctx, sess.AccessToken, businessID, amountMicros, loanID, idem)
+			if err != nil {
+				return queueOnNetworkError(err, syncq.Command{
+					Method:         "POST",
+					Path:           path,
+					Body:           body,
+					IdempotencyKey: idem,
+				})
+			}
+			return render(out, renderSimpleOK(fmt.Sprintf("Loan repayment submitted for business %d.", businessID)))
 		},
 	})
 	loans.AddCommand(&cobra.Command{
-		Use:   "repay [business_id] [stonky]",
-		Short: "Repay business loans",
+		Use:   "auto-repay [business_id] [on|off] [buffer_stonky]",
+		Short: "Repay loans each tick from wallet balance above a buffer",
+		Args:  cobra.MaximumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			businessID, err := int64FromArgOrPrompt(cmd.Context(), apiBase, args, 0, "Business ID")
+			if err != nil {
+				return err
+			}
+			mode := ""
+			if len(args) >= 2 {
+				mode = strings.ToLower(strings.TrimSpace(args[1]))
+			} else {
+				mode, err = promptChoice("Auto-repay", []string{"on", "off"}, "on")
+				if err != nil {
+					return err
+				}
+			}
+			if mode != "on" && mode != "off" {
+				return fmt.Errorf("auto-repay must be on or off")
+			}
+			enabled := mode == "on"
+			buffer := 0.0
+			if len(args) >= 3 {
+				buffer, err = strconv.ParseFloat(strings.TrimSpace(args[2]), 64)
+				if err != nil || buffer < 0 {
+					return fmt.Errorf("buffer must be a non-negative number")
+				}
+			} else if enabled {
+				raw, err := promptOptional("Wallet buffer to keep (stonky, default 0)")
+				if err != nil {
+					return err
+				}
+				if raw != "" {
+					buffer, err = strconv.ParseFloat(raw, 64)
+					if err != nil || buffer < 0 {
+						return fmt.Errorf("buffer must be a non-negative number")
+					}
+				}
+			}
+			bufferMicros := game.StonkyToMicros(buffer)
+			idem := uuid.NewString()
+			path := fmt.Sprintf("/v1/businesses/%d/loans/auto-repay", businessID)
+			body := map[string]any{"enabled": enabled, "buffer_micros": bufferMicros}
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.SetBusinessLoanAutoRepay(ctx, sess.AccessToken, businessID, enabled, bufferMicros, idem)
+			if err != nil {
+				return queueOnNetworkError(err, syncq.Command{
+					Method:         "POST",
+					Path:           path,
+					Body:           body,
+					IdempotencyKey: idem,
+				})
+			}
+			if !enabled {
+				return render(out, renderSimpleOK(fmt.Sprintf("Loan auto-repay disabled for business %d.", businessID)))
+			}
+			return render(out, renderSimpleOK(fmt.Sprintf("Loan auto-repay enabled for business %d (buffer %s stonky).", businessID, formatMicros(bufferMicros))))
+		},
+	})
+	return loans
+}
+
+func newBusinessDelistCmd(apiBase *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delist [business_id]",
+		Short: "Take your business's stock off the market (only while no one else holds it)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			businessID, err := int64FromArgOrPrompt(cmd.Context(), apiBase, args, 0, "Business ID")
+			if err != nil {
+				return err
+			}
+			idem := uuid.NewString()
+			path := fmt.Sprintf("/v1/businesses/%d/delist", businessID)
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.DelistBusiness(ctx, sess.AccessToken, businessID, idem)
+			if err != nil {
+				return queueOnNetworkError(err, syncq.Command{
+					Method:         "POST",
+					Path:           path,
+					Body:           map[string]any{},
+					IdempotencyKey: idem,
+				})
+			}
+			return render(out, renderSimpleOK(fmt.Sprintf("Business %d delisted.", businessID)))
+		},
+	}
+}
+
+func newBusinessSellCmd(apiBase *string) *cobra.Command {
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "sell [business_id]",
+		Short: "Sell your business to the bank at algorithmic valuation",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			businessID, err := int64FromArgOrPrompt(cmd.Context(), apiBase, args, 0, "Business ID")
+			if err != nil {
+				return err
+			}
+			idem := uuid.NewString()
+			path := fmt.Sprintf("/v1/businesses/%d/sell", businessID)
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			if !yes {
+				if err := confirmBusinessSale(ctx, client, sess.AccessToken, businessID); err != nil {
+					return err
+				}
+			}
+			out, err := client.SellBusinessToBank(ctx, sess.AccessToken, businessID, idem)
+			if err != nil {
+				return queueOnNetworkError(err, syncq.Command{
+					Method:         "POST",
+					Path:           path,
+					Body:           map[string]any{},
+					IdempotencyKey: idem,
+				})
+			}
+			return render(out, renderSimpleOK(fmt.Sprintf("Business %d sold to the bank.", businessID)))
+		},
+	}
+	cmd.Flags().BoolVar(&yes, "yes", false, "Skip the valuation preview and confirmation")
+	return cmd
+}
+
+func newBusinessDeleteCmd(apiBase *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete [business_id]",
+		Short: "Delete a business you created by mistake (must be empty)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			businessID, err := int64FromArgOrPrompt(cmd.Context(), apiBase, args, 0, "Business ID")
+			if err != nil {
+				return err
+			}
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.DeleteBusiness(ctx, sess.AccessToken, businessID, uuid.NewString())
+			if err != nil {
+				return err
+			}
+			return render(out, renderSimpleOK(fmt.Sprintf("Business %d deleted.", businessID)))
+		},
+	}
+}
+
+func newBusinessRevenueModeCmd(apiBase *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revenue-mode [business_id] [push|accrue]",
+		Short: "Pay revenue to your wallet each tick (push) or hold it for claiming (accrue)",
 		Args:  cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1487,29 +2773,22 @@ func newBusinessLoansCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			amount := 0.0
+			mode := ""
 			if len(args) >= 2 {
-				amount, err = strconv.ParseFloat(strings.TrimSpace(args[1]), 64)
-				if err != nil || amount <= 0 {
-					return fmt.Errorf("repay amount must be a positive number")
-				}
+				mode = strings.ToLower(strings.TrimSpace(args[1]))
 			} else {
-				amount, err = promptFloat("Repay amount (stonky)", 0)
+				mode, err = promptChoice("Revenue mode", []string{"push", "accrue"}, "push")
 				if err != nil {
 					return err
 				}
 			}
-			amountMicros := game.StonkyToMicros(amount)
 			idem := uuid.NewString()
-			path := fmt.Sprintf("/v1/businesses/%d/loans/repay", businessID)
-			body := map[string]any{"amount_micros": amountMicros}
+			path := fmt.Sprintf("/v1/businesses/%d/revenue-mode", businessID)
+			body := map[string]any{"mode": mode}
 			client := newClient(apiBase)
 			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 			defer cancel()
-			if err := confirmWalletSpend(ctx, client, sess.AccessToken, amountMicros); err != nil {
-				return err
-			}
-			out, err := client.RepayBusinessLoan(ctx, sess.AccessToken, businessID, amountMicros, idem)
+			out, err := client.SetBusinessRevenueMode(ctx, sess.AccessToken, businessID, mode, idem)
 			if err != nil {
 				return queueOnNetworkError(err, syncq.Command{
 					Method:         "POST",
@@ -1518,19 +2797,18 @@ func newBusinessLoansCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Loan repayment submitted for business %d.", businessID))
+			return render(out, renderSimpleOK(fmt.Sprintf("Business %d revenue mode set to %s.", businessID, mode)))
 		},
-	})
-	return loans
+	}
 }
 
-func newBusinessSellCmd(apiBase *string) *cobra.Command {
+func newBusinessClaimCmd(apiBase *string) *cobra.Command {
 	return &cobra.Command{
-		Use:   "sell [business_id]",
-		Short: "Sell your business to the bank at algorithmic valuation",
+		Use:   "claim [business_id]",
+		Short: "Claim revenue accrued by a business in accrue mode",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1539,20 +2817,19 @@ func newBusinessSellCmd(apiBase *string) *cobra.Command {
 				return err
 			}
 			idem := uuid.NewString()
-			path := fmt.Sprintf("/v1/businesses/%d/sell", businessID)
 			client := newClient(apiBase)
 			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 			defer cancel()
-			out, err := client.SellBusinessToBank(ctx, sess.AccessToken, businessID, idem)
+			out, err := client.ClaimBusinessRevenue(ctx, sess.AccessToken, businessID, idem)
 			if err != nil {
 				return queueOnNetworkError(err, syncq.Command{
 					Method:         "POST",
-					Path:           path,
+					Path:           fmt.Sprintf("/v1/businesses/%d/claim", businessID),
 					Body:           map[string]any{},
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Business %d sold to the bank.", businessID))
+			return render(out, renderSimpleOK(fmt.Sprintf("Business %d: claimed %s stonky.", businessID, formatMicros(int64FromAny(out["owner_share_micros"])))))
 		},
 	}
 }
@@ -1563,7 +2840,7 @@ func newBusinessStrategyCmd(apiBase *string) *cobra.Command {
 		Short: "Set business strategy mode",
 		Args:  cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1595,7 +2872,7 @@ func newBusinessStrategyCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Business %d strategy set to %s.", businessID, strategy))
+			return render(out, renderSimpleOK(fmt.Sprintf("Business %d strategy set to %s.", businessID, strategy)))
 		},
 	}
 }
@@ -1605,12 +2882,35 @@ func newBusinessUpgradesCmd(apiBase *string) *cobra.Command {
 		Use:   "upgrades",
 		Short: "Buy strategic business upgrades",
 	}
+	upgrades.AddCommand(&cobra.Command{
+		Use:   "list [business_id]",
+		Short: "Show upgrade levels and next-level costs",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			businessID, err := int64FromArgOrPrompt(cmd.Context(), apiBase, args, 0, "Business ID")
+			if err != nil {
+				return err
+			}
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.BusinessUpgrades(ctx, sess.AccessToken, businessID)
+			if err != nil {
+				return err
+			}
+			return render(out, renderBusinessUpgrades(businessID))
+		},
+	})
 	upgrades.AddCommand(&cobra.Command{
 		Use:   "buy [business_id] [marketing|rd|automation|compliance|seats]",
 		Short: "Purchase an upgrade level",
 		Args:  cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1666,7 +2966,7 @@ func newBusinessUpgradesCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Business %d upgraded: %s.", businessID, upgrade))
+			return render(out, renderSimpleOK(fmt.Sprintf("Business %d upgraded: %s.", businessID, upgrade)))
 		},
 	})
 	return upgrades
@@ -1705,6 +3005,10 @@ func int64Field(raw map[string]any, key string) int64 {
 	if !ok {
 		return 0
 	}
+	return int64FromAny(v)
+}
+
+func int64FromAny(v any) int64 {
 	switch t := v.(type) {
 	case int:
 		return int64(t)
@@ -1742,9 +3046,10 @@ func confirmWalletSpend(ctx context.Context, client *cl.Client, accessToken stri
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Balance:                 %s stonky\n", formatMicros(balance))
-	fmt.Printf("Amount to be spent:      %s stonky\n", formatMicros(amountMicros))
-	fmt.Printf("Balance after spending:  %s stonky\n", formatMicros(balance-amountMicros))
+	w := messageOut()
+	fmt.Fprintf(w, "Balance:                 %s stonky\n", formatMicros(balance))
+	fmt.Fprintf(w, "Amount to be spent:      %s stonky\n", formatMicros(amountMicros))
+	fmt.Fprintf(w, "Balance after spending:  %s stonky\n", formatMicros(balance-amountMicros))
 	ok, err := promptConfirm("Continue", false)
 	if err != nil {
 		return err
@@ -1755,19 +3060,48 @@ func confirmWalletSpend(ctx context.Context, client *cl.Client, accessToken stri
 	return nil
 }
 
-func estimateEmployeeHireCost(ctx context.Context, client *cl.Client, accessToken string, businessID, count int64, strategy string) (int64, error) {
-	raw, err := client.QuoteHireEmployeesBulk(ctx, accessToken, businessID, int(count), strategy)
+// confirmBusinessSale shows the bank's valuation range for a business and
+// requires a typed "yes" before it is sold. Under --json the preview is
+// skipped so only the sale result reaches stdout.
+func confirmBusinessSale(ctx context.Context, client *cl.Client, accessToken string, businessID int64) error {
+	if !jsonOutput {
+		preview, err := client.PreviewBusinessSale(ctx, accessToken, businessID)
+		if err != nil {
+			return err
+		}
+		if err := renderBusinessSalePreview(preview); err != nil {
+			return err
+		}
+	}
+	answer, err := promptOptional(`Type "yes" to sell`)
 	if err != nil {
-		return 0, err
+		return err
+	}
+	if !strings.EqualFold(answer, "yes") {
+		return fmt.Errorf("cancelled")
 	}
-	return int64Field(raw, "estimated_cost_micros"), nil
+	return nil
 }
 
 func hireEmployeesWithConfirmation(ctx context.Context, client *cl.Client, accessToken string, businessID, count int64, strategy string) error {
-	estimatedCost, err := estimateEmployeeHireCost(ctx, client, accessToken, businessID, count, strategy)
+	quote, err := client.QuoteHireEmployeesBulk(ctx, accessToken, businessID, int(count), strategy)
 	if err != nil {
 		return err
 	}
+	estimatedCost := int64Field(quote, "estimated_cost_micros")
+	if count == 1 && !jsonOutput {
+		if ids, ok := quote["candidate_id_preview"].([]any); ok && len(ids) > 0 {
+			if candidateID := int64FromAny(ids[0]); candidateID > 0 {
+				preview, err := client.PreviewHire(ctx, accessToken, businessID, candidateID)
+				if err != nil {
+					return err
+				}
+				if err := renderHirePreview(preview); err != nil {
+					return err
+				}
+			}
+		}
+	}
 	if err := confirmWalletSpend(ctx, client, accessToken, estimatedCost); err != nil {
 		return err
 	}
@@ -1783,7 +3117,7 @@ func hireEmployeesWithConfirmation(ctx context.Context, client *cl.Client, acces
 			IdempotencyKey: idem,
 		})
 	}
-	return renderSimpleOK(out, fmt.Sprintf("Hired %d employee(s) for business %d using %s.", int64Field(out, "hired_count"), businessID, strategy))
+	return render(out, renderSimpleOK(fmt.Sprintf("Hired %d employee(s) for business %d using %s.", int64Field(out, "hired_count"), businessID, strategy)))
 }
 
 func estimateTrainingCost(ctx context.Context, client *cl.Client, accessToken string, businessID, employeeID int64) (int64, error) {
@@ -1803,21 +3137,14 @@ func estimateTrainingCost(ctx context.Context, client *cl.Client, accessToken st
 	return 0, fmt.Errorf("employee not found")
 }
 
-func machineryCostMicros(machineType string) int64 {
-	switch machineType {
-	case "assembly_line":
-		return 6_500 * game.MicrosPerStonky
-	case "robotics_cell":
-		return 12_500 * game.MicrosPerStonky
-	case "cloud_cluster":
-		return 18_000 * game.MicrosPerStonky
-	case "bio_reactor":
-		return 25_000 * game.MicrosPerStonky
-	case "quantum_rig":
-		return 40_000 * game.MicrosPerStonky
-	default:
-		return 0
+// quoteMachineryCost asks the server what a machinery purchase will cost
+// at the business's current levels.
+func quoteMachineryCost(ctx context.Context, client *cl.Client, accessToken string, businessID int64, items []map[string]any) (int64, error) {
+	quote, err := client.QuoteBusinessMachineryBatch(ctx, accessToken, businessID, items)
+	if err != nil {
+		return 0, err
 	}
+	return int64Field(quote, "total_cost_micros"), nil
 }
 
 func estimateUpgradeCost(ctx context.Context, client *cl.Client, accessToken string, businessID int64, upgrade string, count int64) (int64, error) {
@@ -1831,18 +3158,18 @@ func estimateUpgradeCost(ctx context.Context, client *cl.Client, accessToken str
 	}
 	switch upgrade {
 	case "marketing":
-		return upgradeCostFromLevel(int(state.MarketingLevel)), nil
+		return game.BusinessUpgradeCostMicros(state.MarketingLevel), nil
 	case "rd":
-		return upgradeCostFromLevel(int(state.RDLevel)), nil
+		return game.BusinessUpgradeCostMicros(state.RDLevel), nil
 	case "automation":
-		return upgradeCostFromLevel(int(state.AutomationLevel)), nil
+		return game.BusinessUpgradeCostMicros(state.AutomationLevel), nil
 	case "compliance":
-		return upgradeCostFromLevel(int(state.ComplianceLevel)), nil
+		return game.BusinessUpgradeCostMicros(state.ComplianceLevel), nil
 	case "seats":
 		level := int((state.EmployeeLimit - game.BaseBusinessEmployeeLimit) / game.SeatUpgradeIncrement)
 		var total int64
 		for i := int64(0); i < count; i++ {
-			total += seatUpgradeCostFromLevel(level + int(i))
+			total += game.SeatUpgradeCostMicros(level + int(i))
 		}
 		return total, nil
 	default:
@@ -1850,14 +3177,6 @@ func estimateUpgradeCost(ctx context.Context, client *cl.Client, accessToken str
 	}
 }
 
-func upgradeCostFromLevel(level int) int64 {
-	return int64(math.Round(float64((900+level*350)*int(game.MicrosPerStonky)) * (1 + float64(level)*0.12)))
-}
-
-func seatUpgradeCostFromLevel(level int) int64 {
-	return int64(math.Round(float64((1_800+level*700)*int(game.MicrosPerStonky)) * (1 + float64(level)*0.18)))
-}
-
 func estimateFundBuyCost(ctx context.Context, client *cl.Client, accessToken, code string, units int64) (int64, error) {
 	raw, err := client.ListFunds(ctx, accessToken)
 	if err != nil {
@@ -1873,7 +3192,7 @@ func estimateFundBuyCost(ctx context.Context, client *cl.Client, accessToken, co
 			continue
 		}
 		notional := orderNotional(f.NavMicros, units)
-		fee := int64(math.Round(float64(notional) * 0.0010))
+		fee := int64(math.Round(float64(notional) * float64(game.FundFeeBps) / 10_000))
 		return notional + fee, nil
 	}
 	return 0, fmt.Errorf("fund not found")
@@ -1904,7 +3223,7 @@ func newBusinessReserveCmd(apiBase *string) *cobra.Command {
 }
 
 func runReserveTransfer(cmd *cobra.Command, apiBase *string, args []string, direction string) error {
-	sess, err := cl.LoadSession()
+	sess, err := loadSession()
 	if err != nil {
 		return fmt.Errorf("login required: %w", err)
 	}
@@ -1948,7 +3267,7 @@ func runReserveTransfer(cmd *cobra.Command, apiBase *string, args []string, dire
 			IdempotencyKey: idem,
 		})
 	}
-	return renderSimpleOK(out, fmt.Sprintf("Business %d reserve %s: %s stonky.", businessID, direction, formatMicros(amountMicros)))
+	return render(out, renderSimpleOK(fmt.Sprintf("Business %d reserve %s: %s stonky.", businessID, direction, formatMicros(amountMicros))))
 }
 
 func newFundsCmd(apiBase *string) *cobra.Command {
@@ -1960,7 +3279,7 @@ func newFundsCmd(apiBase *string) *cobra.Command {
 		Use:   "list",
 		Short: "List available funds and NAV",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -1971,23 +3290,19 @@ func newFundsCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return renderFundsList(out)
+			return render(out, renderFundsList)
 		},
 	})
 	funds.AddCommand(&cobra.Command{
-		Use:   "buy [fund_code] [shares]",
+		Use:   "buy [fund_code] [units]",
 		Short: "Buy fund units",
 		Args:  cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
-			code, qty, err := fundCodeAndQty(args)
-			if err != nil {
-				return err
-			}
-			units, err := game.SharesToUnits(qty)
+			code, units, err := fundCodeAndQty(args)
 			if err != nil {
 				return err
 			}
@@ -2013,23 +3328,19 @@ func newFundsCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Bought %.4f units of %s.", qty, code))
+			return render(out, renderFundTrade(fmt.Sprintf("Bought %.4f units of %s.", game.UnitsToShares(units), code)))
 		},
 	})
 	funds.AddCommand(&cobra.Command{
-		Use:   "sell [fund_code] [shares]",
+		Use:   "sell [fund_code] [units]",
 		Short: "Sell fund units",
 		Args:  cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
-			code, qty, err := fundCodeAndQty(args)
-			if err != nil {
-				return err
-			}
-			units, err := game.SharesToUnits(qty)
+			code, units, err := fundCodeAndQty(args)
 			if err != nil {
 				return err
 			}
@@ -2048,37 +3359,121 @@ func newFundsCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Sold %.4f units of %s.", qty, code))
+			return render(out, renderFundTrade(fmt.Sprintf("Sold %.4f units of %s.", game.UnitsToShares(units), code)))
+		},
+	})
+	funds.AddCommand(&cobra.Command{
+		Use:   "position [fund_code]",
+		Short: "Show your holding in one fund",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			code, err := fundCodeArgOrPrompt(args, 0, "Fund code")
+			if err != nil {
+				return err
+			}
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.FundPosition(ctx, sess.AccessToken, code)
+			if err != nil {
+				return err
+			}
+			return render(out, renderFundPosition)
+		},
+	})
+	funds.AddCommand(&cobra.Command{
+		Use:   "swap [from_code] [to_code] [units]",
+		Short: "Move fund units into another fund with a single swap fee",
+		Args:  cobra.MaximumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			from, err := fundCodeArgOrPrompt(args, 0, "Swap from")
+			if err != nil {
+				return err
+			}
+			to, err := fundCodeArgOrPrompt(args, 1, "Swap into")
+			if err != nil {
+				return err
+			}
+			units, err := fundQtyArgOrPrompt(args, 2)
+			if err != nil {
+				return err
+			}
+			idem := uuid.NewString()
+			body := map[string]any{"from": from, "to": to, "units": units}
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.SwapFunds(ctx, sess.AccessToken, from, to, idem, units)
+			if err != nil {
+				return queueOnNetworkError(err, syncq.Command{
+					Method:         "POST",
+					Path:           "/v1/funds/swap",
+					Body:           body,
+					IdempotencyKey: idem,
+				})
+			}
+			return render(out, renderFundSwap)
 		},
 	})
 	return funds
 }
 
-func fundCodeAndQty(args []string) (string, float64, error) {
-	code := ""
-	var qty float64
-	var err error
-	if len(args) >= 1 {
-		code = strings.ToUpper(strings.TrimSpace(args[0]))
-	} else {
-		code, err = promptChoice("Fund code", []string{"TECH6X", "CORE20", "VOLT10", "DIVMAX", "AIEDGE", "STABLE"}, "CORE20")
-		if err != nil {
-			return "", 0, err
-		}
-		code = strings.ToUpper(code)
+func fundCodeArgOrPrompt(args []string, idx int, label string) (string, error) {
+	if len(args) > idx {
+		return strings.ToUpper(strings.TrimSpace(args[idx])), nil
 	}
-	if len(args) >= 2 {
-		qty, err = strconv.ParseFloat(strings.TrimSpace(args[1]), 64)
-		if err != nil || qty <= 0 {
-			return "", 0, fmt.Errorf("shares must be a positive number")
+	code, err := promptChoice(label, []string{"TECH6X", "CORE20", "VOLT10", "DIVMAX", "AIEDGE", "STABLE"}, "CORE20")
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(code), nil
+}
+
+// fundCodeAndQty reads a fund code and an amount of units. Funds trade in
+// fractional units like shares do (0.0001 steps), priced at NAV per unit.
+func fundCodeAndQty(args []string) (string, int64, error) {
+	code, err := fundCodeArgOrPrompt(args, 0, "Fund code")
+	if err != nil {
+		return "", 0, err
+	}
+	units, err := fundQtyArgOrPrompt(args, 1)
+	if err != nil {
+		return "", 0, err
+	}
+	return code, units, nil
+}
+
+// fundQtyArgOrPrompt returns the stored fund units for a typed amount,
+// warning when it was rounded to the 0.0001-unit step.
+func fundQtyArgOrPrompt(args []string, idx int) (int64, error) {
+	var qty float64
+	if len(args) > idx {
+		v, err := strconv.ParseFloat(strings.TrimSpace(args[idx]), 64)
+		if err != nil || v <= 0 {
+			return 0, fmt.Errorf("units must be a positive number")
 		}
+		qty = v
 	} else {
-		qty, err = promptFloat("Units", 0)
+		v, err := promptFloat("Units (fractional, 0.0001 steps)", 0)
 		if err != nil {
-			return "", 0, err
+			return 0, err
 		}
+		qty = v
 	}
-	return code, qty, nil
+	units, err := game.FundAmountToUnits(qty)
+	if err != nil {
+		return 0, err
+	}
+	warnIfSharesRounded(qty, units)
+	return units, nil
 }
 
 func newLeaderboardCmd(apiBase *string) *cobra.Command {
@@ -2088,11 +3483,13 @@ func newLeaderboardCmd(apiBase *string) *cobra.Command {
 	}
 	lb.AddCommand(&cobra.Command{
 		Use:   "global",
-		Short: "Global leaderboard",
+		Short: "Global leaderboard (works without login)",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			// The global board is public, so spectators without a usable
+			// session still get it.
+			sess, err := loadSession()
 			if err != nil {
-				return fmt.Errorf("login required: %w", err)
+				printInfo("Viewing as guest; run `stk login` to play.")
 			}
 			client := newClient(apiBase)
 			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
@@ -2101,14 +3498,14 @@ func newLeaderboardCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return renderLeaderboard(out, "Global Leaderboard")
+			return withPager(func() error { return render(out, renderLeaderboard("Global Leaderboard")) })
 		},
 	})
 	lb.AddCommand(&cobra.Command{
 		Use:   "friends",
 		Short: "Friends leaderboard",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -2119,7 +3516,24 @@ func newLeaderboardCmd(apiBase *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return renderLeaderboard(out, "Friends Leaderboard")
+			return withPager(func() error { return render(out, renderLeaderboard("Friends Leaderboard")) })
+		},
+	})
+	lb.AddCommand(&cobra.Command{
+		Use:   "watch",
+		Short: "Live global leaderboard, refreshed after every market tick (works without login)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			client := newClient(apiBase)
+			printInfo("Watching the global leaderboard; press Ctrl+C to stop.")
+			err := client.WatchLeaderboard(ctx, func(out map[string]any) error {
+				return render(out, renderLeaderboard(fmt.Sprintf("Global Leaderboard (live, %s)", formatClock(time.Now()))))
+			})
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
 		},
 	})
 	return lb
@@ -2145,7 +3559,7 @@ func newFriendsCmd(apiBase *string) *cobra.Command {
 		Short: "Follow a user using invite code",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -2167,7 +3581,7 @@ func newFriendsCmd(apiBase *string) *cobra.Command {
 					IdempotencyKey: idem,
 				})
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Now following invite code %s.", code))
+			return render(out, renderSimpleOK(fmt.Sprintf("Now following invite code %s.", code)))
 		},
 	})
 	friends.AddCommand(&cobra.Command{
@@ -2175,7 +3589,58 @@ func newFriendsCmd(apiBase *string) *cobra.Command {
 		Short: "Unfollow a user using invite code",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			sess, err := cl.LoadSession()
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			code, err := inviteCodeFromArgsOrPrompt(args)
+			if err != nil {
+				return err
+			}
+			idem := uuid.NewString()
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.RemoveFriend(ctx, sess.AccessToken, code, idem)
+			if err != nil {
+				return queueOnNetworkError(err, syncq.Command{
+					Method:         "DELETE",
+					Path:           "/v1/friends/" + url.PathEscape(code),
+					IdempotencyKey: idem,
+				})
+			}
+			return render(out, renderSimpleOK(fmt.Sprintf("Stopped following invite code %s.", code)))
+		},
+	})
+	friends.AddCommand(&cobra.Command{
+		Use:   "view [invite_code]",
+		Short: "View a player's public profile",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
+			if err != nil {
+				return fmt.Errorf("login required: %w", err)
+			}
+			code, err := inviteCodeFromArgsOrPrompt(args)
+			if err != nil {
+				return err
+			}
+			client := newClient(apiBase)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			out, err := client.PlayerProfile(ctx, sess.AccessToken, code)
+			if err != nil {
+				return err
+			}
+			return render(out, renderPublicProfile)
+		},
+	})
+	friends.AddCommand(&cobra.Command{
+		Use:   "rank [invite_code]",
+		Short: "Show a followed player's rank over the season",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sess, err := loadSession()
 			if err != nil {
 				return fmt.Errorf("login required: %w", err)
 			}
@@ -2186,24 +3651,140 @@ func newFriendsCmd(apiBase *string) *cobra.Command {
 			client := newClient(apiBase)
 			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 			defer cancel()
-			out, err := client.RemoveFriend(ctx, sess.AccessToken, code)
+			out, err := client.RankHistory(ctx, sess.AccessToken, code)
 			if err != nil {
 				return err
 			}
-			return renderSimpleOK(out, fmt.Sprintf("Stopped following invite code %s.", code))
+			return render(out, renderRankHistory)
 		},
 	})
 	return friends
 }
 
-func queueOnNetworkError(err error, _ syncq.Command) error {
+func newVersionCmd(apiBase *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Show CLI and API versions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			local := buildinfo.Get()
+			fmt.Printf("stk:  %s (commit %s, %s)\n", local.Version, local.Commit, local.GoVersion)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			defer cancel()
+			remote, err := newClient(apiBase).ServerVersion(ctx)
+			if err != nil {
+				printWarn(fmt.Sprintf("api:  unavailable at %s: %v", *apiBase, err))
+				return nil
+			}
+			fmt.Printf("api:  %s (commit %s, %s)\n", remote.Version, remote.Commit, remote.GoVersion)
+			return nil
+		},
+	}
+}
+
+func newDoctorCmd(apiBase *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check API reachability, session, and local sync queue",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			printBanner("Stanks Doctor")
+			failed := 0
+			check := func(ok bool, label, detail string) {
+				printCheck(ok, label, detail)
+				if !ok {
+					failed++
+				}
+			}
+
+			client := newClient(apiBase)
+			if strings.TrimSpace(client.BaseURL) == "" {
+				check(false, "config", "API base URL is empty (set STK_API_BASE_URL)")
+			} else {
+				check(true, "config", "API base URL "+client.BaseURL)
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+			defer cancel()
+			if _, err := client.Health(ctx); err != nil {
+				check(false, "api", fmt.Sprintf("GET /healthz failed: %v", err))
+			} else {
+				check(true, "api", "GET /healthz ok")
+			}
+
+			sess, err := cl.LoadSession()
+			if err != nil {
+				check(false, "session", fmt.Sprintf("no saved session (%v); run `stk login`", err))
+			} else {
+				check(true, "session", "saved session for "+ternaryString(sess.Email != "", sess.Email, sess.UserID))
+				exp, err := cl.TokenExpiry(sess.AccessToken)
+				switch {
+				case err != nil:
+					check(false, "token", err.Error())
+				case time.Now().After(exp):
+					check(false, "token", fmt.Sprintf("expired at %s; run `stk login`", formatTime(exp)))
+				default:
+					check(true, "token", fmt.Sprintf("valid until %s (%s left)", formatTime(exp), time.Until(exp).Round(time.Minute)))
+				}
+				me, err := client.Me(ctx, sess.AccessToken)
+				if err != nil {
+					check(false, "auth", fmt.Sprintf("GET /v1/me failed: %v", err))
+				} else {
+					check(true, "auth", fmt.Sprintf("GET /v1/me ok as %v", me["username"]))
+				}
+			}
+
+			queue, err := syncq.Load()
+			if err != nil {
+				check(false, "sync queue", fmt.Sprintf("cannot read queue: %v", err))
+			} else if syncq.MaxSize > 0 && len(queue) >= syncq.MaxSize {
+				check(false, "sync queue", fmt.Sprintf("full (%d/%d); new offline writes are rejected until you run `stk sync`", len(queue), syncq.MaxSize))
+			} else if len(queue) > 0 {
+				check(false, "sync queue", fmt.Sprintf("%d pending command(s); run `stk sync`", len(queue)))
+			} else {
+				check(true, "sync queue", "empty")
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d check(s) failed", failed)
+			}
+			printSuccess("All checks passed.")
+			return nil
+		},
+	}
+}
+
+// queueOnNetworkError passes API errors through unchanged and persists writes
+// that never reached the API to the local sync queue for `stk sync` to replay.
+func queueOnNetworkError(err error, cmd syncq.Command) error {
 	if err == nil {
 		return nil
 	}
 	if isAPIStructuredError(err) {
 		return err
 	}
-	return fmt.Errorf("request failed (offline queue removed): %w", err)
+	if errors.Is(err, context.Canceled) {
+		return &unknownStatusError{cmd: cmd, err: err}
+	}
+	cmd.MaybeSent = requestMaybeSent(err)
+	if qerr := syncq.Push(cmd); qerr != nil {
+		return fmt.Errorf("request failed and could not be queued (%v): %w", qerr, err)
+	}
+	printWarn(fmt.Sprintf("Network error (%v).", err))
+	printInfo(fmt.Sprintf("Queued %s %s locally. Run `stk sync` to send it once you're back online.", cmd.Method, cmd.Path))
+	return nil
+}
+
+// requestMaybeSent reports whether a failed request could have reached the
+// API. Only dial and DNS failures prove it never left this machine.
+func requestMaybeSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return false
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return false
+	}
+	return true
 }
 
 func isAPIStructuredError(err error) bool {
@@ -2245,7 +3826,7 @@ func int64FromArgOrPrompt(ctx context.Context, apiBase *string, args []string, i
 	}
 
 	if label == "Business ID" {
-		sess, err := cl.LoadSession()
+		sess, err := loadSession()
 		if err == nil {
 			client := newClient(apiBase)
 			dash, err := client.Dashboard(ctx, sess.AccessToken)
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+)
+
+// noPager is set by the root --no-pager flag.
+var noPager bool
+
+// withPager runs a table renderer and, when stdin and stdout are both a
+// terminal and the output is taller than the window, shows it through
+// $PAGER (or less, or a built-in pager when neither is available). Piped
+// output and --no-pager print straight through.
+func withPager(render func() error) error {
+	if noPager || !stdoutIsTerminal() || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return render()
+	}
+	_, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || height <= 0 {
+		return render()
+	}
+	out, renderErr := captureStdout(render)
+	if renderErr != nil || bytes.Count(out, []byte("\n")) < height {
+		os.Stdout.Write(out)
+		return renderErr
+	}
+	return runPager(out, height)
+}
+
+// captureStdout collects everything render writes to stdout, including
+// colored output, which fatih/color sends to color.Output.
+func captureStdout(render func() error) ([]byte, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, render()
+	}
+	stdout, colorOut := os.Stdout, color.Output
+	os.Stdout, color.Output = w, w
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		io.Copy(&buf, r)
+		close(done)
+	}()
+	renderErr := render()
+	os.Stdout, color.Output = stdout, colorOut
+	w.Close()
+	<-done
+	r.Close()
+	return buf.Bytes(), renderErr
+}
+
+// runPager shows out through $PAGER or less. Ctrl-C belongs to the pager
+// while it runs (less uses it to abort a search), so stk stops reacting to
+// it; paging is always the last thing a command does.
+func runPager(out []byte, height int) error {
+	args := strings.Fields(os.Getenv("PAGER"))
+	if len(args) == 0 {
+		if _, err := exec.LookPath("less"); err == nil {
+			args = []string{"less", "-FRX"}
+		}
+	}
+	if len(args) == 0 {
+		return builtinPager(out, height)
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(out)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	signal.Ignore(os.Interrupt)
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			// The pager never started; print the output instead.
+			os.Stdout.Write(out)
+		}
+	}
+	return nil
+}
+
+// builtinPager prints a screenful at a time, waiting for Enter between
+// pages; q stops early.
+func builtinPager(out []byte, height int) error {
+	lines := strings.SplitAfter(string(out), "\n")
+	in := bufio.NewReader(os.Stdin)
+	page := height - 1
+	for start := 0; start < len(lines); start += page {
+		end := min(start+page, len(lines))
+		fmt.Print(strings.Join(lines[start:end], ""))
+		if end == len(lines) {
+			break
+		}
+		neutral.Printf("-- more (%d/%d) Enter for next page, q to quit --", end, len(lines))
+		answer, err := in.ReadString('\n')
+		// Erase the prompt line the Enter key left behind.
+		fmt.Print("\033[1A\033[2K\r")
+		if err != nil || strings.EqualFold(strings.TrimSpace(answer), "q") {
+			break
+		}
+	}
+	return nil
+}
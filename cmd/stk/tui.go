@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -99,9 +100,13 @@ func initialModel(apiBase string) mainModel {
 	}
 	m.menu.Title = "Stanks TUI"
 
+	// An expired token starts the TUI logged out rather than failing every
+	// request with a 401.
 	sess, err := cl.LoadSession()
 	if err == nil {
-		m.session = &sess
+		if _, err := cl.SessionTimeLeft(sess, time.Now()); !errors.Is(err, cl.ErrSessionExpired) {
+			m.session = &sess
+		}
 	}
 
 	return m
@@ -688,7 +693,7 @@ func (m *mainModel) initLoginForm() {
 }
 
 func (m *mainModel) initSignupForm() {
-	m.inputs = make([]textinput.Model, 3)
+	m.inputs = make([]textinput.Model, 4)
 	m.inputs[0] = textinput.New()
 	m.inputs[0].Placeholder = "Email"
 	m.inputs[0].Focus()
@@ -697,6 +702,8 @@ func (m *mainModel) initSignupForm() {
 	m.inputs[1].EchoMode = textinput.EchoPassword
 	m.inputs[2] = textinput.New()
 	m.inputs[2].Placeholder = "Username (optional)"
+	m.inputs[3] = textinput.New()
+	m.inputs[3].Placeholder = "Invite code (required on invite-only servers)"
 	m.focusIndex = 0
 }
 
@@ -879,7 +886,7 @@ func (m mainModel) submitForm() tea.Cmd {
 			return successMsg("Logged in successfully!")
 
 		case stateSignup:
-			_, err := m.client.Signup(ctx, m.inputs[0].Value(), m.inputs[1].Value(), m.inputs[2].Value())
+			_, err := m.client.Signup(ctx, m.inputs[0].Value(), m.inputs[1].Value(), m.inputs[2].Value(), strings.TrimSpace(m.inputs[3].Value()))
 			if err != nil {
 				return errorMsg(err)
 			}
@@ -1002,7 +1009,7 @@ func (m mainModel) submitForm() tea.Cmd {
 				if m.subState == "loan_take" {
 					_, err = m.client.TakeBusinessLoan(ctx, m.session.AccessToken, businessID, micros, uuid.NewString())
 				} else {
-					_, err = m.client.RepayBusinessLoan(ctx, m.session.AccessToken, businessID, micros, uuid.NewString())
+					_, err = m.client.RepayBusinessLoan(ctx, m.session.AccessToken, businessID, micros, 0, uuid.NewString())
 				}
 				if err != nil {
 					return errorMsg(err)
@@ -1169,16 +1176,19 @@ func (m mainModel) dashboardView() string {
 	}
 	s := fmt.Sprintf("  Season: %d\n\n", d.SeasonID)
 	s += fmt.Sprintf("  Balance:        %s stonky\n", cyanStyle.Render(formatMicros(d.BalanceMicros)))
-	s += fmt.Sprintf("  Net Worth:      %s stonky\n", cyanStyle.Render(formatMicros(d.NetWorthMicros)))
+	s += fmt.Sprintf("  Net Worth:      %s stonky%s\n", cyanStyle.Render(formatMicros(d.NetWorthMicros)), formatLeaderboardTop(d.LeaderboardTopBps, d.LeaderboardPlayers))
 	s += fmt.Sprintf("  P/L vs Start:   %s stonky\n", colorizeMicrosTUI(d.NetWorthMicros-game.StarterBalanceMicros))
 	s += fmt.Sprintf("  Stake Value:    %s stonky\n", cyanStyle.Render(formatMicros(stakeValue)))
 	s += fmt.Sprintf("  Stake P/L:      %s stonky\n", colorizeMicrosTUI(stakePL))
+	if d.PortfolioBeta != nil {
+		s += fmt.Sprintf("  Beta:           %.2f vs market\n", *d.PortfolioBeta)
+	}
 	s += "\n" + headerStyle.Render("Positions") + "\n"
 	if len(d.Positions) == 0 {
 		s += infoStyle.Render("No positions yet.") + "\n"
 	} else {
 		for _, p := range d.Positions {
-			s += fmt.Sprintf("  %-8s %10.4f @ %-12s P/L: %s\n", p.Symbol, game.UnitsToShares(p.QuantityUnits), formatMicros(p.CurrentPriceMicros), colorizeMicrosTUI(p.UnrealizedMicros))
+			s += fmt.Sprintf("  %-8s %10.4f @ %-12s P/L: %s\n", p.Symbol, game.UnitsToShares(p.QuantityUnits), formatPrice(p.CurrentPriceMicros), colorizeMicrosTUI(p.UnrealizedMicros))
 		}
 	}
 	s += "\n" + headerStyle.Render("Businesses") + "\n"
@@ -1209,7 +1219,7 @@ func (m mainModel) stocksView() string {
 	}
 	s := fmt.Sprintf("  %-8s %-24s %12s\n", "SYMBOL", "NAME", "PRICE")
 	for _, st := range m.stocks {
-		s += fmt.Sprintf("  %-8s %-24s %12s\n", st.Symbol, truncate(st.DisplayName, 24), formatMicros(st.CurrentPriceMicros))
+		s += fmt.Sprintf("  %-8s %-24s %12s\n", st.Symbol, truncate(st.DisplayName, 24), formatPrice(st.CurrentPriceMicros))
 	}
 	return s
 }
@@ -1221,9 +1231,9 @@ func (m mainModel) fundsView() string {
 	if len(m.funds) == 0 {
 		return infoStyle.Render("Loading funds...")
 	}
-	s := fmt.Sprintf("  %-8s %12s %-40s\n", "CODE", "NAV", "COMPONENTS")
+	s := fmt.Sprintf("  %-8s %-20s %12s %-40s\n", "CODE", "NAME", "NAV", "COMPONENTS")
 	for _, f := range m.funds {
-		s += fmt.Sprintf("  %-8s %12s %-40s\n", f.Code, formatMicros(f.NavMicros), truncate(strings.Join(f.Components, ","), 40))
+		s += fmt.Sprintf("  %-8s %-20s %12s %-40s\n", f.Code, truncate(f.Name, 20), formatMicros(f.NavMicros), truncate(strings.Join(f.Components, ","), 40))
 	}
 	return s
 }
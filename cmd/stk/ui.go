@@ -3,13 +3,18 @@ package main
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	cl "stanks/internal/cli"
 	"stanks/internal/game"
+	"stanks/internal/syncq"
 
 	"github.com/fatih/color"
 )
@@ -21,12 +26,67 @@ var (
 	warn        = color.New(color.FgYellow, color.Bold)
 	danger      = color.New(color.FgRed, color.Bold)
 	neutral     = color.New(color.FgHiWhite)
+
+	// quietOutput is set by the root --quiet flag.
+	quietOutput bool
+	// jsonOutput is set by the root --json flag: renderers print the raw API
+	// response and status lines move to stderr.
+	jsonOutput bool
 )
 
 type stocksPayload struct {
 	Stocks []game.StockView `json:"stocks"`
 }
 
+type recentIPOsPayload struct {
+	Ticks  int64            `json:"ticks"`
+	Stocks []game.RecentIPO `json:"stocks"`
+}
+
+type fundTradeResult struct {
+	NavMicros      int64 `json:"nav_micros"`
+	NotionalMicros int64 `json:"notional_micros"`
+	FeeMicros      int64 `json:"fee_micros"`
+	BalanceMicros  int64 `json:"balance_micros"`
+}
+
+type fundSwapResult struct {
+	FromCode       string `json:"from_code"`
+	ToCode         string `json:"to_code"`
+	FromUnits      int64  `json:"from_units"`
+	ToUnits        int64  `json:"to_units"`
+	FromNavMicros  int64  `json:"from_nav_micros"`
+	ToNavMicros    int64  `json:"to_nav_micros"`
+	NotionalMicros int64  `json:"notional_micros"`
+	FeeMicros      int64  `json:"fee_micros"`
+	CashBackMicros int64  `json:"cash_back_micros"`
+	BalanceMicros  int64  `json:"balance_micros"`
+}
+
+type automationSkipsPayload struct {
+	Skips []game.AutomationSkip `json:"skips"`
+}
+
+type watchlistPayload struct {
+	Watchlist []game.WatchItem `json:"watchlist"`
+}
+
+type alertsPayload struct {
+	Alerts []game.PriceAlert `json:"alerts"`
+}
+
+type regimeHistoryPayload struct {
+	Regimes []game.RegimePeriod `json:"regimes"`
+}
+
+type newsPayload struct {
+	News []game.NewsItem `json:"news"`
+}
+
+type positionsPayload struct {
+	Positions []game.PositionView `json:"positions"`
+}
+
 type candidatesPayload struct {
 	Candidates []employeeCandidate `json:"candidates"`
 }
@@ -47,6 +107,14 @@ type loansPayload struct {
 	Loans []businessLoan `json:"loans"`
 }
 
+type upgradesPayload struct {
+	Upgrades []game.BusinessUpgradeCost `json:"upgrades"`
+}
+
+type liquidationPayload struct {
+	Positions []game.LiquidationEntry `json:"positions"`
+}
+
 type leaderboardPayload struct {
 	Rows []game.LeaderboardRow `json:"rows"`
 }
@@ -89,13 +157,17 @@ type businessMachine struct {
 	OutputBonusMicros int64     `json:"output_bonus_micros"`
 	UpkeepMicros      int64     `json:"upkeep_micros"`
 	ReliabilityBps    int32     `json:"reliability_bps"`
+	FailureStreak     int32     `json:"failure_streak"`
 	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 type fundView struct {
 	Code       string   `json:"code"`
+	Name       string   `json:"name"`
+	Strategy   string   `json:"strategy"`
 	Components []string `json:"components"`
 	NavMicros  int64    `json:"nav_micros"`
+	UnitScale  int64    `json:"unit_scale"`
 }
 
 type businessLoan struct {
@@ -109,25 +181,119 @@ type businessLoan struct {
 	UpdatedAt         time.Time `json:"updated_at"`
 }
 
+// displayLocation is the zone every rendered timestamp is converted to; see
+// configureTimezone.
+var displayLocation = time.Local
+
+// configureTimezone selects the zone for formatTime and friends. Empty or
+// "local" keeps the system zone.
+func configureTimezone(name string) error {
+	name = strings.TrimSpace(name)
+	switch strings.ToLower(name) {
+	case "", "local":
+		displayLocation = time.Local
+		return nil
+	case "utc":
+		displayLocation = time.UTC
+		return nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("unknown timezone %q: use local, UTC, or an IANA name like Europe/Berlin", name)
+	}
+	displayLocation = loc
+	return nil
+}
+
+// formatTime, formatDate and formatClock render t in displayLocation.
+func formatTime(t time.Time) string {
+	return t.In(displayLocation).Format("2006-01-02 15:04")
+}
+
+func formatDate(t time.Time) string {
+	return t.In(displayLocation).Format("2006-01-02")
+}
+
+func formatClock(t time.Time) string {
+	return t.In(displayLocation).Format("15:04:05")
+}
+
+// configureOutput disables colors for --quiet, --json or NO_COLOR.
+func configureOutput(quiet, asJSON bool) {
+	quietOutput = quiet
+	jsonOutput = asJSON
+	if quiet || asJSON || os.Getenv("NO_COLOR") != "" {
+		color.NoColor = true
+	}
+}
+
+// printJSON writes v to stdout as indented JSON for --json output.
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// render prints raw as JSON under --json, and through draw otherwise, so
+// renderers only deal with the human-readable form.
+func render(raw map[string]any, draw func(map[string]any) error) error {
+	if jsonOutput {
+		return printJSON(raw)
+	}
+	return draw(raw)
+}
+
+// messageOut is where status lines and prompts go: stderr under --json, so
+// stdout stays a clean JSON stream.
+func messageOut() io.Writer {
+	if jsonOutput {
+		return os.Stderr
+	}
+	return color.Output
+}
+
+// printBanner prints a "== TITLE ==" section banner unless --quiet is set.
+func printBanner(format string, args ...any) {
+	if quietOutput || jsonOutput {
+		return
+	}
+	accent.Printf("\n== "+format+" ==\n", args...)
+}
+
 func printSuccess(msg string) {
-	success.Println(msg)
+	success.Fprintln(messageOut(), msg)
 }
 
 func printWarn(msg string) {
-	warn.Println(msg)
+	warn.Fprintln(messageOut(), msg)
 }
 
 func printError(msg string) {
-	danger.Println(msg)
+	danger.Fprintln(messageOut(), msg)
+}
+
+func printCheck(ok bool, label, detail string) {
+	if ok {
+		success.Printf("[PASS] ")
+	} else {
+		danger.Printf("[FAIL] ")
+	}
+	fmt.Printf("%-11s %s\n", label, detail)
+}
+
+func warnIfSharesRounded(typed float64, units int64) {
+	if game.SharesRounded(typed, units) {
+		printWarn(fmt.Sprintf("Rounded %g to %.4f (quantities trade in %.4f increments).", typed, game.UnitsToShares(units), game.UnitsToShares(1)))
+	}
 }
 
 func printInfo(msg string) {
-	neutral.Println(msg)
+	neutral.Fprintln(messageOut(), msg)
 }
 
 func promptRequired(label string) (string, error) {
 	for {
-		fmt.Printf("%s: ", label)
+		fmt.Fprintf(messageOut(), "%s: ", label)
 		text, err := stdinReader.ReadString('\n')
 		if err != nil {
 			return "", err
@@ -141,7 +307,7 @@ func promptRequired(label string) (string, error) {
 }
 
 func promptOptional(label string) (string, error) {
-	fmt.Printf("%s: ", label)
+	fmt.Fprintf(messageOut(), "%s: ", label)
 	text, err := stdinReader.ReadString('\n')
 	if err != nil {
 		return "", err
@@ -155,7 +321,7 @@ func promptChoice(label string, options []string, defaultValue string) (string,
 		normalized[strings.ToLower(strings.TrimSpace(opt))] = struct{}{}
 	}
 	for {
-		fmt.Printf("%s (%s) [%s]: ", label, strings.Join(options, "/"), defaultValue)
+		fmt.Fprintf(messageOut(), "%s (%s) [%s]: ", label, strings.Join(options, "/"), defaultValue)
 		text, err := stdinReader.ReadString('\n')
 		if err != nil {
 			return "", err
@@ -215,7 +381,7 @@ func promptConfirm(label string, defaultYes bool) (bool, error) {
 		def = "y"
 	}
 	for {
-		fmt.Printf("%s (y/n) [%s]: ", label, def)
+		fmt.Fprintf(messageOut(), "%s (y/n) [%s]: ", label, def)
 		text, err := stdinReader.ReadString('\n')
 		if err != nil {
 			return false, err
@@ -249,124 +415,252 @@ func promptSymbol(label string) (string, error) {
 	}
 }
 
-func renderDashboard(raw map[string]any) error {
-	d, err := decodeInto[game.Dashboard](raw)
+func formatLeaderboardTop(topBps, players int64) string {
+	if players <= 0 || topBps <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("  (top %.2f%% of %d)", float64(topBps)/100, players)
+}
+
+type hirePreview struct {
+	FullName               string `json:"full_name"`
+	Role                   string `json:"role"`
+	Trait                  string `json:"trait"`
+	HireCostMicros         int64  `json:"hire_cost_micros"`
+	CandidateRiskBps       int64  `json:"candidate_risk_bps"`
+	CurrentRevenueMicros   int64  `json:"current_revenue_per_tick_micros"`
+	ProjectedRevenueMicros int64  `json:"projected_revenue_per_tick_micros"`
+	RevenueDeltaMicros     int64  `json:"revenue_delta_micros"`
+	CurrentAvgRiskBps      int64  `json:"current_avg_risk_bps"`
+	ProjectedAvgRiskBps    int64  `json:"projected_avg_risk_bps"`
+}
+
+type businessSalePreview struct {
+	BusinessID            int64  `json:"business_id"`
+	Name                  string `json:"name"`
+	GrossLowMicros        int64  `json:"gross_valuation_low_micros"`
+	GrossHighMicros       int64  `json:"gross_valuation_high_micros"`
+	LoanPayoffMicros      int64  `json:"loan_payoff_micros"`
+	UnclaimedMicros       int64  `json:"unclaimed_revenue_micros"`
+	PayoutLowMicros       int64  `json:"payout_low_micros"`
+	PayoutHighMicros      int64  `json:"payout_high_micros"`
+	OwnerStakeBps         int64  `json:"owner_stake_bps"`
+	OwnerPayoutLowMicros  int64  `json:"owner_payout_low_micros"`
+	OwnerPayoutHighMicros int64  `json:"owner_payout_high_micros"`
+}
+
+func renderBusinessSalePreview(raw map[string]any) error {
+	p, err := decodeInto[businessSalePreview](raw)
 	if err != nil {
 		return err
 	}
+	printBanner("SELL BUSINESS %d", p.BusinessID)
+	fmt.Printf("Business:                %s\n", p.Name)
+	fmt.Printf("Bank Valuation:          %s - %s stonky\n", formatMicros(p.GrossLowMicros), formatMicros(p.GrossHighMicros))
+	fmt.Printf("Loan Payoff:             %s stonky\n", formatMicros(p.LoanPayoffMicros))
+	fmt.Printf("Unclaimed Revenue:       %s stonky (claimed and taxed before the sale)\n", formatMicros(p.UnclaimedMicros))
+	fmt.Printf("Total Payout:            %s - %s stonky\n", formatMicros(p.PayoutLowMicros), formatMicros(p.PayoutHighMicros))
+	fmt.Printf("Your Payout:             %s - %s stonky (%.2f%% stake)\n", formatMicros(p.OwnerPayoutLowMicros), formatMicros(p.OwnerPayoutHighMicros), float64(p.OwnerStakeBps)/100)
+	printWarn("Selling is irreversible; the bank's valuation is drawn at random within this range.")
+	return nil
+}
 
-	accent.Printf("\n== DASHBOARD (Season %d) ==\n", d.SeasonID)
-	startingPL := d.NetWorthMicros - game.StarterBalanceMicros
-	openPL := int64(0)
-	stakePL := int64(0)
-	stakeValue := int64(0)
-	for _, p := range d.Positions {
-		openPL += p.UnrealizedMicros
-	}
-	for _, stake := range d.Stakes {
-		stakePL += stake.UnrealizedPLMicros
-		stakeValue += stake.EstimatedValueMicros
-	}
-	downFromPeak := d.NetWorthMicros - d.PeakNetWorthMicros
-
-	fmt.Printf("Balance:            %s stonky\n", formatMicros(d.BalanceMicros))
-	fmt.Printf("Net Worth:          %s stonky\n", formatMicros(d.NetWorthMicros))
-	fmt.Printf("Peak Net Worth:     %s stonky\n", formatMicros(d.PeakNetWorthMicros))
-	fmt.Printf("P/L vs Start:       %s stonky\n", colorizeMicros(startingPL))
-	fmt.Printf("Open Position P/L:  %s stonky\n", colorizeMicros(openPL))
-	fmt.Printf("Stake Value:        %s stonky\n", formatMicros(stakeValue))
-	fmt.Printf("Stake P/L:          %s stonky\n", colorizeMicros(stakePL))
-	fmt.Printf("From Peak:          %s stonky\n", colorizeMicros(downFromPeak))
-	fmt.Printf("Reputation:         %s (%d/10000)\n", d.Progression.ReputationTitle, d.Progression.ReputationScore)
-	fmt.Printf("Profit Streak:      %d ticks (best %d, next reward %d)\n", d.Progression.CurrentProfitStreak, d.Progression.BestProfitStreak, d.Progression.NextStreakTarget)
-	fmt.Printf("Risk Appetite:      %.2f%%\n", float64(d.Progression.RiskAppetiteBps)/100)
-	fmt.Printf("Last Tick Delta:    %s stonky\n", colorizeMicros(d.Progression.LastNetWorthDeltaMicros))
-	fmt.Printf("Risk Payout:        %s stonky\n", colorizeMicros(d.Progression.LastRiskPayoutMicros))
-	fmt.Printf("Streak Reward:      %s stonky\n", colorizeMicros(d.Progression.LastStreakRewardMicros))
-
-	fmt.Println()
-	accent.Println("World")
-	fmt.Printf("Regime:             %s\n", d.World.Regime)
-	fmt.Printf("Politics:           %s (%s)\n", d.World.PoliticalClimate, d.World.PolicyFocus)
-	fmt.Printf("Catalyst:           %s (%d ticks left)\n", d.World.CatalystName, d.World.CatalystTicksRemaining)
-	fmt.Printf("Plan:               %s\n", d.World.CatalystSummary)
-	fmt.Printf("Headline:           %s\n", d.World.Headline)
-	for _, region := range d.World.Regions {
-		fmt.Printf("Region %-10s %8s\n", region.Name+":", colorizePercent(float64(region.TrendBps)/100))
+func renderHirePreview(raw map[string]any) error {
+	p, err := decodeInto[hirePreview](raw)
+	if err != nil {
+		return err
+	}
+	printBanner("HIRE PREVIEW")
+	fmt.Printf("Candidate:               %s (%s, %s)\n", p.FullName, p.Role, p.Trait)
+	fmt.Printf("Hire Cost:               %s stonky\n", formatMicros(p.HireCostMicros))
+	fmt.Printf("Revenue/Tick:            %s -> %s stonky (%s)\n", formatMicros(p.CurrentRevenueMicros), formatMicros(p.ProjectedRevenueMicros), colorizeMicros(p.RevenueDeltaMicros))
+	fmt.Printf("Avg Risk:                %.2f%% -> %.2f%% (candidate %.2f%%)\n", float64(p.CurrentAvgRiskBps)/100, float64(p.ProjectedAvgRiskBps)/100, float64(p.CandidateRiskBps)/100)
+	if p.ProjectedAvgRiskBps > p.CurrentAvgRiskBps && p.RevenueDeltaMicros < 0 {
+		printWarn("This hire raises average risk and lowers revenue per tick.")
 	}
+	return nil
+}
 
-	fmt.Println()
-	accent.Println("Positions")
-	if len(d.Positions) == 0 {
-		printInfo("No open positions yet.")
-	} else {
-		fmt.Printf("%-8s %-22s %10s %12s %12s %12s %9s %14s %14s\n", "SYMBOL", "NAME", "QTY", "BUY", "NOW", "DELTA", "DELTA%", "VALUE", "P/L")
+func renderDashboard(format outputFormat) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		d, err := decodeInto[game.Dashboard](raw)
+		if err != nil {
+			return err
+		}
+		if format == formatCSV {
+			return writePositionsCSV(d.Positions)
+		}
+
+		printBanner("DASHBOARD (Season %d)", d.SeasonID)
+		startingPL := d.NetWorthMicros - game.StarterBalanceMicros
+		openPL := int64(0)
+		stakePL := int64(0)
+		stakeValue := int64(0)
 		for _, p := range d.Positions {
-			valueMicros := orderNotional(p.CurrentPriceMicros, p.QuantityUnits)
-			priceDeltaMicros := p.CurrentPriceMicros - p.AvgPriceMicros
-			priceDeltaPct := 0.0
-			if p.AvgPriceMicros != 0 {
-				priceDeltaPct = (float64(priceDeltaMicros) / float64(p.AvgPriceMicros)) * 100
+			openPL += p.UnrealizedMicros
+		}
+		for _, stake := range d.Stakes {
+			stakePL += stake.UnrealizedPLMicros
+			stakeValue += stake.EstimatedValueMicros
+		}
+		downFromPeak := d.NetWorthMicros - d.PeakNetWorthMicros
+
+		fmt.Printf("Balance:            %s stonky\n", formatMicros(d.BalanceMicros))
+		fmt.Printf("Net Worth:          %s stonky%s\n", formatMicros(d.NetWorthMicros), formatLeaderboardTop(d.LeaderboardTopBps, d.LeaderboardPlayers))
+		fmt.Printf("Peak Net Worth:     %s stonky\n", formatMicros(d.PeakNetWorthMicros))
+		fmt.Printf("P/L vs Start:       %s stonky\n", colorizeMicros(startingPL))
+		returnPct := float64(startingPL) / float64(game.StarterBalanceMicros) * 100
+		if annualized, ok := annualizedReturnPct(returnPct, time.Since(d.SeasonStartsAt)); ok {
+			fmt.Printf("Return:             %s (%s annualized)\n", colorizePercent(returnPct), colorizePercent(annualized))
+		} else {
+			fmt.Printf("Return:             %s\n", colorizePercent(returnPct))
+		}
+		fmt.Printf("Open Position P/L:  %s stonky\n", colorizeMicros(openPL))
+		fmt.Printf("Realized P/L:       %s stonky (this season, %s lifetime)\n", colorizeMicros(d.RealizedPLMicros), formatMicros(d.LifetimeRealizedPLMicros))
+		if d.DividendsReceivedMicros > 0 {
+			fmt.Printf("Dividends:          %s stonky (%s reinvested)\n", colorizeMicros(d.DividendsReceivedMicros), formatMicros(d.DividendsReinvestedMicros))
+		}
+		if d.PortfolioBeta != nil {
+			fmt.Printf("Portfolio Beta:     %.2f vs market\n", *d.PortfolioBeta)
+		}
+		fmt.Printf("Stake Value:        %s stonky\n", formatMicros(stakeValue))
+		fmt.Printf("Stake P/L:          %s stonky\n", colorizeMicros(stakePL))
+		fmt.Printf("From Peak:          %s stonky\n", colorizeMicros(downFromPeak))
+		fmt.Printf("Reputation:         %s (%d/10000)\n", d.Progression.ReputationTitle, d.Progression.ReputationScore)
+		fmt.Printf("Profit Streak:      %d ticks (best %d, next reward %d)\n", d.Progression.CurrentProfitStreak, d.Progression.BestProfitStreak, d.Progression.NextStreakTarget)
+		fmt.Printf("Risk Appetite:      %.2f%%\n", float64(d.Progression.RiskAppetiteBps)/100)
+		fmt.Printf("Last Tick Delta:    %s stonky\n", colorizeMicros(d.Progression.LastNetWorthDeltaMicros))
+		fmt.Printf("Risk Payout:        %s stonky\n", colorizeMicros(d.Progression.LastRiskPayoutMicros))
+		fmt.Printf("Streak Reward:      %s stonky\n", colorizeMicros(d.Progression.LastStreakRewardMicros))
+
+		fmt.Println()
+		accent.Println("World")
+		fmt.Printf("Regime:             %s\n", d.World.Regime)
+		fmt.Printf("Politics:           %s (%s)\n", d.World.PoliticalClimate, d.World.PolicyFocus)
+		fmt.Printf("Catalyst:           %s (%d ticks left)\n", d.World.CatalystName, d.World.CatalystTicksRemaining)
+		fmt.Printf("Plan:               %s\n", d.World.CatalystSummary)
+		fmt.Printf("Headline:           %s\n", d.World.Headline)
+		for _, region := range d.World.Regions {
+			fmt.Printf("Region %-10s %8s\n", region.Name+":", colorizePercent(float64(region.TrendBps)/100))
+		}
+
+		fmt.Println()
+		accent.Println("Positions")
+		if len(d.Positions) == 0 {
+			printInfo("No open positions yet.")
+		} else {
+			fmt.Printf("%-8s %-22s %10s %12s %12s %12s %12s %9s %14s %14s %12s\n", "SYMBOL", "NAME", "QTY", "BUY", "BREAKEVEN", "NOW", "DELTA", "DELTA%", "VALUE", "P/L", "DIVIDENDS")
+			for _, p := range d.Positions {
+				valueMicros := orderNotional(p.CurrentPriceMicros, p.QuantityUnits)
+				priceDeltaMicros := p.CurrentPriceMicros - p.AvgPriceMicros
+				priceDeltaPct := 0.0
+				if p.AvgPriceMicros != 0 {
+					priceDeltaPct = (float64(priceDeltaMicros) / float64(p.AvgPriceMicros)) * 100
+				}
+				fmt.Printf("%-8s %-22s %10.4f %12s %12s %12s %12s %9s %14s %14s %12s\n",
+					p.Symbol,
+					truncate(p.DisplayName, 22),
+					game.UnitsToShares(p.QuantityUnits),
+					formatPrice(p.AvgPriceMicros),
+					formatPrice(p.BreakEvenMicros),
+					formatPrice(p.CurrentPriceMicros),
+					colorizeMicros(priceDeltaMicros),
+					colorizePercent(priceDeltaPct),
+					formatMicros(valueMicros),
+					colorizeMicros(p.UnrealizedMicros),
+					formatMicros(p.DividendsMicros),
+				)
 			}
-			fmt.Printf("%-8s %-22s %10.4f %12s %12s %12s %9s %14s %14s\n",
-				p.Symbol,
-				truncate(p.DisplayName, 22),
-				game.UnitsToShares(p.QuantityUnits),
-				formatMicros(p.AvgPriceMicros),
-				formatMicros(p.CurrentPriceMicros),
-				colorizeMicros(priceDeltaMicros),
-				colorizePercent(priceDeltaPct),
-				formatMicros(valueMicros),
-				colorizeMicros(p.UnrealizedMicros),
-			)
 		}
-	}
 
-	fmt.Println()
-	accent.Println("Businesses")
-	if len(d.Businesses) == 0 {
-		printInfo("No businesses yet.")
-	} else {
-		fmt.Printf("%-6s %-20s %-9s %-8s %-10s %-9s %17s %8s %12s %12s %12s %10s\n", "ID", "NAME", "VISIBILITY", "LISTED", "STRATEGY", "CYCLE", "EMPLOYEES/CAP", "MACH", "REV/TICK", "UPKEEP", "LOANS", "RESERVE")
-		for _, b := range d.Businesses {
-			listed := "no"
-			if b.IsListed {
-				listed = "yes"
+		fmt.Println()
+		accent.Println("Businesses")
+		if len(d.Businesses) == 0 {
+			printInfo("No businesses yet.")
+		} else {
+			fmt.Printf("%-6s %-20s %-9s %-8s %-10s %-9s %17s %8s %12s %12s %12s %10s\n", "ID", "NAME", "VISIBILITY", "LISTED", "STRATEGY", "CYCLE", "EMPLOYEES/CAP", "MACH", "REV/TICK", "UPKEEP", "LOANS", "RESERVE")
+			for _, b := range d.Businesses {
+				listed := "no"
+				if b.IsListed {
+					listed = "yes"
+				}
+				fmt.Printf("%-6d %-20s %-9s %-8s %-10s %-9s %17s %8d %12s %12s %12s %10s\n",
+					b.ID,
+					truncate(b.Name, 20),
+					b.Visibility,
+					listed,
+					truncate(b.Strategy, 10),
+					truncate(b.CyclePhase, 9),
+					fmt.Sprintf("%d/%d", b.EmployeeCount, b.EmployeeLimit),
+					b.MachineryCount,
+					formatMicros(b.RevenuePerTickMicros),
+					formatMicros(b.MachineryUpkeepMicros),
+					formatMicros(b.LoanOutstandingMicros),
+					formatMicros(b.CashReserveMicros),
+				)
 			}
-			fmt.Printf("%-6d %-20s %-9s %-8s %-10s %-9s %17s %8d %12s %12s %12s %10s\n",
-				b.ID,
-				truncate(b.Name, 20),
-				b.Visibility,
-				listed,
-				truncate(b.Strategy, 10),
-				truncate(b.CyclePhase, 9),
-				fmt.Sprintf("%d/%d", b.EmployeeCount, b.EmployeeLimit),
-				b.MachineryCount,
-				formatMicros(b.RevenuePerTickMicros),
-				formatMicros(b.MachineryUpkeepMicros),
-				formatMicros(b.LoanOutstandingMicros),
-				formatMicros(b.CashReserveMicros),
-			)
 		}
-	}
-	fmt.Println()
-	accent.Println("Stakes")
-	if len(d.Stakes) == 0 {
-		printInfo("No passive business stakes yet.")
-	} else {
-		fmt.Printf("%-6s %-20s %-10s %14s %14s %14s\n", "ID", "BUSINESS", "STAKE", "REV/TICK", "VALUE", "P/L")
-		for _, stake := range d.Stakes {
-			fmt.Printf("%-6d %-20s %9.2f%% %14s %14s %14s\n",
-				stake.BusinessID,
-				truncate(stake.BusinessName, 20),
-				float64(stake.StakeBps)/100,
-				formatMicros(stake.RevenueShareMicros),
-				formatMicros(stake.EstimatedValueMicros),
-				colorizeMicros(stake.UnrealizedPLMicros),
-			)
+		fmt.Println()
+		accent.Println("Stakes")
+		if len(d.Stakes) == 0 {
+			printInfo("No passive business stakes yet.")
+		} else {
+			fmt.Printf("%-6s %-20s %-10s %14s %14s %14s\n", "ID", "BUSINESS", "STAKE", "REV/TICK", "VALUE", "P/L")
+			for _, stake := range d.Stakes {
+				fmt.Printf("%-6d %-20s %9.2f%% %14s %14s %14s\n",
+					stake.BusinessID,
+					truncate(stake.BusinessName, 20),
+					float64(stake.StakeBps)/100,
+					formatMicros(stake.RevenueShareMicros),
+					formatMicros(stake.EstimatedValueMicros),
+					colorizeMicros(stake.UnrealizedPLMicros),
+				)
+			}
 		}
+
+		fmt.Println()
+		return nil
 	}
+}
+
+// annualizedReturnPct compounds a season-to-date return over a year. Less than
+// a day of history extrapolates wildly, so it reports false until then.
+func annualizedReturnPct(returnPct float64, elapsed time.Duration) (float64, bool) {
+	if elapsed < 24*time.Hour || returnPct <= -100 {
+		return 0, false
+	}
+	years := elapsed.Hours() / (24 * 365)
+	return (math.Pow(1+returnPct/100, 1/years) - 1) * 100, true
+}
 
+func renderHoldings(raw map[string]any) error {
+	payload, err := decodeInto[positionsPayload](raw)
+	if err != nil {
+		return err
+	}
+	printBanner("HOLDINGS")
+	if len(payload.Positions) == 0 {
+		printInfo("No open positions yet.")
+		fmt.Println()
+		return nil
+	}
+	fmt.Printf("%-8s %-22s %10s %12s %12s %14s %14s\n", "SYMBOL", "NAME", "QTY", "BUY", "NOW", "VALUE", "P/L")
+	var totalValue, totalPL int64
+	for _, p := range payload.Positions {
+		valueMicros := orderNotional(p.CurrentPriceMicros, p.QuantityUnits)
+		totalValue += valueMicros
+		totalPL += p.UnrealizedMicros
+		fmt.Printf("%-8s %-22s %10.4f %12s %12s %14s %14s\n",
+			p.Symbol,
+			truncate(p.DisplayName, 22),
+			game.UnitsToShares(p.QuantityUnits),
+			formatPrice(p.AvgPriceMicros),
+			formatPrice(p.CurrentPriceMicros),
+			formatMicros(valueMicros),
+			colorizeMicros(p.UnrealizedMicros),
+		)
+	}
+	fmt.Printf("Total value %s stonky, P/L %s stonky\n", formatMicros(totalValue), colorizeMicros(totalPL))
 	fmt.Println()
 	return nil
 }
@@ -376,22 +670,62 @@ func renderStocksList(raw map[string]any) error {
 	if err != nil {
 		return err
 	}
-	accent.Println("\n== STOCK MARKET ==")
+	printBanner("STOCK MARKET")
 	if len(payload.Stocks) == 0 {
 		printInfo("No stocks found.")
 		return nil
 	}
-	fmt.Printf("%-8s %-24s %12s %-8s\n", "SYMBOL", "NAME", "PRICE", "LISTED")
+	fmt.Printf("%-8s %-24s %12s %-8s %-8s %12s\n", "SYMBOL", "NAME", "PRICE", "LISTED", "VOL", "AVAILABLE")
 	for _, s := range payload.Stocks {
 		listed := "yes"
 		if !s.ListedPublic {
 			listed = "no"
 		}
-		fmt.Printf("%-8s %-24s %12s %-8s\n",
+		available := "-"
+		if s.SharesOutstandingUnits > 0 {
+			available = fmt.Sprintf("%.4f", game.UnitsToShares(s.AvailableUnits))
+		}
+		fmt.Printf("%-8s %-24s %12s %-8s %-8s %12s\n",
 			s.Symbol,
 			truncate(s.DisplayName, 24),
-			formatMicros(s.CurrentPriceMicros),
+			formatPrice(s.CurrentPriceMicros),
 			listed,
+			s.VolatilityTier,
+			available,
+		)
+	}
+	fmt.Println()
+	return nil
+}
+
+func renderRecentIPOs(raw map[string]any) error {
+	payload, err := decodeInto[recentIPOsPayload](raw)
+	if err != nil {
+		return err
+	}
+	printBanner("RECENT IPOS (last %d ticks)", payload.Ticks)
+	if len(payload.Stocks) == 0 {
+		printInfo("No new listings in this window.")
+		return nil
+	}
+	fmt.Printf("%-8s %-22s %-20s %12s %12s %9s %8s\n", "SYMBOL", "NAME", "BUSINESS", "IPO", "NOW", "CHANGE", "AGE")
+	for _, st := range payload.Stocks {
+		changePct := 0.0
+		if st.IPOPriceMicros > 0 {
+			changePct = float64(st.CurrentPriceMicros-st.IPOPriceMicros) / float64(st.IPOPriceMicros) * 100
+		}
+		business := st.BusinessName
+		if business == "" {
+			business = "-"
+		}
+		fmt.Printf("%-8s %-22s %-20s %12s %12s %9s %8s\n",
+			st.Symbol,
+			truncate(st.DisplayName, 22),
+			truncate(business, 20),
+			formatPrice(st.IPOPriceMicros),
+			formatPrice(st.CurrentPriceMicros),
+			colorizePercent(changePct),
+			fmt.Sprintf("%dt", st.TicksSinceListing),
 		)
 	}
 	fmt.Println()
@@ -403,9 +737,16 @@ func renderStockDetail(raw map[string]any) error {
 	if err != nil {
 		return err
 	}
-	accent.Printf("\n== %s (%s) ==\n", detail.Symbol, detail.DisplayName)
-	fmt.Printf("Current Price: %s stonky\n", formatMicros(detail.CurrentPriceMicros))
+	printBanner("%s (%s)", detail.Symbol, detail.DisplayName)
+	fmt.Printf("Current Price: %s stonky\n", formatPrice(detail.CurrentPriceMicros))
 	fmt.Printf("Listed Public: %t\n", detail.ListedPublic)
+	fmt.Printf("Volatility:    %s (%.2fx)\n", detail.VolatilityTier, float64(detail.VolatilityBps)/10_000)
+	fmt.Printf("Sentiment:     %s (%.0f%% buys, %d orders in %s)\n",
+		colorizeSentiment(detail.Sentiment.Label),
+		float64(detail.Sentiment.BuyShareBps)/100,
+		detail.Sentiment.Orders,
+		time.Duration(detail.Sentiment.WindowSeconds)*time.Second,
+	)
 
 	if len(detail.Series) > 1 {
 		latest := detail.Series[0].PriceMicros
@@ -424,37 +765,183 @@ func renderStockDetail(raw map[string]any) error {
 		}
 		for i := 0; i < limit; i++ {
 			point := detail.Series[i]
-			fmt.Printf("%-20s %12s\n", point.TickAt.Local().Format("2006-01-02 15:04"), formatMicros(point.PriceMicros))
+			fmt.Printf("%-20s %12s\n", formatTime(point.TickAt), formatPrice(point.PriceMicros))
 		}
 	}
 	fmt.Println()
 	return nil
 }
 
-func renderOrderResult(raw map[string]any, side, symbol string, qty float64) error {
-	out, err := decodeInto[game.OrderResult](raw)
-	if err != nil {
-		return err
+func colorizeSentiment(label string) string {
+	switch label {
+	case game.SentimentBullish:
+		return success.Sprint(label)
+	case game.SentimentBearish:
+		return danger.Sprint(label)
+	default:
+		return neutral.Sprint(label)
 	}
-	action := strings.ToUpper(side)
-	accent.Printf("\n== ORDER %s ==\n", action)
-	fmt.Printf("Symbol:  %s\n", strings.ToUpper(symbol))
-	fmt.Printf("Shares:  %.4f\n", qty)
-	fmt.Printf("Price:   %s stonky\n", formatMicros(out.PriceMicros))
-	fmt.Printf("Notional:%s stonky\n", formatMicros(out.NotionalMicros))
-	fmt.Printf("Fee:     %s stonky\n", formatMicros(out.FeeMicros))
-	fmt.Printf("Balance: %s stonky\n", formatMicros(out.BalanceMicros))
-	fmt.Println()
-	return nil
 }
 
-func renderBusinessCreated(raw map[string]any, name, visibility string) error {
-	out, err := decodeInto[createBusinessPayload](raw)
-	if err != nil {
-		return err
+func renderStockCandles(bucket string) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		out, err := decodeInto[game.StockCandles](raw)
+		if err != nil {
+			return err
+		}
+		printBanner("%s CANDLES (%s)", out.Symbol, bucket)
+		if len(out.Candles) == 0 {
+			printInfo("No ticks in this window.")
+			fmt.Println()
+			return nil
+		}
+		closes := make([]int64, 0, len(out.Candles))
+		for _, c := range out.Candles {
+			closes = append(closes, c.CloseMicros)
+		}
+		fmt.Printf("Closes: %s\n", sparkline(closes))
+		first, last := out.Candles[0], out.Candles[len(out.Candles)-1]
+		fmt.Printf("Change: %s stonky\n\n", colorizeMicros(last.CloseMicros-first.OpenMicros))
+
+		fmt.Printf("%-17s %12s %12s %12s %12s %6s\n", "BUCKET", "OPEN", "HIGH", "LOW", "CLOSE", "TICKS")
+		start := 0
+		if len(out.Candles) > 24 {
+			start = len(out.Candles) - 24
+		}
+		for _, c := range out.Candles[start:] {
+			fmt.Printf("%-17s %12s %12s %12s %12s %6d\n",
+				formatTime(c.BucketStart),
+				formatMicros(c.OpenMicros),
+				formatMicros(c.HighMicros),
+				formatMicros(c.LowMicros),
+				formatMicros(c.CloseMicros),
+				c.Ticks,
+			)
+		}
+		fmt.Println()
+		return nil
+	}
+}
+
+// candleChartHeight is the number of price rows in `stk stocks chart`.
+const candleChartHeight = 16
+
+// renderCandleChart draws one column per candle: a body (█) from open to
+// close, green when it closed up and red when down, with wicks (│) out to
+// the high and low. Flat carried-forward candles for buckets with no ticks
+// are drawn as a dim ─ at the previous close.
+func renderCandleChart(interval string) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		out, err := decodeInto[game.StockCandles](raw)
+		if err != nil {
+			return err
+		}
+		printBanner("%s CHART (%s)", out.Symbol, interval)
+		if len(out.Candles) == 0 {
+			printInfo("No ticks in this window.")
+			fmt.Println()
+			return nil
+		}
+		lo, hi := out.Candles[0].LowMicros, out.Candles[0].HighMicros
+		for _, c := range out.Candles {
+			lo = min(lo, c.LowMicros)
+			hi = max(hi, c.HighMicros)
+		}
+		row := func(v int64) int {
+			if hi == lo {
+				return candleChartHeight / 2
+			}
+			return int(float64(v-lo) / float64(hi-lo) * float64(candleChartHeight-1))
+		}
+		labels := map[int]int64{candleChartHeight - 1: hi, (candleChartHeight - 1) / 2: lo + (hi-lo)/2, 0: lo}
+		for r := candleChartHeight - 1; r >= 0; r-- {
+			label := ""
+			if v, ok := labels[r]; ok {
+				label = formatPrice(v)
+			}
+			fmt.Printf("%12s ┤", label)
+			for _, c := range out.Candles {
+				bodyLo, bodyHi := row(min(c.OpenMicros, c.CloseMicros)), row(max(c.OpenMicros, c.CloseMicros))
+				paint := success
+				if c.CloseMicros < c.OpenMicros {
+					paint = danger
+				}
+				switch {
+				case c.Ticks == 0 && r == bodyLo:
+					fmt.Print(neutral.Sprint("─"))
+				case c.Ticks > 0 && r >= bodyLo && r <= bodyHi:
+					fmt.Print(paint.Sprint("█"))
+				case c.Ticks > 0 && r >= row(c.LowMicros) && r <= row(c.HighMicros):
+					fmt.Print(paint.Sprint("│"))
+				default:
+					fmt.Print(" ")
+				}
+			}
+			fmt.Println()
+		}
+		first, last := out.Candles[0], out.Candles[len(out.Candles)-1]
+		fmt.Printf("%12s  %s -> %s\n", "", formatTime(first.BucketStart), formatTime(last.BucketStart))
+		fmt.Printf("Last:   %s stonky\n", formatPrice(last.CloseMicros))
+		fmt.Printf("Change: %s stonky\n\n", colorizeMicros(last.CloseMicros-first.OpenMicros))
+		return nil
+	}
+}
+
+// sparkline draws values as block characters scaled between their min and max.
+func sparkline(values []int64) string {
+	const blocks = "▁▂▃▄▅▆▇█"
+	levels := []rune(blocks)
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if hi > lo {
+			idx = int(float64(v-lo) / float64(hi-lo) * float64(len(levels)-1))
+		}
+		b.WriteRune(levels[idx])
+	}
+	return b.String()
+}
+
+func renderOrderResult(side, symbol string) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		out, err := decodeInto[game.OrderResult](raw)
+		if err != nil {
+			return err
+		}
+		action := strings.ToUpper(side)
+		printBanner("ORDER %s", action)
+		fmt.Printf("Symbol:  %s\n", strings.ToUpper(symbol))
+		fmt.Printf("Shares:  %.4f\n", game.UnitsToShares(out.QuantityUnits))
+		fmt.Printf("Price:   %s stonky\n", formatPrice(out.PriceMicros))
+		fmt.Printf("Notional:%s stonky\n", formatMicros(out.NotionalMicros))
+		if out.FeeDiscountMicros > 0 {
+			fmt.Printf("Fee:     %s stonky (%s off for fund membership)\n", formatMicros(out.FeeMicros), formatMicros(out.FeeDiscountMicros))
+		} else {
+			fmt.Printf("Fee:     %s stonky\n", formatMicros(out.FeeMicros))
+		}
+		fmt.Printf("Balance: %s stonky\n", formatMicros(out.BalanceMicros))
+		fmt.Println()
+		return nil
+	}
+}
+
+func renderBusinessCreated(name, visibility string) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		out, err := decodeInto[createBusinessPayload](raw)
+		if err != nil {
+			return err
+		}
+		printSuccess(fmt.Sprintf("Business created: #%d %s (%s)", out.ID, name, visibility))
+		return nil
 	}
-	printSuccess(fmt.Sprintf("Business created: #%d %s (%s)", out.ID, name, visibility))
-	return nil
 }
 
 func renderBusinessState(raw map[string]any) error {
@@ -462,7 +949,7 @@ func renderBusinessState(raw map[string]any) error {
 	if err != nil {
 		return err
 	}
-	accent.Printf("\n== BUSINESS #%d ==\n", out.ID)
+	printBanner("BUSINESS #%d", out.ID)
 	fmt.Printf("Name:        %s\n", out.Name)
 	fmt.Printf("Visibility:  %s\n", out.Visibility)
 	fmt.Printf("Listed:      %t\n", out.IsListed)
@@ -482,6 +969,9 @@ func renderBusinessState(raw map[string]any) error {
 	fmt.Printf("Brand:       %.2f%%\n", float64(out.BrandBps)/100)
 	fmt.Printf("Op Health:   %.2f%%\n", float64(out.OperationalHealthBps)/100)
 	fmt.Printf("Reserve:     %s stonky\n", formatMicros(out.CashReserveMicros))
+	if out.RevenueMode == "accrue" || out.UnclaimedMicros > 0 {
+		fmt.Printf("Revenue:     %s mode, %s stonky unclaimed\n", out.RevenueMode, formatMicros(out.UnclaimedMicros))
+	}
 	fmt.Printf("Revenue/tick:%s stonky\n", formatMicros(out.RevenuePerTickMicros))
 	fmt.Printf("Salary/tick: %s stonky\n", formatMicros(out.EmployeeSalaryMicros))
 	fmt.Printf("Maint/tick:  %s stonky\n", formatMicros(out.MaintenanceMicros))
@@ -500,7 +990,7 @@ func renderEmployeeCandidates(raw map[string]any) error {
 	if err != nil {
 		return err
 	}
-	accent.Println("\n== EMPLOYEE CANDIDATES ==")
+	printBanner("EMPLOYEE CANDIDATES")
 	if len(out.Candidates) == 0 {
 		printInfo("No candidates available.")
 		return nil
@@ -521,123 +1011,600 @@ func renderEmployeeCandidates(raw map[string]any) error {
 	return nil
 }
 
-func renderBusinessEmployees(raw map[string]any, businessID int64) error {
-	out, err := decodeInto[businessEmployeesPayload](raw)
+func renderBusinessEmployees(businessID int64) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		out, err := decodeInto[businessEmployeesPayload](raw)
+		if err != nil {
+			return err
+		}
+		printBanner("BUSINESS #%d EMPLOYEES", businessID)
+		if len(out.Employees) == 0 {
+			printInfo("No employees hired yet.")
+			return nil
+		}
+		fmt.Printf("%-4s %-18s %-10s %-12s %12s %8s %-16s\n", "ID", "NAME", "ROLE", "TRAIT", "REV/TICK", "RISK", "HIRED")
+		for _, e := range out.Employees {
+			fmt.Printf("%-4d %-18s %-10s %-12s %12s %7.2f%% %-16s\n",
+				e.ID,
+				truncate(e.FullName, 18),
+				truncate(e.Role, 10),
+				truncate(e.Trait, 12),
+				formatMicros(e.RevenuePerTickMicros),
+				float64(e.RiskBps)/100,
+				formatTime(e.CreatedAt),
+			)
+		}
+		fmt.Println()
+		return nil
+	}
+}
+
+func renderBusinessMachinery(businessID int64) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		out, err := decodeInto[machineryPayload](raw)
+		if err != nil {
+			return err
+		}
+		printBanner("BUSINESS #%d MACHINERY", businessID)
+		if len(out.Machinery) == 0 {
+			printInfo("No machinery installed yet.")
+			return nil
+		}
+		fmt.Printf("%-4s %-16s %8s %12s %12s %10s %8s\n", "ID", "TYPE", "LEVEL", "OUTPUT", "UPKEEP", "RELIAB.", "FAILS")
+		for _, m := range out.Machinery {
+			fmt.Printf("%-4d %-16s %8d %12s %12s %9.2f%% %8d\n",
+				m.ID,
+				truncate(m.MachineType, 16),
+				m.Level,
+				formatMicros(m.OutputBonusMicros),
+				formatMicros(m.UpkeepMicros),
+				float64(m.ReliabilityBps)/100,
+				m.FailureStreak,
+			)
+		}
+		fmt.Println()
+		return nil
+	}
+}
+
+func renderBusinessLoans(businessID int64) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		out, err := decodeInto[loansPayload](raw)
+		if err != nil {
+			return err
+		}
+		printBanner("BUSINESS #%d LOANS", businessID)
+		if len(out.Loans) == 0 {
+			printInfo("No loans on this business.")
+			return nil
+		}
+		fmt.Printf("%-4s %12s %12s %9s %8s %-10s\n", "ID", "PRINCIPAL", "OUTSTAND", "RATE", "MISSED", "STATUS")
+		for _, l := range out.Loans {
+			fmt.Printf("%-4d %12s %12s %8.2f%% %8d %-10s\n",
+				l.ID,
+				formatMicros(l.PrincipalMicros),
+				formatMicros(l.OutstandingMicros),
+				float64(l.InterestBps)/100,
+				l.MissedTicks,
+				l.Status,
+			)
+		}
+		fmt.Println()
+		return nil
+	}
+}
+
+func renderBusinessUpgrades(businessID int64) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		out, err := decodeInto[upgradesPayload](raw)
+		if err != nil {
+			return err
+		}
+		printBanner("BUSINESS #%d UPGRADES", businessID)
+		fmt.Printf("%-12s %6s %14s\n", "UPGRADE", "LEVEL", "NEXT COST")
+		for _, u := range out.Upgrades {
+			next := formatMicros(u.NextCostMicros)
+			if u.Maxed {
+				next = "maxed"
+			}
+			fmt.Printf("%-12s %6d %14s", u.Upgrade, u.Level, next)
+			if u.Upgrade == "seats" {
+				fmt.Printf("  (%d employee limit)", u.EmployeeLimit)
+			}
+			fmt.Println()
+		}
+		fmt.Println()
+		return nil
+	}
+}
+
+func renderAutomationSkips(raw map[string]any) error {
+	out, err := decodeInto[automationSkipsPayload](raw)
 	if err != nil {
 		return err
 	}
-	accent.Printf("\n== BUSINESS #%d EMPLOYEES ==\n", businessID)
-	if len(out.Employees) == 0 {
-		printInfo("No employees hired yet.")
+	printBanner("SKIPPED AUTOMATION")
+	if len(out.Skips) == 0 {
+		printInfo("No automated operations have been skipped.")
 		return nil
 	}
-	fmt.Printf("%-4s %-18s %-10s %-12s %12s %8s %-16s\n", "ID", "NAME", "ROLE", "TRAIT", "REV/TICK", "RISK", "HIRED")
-	for _, e := range out.Employees {
-		fmt.Printf("%-4d %-18s %-10s %-12s %12s %7.2f%% %-16s\n",
-			e.ID,
-			truncate(e.FullName, 18),
-			truncate(e.Role, 10),
-			truncate(e.Trait, 12),
-			formatMicros(e.RevenuePerTickMicros),
-			float64(e.RiskBps)/100,
-			e.CreatedAt.Local().Format("2006-01-02 15:04"),
+	fmt.Printf("%-18s %8s %14s %14s %6s %-16s %s\n", "OPERATION", "REF", "NEEDED", "AVAILABLE", "TICKS", "LAST SKIPPED", "STATUS")
+	for _, sk := range out.Skips {
+		status := "retrying"
+		if sk.ResolvedAt != nil {
+			status = "resolved " + formatTime(*sk.ResolvedAt)
+		}
+		fmt.Printf("%-18s %8d %14s %14s %6d %-16s %s\n",
+			sk.Operation,
+			sk.RefID,
+			formatMicros(sk.NeededMicros),
+			formatMicros(sk.AvailableMicros),
+			sk.SkippedTicks,
+			formatTime(sk.LastSkippedAt),
+			status,
 		)
 	}
 	fmt.Println()
 	return nil
 }
 
-func renderBusinessMachinery(raw map[string]any, businessID int64) error {
-	out, err := decodeInto[machineryPayload](raw)
+func renderLiquidationOrder(raw map[string]any) error {
+	out, err := decodeInto[liquidationPayload](raw)
 	if err != nil {
 		return err
 	}
-	accent.Printf("\n== BUSINESS #%d MACHINERY ==\n", businessID)
-	if len(out.Machinery) == 0 {
-		printInfo("No machinery installed yet.")
+	printBanner("FORCED-SALE ORDER")
+	if len(out.Positions) == 0 {
+		printInfo("No open positions.")
 		return nil
 	}
-	fmt.Printf("%-4s %-16s %8s %12s %12s %10s\n", "ID", "TYPE", "LEVEL", "OUTPUT", "UPKEEP", "RELIAB.")
-	for _, m := range out.Machinery {
-		fmt.Printf("%-4d %-16s %8d %12s %12s %9.2f%%\n",
-			m.ID,
-			truncate(m.MachineType, 16),
-			m.Level,
-			formatMicros(m.OutputBonusMicros),
-			formatMicros(m.UpkeepMicros),
-			float64(m.ReliabilityBps)/100,
+	fmt.Printf("%-3s %-10s %10s %14s %9s\n", "#", "SYMBOL", "SHARES", "VALUE", "PRIORITY")
+	for i, p := range out.Positions {
+		fmt.Printf("%-3d %-10s %10.4f %14s %9d\n",
+			i+1,
+			p.Symbol,
+			game.UnitsToShares(p.QuantityUnits),
+			formatMicros(p.ValueMicros),
+			p.Priority,
 		)
 	}
 	fmt.Println()
 	return nil
 }
 
-func renderBusinessLoans(raw map[string]any, businessID int64) error {
-	out, err := decodeInto[loansPayload](raw)
+func renderWatchlist(raw map[string]any) error {
+	out, err := decodeInto[watchlistPayload](raw)
 	if err != nil {
 		return err
 	}
-	accent.Printf("\n== BUSINESS #%d LOANS ==\n", businessID)
-	if len(out.Loans) == 0 {
-		printInfo("No loans on this business.")
+	printBanner("WATCHLIST")
+	if len(out.Watchlist) == 0 {
+		printInfo("Nothing watched yet. Add a symbol with `stk stocks watch add SYMBOL`.")
 		return nil
 	}
-	fmt.Printf("%-4s %12s %12s %9s %8s %-10s\n", "ID", "PRINCIPAL", "OUTSTAND", "RATE", "MISSED", "STATUS")
-	for _, l := range out.Loans {
-		fmt.Printf("%-4d %12s %12s %8.2f%% %8d %-10s\n",
-			l.ID,
-			formatMicros(l.PrincipalMicros),
-			formatMicros(l.OutstandingMicros),
-			float64(l.InterestBps)/100,
-			l.MissedTicks,
-			l.Status,
+	fmt.Printf("%-8s %-22s %12s %12s %9s %-16s\n", "SYMBOL", "NAME", "ADDED AT", "NOW", "CHANGE", "SINCE")
+	for _, w := range out.Watchlist {
+		fmt.Printf("%-8s %-22s %12s %12s %9s %-16s\n",
+			w.Symbol,
+			truncate(w.DisplayName, 22),
+			formatPrice(w.AddedPriceMicros),
+			formatPrice(w.CurrentPriceMicros),
+			colorizePercent(float64(w.ChangeBps)/100),
+			formatTime(w.AddedAt),
 		)
 	}
 	fmt.Println()
 	return nil
 }
 
+func renderTriggeredAlerts(alerts []game.PriceAlert) {
+	if jsonOutput {
+		_ = printJSON(map[string]any{"alerts": alerts})
+		return
+	}
+	printBanner("PRICE ALERTS")
+	if len(alerts) == 0 {
+		printInfo("No new alerts. Set one with `stk alerts set SYMBOL above|below PRICE`.")
+		return
+	}
+	fmt.Printf("%-8s %-6s %12s %12s %-16s\n", "SYMBOL", "WHEN", "TARGET", "HIT AT", "TRIGGERED")
+	for _, a := range alerts {
+		hit, when := "-", "-"
+		if a.TriggeredPriceMicros != nil {
+			hit = formatPrice(*a.TriggeredPriceMicros)
+		}
+		if a.TriggeredAt != nil {
+			when = formatTime(*a.TriggeredAt)
+		}
+		fmt.Printf("%-8s %-6s %12s %12s %-16s\n", a.Symbol, a.Direction, formatPrice(a.TargetPriceMicros), hit, when)
+	}
+	fmt.Println()
+}
+
+// writePositionsCSV prints stock positions for `stk dash --csv`, amounts in
+// plain decimal stonky.
+func writePositionsCSV(positions []game.PositionView) error {
+	rows := make([][]string, 0, len(positions))
+	for _, p := range positions {
+		rows = append(rows, []string{
+			p.Symbol,
+			csvShares(p.QuantityUnits),
+			csvMicros(p.AvgPriceMicros),
+			csvMicros(p.CurrentPriceMicros),
+			csvMicros(orderNotional(p.CurrentPriceMicros, p.QuantityUnits)),
+			csvMicros(p.UnrealizedMicros),
+		})
+	}
+	return writeCSV([]string{"symbol", "shares", "avg_price", "current_price", "market_value", "unrealized_pl"}, rows)
+}
+
+func renderOrderHistory(format outputFormat) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		out, err := decodeInto[game.OrderPage](raw)
+		if err != nil {
+			return err
+		}
+		if format == formatCSV {
+			rows := make([][]string, 0, len(out.Orders))
+			for _, o := range out.Orders {
+				realized := ""
+				if o.RealizedPLMicros != nil {
+					realized = csvMicros(*o.RealizedPLMicros)
+				}
+				rows = append(rows, []string{
+					csvTime(o.CreatedAt),
+					o.Symbol,
+					o.Side,
+					strconv.FormatBool(o.Short),
+					csvShares(o.QuantityUnits),
+					csvMicros(o.PriceMicros),
+					csvMicros(o.NotionalMicros),
+					csvMicros(o.FeeMicros),
+					realized,
+				})
+			}
+			return writeCSV([]string{"time", "symbol", "side", "short", "shares", "price", "notional", "fee", "realized_pl"}, rows)
+		}
+		printBanner("ORDER HISTORY")
+		if len(out.Orders) == 0 {
+			printInfo("No orders on this page.")
+			return nil
+		}
+		fmt.Printf("%-16s %-8s %-4s %10s %12s %10s %14s\n", "TIME", "SYMBOL", "SIDE", "SHARES", "PRICE", "FEE", "REALIZED P/L")
+		for _, o := range out.Orders {
+			realized := "-"
+			if o.RealizedPLMicros != nil {
+				realized = colorizeMicros(*o.RealizedPLMicros)
+			}
+			fmt.Printf("%-16s %-8s %-4s %10.4f %12s %10s %14s\n",
+				formatTime(o.CreatedAt),
+				o.Symbol,
+				o.Side,
+				game.UnitsToShares(o.QuantityUnits),
+				formatPrice(o.PriceMicros),
+				formatMicros(o.FeeMicros),
+				realized,
+			)
+		}
+		if out.Limit > 0 {
+			pages := (out.Total + int64(out.Limit) - 1) / int64(out.Limit)
+			fmt.Printf("\nPage %d of %d (%d orders). Use --page for older trades.\n", out.Offset/out.Limit+1, pages, out.Total)
+		}
+		fmt.Println()
+		return nil
+	}
+}
+
 func renderFundsList(raw map[string]any) error {
 	out, err := decodeInto[fundsPayload](raw)
 	if err != nil {
 		return err
 	}
-	accent.Println("\n== MUTUAL FUNDS ==")
+	printBanner("MUTUAL FUNDS")
 	if len(out.Funds) == 0 {
 		printInfo("No funds available.")
 		return nil
 	}
-	fmt.Printf("%-8s %12s %-60s\n", "CODE", "NAV", "COMPONENTS")
+	fmt.Printf("%-8s %-22s %12s\n", "CODE", "NAME", "NAV/UNIT")
 	for _, f := range out.Funds {
-		fmt.Printf("%-8s %12s %-60s\n",
-			f.Code,
-			formatMicros(f.NavMicros),
-			truncate(strings.Join(f.Components, ","), 60),
-		)
+		fmt.Printf("%-8s %-22s %12s\n", f.Code, truncate(f.Name, 22), formatMicros(f.NavMicros))
+		if f.Strategy != "" {
+			fmt.Printf("         %s\n", f.Strategy)
+		}
+		fmt.Printf("         holds: %s\n", truncate(strings.Join(f.Components, ","), 70))
+	}
+	scale := game.FundUnitScale
+	if len(out.Funds) > 0 && out.Funds[0].UnitScale > 0 {
+		scale = out.Funds[0].UnitScale
 	}
 	fmt.Println()
+	printInfo(fmt.Sprintf("NAV is the price of one whole unit. Units are fractional down to %.4f, like shares.", 1/float64(scale)))
+	fmt.Println()
 	return nil
 }
 
-func renderLeaderboard(raw map[string]any, title string) error {
-	out, err := decodeInto[leaderboardPayload](raw)
+func renderLeaderboard(title string) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		out, err := decodeInto[leaderboardPayload](raw)
+		if err != nil {
+			return err
+		}
+		printBanner("%s", strings.ToUpper(title))
+		if len(out.Rows) == 0 {
+			printInfo("No leaderboard rows yet.")
+			return nil
+		}
+		fmt.Printf("%-6s %-18s %-12s %14s\n", "RANK", "PLAYER", "INVITE", "NET WORTH")
+		for _, row := range out.Rows {
+			fmt.Printf("%-6d %-18s %-12s %14s\n",
+				row.Rank,
+				truncate(row.Username, 18),
+				truncate(row.InviteCode, 12),
+				formatMicros(row.NetWorthMicros),
+			)
+		}
+		fmt.Println()
+		return nil
+	}
+}
+
+func renderCosts(raw map[string]any) error {
+	c, err := decodeInto[game.CostBreakdown](raw)
 	if err != nil {
 		return err
 	}
-	accent.Printf("\n== %s ==\n", strings.ToUpper(title))
-	if len(out.Rows) == 0 {
-		printInfo("No leaderboard rows yet.")
+	printBanner("COSTS (Season %d)", c.SeasonID)
+	fmt.Printf("Trade Fees:         %s stonky\n", formatMicros(c.TradeFeesMicros))
+	fmt.Printf("Debt Interest:      %s stonky\n", formatMicros(c.DebtInterestMicros))
+	fmt.Printf("Loan Late Fees:     %s stonky\n", formatMicros(c.LoanLateFeesMicros))
+	fmt.Printf("Business Losses:    %s stonky\n", formatMicros(c.BusinessLossesMicros))
+	fmt.Printf("Business Tax:       %s stonky\n", formatMicros(c.BusinessTaxMicros))
+	fmt.Printf("Total:              %s stonky\n", formatMicros(c.TotalMicros))
+	printInfo("Upkeep, salaries, and loan interest are netted into business revenue; only losing ticks appear above.")
+	fmt.Println()
+	return nil
+}
+
+func renderPublicProfile(raw map[string]any) error {
+	p, err := decodeInto[game.PublicProfile](raw)
+	if err != nil {
+		return err
+	}
+	printBanner("PLAYER %s", strings.ToUpper(p.Username))
+	fmt.Printf("Invite Code:  %s\n", p.InviteCode)
+	fmt.Printf("Joined:       %s\n", formatDate(p.JoinedAt))
+	if p.Rank == 0 {
+		fmt.Printf("Rank:         unranked this season\n")
+	} else {
+		fmt.Printf("Rank:         #%d%s\n", p.Rank, formatLeaderboardTop(p.LeaderboardTopBps, p.Players))
+		fmt.Printf("Net Worth:    %s stonky\n", formatMicros(p.NetWorthMicros))
+	}
+	fmt.Printf("Businesses:   %d\n", p.BusinessCount)
+	switch {
+	case p.Following && p.FollowsYou:
+		fmt.Printf("Friends:      you follow each other\n")
+	case p.Following:
+		fmt.Printf("Friends:      you follow them\n")
+	case p.FollowsYou:
+		fmt.Printf("Friends:      they follow you\n")
+	}
+	fmt.Println()
+	return nil
+}
+
+func renderRankHistory(raw map[string]any) error {
+	h, err := decodeInto[game.RankHistory](raw)
+	if err != nil {
+		return err
+	}
+	printBanner("RANK HISTORY %s", strings.ToUpper(h.Username))
+	if len(h.Points) == 0 {
+		printInfo("No leaderboard snapshots yet this season.")
 		return nil
 	}
-	fmt.Printf("%-6s %-18s %-12s %14s\n", "RANK", "PLAYER", "INVITE", "NET WORTH")
-	for _, row := range out.Rows {
-		fmt.Printf("%-6d %-18s %-12s %14s\n",
-			row.Rank,
-			truncate(row.Username, 18),
-			truncate(row.InviteCode, 12),
-			formatMicros(row.NetWorthMicros),
-		)
+	first, last := h.Points[0], h.Points[len(h.Points)-1]
+	best := first
+	for _, p := range h.Points {
+		if p.Rank < best.Rank {
+			best = p
+		}
+	}
+	fmt.Printf("Now:   #%d of %d (tick %d)\n", last.Rank, last.Players, last.Tick)
+	fmt.Printf("Best:  #%d (tick %d)\n", best.Rank, best.Tick)
+	moved := fmt.Sprintf("%+d", first.Rank-last.Rank)
+	switch {
+	case first.Rank > last.Rank:
+		moved = success.Sprint(moved)
+	case first.Rank < last.Rank:
+		moved = danger.Sprint(moved)
+	}
+	fmt.Printf("Moved: %s places since tick %d\n", moved, first.Tick)
+	fmt.Println()
+	fmt.Printf("%8s %8s %8s %16s %-16s\n", "TICK", "RANK", "PLAYERS", "NET WORTH", "AT")
+	for _, p := range h.Points {
+		fmt.Printf("%8d %8s %8d %16s %-16s\n", p.Tick, fmt.Sprintf("#%d", p.Rank), p.Players, formatMicros(p.NetWorthMicros), formatTime(p.RecordedAt))
+	}
+	fmt.Println()
+	return nil
+}
+
+func renderRegimeHistory(raw map[string]any) error {
+	out, err := decodeInto[regimeHistoryPayload](raw)
+	if err != nil {
+		return err
+	}
+	printBanner("MARKET REGIMES")
+	if len(out.Regimes) == 0 {
+		printInfo("No regime history yet.")
+		return nil
+	}
+	fmt.Printf("%-8s %10s %8s %-16s %-16s\n", "REGIME", "FROM TICK", "TICKS", "STARTED", "ENDED")
+	for _, p := range out.Regimes {
+		ticks := "-"
+		ended := "now"
+		if p.EndedAt != nil {
+			ticks = strconv.FormatInt(p.Ticks, 10)
+			ended = formatTime(*p.EndedAt)
+		}
+		fmt.Printf("%-8s %10d %8s %-16s %-16s\n", colorizeRegime(p.Regime), p.StartTick, ticks, formatTime(p.StartedAt), ended)
+	}
+	fmt.Println()
+	return nil
+}
+
+// colorizeRegime pads before coloring so table columns stay aligned.
+func colorizeRegime(regime string) string {
+	text := fmt.Sprintf("%-8s", regime)
+	switch regime {
+	case "bull":
+		return success.Sprint(text)
+	case "bear":
+		return danger.Sprint(text)
+	default:
+		return text
+	}
+}
+
+func renderNews(raw map[string]any) error {
+	out, err := decodeInto[newsPayload](raw)
+	if err != nil {
+		return err
+	}
+	printBanner("NEWS")
+	if len(out.News) == 0 {
+		printInfo("No news yet this season.")
+		return nil
+	}
+	fmt.Printf("%-16s %-10s %s\n", "TIME", "TYPE", "SUMMARY")
+	for _, n := range out.News {
+		fmt.Printf("%-16s %s %s\n", formatTime(n.CreatedAt), colorizeNewsType(n.Type), n.Summary)
+	}
+	fmt.Println()
+	return nil
+}
+
+type whoamiPayload struct {
+	Email           string     `json:"email"`
+	UserID          string     `json:"user_id"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	Expired         bool       `json:"expired"`
+	NearExpiry      bool       `json:"near_expiry"`
+	HasRefreshToken bool       `json:"has_refresh_token"`
+}
+
+func renderWhoami(sess cl.Session, now time.Time) error {
+	out := whoamiPayload{
+		Email:           sess.Email,
+		UserID:          sess.UserID,
+		HasRefreshToken: strings.TrimSpace(sess.RefreshToken) != "",
+	}
+	left, err := cl.SessionTimeLeft(sess, now)
+	hasExpiry := err == nil || errors.Is(err, cl.ErrSessionExpired)
+	if hasExpiry {
+		exp, _ := cl.TokenExpiry(sess.AccessToken)
+		out.ExpiresAt = &exp
+		out.Expired = errors.Is(err, cl.ErrSessionExpired)
+		out.NearExpiry = !out.Expired && left < sessionWarnWithin
+	}
+	if jsonOutput {
+		return printJSON(out)
+	}
+
+	printBanner("WHOAMI")
+	fmt.Printf("%-9s %s\n", "Email", out.Email)
+	fmt.Printf("%-9s %s\n", "User ID", out.UserID)
+	fmt.Printf("%-9s ", "Token")
+	switch {
+	case !hasExpiry:
+		neutral.Println("no expiry recorded")
+	case out.Expired:
+		danger.Printf("expired %s\n", formatTime(*out.ExpiresAt))
+	case out.NearExpiry:
+		warn.Printf("expires in %s\n", left.Round(time.Second))
+	default:
+		success.Printf("valid for %s\n", left.Round(time.Second))
+	}
+	if out.HasRefreshToken {
+		fmt.Printf("%-9s %s\n", "Refresh", "saved; expired tokens renew automatically")
+	} else {
+		fmt.Printf("%-9s %s\n", "Refresh", "none; run `stk login` when the token expires")
+	}
+	fmt.Println()
+	return nil
+}
+
+// colorizeNewsType pads before coloring so table columns stay aligned.
+func colorizeNewsType(newsType string) string {
+	text := fmt.Sprintf("%-10s", newsType)
+	switch newsType {
+	case game.NewsViral, game.NewsIPO, game.NewsLeader:
+		return success.Sprint(text)
+	case game.NewsCrisis, game.NewsDefault:
+		return danger.Sprint(text)
+	case game.NewsRegime:
+		return accent.Sprint(text)
+	default:
+		return text
+	}
+}
+
+func renderSeason(raw map[string]any) error {
+	out, err := decodeInto[game.SeasonInfo](raw)
+	if err != nil {
+		return err
+	}
+	r := out.Rules
+	printBanner("%s", strings.ToUpper(out.Name))
+	fmt.Printf("Status:      %s\n", out.Status)
+	fmt.Printf("Schedule:    %s -> %s\n", formatTime(out.StartsAt), formatTime(out.EndsAt))
+	if out.EndAfterTicks > 0 {
+		fmt.Printf("Ticks:       %d of %d\n", out.TickCount, out.EndAfterTicks+int64(out.Extensions))
+		if out.Rules.AntiSnipeMaxExtensions > 0 {
+			fmt.Printf("Anti-Snipe:  %d of %d extension(s) used\n", out.Extensions, out.Rules.AntiSnipeMaxExtensions)
+		}
+	} else {
+		fmt.Printf("Ticks:       %d\n", out.TickCount)
 	}
+	if out.TickEverySeconds > 0 {
+		fmt.Printf("Tick Every:  %s\n", (time.Duration(out.TickEverySeconds) * time.Second).String())
+	}
+	if out.MarketVolatility != "" {
+		fmt.Printf("Volatility:  %s\n", out.MarketVolatility)
+	}
+	if out.Market.Scheduled {
+		fmt.Printf("Market:      %s-%s %s\n", out.Market.OpenTime, out.Market.CloseTime, out.Market.Timezone)
+	} else {
+		fmt.Println("Market:      open 24/7")
+	}
+	if out.Market.Halted {
+		danger.Printf("Halted:      circuit breaker, %d tick(s) left (index %s last tick)\n", out.Market.HaltTicksRemaining, fmt.Sprintf("%+.2f%%", float64(out.Market.IndexChangeBps)/100))
+	}
+
+	fmt.Println()
+	accent.Println("Rules")
+	fmt.Printf("Starter Balance:  %s stonky (+%s signup bonus)\n", formatMicros(r.StarterBalanceMicros), formatMicros(r.SignupBonusMicros))
+	fmt.Printf("Business Unlock:  %s stonky net worth\n", formatMicros(r.BusinessUnlockMicros))
+	fmt.Printf("Trade Fee:        %.2f%% (min %s stonky)\n", float64(r.TradeFeeBps)/100, formatMicros(r.MinTradeFeeMicros))
+	fmt.Printf("Fund Fee:         %.2f%% (swap %.2f%%)\n", float64(r.FundFeeBps)/100, float64(r.FundSwapFeeBps)/100)
+	fmt.Printf("Debt Limit:       %.0f%% of peak, %s to %s stonky\n", float64(r.DebtLimitPeakBps)/100, formatMicros(r.DebtLimitMinMicros), formatMicros(r.DebtLimitMaxMicros))
+	if out.InterestAPR > 0 {
+		fmt.Printf("Debt Interest:    %.1f%% APR\n", out.InterestAPR*100)
+	}
+	fmt.Printf("Loan Collateral:  %.0f%% of holdings, compounding every %d tick(s)\n", float64(r.CollateralHoldingsBps)/100, r.LoanCompoundEveryTicks)
+	if r.BusinessTaxBps > 0 {
+		fmt.Printf("Business Tax:     %.2f%% above %s stonky per tick\n", float64(r.BusinessTaxBps)/100, formatMicros(r.BusinessTaxThresholdMicros))
+	}
+	if r.MaxMachineryLevels > 0 {
+		fmt.Printf("Machinery Cap:    %d levels per business\n", r.MaxMachineryLevels)
+	}
+	if r.IdleRevenueDecayBps > 0 {
+		fmt.Printf("Idle Decay:       %.2f%% base revenue per idle tick\n", float64(r.IdleRevenueDecayBps)/100)
+	}
+	if r.CircuitBreakerDropBps > 0 && r.CircuitBreakerHaltTicks > 0 {
+		fmt.Printf("Circuit Breaker:  %d-tick halt after a %.2f%% index drop in one tick\n", r.CircuitBreakerHaltTicks, float64(r.CircuitBreakerDropBps)/100)
+	}
+	fmt.Printf("Default Payout:   %.0f%% of last price to outside holders\n", float64(r.DefaultPayoutBps)/100)
+	fmt.Printf("Events:           viral %.1f%%, crisis %.1f%% per tick\n", r.ViralBaseChance*100, r.CrisisBaseChance*100)
 	fmt.Println()
 	return nil
 }
@@ -647,7 +1614,7 @@ func renderWorld(raw map[string]any) error {
 	if err != nil {
 		return err
 	}
-	accent.Println("\n== WORLD ==")
+	printBanner("WORLD")
 	fmt.Printf("Regime:      %s\n", out.Regime)
 	fmt.Printf("Politics:    %s (%s)\n", out.PoliticalClimate, out.PolicyFocus)
 	fmt.Printf("Catalyst:    %s (%d ticks left)\n", out.CatalystName, out.CatalystTicksRemaining)
@@ -680,7 +1647,7 @@ func renderRushStatus(raw map[string]any) error {
 	if err != nil {
 		return err
 	}
-	accent.Println("\n== RUSH ==")
+	printBanner("RUSH")
 	fmt.Printf("Streak:             %d (best %d)\n", out.CurrentStreak, out.BestStreak)
 	fmt.Printf("Rounds / Wins:      %d / %d\n", out.RoundCount, out.WinCount)
 	fmt.Printf("Win Rate:           %.2f%%\n", float64(out.WinRateBps)/100)
@@ -714,7 +1681,7 @@ func renderRushPlay(raw map[string]any) error {
 	milestone := mapInt64(raw, "milestone_reward_micros")
 	vault := mapInt64(raw, "vault_reward_micros")
 	multiplier := mapInt64(raw, "multiplier_bps")
-	accent.Println("\n== RUSH RESULT ==")
+	printBanner("RUSH RESULT")
 	fmt.Printf("Mode:               %s\n", mode)
 	fmt.Printf("Wager:              %s stonky\n", formatMicros(wager))
 	fmt.Printf("Outcome:            %s\n", ternaryString(won, "win", "loss"))
@@ -738,7 +1705,7 @@ func renderStakes(raw map[string]any) error {
 	if err != nil {
 		return err
 	}
-	accent.Println("\n== STAKES ==")
+	printBanner("STAKES")
 	if len(out.Stakes) == 0 {
 		printInfo("You do not own any business stakes yet.")
 		return nil
@@ -759,24 +1726,195 @@ func renderStakes(raw map[string]any) error {
 	return nil
 }
 
-func renderSimpleOK(raw map[string]any, successMessage string) error {
-	ok := false
-	if v, has := raw["ok"]; has {
-		switch t := v.(type) {
-		case bool:
-			ok = t
-		case string:
-			ok = strings.EqualFold(strings.TrimSpace(t), "true")
+func renderMachineryBatchResult(businessID int64) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		out, err := decodeInto[struct {
+			Machinery []struct {
+				MachineType string `json:"machine_type"`
+				AddedLevels int64  `json:"added_levels"`
+				NewLevel    int32  `json:"new_level"`
+				CostMicros  int64  `json:"cost_micros"`
+			} `json:"machinery"`
+			TotalCostMicros  int64 `json:"total_cost_micros"`
+			NewBalanceMicros int64 `json:"new_balance_micros"`
+		}](raw)
+		if err != nil {
+			return err
+		}
+		printBanner("BUSINESS #%d MACHINERY BATCH", businessID)
+		fmt.Printf("%-16s %6s %8s %14s\n", "TYPE", "ADDED", "LEVEL", "COST")
+		for _, m := range out.Machinery {
+			fmt.Printf("%-16s %6d %8d %14s\n", truncate(m.MachineType, 16), m.AddedLevels, m.NewLevel, formatMicros(m.CostMicros))
 		}
+		fmt.Printf("Total:   %s stonky\n", formatMicros(out.TotalCostMicros))
+		fmt.Printf("Balance: %s stonky\n", formatMicros(out.NewBalanceMicros))
+		fmt.Println()
+		return nil
 	}
-	if ok || successMessage != "" {
+}
+
+// renderFundTrade prints a fund order result with the fee actually charged.
+func renderFundTrade(successMessage string) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		out, err := decodeInto[fundTradeResult](raw)
+		if err != nil {
+			return err
+		}
 		printSuccess(successMessage)
+		fmt.Printf("NAV:      %s stonky\n", formatMicros(out.NavMicros))
+		fmt.Printf("Notional: %s stonky\n", formatMicros(out.NotionalMicros))
+		fmt.Printf("Fee:      %s stonky\n", formatMicros(out.FeeMicros))
+		fmt.Printf("Balance:  %s stonky\n", formatMicros(out.BalanceMicros))
 		return nil
 	}
-	printInfo("Done.")
+}
+
+// syncProgress reports replay progress one command at a time. On a terminal
+// it rewrites a single line; otherwise it prints one plain line per command
+// so redirected output stays readable.
+type syncProgress struct {
+	total int
+	tty   bool
+	width int
+}
+
+func newSyncProgress(total int) *syncProgress {
+	return &syncProgress{total: total, tty: stdoutIsTerminal()}
+}
+
+func (p *syncProgress) step(n int, q syncq.Command) {
+	if quietOutput {
+		return
+	}
+	line := fmt.Sprintf("Replaying %d/%d: %s %s", n, p.total, q.Method, q.Path)
+	if !p.tty {
+		fmt.Println(line)
+		return
+	}
+	p.clear()
+	fmt.Print(line)
+	p.width = len(line)
+}
+
+// clear erases the in-place progress line so the next message starts clean.
+func (p *syncProgress) clear() {
+	if !p.tty || p.width == 0 {
+		return
+	}
+	fmt.Printf("\r%s\r", strings.Repeat(" ", p.width))
+	p.width = 0
+}
+
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func renderSyncDryRun(batch []syncq.Command, queued int) {
+	if jsonOutput {
+		_ = printJSON(map[string]any{"commands": batch, "queued": queued})
+		return
+	}
+	printBanner("Sync Dry Run")
+	fmt.Printf("%-4s %-7s %-40s %s\n", "#", "METHOD", "PATH", "IDEMPOTENCY KEY")
+	for i, q := range batch {
+		fmt.Printf("%-4d %-7s %-40s %s\n", i+1, q.Method, truncate(q.Path, 40), q.IdempotencyKey)
+	}
+	printInfo(fmt.Sprintf("Would replay %d of %d queued command(s); nothing was sent.", len(batch), queued))
+}
+
+// renderQueueList prints every queued write. An entry is flagged when its
+// request may have reached the API before failing, or when an earlier entry
+// carries the same idempotency key; either may already have been applied.
+func renderQueueList(queue []syncq.Command) {
+	if jsonOutput {
+		_ = printJSON(map[string]any{"commands": queue})
+		return
+	}
+	printBanner("Sync Queue")
+	if len(queue) == 0 {
+		printInfo("Sync queue is empty.")
+		return
+	}
+	fmt.Printf("%-4s %-7s %-40s %-36s %s\n", "#", "METHOD", "PATH", "IDEMPOTENCY KEY", "FLAG")
+	seen := make(map[string]bool, len(queue))
+	flagged := 0
+	for i, q := range queue {
+		flag := ""
+		switch {
+		case q.IdempotencyKey != "" && seen[q.IdempotencyKey]:
+			flag = "duplicate key"
+		case q.MaybeSent:
+			flag = "may have applied"
+		}
+		seen[q.IdempotencyKey] = true
+		if flag != "" {
+			flagged++
+			flag = accent.Sprint(flag)
+		}
+		fmt.Printf("%-4d %-7s %-40s %-36s %s\n", i+1, q.Method, truncate(q.Path, 40), q.IdempotencyKey, flag)
+	}
+	next := min(len(queue), syncq.ReplayBatchSize)
+	printInfo(fmt.Sprintf("%d queued command(s); the next `stk sync` replays %d.", len(queue), next))
+	if flagged > 0 {
+		printWarn(fmt.Sprintf("%d flagged command(s) may already have succeeded. The API rejects a replay of one that did as a duplicate idempotency key, so nothing is applied twice.", flagged))
+	}
+}
+
+func renderFundPosition(raw map[string]any) error {
+	out, err := decodeInto[game.FundPositionView](raw)
+	if err != nil {
+		return err
+	}
+	printBanner("%s - %s", out.Code, out.Name)
+	fmt.Printf("Units:    %.4f\n", game.UnitsToShares(out.Units))
+	fmt.Printf("Avg NAV:  %s stonky\n", formatPrice(out.AvgNavMicros))
+	fmt.Printf("NAV:      %s stonky\n", formatPrice(out.NavMicros))
+	fmt.Printf("Value:    %s stonky\n", formatMicros(out.ValueMicros))
+	fmt.Printf("P/L:      %s\n", colorizeMicros(out.UnrealizedMicros))
 	return nil
 }
 
+func renderFundSwap(raw map[string]any) error {
+	out, err := decodeInto[fundSwapResult](raw)
+	if err != nil {
+		return err
+	}
+	printSuccess(fmt.Sprintf("Swapped %.4f units of %s into %.4f units of %s.",
+		game.UnitsToShares(out.FromUnits), out.FromCode, game.UnitsToShares(out.ToUnits), out.ToCode))
+	fmt.Printf("NAV:      %s -> %s stonky\n", formatMicros(out.FromNavMicros), formatMicros(out.ToNavMicros))
+	fmt.Printf("Notional: %s stonky\n", formatMicros(out.NotionalMicros))
+	fmt.Printf("Fee:      %s stonky\n", formatMicros(out.FeeMicros))
+	if out.CashBackMicros > 0 {
+		fmt.Printf("Cash:     %s stonky returned to wallet\n", formatMicros(out.CashBackMicros))
+	}
+	fmt.Printf("Balance:  %s stonky\n", formatMicros(out.BalanceMicros))
+	return nil
+}
+
+func renderSimpleOK(successMessage string) func(map[string]any) error {
+	return func(raw map[string]any) error {
+		ok := false
+		if v, has := raw["ok"]; has {
+			switch t := v.(type) {
+			case bool:
+				ok = t
+			case string:
+				ok = strings.EqualFold(strings.TrimSpace(t), "true")
+			}
+		}
+		if ok || successMessage != "" {
+			printSuccess(successMessage)
+			return nil
+		}
+		printInfo("Done.")
+		return nil
+	}
+}
+
 func decodeInto[T any](in any) (T, error) {
 	var out T
 	raw, err := json.Marshal(in)
@@ -813,15 +1951,72 @@ func colorizePercent(v float64) string {
 	}
 }
 
+// displayDecimals and pennyDecimals are set from STK_DISPLAY_DECIMALS and
+// STK_PENNY_DECIMALS; see configureDisplayPrecision.
+var (
+	displayDecimals = 2
+	pennyDecimals   = 0
+)
+
+// configureDisplayPrecision clamps both values to 0..6, the precision micros
+// can actually carry.
+func configureDisplayPrecision(decimals, penny int) {
+	displayDecimals = min(max(decimals, 0), 6)
+	pennyDecimals = min(max(penny, 0), 6)
+}
+
+// formatMicros renders an amount at displayDecimals. Amounts under one
+// stonky get as many extra decimals (up to 6) as they need, so 0.015 is not
+// shown as 0.01.
 func formatMicros(v int64) string {
+	return formatMicrosDecimals(v, subStonkyDecimals(v, displayDecimals))
+}
+
+// subStonkyDecimals widens decimals for a non-zero amount under one stonky
+// until no non-zero digit is cut off.
+func subStonkyDecimals(v int64, decimals int) int {
+	if v == 0 || v <= -game.MicrosPerStonky || v >= game.MicrosPerStonky {
+		return decimals
+	}
+	if v < 0 {
+		v = -v
+	}
+	divisor := int64(1)
+	for i := decimals; i < 6; i++ {
+		divisor *= 10
+	}
+	for decimals < 6 && v%divisor != 0 {
+		decimals++
+		divisor /= 10
+	}
+	return decimals
+}
+
+// formatPrice renders a share price, switching to pennyDecimals below one
+// stonky when that is finer than the normal display precision.
+func formatPrice(v int64) string {
+	if pennyDecimals > displayDecimals && v > -game.MicrosPerStonky && v < game.MicrosPerStonky {
+		return formatMicrosDecimals(v, pennyDecimals)
+	}
+	return formatMicros(v)
+}
+
+func formatMicrosDecimals(v int64, decimals int) string {
 	sign := ""
 	if v < 0 {
 		sign = "-"
 		v = -v
 	}
 	whole := v / game.MicrosPerStonky
-	frac := (v % game.MicrosPerStonky) / 10_000
-	return fmt.Sprintf("%s%s.%02d", sign, comma(whole), frac)
+	if decimals <= 0 {
+		return sign + comma(whole)
+	}
+	divisor := int64(1)
+	for i := decimals; i < 6; i++ {
+		divisor *= 10
+	}
+	frac := (v % game.MicrosPerStonky) / divisor
+	return fmt.Sprintf("%s%s.%0*d", sign, comma(whole), decimals, frac)
 }
 
 func signedMicros(v int64) string {
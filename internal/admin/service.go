@@ -55,6 +55,7 @@ type Stock struct {
 	CurrentPriceMicros int64  `json:"current_price_micros"`
 	AnchorPriceMicros  int64  `json:"anchor_price_micros"`
 	ListedPublic       bool   `json:"listed_public"`
+	VolatilityBps      int32  `json:"volatility_bps"`
 }
 
 type Stake struct {
@@ -865,7 +866,7 @@ func (s *Service) ListStocks(ctx context.Context) ([]Stock, error) {
 		return nil, err
 	}
 	rows, err := s.db.Query(ctx, `
-		SELECT symbol, display_name, current_price_micros, anchor_price_micros, listed_public
+		SELECT symbol, display_name, current_price_micros, anchor_price_micros, listed_public, volatility_bps
 		FROM game.stocks
 		WHERE season_id = $1
 		ORDER BY symbol
@@ -878,7 +879,7 @@ func (s *Service) ListStocks(ctx context.Context) ([]Stock, error) {
 	var out []Stock
 	for rows.Next() {
 		var row Stock
-		if err := rows.Scan(&row.Symbol, &row.DisplayName, &row.CurrentPriceMicros, &row.AnchorPriceMicros, &row.ListedPublic); err != nil {
+		if err := rows.Scan(&row.Symbol, &row.DisplayName, &row.CurrentPriceMicros, &row.AnchorPriceMicros, &row.ListedPublic, &row.VolatilityBps); err != nil {
 			return nil, err
 		}
 		out = append(out, row)
@@ -940,3 +941,32 @@ func (s *Service) SetStockPrice(ctx context.Context, symbol string, priceMicros
 	row.AnchorPriceMicros = priceMicros
 	return row, nil
 }
+
+func (s *Service) SetStockVolatility(ctx context.Context, symbol, tier string) (Stock, error) {
+	seasonID, err := s.ActiveSeasonID(ctx)
+	if err != nil {
+		return Stock{}, err
+	}
+	if err := game.ValidateSymbol(symbol); err != nil {
+		return Stock{}, err
+	}
+	volBps, ok := game.VolatilityTierBps(tier)
+	if !ok {
+		return Stock{}, fmt.Errorf("volatility tier must be calm, normal, or wild")
+	}
+
+	var row Stock
+	err = s.db.QueryRow(ctx, `
+		UPDATE game.stocks
+		SET volatility_bps = $3, updated_at = now()
+		WHERE season_id = $1 AND symbol = $2
+		RETURNING symbol, display_name, current_price_micros, anchor_price_micros, listed_public, volatility_bps
+	`, seasonID, symbol, volBps).Scan(&row.Symbol, &row.DisplayName, &row.CurrentPriceMicros, &row.AnchorPriceMicros, &row.ListedPublic, &row.VolatilityBps)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return Stock{}, fmt.Errorf("stock %s not found", symbol)
+		}
+		return Stock{}, err
+	}
+	return row, nil
+}
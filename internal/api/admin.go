@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"stanks/internal/admin"
+	"stanks/internal/game"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -30,13 +31,13 @@ func (s *Server) handleAdminPlayer(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleAdminChangeBalance(w http.ResponseWriter, r *http.Request) {
 	var in struct {
-		DeltaMicros int64 `json:"delta_micros"`
+		DeltaMicros flexInt64 `json:"delta_micros"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	row, err := s.admin.ChangeBalance(r.Context(), chi.URLParam(r, "userID"), in.DeltaMicros)
+	row, err := s.admin.ChangeBalance(r.Context(), chi.URLParam(r, "userID"), int64(in.DeltaMicros))
 	if err != nil {
 		writeDomainError(w, err)
 		return
@@ -46,13 +47,13 @@ func (s *Server) handleAdminChangeBalance(w http.ResponseWriter, r *http.Request
 
 func (s *Server) handleAdminSetBalance(w http.ResponseWriter, r *http.Request) {
 	var in struct {
-		AmountMicros int64 `json:"amount_micros"`
+		AmountMicros flexInt64 `json:"amount_micros"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	row, err := s.admin.SetBalance(r.Context(), chi.URLParam(r, "userID"), in.AmountMicros)
+	row, err := s.admin.SetBalance(r.Context(), chi.URLParam(r, "userID"), int64(in.AmountMicros))
 	if err != nil {
 		writeDomainError(w, err)
 		return
@@ -62,13 +63,13 @@ func (s *Server) handleAdminSetBalance(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleAdminChangePeak(w http.ResponseWriter, r *http.Request) {
 	var in struct {
-		DeltaMicros int64 `json:"delta_micros"`
+		DeltaMicros flexInt64 `json:"delta_micros"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	row, err := s.admin.ChangePeak(r.Context(), chi.URLParam(r, "userID"), in.DeltaMicros)
+	row, err := s.admin.ChangePeak(r.Context(), chi.URLParam(r, "userID"), int64(in.DeltaMicros))
 	if err != nil {
 		writeDomainError(w, err)
 		return
@@ -78,13 +79,13 @@ func (s *Server) handleAdminChangePeak(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleAdminSetPeak(w http.ResponseWriter, r *http.Request) {
 	var in struct {
-		AmountMicros int64 `json:"amount_micros"`
+		AmountMicros flexInt64 `json:"amount_micros"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	row, err := s.admin.SetPeak(r.Context(), chi.URLParam(r, "userID"), in.AmountMicros)
+	row, err := s.admin.SetPeak(r.Context(), chi.URLParam(r, "userID"), int64(in.AmountMicros))
 	if err != nil {
 		writeDomainError(w, err)
 		return
@@ -147,14 +148,14 @@ func (s *Server) handleAdminPositions(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleAdminSetPosition(w http.ResponseWriter, r *http.Request) {
 	var in struct {
-		QuantityUnits  int64 `json:"quantity_units"`
-		AvgPriceMicros int64 `json:"avg_price_micros"`
+		QuantityUnits  flexInt64 `json:"quantity_units"`
+		AvgPriceMicros flexInt64 `json:"avg_price_micros"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	row, err := s.admin.SetPosition(r.Context(), chi.URLParam(r, "userID"), strings.ToUpper(chi.URLParam(r, "symbol")), in.QuantityUnits, in.AvgPriceMicros)
+	row, err := s.admin.SetPosition(r.Context(), chi.URLParam(r, "userID"), strings.ToUpper(chi.URLParam(r, "symbol")), int64(in.QuantityUnits), int64(in.AvgPriceMicros))
 	if err != nil {
 		writeDomainError(w, err)
 		return
@@ -236,13 +237,13 @@ func (s *Server) handleAdminSetBusinessRevenue(w http.ResponseWriter, r *http.Re
 		return
 	}
 	var in struct {
-		AmountMicros int64 `json:"amount_micros"`
+		AmountMicros flexInt64 `json:"amount_micros"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	row, err := s.admin.SetBusinessRevenue(r.Context(), businessID, in.AmountMicros)
+	row, err := s.admin.SetBusinessRevenue(r.Context(), businessID, int64(in.AmountMicros))
 	if err != nil {
 		writeDomainError(w, err)
 		return
@@ -330,13 +331,29 @@ func (s *Server) handleAdminStocks(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleAdminSetStockPrice(w http.ResponseWriter, r *http.Request) {
 	var in struct {
-		PriceMicros int64 `json:"price_micros"`
+		PriceMicros flexInt64 `json:"price_micros"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	row, err := s.admin.SetStockPrice(r.Context(), strings.ToUpper(chi.URLParam(r, "symbol")), in.PriceMicros)
+	row, err := s.admin.SetStockPrice(r.Context(), strings.ToUpper(chi.URLParam(r, "symbol")), int64(in.PriceMicros))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, row)
+}
+
+func (s *Server) handleAdminSetStockVolatility(w http.ResponseWriter, r *http.Request) {
+	var in struct {
+		Tier string `json:"tier"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	row, err := s.admin.SetStockVolatility(r.Context(), strings.ToUpper(chi.URLParam(r, "symbol")), in.Tier)
 	if err != nil {
 		writeDomainError(w, err)
 		return
@@ -375,3 +392,28 @@ func parseBusinessID(w http.ResponseWriter, r *http.Request) (int64, bool) {
 	}
 	return businessID, true
 }
+
+func (s *Server) handleAdminSeedDemoSocial(w http.ResponseWriter, r *http.Request) {
+	var in struct {
+		Bots int `json:"bots"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	out, err := s.game.SeedDemoSocialGraph(r.Context(), game.DemoSocialGraphInput{
+		SeasonID:     seasonID,
+		TargetUserID: chi.URLParam(r, "userID"),
+		Bots:         in.Bots,
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// gzipLargeResponses gzips response bodies of at least minBytes for clients
+// that send Accept-Encoding: gzip. Smaller bodies are written unchanged, so
+// short replies do not pay the gzip framing overhead.
+func gzipLargeResponses(minBytes int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if !acceptsGzip(r) || r.Header.Get("Upgrade") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			gw := &gzipResponseWriter{ResponseWriter: w, minBytes: minBytes, status: http.StatusOK}
+			defer gw.finish()
+			next.ServeHTTP(gw, r)
+		})
+	}
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		enc, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.EqualFold(strings.TrimSpace(enc), "gzip") && strings.ReplaceAll(params, " ", "") != "q=0" {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter buffers the body until it either reaches minBytes
+// (switch to gzip) or the handler returns (write it as-is).
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	minBytes    int
+	status      int
+	wroteHeader bool
+	buf         bytes.Buffer
+	gz          *gzip.Writer
+	passthrough bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+	if w.Header().Get("Content-Encoding") != "" || status == http.StatusNoContent || status == http.StatusNotModified {
+		w.passthrough = true
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *gzipResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.passthrough {
+		return w.ResponseWriter.Write(p)
+	}
+	if w.gz != nil {
+		return w.gz.Write(p)
+	}
+	w.buf.Write(p)
+	if w.buf.Len() >= w.minBytes {
+		if err := w.startGzip(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (w *gzipResponseWriter) startGzip() error {
+	h := w.Header()
+	h.Set("Content-Encoding", "gzip")
+	h.Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	w.gz = gzip.NewWriter(w.ResponseWriter)
+	_, err := w.gz.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+func (w *gzipResponseWriter) finish() {
+	switch {
+	case w.passthrough:
+	case w.gz != nil:
+		_ = w.gz.Close()
+	default:
+		if !w.wroteHeader {
+			w.status = http.StatusOK
+		}
+		w.ResponseWriter.WriteHeader(w.status)
+		_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGzipLargeResponses(t *testing.T) {
+	large := strings.Repeat(`{"symbol":"NEBULA"}`, 200)
+	handler := gzipLargeResponses(1024)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/small" {
+			_, _ = io.WriteString(w, `{"ok":true}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, large)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/large", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201", rec.Code)
+	}
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected large response to be gzipped")
+	}
+	gz, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	body, _ := io.ReadAll(gz)
+	if string(body) != large {
+		t.Fatalf("decompressed body mismatch")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/small", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Header().Get("Content-Encoding") != "" || rec.Body.String() != `{"ok":true}` {
+		t.Fatalf("small response should be sent uncompressed, got %q", rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/large", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Header().Get("Content-Encoding") != "" || rec.Body.String() != large {
+		t.Fatalf("client without gzip support should get plain body")
+	}
+}
@@ -0,0 +1,569 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"stanks/internal/admin"
+	"stanks/internal/auth"
+	"stanks/internal/buildinfo"
+	"stanks/internal/game"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// routeDoc describes one route for GET /v1/openapi.json. Request and
+// Response hold a zero value of the JSON body type; nil means no request
+// body, or a free-form JSON object response.
+type routeDoc struct {
+	Summary  string
+	Request  any
+	Response any
+	Status   int
+}
+
+// Request bodies shared by several routes.
+type (
+	amountBody struct {
+		AmountMicros flexInt64 `json:"amount_micros"`
+	}
+	priceBody struct {
+		PriceMicros flexInt64 `json:"price_micros"`
+	}
+	stakeBody struct {
+		Username string `json:"username"`
+		StakeBps int32  `json:"stake_bps"`
+	}
+	hireBatchBody struct {
+		Count    int    `json:"count"`
+		Strategy string `json:"strategy"`
+	}
+	visibilityBody struct {
+		Visibility string `json:"visibility"`
+	}
+	deltaBody struct {
+		DeltaMicros flexInt64 `json:"delta_micros"`
+	}
+	okBody struct {
+		OK bool `json:"ok"`
+	}
+)
+
+// routeDocs documents every route the server mounts, keyed by
+// "METHOD /path" as chi reports it. TestOpenAPIDocumentsEveryRoute keeps
+// this table and the router in step.
+var routeDocs = map[string]routeDoc{
+	"GET /healthz":         {Summary: "Liveness check", Response: okBody{}},
+	"GET /version":         {Summary: "Build version", Response: buildinfo.Info{}},
+	"GET /v1/openapi.json": {Summary: "This OpenAPI document"},
+
+	"POST /v1/auth/signup": {Summary: "Create an account", Status: http.StatusCreated, Response: auth.Session{}, Request: struct {
+		Email      string `json:"email"`
+		Password   string `json:"password"`
+		Username   string `json:"username"`
+		InviteCode string `json:"invite_code"`
+	}{}},
+	"POST /v1/auth/login": {Summary: "Log in", Response: auth.Session{}, Request: struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}{}},
+	"POST /v1/auth/refresh": {Summary: "Exchange a refresh token for a new session", Response: auth.Session{}, Request: struct {
+		RefreshToken string `json:"refresh_token"`
+	}{}},
+
+	"GET /v1/stocks": {Summary: "List stocks", Response: struct {
+		Stocks []game.StockView `json:"stocks"`
+	}{}},
+	"GET /v1/stocks/recent": {Summary: "Recently listed stocks", Response: struct {
+		Ticks  int64            `json:"ticks"`
+		Stocks []game.RecentIPO `json:"stocks"`
+	}{}},
+	"GET /v1/seasons/active": {Summary: "Active season schedule and rules", Response: game.SeasonInfo{}},
+	"GET /v1/leaderboard/global": {Summary: "Global leaderboard", Response: struct {
+		Rows []game.LeaderboardRow `json:"rows"`
+	}{}},
+	"GET /v1/stream/leaderboard": {Summary: "Leaderboard WebSocket stream"},
+	"GET /v1/news": {Summary: "Season news feed, newest first (?limit=)", Response: struct {
+		News []game.NewsItem `json:"news"`
+	}{}},
+
+	"GET /v1/me":              {Summary: "Player profile", Response: game.PlayerProfile{}},
+	"POST /v1/me/daily-bonus": {Summary: "Claim the daily bonus"},
+	"GET /v1/me/costs":        {Summary: "Season costs by category", Response: game.CostBreakdown{}},
+	"GET /v1/me/liquidation-order": {Summary: "Forced-sale order of positions", Response: struct {
+		Positions []game.LiquidationEntry `json:"positions"`
+	}{}},
+	"GET /v1/me/automation-skips": {Summary: "Automated operations skipped for insufficient funds", Response: struct {
+		Skips []game.AutomationSkip `json:"skips"`
+	}{}},
+	"GET /v1/dashboard": {Summary: "Player dashboard", Response: game.Dashboard{}},
+	"GET /v1/positions": {Summary: "Open stock positions", Response: struct {
+		Positions []game.PositionView `json:"positions"`
+	}{}},
+	"POST /v1/positions/{symbol}/stop": {Summary: "Set or clear a stop-loss on a position", Response: game.StopLoss{}, Request: struct {
+		TriggerPriceMicros flexInt64 `json:"trigger_price_micros"`
+		QuantityUnits      flexInt64 `json:"quantity_units"`
+	}{}},
+	"GET /v1/wallet":       {Summary: "Wallet summary", Response: game.WalletSummary{}},
+	"GET /v1/world":        {Summary: "World state", Response: game.WorldView{}},
+	"GET /v1/market/state": {Summary: "Market open/closed state", Response: game.MarketState{}},
+	"GET /v1/market/regime-history": {Summary: "Market regime periods this season", Response: struct {
+		Regimes []game.RegimePeriod `json:"regimes"`
+	}{}},
+	"GET /v1/rush": {Summary: "Rush minigame status", Response: game.RushStatus{}},
+	"POST /v1/rush/play": {Summary: "Play a rush round", Request: struct {
+		Mode         string    `json:"mode"`
+		AmountMicros flexInt64 `json:"amount_micros"`
+	}{}},
+	"GET /v1/stakes": {Summary: "Business stakes held", Response: struct {
+		Stakes []game.StakeView `json:"stakes"`
+	}{}},
+	"POST /v1/transfer": {Summary: "Send stonky to a player", Request: struct {
+		Username     string    `json:"username"`
+		AmountMicros flexInt64 `json:"amount_micros"`
+	}{}},
+	"GET /v1/stocks/{symbol}":         {Summary: "Stock detail", Response: game.StockDetail{}},
+	"GET /v1/stocks/{symbol}/candles": {Summary: "Stock price candles", Response: game.StockCandles{}},
+	"POST /v1/stocks/{symbol}/liquidation-priority": {Summary: "Set a position's liquidation priority", Request: struct {
+		Priority flexInt64 `json:"priority"`
+	}{}},
+	"GET /v1/orders": {Summary: "Order history, newest first (?limit=&offset=)", Response: game.OrderPage{}},
+	"POST /v1/orders": {Summary: "Place a market order", Response: game.OrderResult{}, Request: struct {
+		Symbol        string    `json:"symbol"`
+		Side          string    `json:"side"`
+		QuantityUnits flexInt64 `json:"quantity_units"`
+		Short         bool      `json:"short,omitempty"`
+	}{}},
+	"POST /v1/orders/quote": {Summary: "Quote a market order's price and fee", Response: game.OrderQuote{}, Request: struct {
+		Symbol        string    `json:"symbol"`
+		Side          string    `json:"side"`
+		QuantityUnits flexInt64 `json:"quantity_units"`
+		Short         bool      `json:"short,omitempty"`
+	}{}},
+
+	"POST /v1/businesses": {Summary: "Create a business", Status: http.StatusCreated, Response: struct {
+		ID int64 `json:"id"`
+	}{}, Request: struct {
+		Name       string `json:"name"`
+		Visibility string `json:"visibility"`
+	}{}},
+	"GET /v1/businesses/{id}":                   {Summary: "Business state", Response: game.BusinessView{}},
+	"GET /v1/businesses/{id}/employees":         {Summary: "Business employees"},
+	"GET /v1/businesses/employees/candidates":   {Summary: "Employee candidate pool"},
+	"GET /v1/businesses/{id}/employees/preview": {Summary: "Preview hiring a candidate"},
+	"POST /v1/businesses/{id}/employees/hire": {Summary: "Hire a candidate", Response: struct {
+		OK             bool  `json:"ok"`
+		HireCostMicros int64 `json:"hire_cost_micros"`
+	}{}, Request: struct {
+		CandidateID int64 `json:"candidate_id"`
+	}{}},
+	"POST /v1/businesses/{id}/employees/hire-batch/quote":    {Summary: "Quote a batch hire", Request: hireBatchBody{}},
+	"POST /v1/businesses/{id}/employees/hire-batch":          {Summary: "Hire a batch of candidates", Request: hireBatchBody{}},
+	"POST /v1/businesses/{id}/employees/{employee_id}/train": {Summary: "Train an employee"},
+	"GET /v1/businesses/{id}/machinery":                      {Summary: "Business machinery"},
+	"GET /v1/businesses/{id}/loans":                          {Summary: "Business loans"},
+	"POST /v1/businesses/{id}/machinery/buy": {Summary: "Buy machinery", Request: struct {
+		MachineType string `json:"machine_type"`
+	}{}},
+	"POST /v1/businesses/{id}/machinery/buy-batch": {Summary: "Buy several machines", Request: struct {
+		Items []game.MachineryBatchItem `json:"items"`
+	}{}},
+	"POST /v1/businesses/{id}/machinery/buy-batch/quote": {Summary: "Quote a machinery batch", Request: struct {
+		Items []game.MachineryBatchItem `json:"items"`
+	}{}},
+	"POST /v1/businesses/{id}/loans/take": {Summary: "Take a business loan", Request: amountBody{}},
+	"POST /v1/businesses/{id}/loans/repay": {Summary: "Repay a business loan", Request: struct {
+		AmountMicros flexInt64 `json:"amount_micros"`
+		LoanID       flexInt64 `json:"loan_id"`
+	}{}},
+	"POST /v1/businesses/{id}/loans/auto-repay": {Summary: "Configure loan auto-repay", Request: struct {
+		Enabled      bool      `json:"enabled"`
+		BufferMicros flexInt64 `json:"buffer_micros"`
+	}{}},
+	"POST /v1/businesses/{id}/strategy": {Summary: "Set business strategy", Response: okBody{}, Request: struct {
+		Strategy string `json:"strategy"`
+	}{}},
+	"POST /v1/businesses/{id}/revenue-mode": {Summary: "Set revenue payout mode", Response: okBody{}, Request: struct {
+		Mode string `json:"mode"`
+	}{}},
+	"POST /v1/businesses/{id}/claim":   {Summary: "Claim accrued business revenue"},
+	"GET /v1/businesses/{id}/upgrades": {Summary: "Business upgrade costs"},
+	"POST /v1/businesses/{id}/upgrades/buy": {Summary: "Buy a business upgrade", Request: struct {
+		Upgrade string `json:"upgrade"`
+	}{}},
+	"POST /v1/businesses/{id}/reserve/deposit":  {Summary: "Deposit into the cash reserve", Response: okBody{}, Request: amountBody{}},
+	"POST /v1/businesses/{id}/reserve/withdraw": {Summary: "Withdraw from the cash reserve", Response: okBody{}, Request: amountBody{}},
+	"POST /v1/businesses/{id}/visibility":       {Summary: "Set business visibility", Response: okBody{}, Request: visibilityBody{}},
+	"POST /v1/businesses/{id}/ipo": {Summary: "List a business on the market", Response: okBody{}, Request: struct {
+		Symbol      string    `json:"symbol"`
+		PriceMicros flexInt64 `json:"price_micros"`
+	}{}},
+	"POST /v1/businesses/{id}/delist":        {Summary: "Delist a business's stock"},
+	"GET /v1/businesses/{id}/sell/preview":   {Summary: "Preview a bank sale"},
+	"POST /v1/businesses/{id}/sell":          {Summary: "Sell a business to the bank"},
+	"DELETE /v1/businesses/{id}":             {Summary: "Delete an empty business"},
+	"POST /v1/businesses/{id}/stakes/give":   {Summary: "Give a business stake", Request: stakeBody{}},
+	"POST /v1/businesses/{id}/stakes/revoke": {Summary: "Revoke a business stake", Request: stakeBody{}},
+
+	"POST /v1/stocks/custom": {Summary: "Create a custom stock", Status: http.StatusCreated, Response: okBody{}, Request: struct {
+		Symbol      string `json:"symbol"`
+		DisplayName string `json:"display_name"`
+		BusinessID  int64  `json:"business_id"`
+	}{}},
+	"POST /v1/stocks/{symbol}/ipo": {Summary: "List a custom stock", Response: okBody{}, Request: priceBody{}},
+	"GET /v1/funds":                {Summary: "List mutual funds"},
+	"POST /v1/funds/{code}/buy": {Summary: "Buy fund units", Request: struct {
+		Units flexInt64 `json:"units"`
+	}{}},
+	"POST /v1/funds/{code}/sell": {Summary: "Sell fund units", Request: struct {
+		Units flexInt64 `json:"units"`
+	}{}},
+	"POST /v1/funds/swap": {Summary: "Swap units between funds", Request: struct {
+		From  string    `json:"from"`
+		To    string    `json:"to"`
+		Units flexInt64 `json:"units"`
+	}{}},
+	"GET /v1/funds/{code}/position": {Summary: "Fund position", Response: game.FundPositionView{}},
+
+	"GET /v1/leaderboard/friends": {Summary: "Friends leaderboard", Response: struct {
+		Rows []game.LeaderboardRow `json:"rows"`
+	}{}},
+	"POST /v1/friends": {Summary: "Add a friend", Response: okBody{}, Request: struct {
+		InviteCode string `json:"invite_code"`
+	}{}},
+	"DELETE /v1/friends/{invite_code}":           {Summary: "Remove a friend", Response: okBody{}},
+	"GET /v1/players/{invite_code}":              {Summary: "Public player profile"},
+	"GET /v1/players/{invite_code}/rank-history": {Summary: "Rank over time of a followed player", Response: game.RankHistory{}},
+
+	"GET /v1/watchlist": {Summary: "Watched stocks with their move since added", Response: struct {
+		Watchlist []game.WatchItem `json:"watchlist"`
+	}{}},
+	"POST /v1/watchlist": {Summary: "Watch a stock", Response: okBody{}, Request: struct {
+		Symbol string `json:"symbol"`
+	}{}},
+	"DELETE /v1/watchlist/{symbol}": {Summary: "Stop watching a stock", Response: okBody{}},
+	"GET /v1/alerts": {Summary: "Triggered price alerts not yet acknowledged", Response: struct {
+		Alerts []game.PriceAlert `json:"alerts"`
+	}{}},
+	"POST /v1/alerts": {Summary: "Set a price alert", Response: game.PriceAlert{}, Request: struct {
+		Symbol            string `json:"symbol"`
+		Direction         string `json:"direction"`
+		TargetPriceMicros int64  `json:"target_price_micros"`
+	}{}},
+	"POST /v1/alerts/ack": {Summary: "Acknowledge triggered price alerts", Response: okBody{}, Request: struct {
+		IDs []int64 `json:"ids"`
+	}{}},
+	"POST /v1/sync/replay": {Summary: "Replay queued offline commands", Request: struct {
+		Commands []map[string]any `json:"commands"`
+	}{}},
+
+	"GET /v1/admin/players": {Summary: "List players", Response: struct {
+		Players []admin.Player `json:"players"`
+	}{}},
+	"GET /v1/admin/players/{userID}":                 {Summary: "Player detail", Response: admin.Player{}},
+	"POST /v1/admin/players/{userID}/balance/change": {Summary: "Change a balance", Response: admin.Player{}, Request: deltaBody{}},
+	"POST /v1/admin/players/{userID}/balance/set":    {Summary: "Set a balance", Response: admin.Player{}, Request: amountBody{}},
+	"POST /v1/admin/players/{userID}/peak/change":    {Summary: "Change peak net worth", Response: admin.Player{}, Request: deltaBody{}},
+	"POST /v1/admin/players/{userID}/peak/set":       {Summary: "Set peak net worth", Response: admin.Player{}, Request: amountBody{}},
+	"POST /v1/admin/players/{userID}/progress": {Summary: "Set player progression", Response: admin.Player{}, Request: struct {
+		ReputationScore     int32 `json:"reputation_score"`
+		CurrentProfitStreak int32 `json:"current_profit_streak"`
+		BestProfitStreak    int32 `json:"best_profit_streak"`
+		RiskAppetiteBps     int32 `json:"risk_appetite_bps"`
+	}{}},
+	"POST /v1/admin/players/{userID}/active-business": {Summary: "Set the active business", Response: admin.Player{}, Request: struct {
+		BusinessID int64 `json:"business_id"`
+	}{}},
+	"GET /v1/admin/players/{userID}/businesses": {Summary: "A player's businesses", Response: struct {
+		Businesses []admin.Business `json:"businesses"`
+	}{}},
+	"GET /v1/admin/players/{userID}/positions": {Summary: "A player's positions", Response: struct {
+		Positions []admin.Position `json:"positions"`
+	}{}},
+	"POST /v1/admin/players/{userID}/positions/{symbol}": {Summary: "Set a position", Response: admin.Position{}, Request: struct {
+		QuantityUnits  flexInt64 `json:"quantity_units"`
+		AvgPriceMicros flexInt64 `json:"avg_price_micros"`
+	}{}},
+	"DELETE /v1/admin/players/{userID}/positions/{symbol}": {Summary: "Delete a position", Response: okBody{}},
+	"POST /v1/admin/businesses/{id}/name": {Summary: "Rename a business", Response: admin.Business{}, Request: struct {
+		Name string `json:"name"`
+	}{}},
+	"POST /v1/admin/businesses/{id}/visibility": {Summary: "Set business visibility", Response: admin.Business{}, Request: visibilityBody{}},
+	"POST /v1/admin/businesses/{id}/listed": {Summary: "Set business listing", Response: admin.Business{}, Request: struct {
+		Listed bool `json:"listed"`
+	}{}},
+	"POST /v1/admin/businesses/{id}/revenue": {Summary: "Set base revenue", Response: admin.Business{}, Request: amountBody{}},
+	"POST /v1/admin/businesses/{id}/narrative": {Summary: "Set business narrative", Response: admin.Business{}, Request: struct {
+		PrimaryRegion        string `json:"primary_region"`
+		NarrativeArc         string `json:"narrative_arc"`
+		NarrativeFocus       string `json:"narrative_focus"`
+		NarrativePressureBps int32  `json:"narrative_pressure_bps"`
+	}{}},
+	"GET /v1/admin/businesses/{id}/stakes": {Summary: "Business stakes", Response: struct {
+		Stakes []admin.Stake `json:"stakes"`
+	}{}},
+	"POST /v1/admin/businesses/{id}/stakes": {Summary: "Set a business stake", Request: stakeBody{}, Response: struct {
+		Stakes []admin.Stake `json:"stakes"`
+	}{}},
+	"DELETE /v1/admin/businesses/{id}": {Summary: "Delete a business", Response: okBody{}},
+	"GET /v1/admin/stocks": {Summary: "List stocks", Response: struct {
+		Stocks []admin.Stock `json:"stocks"`
+	}{}},
+	"POST /v1/admin/stocks/{symbol}/price": {Summary: "Set a stock price", Response: admin.Stock{}, Request: priceBody{}},
+	"POST /v1/admin/stocks/{symbol}/volatility": {Summary: "Set a stock's volatility tier", Response: admin.Stock{}, Request: struct {
+		Tier string `json:"tier"`
+	}{}},
+	"GET /v1/admin/world":  {Summary: "World state", Response: admin.WorldState{}},
+	"POST /v1/admin/world": {Summary: "Set world state", Response: admin.WorldState{}, Request: admin.WorldState{}},
+	"POST /v1/admin/players/{userID}/demo-social": {Summary: "Seed demo social data", Request: struct {
+		Bots int `json:"bots"`
+	}{}},
+}
+
+// routeParamPattern matches chi path parameters, with or without a regexp.
+var routeParamPattern = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	spec, err := s.openAPISpec()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, spec)
+}
+
+// openAPISpec builds an OpenAPI 3 document from the routes actually mounted
+// on the server, so routes that are not wired up (such as the dev-seed
+// ones when disabled) are left out.
+func (s *Server) openAPISpec() (map[string]any, error) {
+	schemas := map[string]any{
+		"Error": map[string]any{
+			"type":       "object",
+			"required":   []string{"error"},
+			"properties": map[string]any{"error": map[string]any{"type": "string"}},
+		},
+	}
+	paths := map[string]any{}
+	authPtr := reflect.ValueOf(s.authMiddleware).Pointer()
+	optionalPtr := reflect.ValueOf(s.optionalAuthMiddleware).Pointer()
+	adminPtr := reflect.ValueOf(s.adminAuthMiddleware).Pointer()
+
+	walk := func(method, route string, _ http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		key := method + " " + route
+		doc, ok := routeDocs[key]
+		if !ok {
+			return fmt.Errorf("route %s is not documented", key)
+		}
+		op := map[string]any{
+			"operationId": operationID(method, route),
+			"summary":     doc.Summary,
+		}
+		for _, mw := range middlewares {
+			switch reflect.ValueOf(mw).Pointer() {
+			case authPtr:
+				op["security"] = []any{map[string]any{"bearerAuth": []string{}}}
+			case optionalPtr:
+				op["security"] = []any{map[string]any{}, map[string]any{"bearerAuth": []string{}}}
+			case adminPtr:
+				op["security"] = []any{map[string]any{"adminBasic": []string{}}}
+			}
+		}
+		var params []any
+		for _, m := range routeParamPattern.FindAllStringSubmatch(route, -1) {
+			params = append(params, map[string]any{
+				"name":     m[1],
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]any{"type": "string"},
+			})
+		}
+		if method == http.MethodPost {
+			params = append(params, map[string]any{
+				"name":        "Idempotency-Key",
+				"in":          "header",
+				"description": "Replays with the same key are rejected with 409; a random key is used when omitted.",
+				"schema":      map[string]any{"type": "string"},
+			})
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+		if doc.Request != nil {
+			op["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(doc.Request), schemas)},
+				},
+			}
+		}
+		status := doc.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		response := map[string]any{"type": "object", "additionalProperties": true}
+		if doc.Response != nil {
+			response = schemaFor(reflect.TypeOf(doc.Response), schemas)
+		}
+		errorResponse := map[string]any{
+			"description": "Error",
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Error"}},
+			},
+		}
+		op["responses"] = map[string]any{
+			fmt.Sprint(status): map[string]any{
+				"description": http.StatusText(status),
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": response},
+				},
+			},
+			"default": errorResponse,
+		}
+		specPath := routeParamPattern.ReplaceAllString(route, "{$1}")
+		item, _ := paths[specPath].(map[string]any)
+		if item == nil {
+			item = map[string]any{}
+			paths[specPath] = item
+		}
+		item[strings.ToLower(method)] = op
+		return nil
+	}
+	if err := chi.Walk(s.mux, walk); err != nil {
+		return nil, err
+	}
+	if err := chi.Walk(s.stream, walk); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Stanks API",
+			"version": buildinfo.Get().Version,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+				"adminBasic": map[string]any{"type": "http", "scheme": "basic"},
+			},
+		},
+	}, nil
+}
+
+// operationID turns "POST /v1/businesses/{id}/sell" into
+// "postBusinessesIdSell".
+func operationID(method, route string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	route = strings.TrimPrefix(route, "/v1")
+	for _, part := range strings.FieldsFunc(routeParamPattern.ReplaceAllString(route, "$1"), func(r rune) bool {
+		return r == '/' || r == '-' || r == '_' || r == '.'
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	flexInt64Type = reflect.TypeOf(flexInt64(0))
+)
+
+// schemaFor returns the JSON schema encoding/json produces for t. Named
+// structs are added to schemas once and referenced as "pkg.Name".
+func schemaFor(t reflect.Type, schemas map[string]any) map[string]any {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case flexInt64Type:
+		return map[string]any{"oneOf": []any{
+			map[string]any{"type": "integer", "format": "int64"},
+			map[string]any{"type": "string", "pattern": "^-?[0-9]+$"},
+		}}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		out := schemaFor(t.Elem(), schemas)
+		if _, ref := out["$ref"]; ref {
+			return map[string]any{"allOf": []any{out}, "nullable": true}
+		}
+		out["nullable"] = true
+		return out
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), schemas)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return structSchema(t, schemas)
+		}
+		// Qualify by package: admin and game both have a Stock, for one.
+		name := path.Base(t.PkgPath()) + "." + t.Name()
+		if _, ok := schemas[name]; !ok {
+			// Reserve the name first so self-referencing types terminate.
+			schemas[name] = map[string]any{}
+			schemas[name] = structSchema(t, schemas)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	default:
+		return map[string]any{}
+	}
+}
+
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	props := map[string]any{}
+	var required []string
+	var addFields func(t reflect.Type)
+	addFields = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+				addFields(f.Type)
+				continue
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			props[name] = schemaFor(f.Type, schemas)
+			if !strings.Contains(opts, "omitempty") {
+				required = append(required, name)
+			}
+		}
+	}
+	addFields(t)
+	out := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		sort.Strings(required)
+		out["required"] = required
+	}
+	return out
+}
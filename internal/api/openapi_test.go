@@ -0,0 +1,59 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"stanks/internal/config"
+)
+
+func TestOpenAPIDocumentsEveryRoute(t *testing.T) {
+	s := New(config.APIConfig{DevSeed: true}, nil, nil, nil, nil)
+	spec, err := s.openAPISpec()
+	if err != nil {
+		t.Fatalf("openAPISpec: %v", err)
+	}
+	paths := spec["paths"].(map[string]any)
+	for key := range routeDocs {
+		method, path, _ := strings.Cut(key, " ")
+		item, ok := paths[path].(map[string]any)
+		if !ok || item[strings.ToLower(method)] == nil {
+			t.Errorf("documented route %s is not mounted", key)
+		}
+	}
+}
+
+func TestOpenAPISecurityFollowsMiddleware(t *testing.T) {
+	s := New(config.APIConfig{}, nil, nil, nil, nil)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Security []map[string][]string `json:"security"`
+		} `json:"paths"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&spec); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if sec := spec.Paths["/v1/dashboard"]["get"].Security; len(sec) != 1 || sec[0]["bearerAuth"] == nil {
+		t.Fatalf("dashboard security = %v, want bearerAuth", sec)
+	}
+	if sec := spec.Paths["/v1/stocks"]["get"].Security; len(sec) != 2 {
+		t.Fatalf("stocks security = %v, want optional bearerAuth", sec)
+	}
+	if sec := spec.Paths["/v1/admin/world"]["get"].Security; len(sec) != 1 || sec[0]["adminBasic"] == nil {
+		t.Fatalf("admin security = %v, want adminBasic", sec)
+	}
+	if sec := spec.Paths["/v1/auth/login"]["post"].Security; len(sec) != 0 {
+		t.Fatalf("login security = %v, want none", sec)
+	}
+	if _, ok := spec.Paths["/v1/admin/players/{userID}/demo-social"]; ok {
+		t.Fatal("dev-seed route should be left out when it is not mounted")
+	}
+}
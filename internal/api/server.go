@@ -14,6 +14,7 @@ import (
 
 	"stanks/internal/admin"
 	"stanks/internal/auth"
+	"stanks/internal/buildinfo"
 	"stanks/internal/config"
 	"stanks/internal/game"
 
@@ -27,6 +28,13 @@ type contextKey string
 
 const userContextKey contextKey = "user"
 
+// recentIPODefaultTicks and recentIPOMaxTicks bound the ?ticks window of
+// GET /v1/stocks/recent.
+const (
+	recentIPODefaultTicks = 288
+	recentIPOMaxTicks     = 10_000
+)
+
 type UserContext struct {
 	UserID string
 	Email  string
@@ -40,6 +48,10 @@ type Server struct {
 	game  *game.Service
 	admin *admin.Service
 	mux   *chi.Mux
+	// stream serves long-lived WebSocket routes outside mux's request
+	// timeout.
+	stream         *chi.Mux
+	leaderboardHub *hub
 }
 
 func New(cfg config.APIConfig, logger *slog.Logger, authClient *auth.Client, gameSvc *game.Service, adminSvc *admin.Service) *Server {
@@ -53,47 +65,94 @@ func New(cfg config.APIConfig, logger *slog.Logger, authClient *auth.Client, gam
 		game:  gameSvc,
 		admin: adminSvc,
 		mux:   chi.NewRouter(),
+
+		stream:         chi.NewRouter(),
+		leaderboardHub: newHub(),
 	}
 	s.routes()
 	return s
 }
 
 func (s *Server) Handler() http.Handler {
-	return s.mux
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/stream/") {
+			s.stream.ServeHTTP(w, r)
+			return
+		}
+		s.mux.ServeHTTP(w, r)
+	})
 }
 
 func (s *Server) routes() {
+	st := s.stream
+	st.Use(middleware.RequestID)
+	st.Use(middleware.RealIP)
+	st.Use(middleware.Recoverer)
+	st.Get("/v1/stream/leaderboard", s.handleLeaderboardStream)
+
 	r := s.mux
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Timeout(2 * time.Minute))
+	if s.cfg.GzipMinBytes > 0 {
+		r.Use(gzipLargeResponses(s.cfg.GzipMinBytes))
+	}
 
 	r.Get("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		writeJSON(w, http.StatusOK, map[string]any{"ok": true})
 	})
+	r.Get("/version", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, buildinfo.Get())
+	})
 
 	r.Route("/v1", func(r chi.Router) {
+		r.Get("/openapi.json", s.handleOpenAPI)
 		r.Post("/auth/signup", s.handleSignup)
 		r.Post("/auth/login", s.handleLogin)
+		r.Post("/auth/refresh", s.handleRefresh)
+
+		// Public spectator data: served to anyone, with the caller attached
+		// to the context when a valid token is sent.
+		r.Group(func(r chi.Router) {
+			r.Use(s.optionalAuthMiddleware)
+			r.Get("/stocks", s.handleStocksList)
+			r.Get("/stocks/recent", s.handleRecentIPOs)
+			r.Get("/seasons/active", s.handleActiveSeason)
+			r.Get("/leaderboard/global", s.handleLeaderboardGlobal)
+			r.Get("/news", s.handleNews)
+		})
 
 		r.Group(func(r chi.Router) {
 			r.Use(s.authMiddleware)
+			r.Get("/me", s.handleMe)
+			r.Post("/me/daily-bonus", s.handleDailyBonus)
+			r.Get("/me/costs", s.handleMyCosts)
+			r.Get("/me/liquidation-order", s.handleLiquidationOrder)
+			r.Get("/me/automation-skips", s.handleAutomationSkips)
 			r.Get("/dashboard", s.handleDashboard)
+			r.Get("/positions", s.handlePositions)
+			r.Post("/positions/{symbol}/stop", s.handleSetStopLoss)
 			r.Get("/wallet", s.handleWallet)
 			r.Get("/world", s.handleWorld)
+			r.Get("/market/state", s.handleMarketState)
+			r.Get("/market/regime-history", s.handleRegimeHistory)
 			r.Get("/rush", s.handleRushStatus)
 			r.Post("/rush/play", s.handleRushPlay)
 			r.Get("/stakes", s.handleStakes)
 			r.Post("/transfer", s.handleTransferStonky)
-			r.Get("/stocks", s.handleStocksList)
 			r.Get("/stocks/{symbol}", s.handleStockDetail)
+			r.Get("/stocks/{symbol}/candles", s.handleStockCandles)
+			r.Post("/stocks/{symbol}/liquidation-priority", s.handleSetLiquidationPriority)
+			r.Get("/orders", s.handleListOrders)
 			r.Post("/orders", s.handleOrder)
+			r.Post("/orders/quote", s.handleQuoteOrder)
 
 			r.Post("/businesses", s.handleCreateBusiness)
 			r.Get("/businesses/{id}", s.handleBusinessState)
 			r.Get("/businesses/{id}/employees", s.handleBusinessEmployees)
 			r.Get("/businesses/employees/candidates", s.handleEmployeeCandidates)
+			r.Get("/businesses/{id}/employees/preview", s.handlePreviewHire)
 			r.Post("/businesses/{id}/employees/hire", s.handleHireEmployee)
 			r.Post("/businesses/{id}/employees/hire-batch/quote", s.handleHireEmployeesBatchQuote)
 			r.Post("/businesses/{id}/employees/hire-batch", s.handleHireEmployeesBatch)
@@ -101,15 +160,24 @@ func (s *Server) routes() {
 			r.Get("/businesses/{id}/machinery", s.handleBusinessMachinery)
 			r.Get("/businesses/{id}/loans", s.handleBusinessLoans)
 			r.Post("/businesses/{id}/machinery/buy", s.handleBuyMachinery)
+			r.Post("/businesses/{id}/machinery/buy-batch", s.handleBuyMachineryBatch)
+			r.Post("/businesses/{id}/machinery/buy-batch/quote", s.handleQuoteMachineryBatch)
 			r.Post("/businesses/{id}/loans/take", s.handleTakeBusinessLoan)
 			r.Post("/businesses/{id}/loans/repay", s.handleRepayBusinessLoan)
+			r.Post("/businesses/{id}/loans/auto-repay", s.handleSetBusinessLoanAutoRepay)
 			r.Post("/businesses/{id}/strategy", s.handleSetBusinessStrategy)
+			r.Post("/businesses/{id}/revenue-mode", s.handleSetBusinessRevenueMode)
+			r.Post("/businesses/{id}/claim", s.handleClaimBusinessRevenue)
+			r.Get("/businesses/{id}/upgrades", s.handleBusinessUpgrades)
 			r.Post("/businesses/{id}/upgrades/buy", s.handleBuyBusinessUpgrade)
 			r.Post("/businesses/{id}/reserve/deposit", s.handleBusinessReserveDeposit)
 			r.Post("/businesses/{id}/reserve/withdraw", s.handleBusinessReserveWithdraw)
 			r.Post("/businesses/{id}/visibility", s.handleBusinessVisibility)
 			r.Post("/businesses/{id}/ipo", s.handleBusinessIPO)
+			r.Post("/businesses/{id}/delist", s.handleBusinessDelist)
+			r.Get("/businesses/{id}/sell/preview", s.handlePreviewBusinessSale)
 			r.Post("/businesses/{id}/sell", s.handleSellBusiness)
+			r.Delete("/businesses/{id}", s.handleDeleteBusiness)
 			r.Post("/businesses/{id}/stakes/give", s.handleTransferBusinessStake)
 			r.Post("/businesses/{id}/stakes/revoke", s.handleRevokeBusinessStake)
 
@@ -118,11 +186,20 @@ func (s *Server) routes() {
 			r.Get("/funds", s.handleFundsList)
 			r.Post("/funds/{code}/buy", s.handleFundBuy)
 			r.Post("/funds/{code}/sell", s.handleFundSell)
+			r.Post("/funds/swap", s.handleFundSwap)
+			r.Get("/funds/{code}/position", s.handleFundPosition)
 
-			r.Get("/leaderboard/global", s.handleLeaderboardGlobal)
 			r.Get("/leaderboard/friends", s.handleLeaderboardFriends)
 			r.Post("/friends", s.handleFriendAdd)
 			r.Delete("/friends/{invite_code}", s.handleFriendDelete)
+			r.Get("/watchlist", s.handleWatchList)
+			r.Post("/watchlist", s.handleWatchAdd)
+			r.Delete("/watchlist/{symbol}", s.handleWatchDelete)
+			r.Get("/alerts", s.handleAlertsTriggered)
+			r.Post("/alerts", s.handleAlertSet)
+			r.Post("/alerts/ack", s.handleAlertsAck)
+			r.Get("/players/{invite_code}", s.handlePlayerProfile)
+			r.Get("/players/{invite_code}/rank-history", s.handlePlayerRankHistory)
 
 			r.Post("/sync/replay", s.handleSyncReplay)
 		})
@@ -151,8 +228,12 @@ func (s *Server) routes() {
 			r.Delete("/admin/businesses/{id}", s.handleAdminDeleteBusiness)
 			r.Get("/admin/stocks", s.handleAdminStocks)
 			r.Post("/admin/stocks/{symbol}/price", s.handleAdminSetStockPrice)
+			r.Post("/admin/stocks/{symbol}/volatility", s.handleAdminSetStockVolatility)
 			r.Get("/admin/world", s.handleAdminWorld)
 			r.Post("/admin/world", s.handleAdminSetWorld)
+			if s.cfg.DevSeed {
+				r.Post("/admin/players/{userID}/demo-social", s.handleAdminSeedDemoSocial)
+			}
 		})
 	})
 }
@@ -182,6 +263,30 @@ func (s *Server) authMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// optionalAuthMiddleware attaches the caller to the context like
+// authMiddleware when a valid bearer token is sent, but lets requests with a
+// missing or unusable token through anonymously instead of rejecting them.
+func (s *Server) optionalAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := bearerToken(r.Header.Get("Authorization"))
+		if token == "" || s.auth == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		user, err := s.auth.VerifyAccessToken(r.Context(), token)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx := context.WithValue(r.Context(), userContextKey, UserContext{
+			UserID: user.ID,
+			Email:  user.Email,
+			Token:  token,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 func (s *Server) adminAuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		username, password, ok := r.BasicAuth()
@@ -216,14 +321,28 @@ func userFromContext(ctx context.Context) (UserContext, error) {
 
 func (s *Server) handleSignup(w http.ResponseWriter, r *http.Request) {
 	var in struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
-		Username string `json:"username"`
+		Email      string `json:"email"`
+		Password   string `json:"password"`
+		Username   string `json:"username"`
+		InviteCode string `json:"invite_code"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	invitedBy := ""
+	if s.cfg.InviteOnly || strings.TrimSpace(in.InviteCode) != "" {
+		// Check the invite before creating the auth account so rejected
+		// signups leave nothing behind.
+		inviter, err := s.game.ResolveInviter(r.Context(), in.InviteCode)
+		switch {
+		case err == nil:
+			invitedBy = inviter
+		case s.cfg.InviteOnly || !errors.Is(err, game.ErrInviteRequired):
+			writeDomainError(w, err)
+			return
+		}
+	}
 	session, err := s.auth.SignUp(r.Context(), strings.TrimSpace(in.Email), strings.TrimSpace(in.Password))
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
@@ -234,7 +353,7 @@ func (s *Server) handleSignup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if session.User.ID != "" {
-		if err := s.game.EnsurePlayer(r.Context(), session.User.ID, session.User.Email, in.Username); err != nil {
+		if err := s.game.EnsurePlayer(r.Context(), session.User.ID, session.User.Email, in.Username, invitedBy); err != nil {
 			writeError(w, http.StatusInternalServerError, err.Error())
 			return
 		}
@@ -268,33 +387,61 @@ func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, "auth login returned empty user id")
 		return
 	}
-	if err := s.game.EnsurePlayer(r.Context(), session.User.ID, session.User.Email, ""); err != nil {
+	if s.cfg.InviteOnly {
+		// Auth accounts created outside /v1/auth/signup never passed the
+		// invite check, so they cannot get a profile through login.
+		exists, err := s.game.PlayerExists(r.Context(), session.User.ID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if !exists {
+			writeDomainError(w, game.ErrInviteRequired)
+			return
+		}
+	}
+	if err := s.game.EnsurePlayer(r.Context(), session.User.ID, session.User.Email, "", ""); err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	if s.cfg.DailyBonusMicros > 0 {
+		if seasonID, err := s.game.ActiveSeasonID(r.Context()); err == nil {
+			if _, err := s.game.ClaimDailyBonus(r.Context(), session.User.ID, seasonID, s.cfg.DailyBonusMicros); err != nil {
+				s.log.Warn("daily bonus on login failed", "user_id", session.User.ID, "err", err)
+			}
+		}
+	}
 	writeJSON(w, http.StatusOK, session)
 }
 
-func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
-	user, err := userFromContext(r.Context())
-	if err != nil {
-		writeError(w, http.StatusUnauthorized, err.Error())
-		return
+// handleRefresh trades a refresh token for a new session. Unlike login it
+// skips the invite and daily bonus checks, which already ran when the
+// refresh token was issued.
+func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	var in struct {
+		RefreshToken string `json:"refresh_token"`
 	}
-	seasonID, err := s.game.ActiveSeasonID(r.Context())
-	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	out, err := s.game.Dashboard(r.Context(), user.UserID, seasonID)
+	session, err := s.auth.Refresh(r.Context(), in.RefreshToken)
 	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			writeError(w, http.StatusServiceUnavailable, "auth backend timeout")
+			return
+		}
+		if errors.Is(err, auth.ErrInvalidRefreshToken) {
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	writeJSON(w, http.StatusOK, out)
+	writeJSON(w, http.StatusOK, session)
 }
 
-func (s *Server) handleWallet(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleMe(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
 		writeError(w, http.StatusUnauthorized, err.Error())
@@ -305,29 +452,38 @@ func (s *Server) handleWallet(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	out, err := s.game.WalletSummary(r.Context(), user.UserID, seasonID)
+	out, err := s.game.PlayerProfile(r.Context(), user.UserID, seasonID)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeDomainError(w, err)
 		return
 	}
 	writeJSON(w, http.StatusOK, out)
 }
 
-func (s *Server) handleWorld(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleDailyBonus(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	if s.cfg.DailyBonusMicros <= 0 {
+		writeError(w, http.StatusNotFound, "daily bonus is disabled")
+		return
+	}
 	seasonID, err := s.game.ActiveSeasonID(r.Context())
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	out, err := s.game.WorldState(r.Context(), seasonID)
+	out, err := s.game.ClaimDailyBonus(r.Context(), user.UserID, seasonID, s.cfg.DailyBonusMicros)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeDomainError(w, err)
 		return
 	}
 	writeJSON(w, http.StatusOK, out)
 }
 
-func (s *Server) handleRushStatus(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
 		writeError(w, http.StatusUnauthorized, err.Error())
@@ -338,7 +494,7 @@ func (s *Server) handleRushStatus(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	out, err := s.game.RushStatus(r.Context(), user.UserID, seasonID)
+	out, err := s.game.Dashboard(r.Context(), user.UserID, seasonID)
 	if err != nil {
 		writeDomainError(w, err)
 		return
@@ -346,7 +502,7 @@ func (s *Server) handleRushStatus(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, out)
 }
 
-func (s *Server) handleRushPlay(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handlePositions(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
 		writeError(w, http.StatusUnauthorized, err.Error())
@@ -357,29 +513,15 @@ func (s *Server) handleRushPlay(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	var in struct {
-		Mode         string `json:"mode"`
-		AmountMicros int64  `json:"amount_micros"`
-	}
-	if err := decodeJSON(r, &in); err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
-		return
-	}
-	out, err := s.game.PlayRush(r.Context(), game.RushPlayInput{
-		UserID:         user.UserID,
-		SeasonID:       seasonID,
-		Mode:           in.Mode,
-		AmountMicros:   in.AmountMicros,
-		IdempotencyKey: idempotencyKey(r),
-	})
+	out, err := s.game.Positions(r.Context(), user.UserID, seasonID)
 	if err != nil {
 		writeDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, out)
+	writeJSON(w, http.StatusOK, map[string]any{"positions": out})
 }
 
-func (s *Server) handleStakes(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleWallet(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
 		writeError(w, http.StatusUnauthorized, err.Error())
@@ -390,15 +532,15 @@ func (s *Server) handleStakes(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	out, err := s.game.ListStakes(r.Context(), user.UserID, seasonID)
+	out, err := s.game.WalletSummary(r.Context(), user.UserID, seasonID)
 	if err != nil {
 		writeDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, map[string]any{"stakes": out})
+	writeJSON(w, http.StatusOK, out)
 }
 
-func (s *Server) handleTransferStonky(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleMyCosts(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
 		writeError(w, http.StatusUnauthorized, err.Error())
@@ -409,59 +551,53 @@ func (s *Server) handleTransferStonky(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	var in struct {
-		Username     string `json:"username"`
-		AmountMicros int64  `json:"amount_micros"`
-	}
-	if err := decodeJSON(r, &in); err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
-		return
-	}
-	out, err := s.game.TransferStonky(r.Context(), game.WalletTransferInput{
-		UserID:            user.UserID,
-		SeasonID:          seasonID,
-		RecipientUsername: in.Username,
-		AmountMicros:      in.AmountMicros,
-		IdempotencyKey:    idempotencyKey(r),
-	})
+	out, err := s.game.MyCosts(r.Context(), user.UserID, seasonID)
 	if err != nil {
-		writeDomainError(w, err)
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	writeJSON(w, http.StatusOK, out)
 }
 
-func (s *Server) handleStocksList(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleLiquidationOrder(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
 	seasonID, err := s.game.ActiveSeasonID(r.Context())
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	includeUnlisted := r.URL.Query().Get("all") == "1"
-	out, err := s.game.ListStocks(r.Context(), seasonID, includeUnlisted)
+	out, err := s.game.LiquidationOrder(r.Context(), user.UserID, seasonID)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, map[string]any{"stocks": out})
+	writeJSON(w, http.StatusOK, map[string]any{"positions": out})
 }
 
-func (s *Server) handleStockDetail(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleAutomationSkips(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
 	seasonID, err := s.game.ActiveSeasonID(r.Context())
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	symbol := chi.URLParam(r, "symbol")
-	out, err := s.game.StockDetail(r.Context(), seasonID, symbol)
+	out, err := s.game.AutomationSkips(r.Context(), user.UserID, seasonID)
 	if err != nil {
-		writeError(w, http.StatusNotFound, err.Error())
+		writeDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, out)
+	writeJSON(w, http.StatusOK, map[string]any{"skips": out})
 }
 
-func (s *Server) handleOrder(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleSetLiquidationPriority(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
 		writeError(w, http.StatusUnauthorized, err.Error())
@@ -473,31 +609,25 @@ func (s *Server) handleOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	var in struct {
-		Symbol        string `json:"symbol"`
-		Side          string `json:"side"`
-		QuantityUnits int64  `json:"quantity_units"`
+		Priority flexInt64 `json:"priority"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-
-	result, err := s.game.PlaceOrder(r.Context(), game.OrderInput{
-		UserID:         user.UserID,
-		SeasonID:       seasonID,
-		Symbol:         in.Symbol,
-		Side:           in.Side,
-		QuantityUnits:  in.QuantityUnits,
-		IdempotencyKey: idempotencyKey(r),
-	})
-	if err != nil {
+	if in.Priority < game.MinLiquidationPriority || in.Priority > game.MaxLiquidationPriority {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("priority must be between %d and %d", game.MinLiquidationPriority, game.MaxLiquidationPriority))
+		return
+	}
+	symbol := chi.URLParam(r, "symbol")
+	if err := s.game.SetLiquidationPriority(r.Context(), user.UserID, seasonID, symbol, int32(in.Priority), idempotencyKey(r)); err != nil {
 		writeDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, result)
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true, "symbol": strings.ToUpper(strings.TrimSpace(symbol)), "liquidation_priority": int64(in.Priority)})
 }
 
-func (s *Server) handleCreateBusiness(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleSetStopLoss(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
 		writeError(w, http.StatusUnauthorized, err.Error())
@@ -509,90 +639,93 @@ func (s *Server) handleCreateBusiness(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	var in struct {
-		Name       string `json:"name"`
-		Visibility string `json:"visibility"`
+		TriggerPriceMicros flexInt64 `json:"trigger_price_micros"`
+		QuantityUnits      flexInt64 `json:"quantity_units"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	id, err := s.game.CreateBusiness(r.Context(), game.CreateBusinessInput{
-		UserID:         user.UserID,
-		SeasonID:       seasonID,
-		Name:           in.Name,
-		Visibility:     in.Visibility,
-		IdempotencyKey: idempotencyKey(r),
+	out, err := s.game.SetStopLoss(r.Context(), game.StopLossInput{
+		UserID:             user.UserID,
+		SeasonID:           seasonID,
+		Symbol:             chi.URLParam(r, "symbol"),
+		TriggerPriceMicros: int64(in.TriggerPriceMicros),
+		QuantityUnits:      int64(in.QuantityUnits),
+		IdempotencyKey:     idempotencyKey(r),
 	})
 	if err != nil {
 		writeDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusCreated, map[string]any{"id": id})
+	writeJSON(w, http.StatusOK, out)
 }
 
-func (s *Server) handleBusinessState(w http.ResponseWriter, r *http.Request) {
-	user, err := userFromContext(r.Context())
-	if err != nil {
-		writeError(w, http.StatusUnauthorized, err.Error())
-		return
-	}
+func (s *Server) handleWorld(w http.ResponseWriter, r *http.Request) {
 	seasonID, err := s.game.ActiveSeasonID(r.Context())
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
-	if err != nil {
-		writeError(w, http.StatusBadRequest, "invalid business id")
-		return
-	}
-	out, err := s.game.BusinessState(r.Context(), user.UserID, seasonID, businessID)
+	out, err := s.game.WorldState(r.Context(), seasonID)
 	if err != nil {
-		writeDomainError(w, err)
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	writeJSON(w, http.StatusOK, out)
 }
 
-func (s *Server) handleBusinessEmployees(w http.ResponseWriter, r *http.Request) {
-	user, err := userFromContext(r.Context())
+func (s *Server) handleMarketState(w http.ResponseWriter, r *http.Request) {
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
 	if err != nil {
-		writeError(w, http.StatusUnauthorized, err.Error())
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	out, err := s.game.MarketState(r.Context(), seasonID)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleRegimeHistory(w http.ResponseWriter, r *http.Request) {
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "invalid business id")
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	employees, err := s.game.ListBusinessEmployees(r.Context(), user.UserID, seasonID, businessID)
+	out, err := s.game.RegimeHistory(r.Context(), seasonID)
 	if err != nil {
 		writeDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, map[string]any{"employees": employees})
+	writeJSON(w, http.StatusOK, map[string]any{"regimes": out})
 }
 
-func (s *Server) handleEmployeeCandidates(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleNews(w http.ResponseWriter, r *http.Request) {
 	seasonID, err := s.game.ActiveSeasonID(r.Context())
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	candidates, err := s.game.ListEmployeeCandidates(r.Context(), seasonID)
+	limit := game.DefaultNewsLimit
+	if v := strings.TrimSpace(r.URL.Query().Get("limit")); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > game.MaxNewsLimit {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", game.MaxNewsLimit))
+			return
+		}
+	}
+	out, err := s.game.News(r.Context(), seasonID, limit)
 	if err != nil {
 		writeDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, map[string]any{"candidates": candidates})
+	writeJSON(w, http.StatusOK, map[string]any{"news": out})
 }
 
-func (s *Server) handleHireEmployee(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleRushStatus(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
 		writeError(w, http.StatusUnauthorized, err.Error())
@@ -603,33 +736,15 @@ func (s *Server) handleHireEmployee(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
-	if err != nil {
-		writeError(w, http.StatusBadRequest, "invalid business id")
-		return
-	}
-	var in struct {
-		CandidateID int64 `json:"candidate_id"`
-	}
-	if err := decodeJSON(r, &in); err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
-		return
-	}
-	err = s.game.HireEmployee(r.Context(), game.HireEmployeeInput{
-		UserID:         user.UserID,
-		SeasonID:       seasonID,
-		BusinessID:     businessID,
-		CandidateID:    in.CandidateID,
-		IdempotencyKey: idempotencyKey(r),
-	})
+	out, err := s.game.RushStatus(r.Context(), user.UserID, seasonID)
 	if err != nil {
 		writeDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
+	writeJSON(w, http.StatusOK, out)
 }
 
-func (s *Server) handleHireEmployeesBatch(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleRushPlay(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
 		writeError(w, http.StatusUnauthorized, err.Error())
@@ -640,25 +755,19 @@ func (s *Server) handleHireEmployeesBatch(w http.ResponseWriter, r *http.Request
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
-	if err != nil {
-		writeError(w, http.StatusBadRequest, "invalid business id")
-		return
-	}
 	var in struct {
-		Count    int    `json:"count"`
-		Strategy string `json:"strategy"`
+		Mode         string    `json:"mode"`
+		AmountMicros flexInt64 `json:"amount_micros"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	out, err := s.game.HireEmployeesBulk(r.Context(), game.BulkHireEmployeesInput{
+	out, err := s.game.PlayRush(r.Context(), game.RushPlayInput{
 		UserID:         user.UserID,
 		SeasonID:       seasonID,
-		BusinessID:     businessID,
-		Count:          in.Count,
-		Strategy:       in.Strategy,
+		Mode:           in.Mode,
+		AmountMicros:   int64(in.AmountMicros),
 		IdempotencyKey: idempotencyKey(r),
 	})
 	if err != nil {
@@ -668,7 +777,7 @@ func (s *Server) handleHireEmployeesBatch(w http.ResponseWriter, r *http.Request
 	writeJSON(w, http.StatusOK, out)
 }
 
-func (s *Server) handleHireEmployeesBatchQuote(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleStakes(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
 		writeError(w, http.StatusUnauthorized, err.Error())
@@ -679,13 +788,488 @@ func (s *Server) handleHireEmployeesBatchQuote(w http.ResponseWriter, r *http.Re
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	out, err := s.game.ListStakes(r.Context(), user.UserID, seasonID)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "invalid business id")
+		writeDomainError(w, err)
 		return
 	}
-	var in struct {
-		Count    int    `json:"count"`
+	writeJSON(w, http.StatusOK, map[string]any{"stakes": out})
+}
+
+func (s *Server) handleTransferStonky(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var in struct {
+		Username     string    `json:"username"`
+		AmountMicros flexInt64 `json:"amount_micros"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	out, err := s.game.TransferStonky(r.Context(), game.WalletTransferInput{
+		UserID:            user.UserID,
+		SeasonID:          seasonID,
+		RecipientUsername: in.Username,
+		AmountMicros:      int64(in.AmountMicros),
+		IdempotencyKey:    idempotencyKey(r),
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleStocksList(w http.ResponseWriter, r *http.Request) {
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	// Unlisted stocks are only shown to signed-in players.
+	_, authErr := userFromContext(r.Context())
+	includeUnlisted := authErr == nil && r.URL.Query().Get("all") == "1"
+	out, err := s.game.ListStocks(r.Context(), seasonID, includeUnlisted)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"stocks": out})
+}
+
+func (s *Server) handleRecentIPOs(w http.ResponseWriter, r *http.Request) {
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ticks := int64(recentIPODefaultTicks)
+	if v := strings.TrimSpace(r.URL.Query().Get("ticks")); v != "" {
+		ticks, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || ticks <= 0 || ticks > recentIPOMaxTicks {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("ticks must be between 1 and %d", recentIPOMaxTicks))
+			return
+		}
+	}
+	out, err := s.game.RecentIPOs(r.Context(), seasonID, ticks)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ticks": ticks, "stocks": out})
+}
+
+func (s *Server) handleActiveSeason(w http.ResponseWriter, r *http.Request) {
+	out, err := s.game.ActiveSeasonInfo(r.Context())
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	out.TickEverySeconds = int64(s.cfg.MarketTickEvery / time.Second)
+	out.MarketVolatility = s.cfg.MarketVolatility
+	out.InterestAPR = s.cfg.InterestAPR
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleStockCandles(w http.ResponseWriter, r *http.Request) {
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	width := r.URL.Query().Get("bucket")
+	if strings.TrimSpace(width) == "" {
+		width = r.URL.Query().Get("interval")
+	}
+	bucket, err := game.ParseCandleBucket(width)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	count := 0
+	if v := strings.TrimSpace(r.URL.Query().Get("count")); v != "" {
+		count, err = strconv.Atoi(v)
+		if err != nil || count <= 0 {
+			writeError(w, http.StatusBadRequest, "count must be a positive integer")
+			return
+		}
+	}
+	var from, to time.Time
+	if v := strings.TrimSpace(r.URL.Query().Get("from")); v != "" {
+		if from, err = time.Parse(time.RFC3339, v); err != nil {
+			writeError(w, http.StatusBadRequest, "from must be RFC3339")
+			return
+		}
+	}
+	if v := strings.TrimSpace(r.URL.Query().Get("to")); v != "" {
+		if to, err = time.Parse(time.RFC3339, v); err != nil {
+			writeError(w, http.StatusBadRequest, "to must be RFC3339")
+			return
+		}
+	}
+	if from, to, err = game.CandleWindow(bucket, count, from, to, time.Now().UTC()); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	out, err := s.game.StockCandles(r.Context(), seasonID, chi.URLParam(r, "symbol"), bucket, from, to)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleStockDetail(w http.ResponseWriter, r *http.Request) {
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	symbol := chi.URLParam(r, "symbol")
+	out, err := s.game.StockDetail(r.Context(), seasonID, symbol)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleOrder(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var in struct {
+		Symbol        string    `json:"symbol"`
+		Side          string    `json:"side"`
+		QuantityUnits flexInt64 `json:"quantity_units"`
+		Short         bool      `json:"short"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := s.game.PlaceOrder(r.Context(), game.OrderInput{
+		UserID:         user.UserID,
+		SeasonID:       seasonID,
+		Symbol:         in.Symbol,
+		Side:           in.Side,
+		QuantityUnits:  int64(in.QuantityUnits),
+		Short:          in.Short,
+		IdempotencyKey: idempotencyKey(r),
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleQuoteOrder(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var in struct {
+		Symbol        string    `json:"symbol"`
+		Side          string    `json:"side"`
+		QuantityUnits flexInt64 `json:"quantity_units"`
+		Short         bool      `json:"short"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	out, err := s.game.QuoteOrder(r.Context(), game.OrderInput{
+		UserID:        user.UserID,
+		SeasonID:      seasonID,
+		Symbol:        in.Symbol,
+		Side:          in.Side,
+		QuantityUnits: int64(in.QuantityUnits),
+		Short:         in.Short,
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleListOrders(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	limit := game.DefaultOrderPageSize
+	if v := strings.TrimSpace(r.URL.Query().Get("limit")); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > game.MaxOrderPageSize {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", game.MaxOrderPageSize))
+			return
+		}
+	}
+	offset := 0
+	if v := strings.TrimSpace(r.URL.Query().Get("offset")); v != "" {
+		offset, err = strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			writeError(w, http.StatusBadRequest, "offset must be >= 0")
+			return
+		}
+	}
+	out, err := s.game.ListOrders(r.Context(), user.UserID, seasonID, limit, offset)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleCreateBusiness(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var in struct {
+		Name       string `json:"name"`
+		Visibility string `json:"visibility"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	id, err := s.game.CreateBusiness(r.Context(), game.CreateBusinessInput{
+		UserID:         user.UserID,
+		SeasonID:       seasonID,
+		Name:           in.Name,
+		Visibility:     in.Visibility,
+		IdempotencyKey: idempotencyKey(r),
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]any{"id": id})
+}
+
+func (s *Server) handleBusinessState(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	out, err := s.game.BusinessState(r.Context(), user.UserID, seasonID, businessID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleBusinessEmployees(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	employees, err := s.game.ListBusinessEmployees(r.Context(), user.UserID, seasonID, businessID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"employees": employees})
+}
+
+func (s *Server) handleEmployeeCandidates(w http.ResponseWriter, r *http.Request) {
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	candidates, err := s.game.ListEmployeeCandidates(r.Context(), seasonID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"candidates": candidates})
+}
+
+func (s *Server) handleHireEmployee(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	var in struct {
+		CandidateID int64 `json:"candidate_id"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	cost, err := s.game.HireEmployee(r.Context(), game.HireEmployeeInput{
+		UserID:         user.UserID,
+		SeasonID:       seasonID,
+		BusinessID:     businessID,
+		CandidateID:    in.CandidateID,
+		IdempotencyKey: idempotencyKey(r),
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true, "hire_cost_micros": cost})
+}
+
+func (s *Server) handleHireEmployeesBatch(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	var in struct {
+		Count    int    `json:"count"`
+		Strategy string `json:"strategy"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	out, err := s.game.HireEmployeesBulk(r.Context(), game.BulkHireEmployeesInput{
+		UserID:         user.UserID,
+		SeasonID:       seasonID,
+		BusinessID:     businessID,
+		Count:          in.Count,
+		Strategy:       in.Strategy,
+		IdempotencyKey: idempotencyKey(r),
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handlePreviewHire(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	candidateID, err := strconv.ParseInt(r.URL.Query().Get("candidate_id"), 10, 64)
+	if err != nil || candidateID <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid candidate_id")
+		return
+	}
+	out, err := s.game.PreviewHire(r.Context(), user.UserID, seasonID, businessID, candidateID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleHireEmployeesBatchQuote(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	var in struct {
+		Count    int    `json:"count"`
 		Strategy string `json:"strategy"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
@@ -789,6 +1373,30 @@ func (s *Server) handleBusinessLoans(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]any{"loans": out})
 }
 
+func (s *Server) handleBusinessUpgrades(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	out, err := s.game.UpgradeCosts(r.Context(), user.UserID, seasonID, businessID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"upgrades": out})
+}
+
 func (s *Server) handleBuyMachinery(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
@@ -826,6 +1434,79 @@ func (s *Server) handleBuyMachinery(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, out)
 }
 
+func (s *Server) handleBuyMachineryBatch(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	var in struct {
+		Items []game.MachineryBatchItem `json:"items"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	out, err := s.game.BuyBusinessMachineryBatch(r.Context(), game.BuyMachineryBatchInput{
+		UserID:         user.UserID,
+		SeasonID:       seasonID,
+		BusinessID:     businessID,
+		Items:          in.Items,
+		IdempotencyKey: idempotencyKey(r),
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleQuoteMachineryBatch(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	var in struct {
+		Items []game.MachineryBatchItem `json:"items"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	out, err := s.game.QuoteBusinessMachineryBatch(r.Context(), game.BuyMachineryBatchInput{
+		UserID:     user.UserID,
+		SeasonID:   seasonID,
+		BusinessID: businessID,
+		Items:      in.Items,
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
 func (s *Server) handleTakeBusinessLoan(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
@@ -843,7 +1524,7 @@ func (s *Server) handleTakeBusinessLoan(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	var in struct {
-		AmountMicros int64 `json:"amount_micros"`
+		AmountMicros flexInt64 `json:"amount_micros"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
@@ -853,7 +1534,7 @@ func (s *Server) handleTakeBusinessLoan(w http.ResponseWriter, r *http.Request)
 		UserID:         user.UserID,
 		SeasonID:       seasonID,
 		BusinessID:     businessID,
-		AmountMicros:   in.AmountMicros,
+		AmountMicros:   int64(in.AmountMicros),
 		IdempotencyKey: idempotencyKey(r),
 	})
 	if err != nil {
@@ -880,17 +1561,62 @@ func (s *Server) handleRepayBusinessLoan(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	var in struct {
-		AmountMicros int64 `json:"amount_micros"`
+		AmountMicros flexInt64 `json:"amount_micros"`
+		LoanID       flexInt64 `json:"loan_id"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if in.LoanID < 0 {
+		writeError(w, http.StatusBadRequest, "invalid loan id")
+		return
+	}
 	out, err := s.game.RepayBusinessLoan(r.Context(), game.BusinessLoanInput{
 		UserID:         user.UserID,
 		SeasonID:       seasonID,
 		BusinessID:     businessID,
-		AmountMicros:   in.AmountMicros,
+		AmountMicros:   int64(in.AmountMicros),
+		LoanID:         int64(in.LoanID),
+		IdempotencyKey: idempotencyKey(r),
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleSetBusinessLoanAutoRepay(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	var in struct {
+		Enabled      bool      `json:"enabled"`
+		BufferMicros flexInt64 `json:"buffer_micros"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	out, err := s.game.SetBusinessLoanAutoRepay(r.Context(), game.BusinessLoanAutoRepayInput{
+		UserID:         user.UserID,
+		SeasonID:       seasonID,
+		BusinessID:     businessID,
+		Enabled:        in.Enabled,
+		BufferMicros:   int64(in.BufferMicros),
 		IdempotencyKey: idempotencyKey(r),
 	})
 	if err != nil {
@@ -936,6 +1662,66 @@ func (s *Server) handleSetBusinessStrategy(w http.ResponseWriter, r *http.Reques
 	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
 }
 
+func (s *Server) handleSetBusinessRevenueMode(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	var in struct {
+		Mode string `json:"mode"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.game.SetBusinessRevenueMode(r.Context(), game.BusinessRevenueModeInput{
+		UserID:         user.UserID,
+		SeasonID:       seasonID,
+		BusinessID:     businessID,
+		Mode:           in.Mode,
+		IdempotencyKey: idempotencyKey(r),
+	}); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (s *Server) handleClaimBusinessRevenue(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	out, err := s.game.ClaimBusinessRevenue(r.Context(), user.UserID, seasonID, businessID, idempotencyKey(r))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
 func (s *Server) handleBuyBusinessUpgrade(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
@@ -990,7 +1776,7 @@ func (s *Server) handleBusinessReserveDeposit(w http.ResponseWriter, r *http.Req
 		return
 	}
 	var in struct {
-		AmountMicros int64 `json:"amount_micros"`
+		AmountMicros flexInt64 `json:"amount_micros"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
@@ -1000,7 +1786,7 @@ func (s *Server) handleBusinessReserveDeposit(w http.ResponseWriter, r *http.Req
 		UserID:         user.UserID,
 		SeasonID:       seasonID,
 		BusinessID:     businessID,
-		AmountMicros:   in.AmountMicros,
+		AmountMicros:   int64(in.AmountMicros),
 		IdempotencyKey: idempotencyKey(r),
 	}); err != nil {
 		writeDomainError(w, err)
@@ -1026,7 +1812,7 @@ func (s *Server) handleBusinessReserveWithdraw(w http.ResponseWriter, r *http.Re
 		return
 	}
 	var in struct {
-		AmountMicros int64 `json:"amount_micros"`
+		AmountMicros flexInt64 `json:"amount_micros"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
@@ -1036,16 +1822,101 @@ func (s *Server) handleBusinessReserveWithdraw(w http.ResponseWriter, r *http.Re
 		UserID:         user.UserID,
 		SeasonID:       seasonID,
 		BusinessID:     businessID,
-		AmountMicros:   in.AmountMicros,
+		AmountMicros:   int64(in.AmountMicros),
 		IdempotencyKey: idempotencyKey(r),
 	}); err != nil {
 		writeDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (s *Server) handleBusinessVisibility(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	var in struct {
+		Visibility string `json:"visibility"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.game.SetBusinessVisibility(r.Context(), user.UserID, seasonID, businessID, in.Visibility); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (s *Server) handleBusinessIPO(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	var in struct {
+		Symbol      string    `json:"symbol"`
+		PriceMicros flexInt64 `json:"price_micros"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.game.BusinessIPO(r.Context(), user.UserID, seasonID, businessID, in.Symbol, int64(in.PriceMicros), idempotencyKey(r)); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (s *Server) handleBusinessDelist(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	businessID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid business id")
+		return
+	}
+	out, err := s.game.DelistBusinessStock(r.Context(), user.UserID, seasonID, businessID, idempotencyKey(r))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
 }
 
-func (s *Server) handleBusinessVisibility(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handlePreviewBusinessSale(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
 		writeError(w, http.StatusUnauthorized, err.Error())
@@ -1061,21 +1932,15 @@ func (s *Server) handleBusinessVisibility(w http.ResponseWriter, r *http.Request
 		writeError(w, http.StatusBadRequest, "invalid business id")
 		return
 	}
-	var in struct {
-		Visibility string `json:"visibility"`
-	}
-	if err := decodeJSON(r, &in); err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
-		return
-	}
-	if err := s.game.SetBusinessVisibility(r.Context(), user.UserID, seasonID, businessID, in.Visibility); err != nil {
+	out, err := s.game.PreviewBusinessSale(r.Context(), user.UserID, seasonID, businessID)
+	if err != nil {
 		writeDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
+	writeJSON(w, http.StatusOK, out)
 }
 
-func (s *Server) handleBusinessIPO(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleSellBusiness(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
 		writeError(w, http.StatusUnauthorized, err.Error())
@@ -1091,22 +1956,15 @@ func (s *Server) handleBusinessIPO(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid business id")
 		return
 	}
-	var in struct {
-		Symbol      string `json:"symbol"`
-		PriceMicros int64  `json:"price_micros"`
-	}
-	if err := decodeJSON(r, &in); err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
-		return
-	}
-	if err := s.game.BusinessIPO(r.Context(), user.UserID, seasonID, businessID, in.Symbol, in.PriceMicros, idempotencyKey(r)); err != nil {
+	out, err := s.game.SellBusinessToBank(r.Context(), user.UserID, seasonID, businessID, idempotencyKey(r))
+	if err != nil {
 		writeDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
+	writeJSON(w, http.StatusOK, out)
 }
 
-func (s *Server) handleSellBusiness(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleDeleteBusiness(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
 		writeError(w, http.StatusUnauthorized, err.Error())
@@ -1122,7 +1980,7 @@ func (s *Server) handleSellBusiness(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid business id")
 		return
 	}
-	out, err := s.game.SellBusinessToBank(r.Context(), user.UserID, seasonID, businessID, idempotencyKey(r))
+	out, err := s.game.DeleteEmptyBusiness(r.Context(), user.UserID, seasonID, businessID, idempotencyKey(r))
 	if err != nil {
 		writeDomainError(w, err)
 		return
@@ -1254,7 +2112,7 @@ func (s *Server) handleIPOStock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	var in struct {
-		PriceMicros int64 `json:"price_micros"`
+		PriceMicros flexInt64 `json:"price_micros"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
@@ -1264,7 +2122,7 @@ func (s *Server) handleIPOStock(w http.ResponseWriter, r *http.Request) {
 		UserID:         user.UserID,
 		SeasonID:       seasonID,
 		Symbol:         chi.URLParam(r, "symbol"),
-		PriceMicros:    in.PriceMicros,
+		PriceMicros:    int64(in.PriceMicros),
 		IdempotencyKey: idempotencyKey(r),
 	}); err != nil {
 		writeDomainError(w, err)
@@ -1307,7 +2165,7 @@ func (s *Server) handleFundTrade(side string, w http.ResponseWriter, r *http.Req
 		return
 	}
 	var in struct {
-		Units int64 `json:"units"`
+		Units flexInt64 `json:"units"`
 	}
 	if err := decodeJSON(r, &in); err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
@@ -1318,7 +2176,61 @@ func (s *Server) handleFundTrade(side string, w http.ResponseWriter, r *http.Req
 		SeasonID:       seasonID,
 		FundCode:       chi.URLParam(r, "code"),
 		Side:           side,
-		Units:          in.Units,
+		Units:          int64(in.Units),
+		IdempotencyKey: idempotencyKey(r),
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleFundPosition(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out, err := s.game.FundPosition(r.Context(), user.UserID, seasonID, chi.URLParam(r, "code"))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleFundSwap(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var in struct {
+		From  string    `json:"from"`
+		To    string    `json:"to"`
+		Units flexInt64 `json:"units"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	out, err := s.game.SwapFunds(r.Context(), game.FundSwapInput{
+		UserID:         user.UserID,
+		SeasonID:       seasonID,
+		FromCode:       in.From,
+		ToCode:         in.To,
+		Units:          int64(in.Units),
 		IdempotencyKey: idempotencyKey(r),
 	})
 	if err != nil {
@@ -1339,7 +2251,23 @@ func (s *Server) handleLeaderboardGlobal(w http.ResponseWriter, r *http.Request)
 		writeDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, map[string]any{"rows": out})
+	viewerID := ""
+	if user, err := userFromContext(r.Context()); err == nil {
+		viewerID = user.UserID
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"rows": redactInviteCodes(out, viewerID)})
+}
+
+// redactInviteCodes blanks every invite code except the viewer's own, so the
+// public leaderboard cannot be used to get around invite-only signups. An
+// empty viewerID redacts every row.
+func redactInviteCodes(rows []game.LeaderboardRow, viewerID string) []game.LeaderboardRow {
+	for i := range rows {
+		if viewerID == "" || rows[i].UserID != viewerID {
+			rows[i].InviteCode = ""
+		}
+	}
+	return rows
 }
 
 func (s *Server) handleLeaderboardFriends(w http.ResponseWriter, r *http.Request) {
@@ -1367,6 +2295,11 @@ func (s *Server) handleFriendAdd(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusUnauthorized, err.Error())
 		return
 	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
 	var in struct {
 		InviteCode string `json:"invite_code"`
 	}
@@ -1374,7 +2307,7 @@ func (s *Server) handleFriendAdd(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	if err := s.game.AddFriend(r.Context(), user.UserID, in.InviteCode); err != nil {
+	if err := s.game.AddFriend(r.Context(), user.UserID, seasonID, in.InviteCode, idempotencyKey(r), s.cfg.MaxFriends); err != nil {
 		writeDomainError(w, err)
 		return
 	}
@@ -1387,13 +2320,197 @@ func (s *Server) handleFriendDelete(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusUnauthorized, err.Error())
 		return
 	}
-	if err := s.game.RemoveFriend(r.Context(), user.UserID, chi.URLParam(r, "invite_code")); err != nil {
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := s.game.RemoveFriend(r.Context(), user.UserID, seasonID, chi.URLParam(r, "invite_code"), idempotencyKey(r)); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (s *Server) handleWatchList(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	items, err := s.game.ListWatch(r.Context(), user.UserID, seasonID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"watchlist": items})
+}
+
+func (s *Server) handleWatchAdd(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var in struct {
+		Symbol string `json:"symbol"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.game.AddWatch(r.Context(), user.UserID, seasonID, in.Symbol, idempotencyKey(r)); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (s *Server) handleWatchDelete(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := s.game.RemoveWatch(r.Context(), user.UserID, seasonID, chi.URLParam(r, "symbol"), idempotencyKey(r)); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (s *Server) handleAlertsTriggered(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	alerts, err := s.game.TriggeredAlerts(r.Context(), user.UserID, seasonID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"alerts": alerts})
+}
+
+func (s *Server) handleAlertSet(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var in struct {
+		Symbol            string    `json:"symbol"`
+		Direction         string    `json:"direction"`
+		TargetPriceMicros flexInt64 `json:"target_price_micros"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	out, err := s.game.SetPriceAlert(r.Context(), game.PriceAlertInput{
+		UserID:            user.UserID,
+		SeasonID:          seasonID,
+		Symbol:            in.Symbol,
+		Direction:         in.Direction,
+		TargetPriceMicros: int64(in.TargetPriceMicros),
+		IdempotencyKey:    idempotencyKey(r),
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleAlertsAck(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var in struct {
+		IDs []int64 `json:"ids"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.game.AcknowledgeAlerts(r.Context(), user.UserID, seasonID, in.IDs, idempotencyKey(r)); err != nil {
 		writeDomainError(w, err)
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
 }
 
+func (s *Server) handlePlayerProfile(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out, err := s.game.PublicProfile(r.Context(), user.UserID, seasonID, chi.URLParam(r, "invite_code"))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handlePlayerRankHistory(w http.ResponseWriter, r *http.Request) {
+	user, err := userFromContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	seasonID, err := s.game.ActiveSeasonID(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	out, err := s.game.RankHistory(r.Context(), user.UserID, seasonID, chi.URLParam(r, "invite_code"))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
 func (s *Server) handleSyncReplay(w http.ResponseWriter, r *http.Request) {
 	user, err := userFromContext(r.Context())
 	if err != nil {
@@ -1427,15 +2544,15 @@ func writeDomainError(w http.ResponseWriter, err error) {
 		writeError(w, http.StatusInternalServerError, "database schema is outdated: run migrations through 0011_world_progression.sql")
 	case errors.Is(err, game.ErrDuplicateIdempotency):
 		writeError(w, http.StatusConflict, err.Error())
-	case errors.Is(err, game.ErrInsufficientFunds), errors.Is(err, game.ErrInsufficientShares):
+	case errors.Is(err, game.ErrInsufficientFunds), errors.Is(err, game.ErrInsufficientShares), errors.Is(err, game.ErrMachineryLimit), errors.Is(err, game.ErrFriendLimit):
 		writeError(w, http.StatusBadRequest, err.Error())
-	case errors.Is(err, game.ErrBusinessLocked), errors.Is(err, game.ErrUnauthorized):
+	case errors.Is(err, game.ErrBusinessLocked), errors.Is(err, game.ErrUnauthorized), errors.Is(err, game.ErrInviteRequired), errors.Is(err, game.ErrNotFollowing):
 		writeError(w, http.StatusForbidden, err.Error())
-	case errors.Is(err, game.ErrInvalidSymbol):
+	case errors.Is(err, game.ErrInvalidSymbol), errors.Is(err, game.ErrStockNotListed), errors.Is(err, game.ErrInvalidAlert), errors.Is(err, game.ErrInvalidFundUnits):
 		writeError(w, http.StatusBadRequest, err.Error())
-	case errors.Is(err, game.ErrStockNotFound):
+	case errors.Is(err, game.ErrStockNotFound), errors.Is(err, game.ErrPlayerNotFound), errors.Is(err, game.ErrPositionNotFound), errors.Is(err, game.ErrLoanNotFound), errors.Is(err, game.ErrFundNotFound):
 		writeError(w, http.StatusNotFound, err.Error())
-	case errors.Is(err, game.ErrTxConflict):
+	case errors.Is(err, game.ErrTxConflict), errors.Is(err, game.ErrSymbolTaken), errors.Is(err, game.ErrNameTaken), errors.Is(err, game.ErrOutsideShareholders), errors.Is(err, game.ErrSupplyExhausted), errors.Is(err, game.ErrBusinessNotEmpty), errors.Is(err, game.ErrMarketClosed), errors.Is(err, game.ErrMarketHalted), errors.Is(err, game.ErrWalletNotFound):
 		writeError(w, http.StatusConflict, err.Error())
 	default:
 		writeError(w, http.StatusInternalServerError, err.Error())
@@ -1451,6 +2568,30 @@ func decodeJSON(r *http.Request, out any) error {
 	return nil
 }
 
+// flexInt64 decodes an integer sent either as a JSON number or as a numeric
+// string ("25000"), for clients that stringify numbers.
+type flexInt64 int64
+
+func (v *flexInt64) UnmarshalJSON(data []byte) error {
+	raw := strings.TrimSpace(string(data))
+	if raw == "null" {
+		return nil
+	}
+	if strings.HasPrefix(raw, `"`) {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		raw = strings.TrimSpace(str)
+	}
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s", string(data))
+	}
+	*v = flexInt64(n)
+	return nil
+}
+
 func writeJSON(w http.ResponseWriter, status int, payload any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"stanks/internal/game"
+)
+
+func TestFlexInt64AcceptsNumbersAndStrings(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: `{"quantity_units": 25000}`, want: 25000},
+		{in: `{"quantity_units": "25000"}`, want: 25000},
+		{in: `{"quantity_units": " -42 "}`, want: -42},
+		{in: `{"quantity_units": null}`, want: 0},
+		{in: `{"quantity_units": "2.5"}`, wantErr: true},
+		{in: `{"quantity_units": "abc"}`, wantErr: true},
+		{in: `{"quantity_units": 1.5}`, wantErr: true},
+	}
+	for _, tc := range tests {
+		var out struct {
+			QuantityUnits flexInt64 `json:"quantity_units"`
+		}
+		err := json.Unmarshal([]byte(tc.in), &out)
+		if tc.wantErr {
+			if err == nil {
+				t.Fatalf("%s: expected error", tc.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.in, err)
+		}
+		if int64(out.QuantityUnits) != tc.want {
+			t.Fatalf("%s: got %d, want %d", tc.in, out.QuantityUnits, tc.want)
+		}
+	}
+}
+
+func TestWriteDomainErrorWalletNotFound(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, fmt.Errorf("place order: %w", game.ErrWalletNotFound))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
+
+func TestWriteDomainErrorNameTaken(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, game.ErrNameTaken)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
+
+func TestWriteDomainErrorOutsideShareholders(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, fmt.Errorf("%w: 2 other player(s) hold ACMELB", game.ErrOutsideShareholders))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
+
+func TestWriteDomainErrorSupplyExhausted(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, fmt.Errorf("%w: 2.0000 shares available", game.ErrSupplyExhausted))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
+
+func TestWriteDomainErrorPositionNotFound(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, fmt.Errorf("%w in DIVMAX", game.ErrPositionNotFound))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestWriteDomainErrorLoanNotFound(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, fmt.Errorf("%w: loan 9 is not an open loan of business 3", game.ErrLoanNotFound))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestWriteDomainErrorFundNotFound(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, fmt.Errorf("%w: NOPE", game.ErrFundNotFound))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestWriteDomainErrorNotFollowing(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, game.ErrNotFollowing)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestWriteDomainErrorInvalidAlert(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, game.ErrInvalidAlert)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestWriteDomainErrorInvalidFundUnits(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, game.ErrInvalidFundUnits)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestWriteDomainErrorMarketHalted(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeDomainError(rec, fmt.Errorf("%w: 2 tick(s) remaining", game.ErrMarketHalted))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
+
+func TestOptionalAuthMiddlewareAllowsAnonymous(t *testing.T) {
+	called := false
+	h := (&Server{}).optionalAuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if _, err := userFromContext(r.Context()); err == nil {
+			t.Errorf("anonymous request should not carry a user")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/leaderboard/global", nil))
+	if !called || rec.Code != http.StatusOK {
+		t.Fatalf("called = %v, status = %d; want handler reached with 200", called, rec.Code)
+	}
+}
+
+func TestRedactInviteCodesKeepsOnlyViewerRow(t *testing.T) {
+	rows := func() []game.LeaderboardRow {
+		return []game.LeaderboardRow{
+			{UserID: "u1", Username: "alice", InviteCode: "AAAA1111"},
+			{UserID: "u2", Username: "bob", InviteCode: "BBBB2222"},
+		}
+	}
+	got := redactInviteCodes(rows(), "u2")
+	if got[0].InviteCode != "" || got[1].InviteCode != "BBBB2222" {
+		t.Fatalf("signed-in redaction = %+v", got)
+	}
+	for _, row := range redactInviteCodes(rows(), "") {
+		if row.InviteCode != "" {
+			t.Fatalf("anonymous viewer saw invite code for %s", row.Username)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+)
+
+const (
+	// leaderboardStreamSize is how many standings each leaderboard push
+	// carries.
+	leaderboardStreamSize = 25
+	// streamBuffer is how many undelivered messages a subscriber may have
+	// queued before it is treated as too slow and dropped.
+	streamBuffer     = 4
+	streamWriteLimit = 10 * time.Second
+)
+
+// hub fans messages out to stream subscribers. Broadcast never blocks: a
+// subscriber whose buffer is full has its channel closed and is removed.
+type hub struct {
+	mu     sync.Mutex
+	subs   map[chan []byte]struct{}
+	latest []byte
+}
+
+func newHub() *hub {
+	return &hub{subs: map[chan []byte]struct{}{}}
+}
+
+// subscribe registers a subscriber, seeding it with the latest message so a
+// new viewer does not wait a full tick for data.
+func (h *hub) subscribe() chan []byte {
+	ch := make(chan []byte, streamBuffer)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.latest != nil {
+		ch <- h.latest
+	}
+	h.subs[ch] = struct{}{}
+	return ch
+}
+
+func (h *hub) unsubscribe(ch chan []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subs[ch]; ok {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
+
+func (h *hub) broadcast(msg []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.latest = msg
+	for ch := range h.subs {
+		select {
+		case ch <- msg:
+		default:
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// serveStream upgrades the request and relays hub messages until the client
+// goes away or falls behind.
+func (s *Server) serveStream(w http.ResponseWriter, r *http.Request, h *hub) {
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		// Spectator boards are embedded anywhere; the stream is read-only
+		// public data.
+		OriginPatterns: []string{"*"},
+	})
+	if err != nil {
+		return
+	}
+	defer conn.CloseNow()
+
+	ch := h.subscribe()
+	defer h.unsubscribe(ch)
+	ctx := conn.CloseRead(r.Context())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				conn.Close(websocket.StatusPolicyViolation, "client too slow")
+				return
+			}
+			writeCtx, cancel := context.WithTimeout(ctx, streamWriteLimit)
+			err := conn.Write(writeCtx, websocket.MessageText, msg)
+			cancel()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) handleLeaderboardStream(w http.ResponseWriter, r *http.Request) {
+	s.serveStream(w, r, s.leaderboardHub)
+}
+
+// RunLeaderboardStream pushes the global leaderboard to stream subscribers
+// after every committed market tick until ctx is done, reconnecting the
+// tick listener if it drops.
+func (s *Server) RunLeaderboardStream(ctx context.Context) {
+	for {
+		err := s.game.ListenMarketTicks(ctx, func(seasonID int64) {
+			s.publishLeaderboard(ctx, seasonID)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		s.log.Warn("market tick listener stopped; retrying", "err", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func (s *Server) publishLeaderboard(ctx context.Context, seasonID int64) {
+	rows, err := s.game.GlobalLeaderboard(ctx, seasonID, leaderboardStreamSize)
+	if err != nil {
+		s.log.Warn("leaderboard stream refresh failed", "season_id", seasonID, "err", err)
+		return
+	}
+	// The stream is public, so no row keeps its invite code.
+	msg, err := json.Marshal(map[string]any{"season_id": seasonID, "rows": redactInviteCodes(rows, "")})
+	if err != nil {
+		return
+	}
+	s.leaderboardHub.broadcast(msg)
+}
@@ -0,0 +1,31 @@
+package api
+
+import "testing"
+
+func TestHubDropsSlowSubscriber(t *testing.T) {
+	h := newHub()
+	fast := h.subscribe()
+	slow := h.subscribe()
+
+	for i := 0; i <= streamBuffer; i++ {
+		h.broadcast([]byte{byte(i)})
+		<-fast
+	}
+
+	for i := 0; i < streamBuffer; i++ {
+		if _, ok := <-slow; !ok {
+			t.Fatalf("slow subscriber closed before its buffered messages were read")
+		}
+	}
+	if _, ok := <-slow; ok {
+		t.Fatalf("slow subscriber should be closed after overflowing its buffer")
+	}
+	if _, ok := h.subs[fast]; !ok {
+		t.Fatalf("fast subscriber should stay subscribed")
+	}
+
+	late := h.subscribe()
+	if got := <-late; len(got) != 1 || got[0] != byte(streamBuffer) {
+		t.Fatalf("late subscriber should get the latest message first, got %v", got)
+	}
+}
@@ -32,6 +32,10 @@ type User struct {
 
 const authDBTimeout = 8 * time.Second
 
+// ErrInvalidRefreshToken is returned by Refresh when the token is unknown or
+// has already been exchanged.
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
 func NewClient(db *pgxpool.Pool) *Client {
 	return &Client{db: db}
 }
@@ -75,18 +79,20 @@ func (c *Client) SignUp(ctx context.Context, email, password string) (Session, e
 		userID = uuid.NewString()
 	}
 	token := uuid.NewString()
+	refresh := uuid.NewString()
 
 	_, err = c.db.Exec(dbCtx, `
-		INSERT INTO auth.users (id, email, password_hash, access_token, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, now(), now())
-	`, userID, email, string(passwordHash), token)
+		INSERT INTO auth.users (id, email, password_hash, access_token, refresh_token, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, now(), now())
+	`, userID, email, string(passwordHash), token, refresh)
 	if err != nil {
 		return Session{}, fmt.Errorf("create user: %w", err)
 	}
 
 	return Session{
-		AccessToken: token,
-		TokenType:   "bearer",
+		AccessToken:  token,
+		RefreshToken: refresh,
+		TokenType:    "bearer",
 		User: User{
 			ID:    userID,
 			Email: email,
@@ -120,17 +126,19 @@ func (c *Client) Login(ctx context.Context, email, password string) (Session, er
 	}
 
 	token := uuid.NewString()
+	refresh := uuid.NewString()
 	if _, err := c.db.Exec(dbCtx, `
 		UPDATE auth.users
-		SET access_token = $2, updated_at = now()
+		SET access_token = $2, refresh_token = $3, updated_at = now()
 		WHERE id = $1
-	`, userID, token); err != nil {
+	`, userID, token, refresh); err != nil {
 		return Session{}, fmt.Errorf("store access token: %w", err)
 	}
 
 	return Session{
-		AccessToken: token,
-		TokenType:   "bearer",
+		AccessToken:  token,
+		RefreshToken: refresh,
+		TokenType:    "bearer",
 		User: User{
 			ID:    userID,
 			Email: email,
@@ -138,6 +146,40 @@ func (c *Client) Login(ctx context.Context, email, password string) (Session, er
 	}, nil
 }
 
+// Refresh exchanges a refresh token for a new access/refresh token pair. Both
+// old tokens stop working, so a refresh token can only be used once.
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (Session, error) {
+	refreshToken = strings.TrimSpace(refreshToken)
+	if refreshToken == "" {
+		return Session{}, ErrInvalidRefreshToken
+	}
+	dbCtx, cancel := withAuthDBTimeout(ctx)
+	defer cancel()
+
+	token := uuid.NewString()
+	refresh := uuid.NewString()
+	var user User
+	err := c.db.QueryRow(dbCtx, `
+		UPDATE auth.users
+		SET access_token = $2, refresh_token = $3, updated_at = now()
+		WHERE refresh_token = $1
+		RETURNING id, email
+	`, refreshToken, token, refresh).Scan(&user.ID, &user.Email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Session{}, ErrInvalidRefreshToken
+		}
+		return Session{}, fmt.Errorf("refresh token: %w", err)
+	}
+
+	return Session{
+		AccessToken:  token,
+		RefreshToken: refresh,
+		TokenType:    "bearer",
+		User:         user,
+	}, nil
+}
+
 func (c *Client) VerifyAccessToken(ctx context.Context, accessToken string) (User, error) {
 	accessToken = strings.TrimSpace(accessToken)
 	if accessToken == "" {
@@ -0,0 +1,56 @@
+// Package buildinfo reports the version stamped into a binary at build time:
+//
+//	go build -ldflags "-X stanks/internal/buildinfo.Version=v1.2.3 -X stanks/internal/buildinfo.Commit=abc1234" ./cmd/stk
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the stamped build info. Without an -ldflags commit it falls
+// back to the VCS revision the Go toolchain embeds for module builds.
+func Get() Info {
+	out := Info{Version: Version, Commit: Commit, GoVersion: runtime.Version()}
+	if out.Commit == "" {
+		out.Commit = vcsRevision()
+	}
+	if out.Commit == "" {
+		out.Commit = "unknown"
+	}
+	return out
+}
+
+func vcsRevision() string {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	rev, dirty := "", false
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			rev = s.Value
+		case "vcs.modified":
+			dirty = s.Value == "true"
+		}
+	}
+	if len(rev) > 12 {
+		rev = rev[:12]
+	}
+	if rev != "" && dirty {
+		rev += "-dirty"
+	}
+	return rev
+}
@@ -0,0 +1,30 @@
+package buildinfo
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestGetUsesStampedValues(t *testing.T) {
+	oldVersion, oldCommit := Version, Commit
+	t.Cleanup(func() { Version, Commit = oldVersion, oldCommit })
+
+	Version, Commit = "v1.2.3", "abc1234"
+	info := Get()
+	if info.Version != "v1.2.3" || info.Commit != "abc1234" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Fatalf("go version = %q, want %q", info.GoVersion, runtime.Version())
+	}
+}
+
+func TestGetAlwaysReportsCommit(t *testing.T) {
+	oldCommit := Commit
+	t.Cleanup(func() { Commit = oldCommit })
+
+	Commit = ""
+	if got := Get().Commit; got == "" {
+		t.Fatal("expected a commit fallback")
+	}
+}
@@ -2,21 +2,45 @@ package cli
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"stanks/internal/auth"
+	"stanks/internal/buildinfo"
+
+	"github.com/coder/websocket"
 )
 
 type Client struct {
 	BaseURL string
 	HTTP    *http.Client
+	// RefreshAccessToken, when set, is called after an authenticated request
+	// comes back 401. The request is retried once with the token it returns.
+	RefreshAccessToken func(ctx context.Context) (string, error)
+
+	mu sync.Mutex
+	// refreshed maps rejected access tokens to their replacements so later
+	// calls made with a stale token skip straight to the new one.
+	refreshed map[string]string
+	// refreshing holds the in-flight refresh for each stale token. mu is
+	// never held across the refresh itself because the hook usually calls
+	// back into this client.
+	refreshing map[string]*tokenRefresh
+}
+
+type tokenRefresh struct {
+	done  chan struct{}
+	token string
+	err   error
 }
 
 func NewClient(baseURL string) *Client {
@@ -42,12 +66,13 @@ func normalizeBaseURL(raw string) string {
 	return "https://" + base
 }
 
-func (c *Client) Signup(ctx context.Context, email, password, username string) (auth.Session, error) {
+func (c *Client) Signup(ctx context.Context, email, password, username, inviteCode string) (auth.Session, error) {
 	var out auth.Session
 	err := c.jsonRequest(ctx, http.MethodPost, "/v1/auth/signup", "", map[string]any{
-		"email":    email,
-		"password": password,
-		"username": username,
+		"email":       email,
+		"password":    password,
+		"username":    username,
+		"invite_code": inviteCode,
 	}, &out, "")
 	return out, err
 }
@@ -61,12 +86,50 @@ func (c *Client) Login(ctx context.Context, email, password string) (auth.Sessio
 	return out, err
 }
 
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (auth.Session, error) {
+	var out auth.Session
+	err := c.jsonRequest(ctx, http.MethodPost, "/v1/auth/refresh", "", map[string]any{
+		"refresh_token": refreshToken,
+	}, &out, "")
+	return out, err
+}
+
+func (c *Client) ServerVersion(ctx context.Context) (buildinfo.Info, error) {
+	var out buildinfo.Info
+	err := c.jsonRequest(ctx, http.MethodGet, "/version", "", nil, &out, "")
+	return out, err
+}
+
+func (c *Client) Health(ctx context.Context) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, "/healthz", "", nil, &out, "")
+	return out, err
+}
+
+func (c *Client) Me(ctx context.Context, accessToken string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, "/v1/me", accessToken, nil, &out, "")
+	return out, err
+}
+
 func (c *Client) Dashboard(ctx context.Context, accessToken string) (map[string]any, error) {
 	var out map[string]any
 	err := c.jsonRequest(ctx, http.MethodGet, "/v1/dashboard", accessToken, nil, &out, "")
 	return out, err
 }
 
+func (c *Client) Positions(ctx context.Context, accessToken string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, "/v1/positions", accessToken, nil, &out, "")
+	return out, err
+}
+
+func (c *Client) MyCosts(ctx context.Context, accessToken string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, "/v1/me/costs", accessToken, nil, &out, "")
+	return out, err
+}
+
 func (c *Client) WalletSummary(ctx context.Context, accessToken string) (map[string]any, error) {
 	var out map[string]any
 	err := c.jsonRequest(ctx, http.MethodGet, "/v1/wallet", accessToken, nil, &out, "")
@@ -119,12 +182,54 @@ func (c *Client) ListStocks(ctx context.Context, accessToken string, all bool) (
 	return out, err
 }
 
+func (c *Client) RecentIPOs(ctx context.Context, accessToken string, ticks int64) (map[string]any, error) {
+	path := "/v1/stocks/recent"
+	if ticks > 0 {
+		path += "?ticks=" + strconv.FormatInt(ticks, 10)
+	}
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, path, accessToken, nil, &out, "")
+	return out, err
+}
+
+func (c *Client) RegimeHistory(ctx context.Context, accessToken string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, "/v1/market/regime-history", accessToken, nil, &out, "")
+	return out, err
+}
+
+func (c *Client) News(ctx context.Context, accessToken string, limit int) (map[string]any, error) {
+	var out map[string]any
+	path := "/v1/news"
+	if limit > 0 {
+		path += "?limit=" + strconv.Itoa(limit)
+	}
+	err := c.jsonRequest(ctx, http.MethodGet, path, accessToken, nil, &out, "")
+	return out, err
+}
+
+func (c *Client) ActiveSeason(ctx context.Context, accessToken string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, "/v1/seasons/active", accessToken, nil, &out, "")
+	return out, err
+}
+
 func (c *Client) StockDetail(ctx context.Context, accessToken, symbol string) (map[string]any, error) {
 	var out map[string]any
 	err := c.jsonRequest(ctx, http.MethodGet, "/v1/stocks/"+url.PathEscape(symbol), accessToken, nil, &out, "")
 	return out, err
 }
 
+func (c *Client) StockCandles(ctx context.Context, accessToken, symbol, bucket string, count int) (map[string]any, error) {
+	var out map[string]any
+	path := "/v1/stocks/" + url.PathEscape(symbol) + "/candles?bucket=" + url.QueryEscape(bucket)
+	if count > 0 {
+		path += "&count=" + strconv.Itoa(count)
+	}
+	err := c.jsonRequest(ctx, http.MethodGet, path, accessToken, nil, &out, "")
+	return out, err
+}
+
 func (c *Client) PlaceOrder(ctx context.Context, accessToken, symbol, side, idem string, qtyUnits int64) (map[string]any, error) {
 	var out map[string]any
 	err := c.jsonRequest(ctx, http.MethodPost, "/v1/orders", accessToken, map[string]any{
@@ -135,6 +240,30 @@ func (c *Client) PlaceOrder(ctx context.Context, accessToken, symbol, side, idem
 	return out, err
 }
 
+// QuoteOrder asks what an order would fill at right now, fee included.
+func (c *Client) QuoteOrder(ctx context.Context, accessToken, symbol, side string, qtyUnits int64, short bool) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, "/v1/orders/quote", accessToken, map[string]any{
+		"symbol":         symbol,
+		"side":           side,
+		"quantity_units": qtyUnits,
+		"short":          short,
+	}, &out, "")
+	return out, err
+}
+
+// ShortSell places a sell order that may open or add to a short position.
+func (c *Client) ShortSell(ctx context.Context, accessToken, symbol, idem string, qtyUnits int64) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, "/v1/orders", accessToken, map[string]any{
+		"symbol":         symbol,
+		"side":           "sell",
+		"quantity_units": qtyUnits,
+		"short":          true,
+	}, &out, idem)
+	return out, err
+}
+
 func (c *Client) CreateBusiness(ctx context.Context, accessToken, name, visibility, idem string) (map[string]any, error) {
 	var out map[string]any
 	err := c.jsonRequest(ctx, http.MethodPost, "/v1/businesses", accessToken, map[string]any{
@@ -170,6 +299,12 @@ func (c *Client) ListBusinessEmployees(ctx context.Context, accessToken string,
 	return out, err
 }
 
+func (c *Client) PreviewHire(ctx context.Context, accessToken string, businessID, candidateID int64) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, fmt.Sprintf("/v1/businesses/%d/employees/preview?candidate_id=%d", businessID, candidateID), accessToken, nil, &out, "")
+	return out, err
+}
+
 func (c *Client) HireEmployee(ctx context.Context, accessToken string, businessID, candidateID int64, idem string) (map[string]any, error) {
 	var out map[string]any
 	err := c.jsonRequest(ctx, http.MethodPost, fmt.Sprintf("/v1/businesses/%d/employees/hire", businessID), accessToken, map[string]any{
@@ -222,6 +357,31 @@ func (c *Client) BuyBusinessMachinery(ctx context.Context, accessToken string, b
 	return out, err
 }
 
+func (c *Client) BuyBusinessMachineryBatch(ctx context.Context, accessToken string, businessID int64, items []map[string]any, idem string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, fmt.Sprintf("/v1/businesses/%d/machinery/buy-batch", businessID), accessToken, map[string]any{
+		"items": items,
+	}, &out, idem)
+	return out, err
+}
+
+func (c *Client) QuoteBusinessMachineryBatch(ctx context.Context, accessToken string, businessID int64, items []map[string]any) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, fmt.Sprintf("/v1/businesses/%d/machinery/buy-batch/quote", businessID), accessToken, map[string]any{
+		"items": items,
+	}, &out, "")
+	return out, err
+}
+
+func (c *Client) SetBusinessLoanAutoRepay(ctx context.Context, accessToken string, businessID int64, enabled bool, bufferMicros int64, idem string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, fmt.Sprintf("/v1/businesses/%d/loans/auto-repay", businessID), accessToken, map[string]any{
+		"enabled":       enabled,
+		"buffer_micros": bufferMicros,
+	}, &out, idem)
+	return out, err
+}
+
 func (c *Client) TakeBusinessLoan(ctx context.Context, accessToken string, businessID int64, amountMicros int64, idem string) (map[string]any, error) {
 	var out map[string]any
 	err := c.jsonRequest(ctx, http.MethodPost, fmt.Sprintf("/v1/businesses/%d/loans/take", businessID), accessToken, map[string]any{
@@ -230,11 +390,17 @@ func (c *Client) TakeBusinessLoan(ctx context.Context, accessToken string, busin
 	return out, err
 }
 
-func (c *Client) RepayBusinessLoan(ctx context.Context, accessToken string, businessID int64, amountMicros int64, idem string) (map[string]any, error) {
+// RepayBusinessLoan repays loanID only when it is non-zero; otherwise the
+// server sweeps open loans oldest first.
+func (c *Client) RepayBusinessLoan(ctx context.Context, accessToken string, businessID int64, amountMicros, loanID int64, idem string) (map[string]any, error) {
 	var out map[string]any
-	err := c.jsonRequest(ctx, http.MethodPost, fmt.Sprintf("/v1/businesses/%d/loans/repay", businessID), accessToken, map[string]any{
+	body := map[string]any{
 		"amount_micros": amountMicros,
-	}, &out, idem)
+	}
+	if loanID > 0 {
+		body["loan_id"] = loanID
+	}
+	err := c.jsonRequest(ctx, http.MethodPost, fmt.Sprintf("/v1/businesses/%d/loans/repay", businessID), accessToken, body, &out, idem)
 	return out, err
 }
 
@@ -246,6 +412,26 @@ func (c *Client) SetBusinessStrategy(ctx context.Context, accessToken string, bu
 	return out, err
 }
 
+func (c *Client) SetBusinessRevenueMode(ctx context.Context, accessToken string, businessID int64, mode, idem string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, fmt.Sprintf("/v1/businesses/%d/revenue-mode", businessID), accessToken, map[string]any{
+		"mode": mode,
+	}, &out, idem)
+	return out, err
+}
+
+func (c *Client) ClaimBusinessRevenue(ctx context.Context, accessToken string, businessID int64, idem string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, fmt.Sprintf("/v1/businesses/%d/claim", businessID), accessToken, map[string]any{}, &out, idem)
+	return out, err
+}
+
+func (c *Client) BusinessUpgrades(ctx context.Context, accessToken string, businessID int64) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, fmt.Sprintf("/v1/businesses/%d/upgrades", businessID), accessToken, nil, &out, "")
+	return out, err
+}
+
 func (c *Client) BuyBusinessUpgrade(ctx context.Context, accessToken string, businessID int64, upgrade, idem string) (map[string]any, error) {
 	var out map[string]any
 	err := c.jsonRequest(ctx, http.MethodPost, fmt.Sprintf("/v1/businesses/%d/upgrades/buy", businessID), accessToken, map[string]any{
@@ -270,12 +456,30 @@ func (c *Client) BusinessReserveWithdraw(ctx context.Context, accessToken string
 	return out, err
 }
 
+func (c *Client) DelistBusiness(ctx context.Context, accessToken string, businessID int64, idem string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, fmt.Sprintf("/v1/businesses/%d/delist", businessID), accessToken, map[string]any{}, &out, idem)
+	return out, err
+}
+
+func (c *Client) PreviewBusinessSale(ctx context.Context, accessToken string, businessID int64) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, fmt.Sprintf("/v1/businesses/%d/sell/preview", businessID), accessToken, nil, &out, "")
+	return out, err
+}
+
 func (c *Client) SellBusinessToBank(ctx context.Context, accessToken string, businessID int64, idem string) (map[string]any, error) {
 	var out map[string]any
 	err := c.jsonRequest(ctx, http.MethodPost, fmt.Sprintf("/v1/businesses/%d/sell", businessID), accessToken, map[string]any{}, &out, idem)
 	return out, err
 }
 
+func (c *Client) DeleteBusiness(ctx context.Context, accessToken string, businessID int64, idem string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodDelete, fmt.Sprintf("/v1/businesses/%d", businessID), accessToken, nil, &out, idem)
+	return out, err
+}
+
 func (c *Client) TransferBusinessStake(ctx context.Context, accessToken string, businessID int64, username string, stakeBps int32, idem string) (map[string]any, error) {
 	var out map[string]any
 	err := c.jsonRequest(ctx, http.MethodPost, fmt.Sprintf("/v1/businesses/%d/stakes/give", businessID), accessToken, map[string]any{
@@ -343,12 +547,94 @@ func (c *Client) SellFund(ctx context.Context, accessToken, fundCode, idem strin
 	return out, err
 }
 
+func (c *Client) FundPosition(ctx context.Context, accessToken, fundCode string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, "/v1/funds/"+url.PathEscape(fundCode)+"/position", accessToken, nil, &out, "")
+	return out, err
+}
+
+func (c *Client) SwapFunds(ctx context.Context, accessToken, fromCode, toCode, idem string, units int64) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, "/v1/funds/swap", accessToken, map[string]any{
+		"from":  fromCode,
+		"to":    toCode,
+		"units": units,
+	}, &out, idem)
+	return out, err
+}
+
+func (c *Client) AutomationSkips(ctx context.Context, accessToken string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, "/v1/me/automation-skips", accessToken, nil, &out, "")
+	return out, err
+}
+
+func (c *Client) LiquidationOrder(ctx context.Context, accessToken string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, "/v1/me/liquidation-order", accessToken, nil, &out, "")
+	return out, err
+}
+
+func (c *Client) SetLiquidationPriority(ctx context.Context, accessToken, symbol string, priority int64, idem string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, "/v1/stocks/"+url.PathEscape(symbol)+"/liquidation-priority", accessToken, map[string]any{
+		"priority": priority,
+	}, &out, idem)
+	return out, err
+}
+
+func (c *Client) SetStopLoss(ctx context.Context, accessToken, symbol string, triggerPriceMicros, qtyUnits int64, idem string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, "/v1/positions/"+url.PathEscape(symbol)+"/stop", accessToken, map[string]any{
+		"trigger_price_micros": triggerPriceMicros,
+		"quantity_units":       qtyUnits,
+	}, &out, idem)
+	return out, err
+}
+
+func (c *Client) ListOrders(ctx context.Context, accessToken string, limit, offset int) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, fmt.Sprintf("/v1/orders?limit=%d&offset=%d", limit, offset), accessToken, nil, &out, "")
+	return out, err
+}
+
 func (c *Client) LeaderboardGlobal(ctx context.Context, accessToken string) (map[string]any, error) {
 	var out map[string]any
 	err := c.jsonRequest(ctx, http.MethodGet, "/v1/leaderboard/global", accessToken, nil, &out, "")
 	return out, err
 }
 
+// WatchLeaderboard streams global leaderboard updates from
+// /v1/stream/leaderboard, calling fn with each one until ctx is done, the
+// server closes the stream, or fn returns an error.
+func (c *Client) WatchLeaderboard(ctx context.Context, fn func(map[string]any) error) error {
+	streamURL := c.BaseURL + "/v1/stream/leaderboard"
+	if rest, ok := strings.CutPrefix(streamURL, "https://"); ok {
+		streamURL = "wss://" + rest
+	} else if rest, ok := strings.CutPrefix(streamURL, "http://"); ok {
+		streamURL = "ws://" + rest
+	}
+	conn, _, err := websocket.Dial(ctx, streamURL, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.CloseNow()
+	conn.SetReadLimit(1 << 20)
+	for {
+		_, raw, err := conn.Read(ctx)
+		if err != nil {
+			return err
+		}
+		var out map[string]any
+		if err := json.Unmarshal(raw, &out); err != nil {
+			return err
+		}
+		if err := fn(out); err != nil {
+			return err
+		}
+	}
+}
+
 func (c *Client) LeaderboardFriends(ctx context.Context, accessToken string) (map[string]any, error) {
 	var out map[string]any
 	err := c.jsonRequest(ctx, http.MethodGet, "/v1/leaderboard/friends", accessToken, nil, &out, "")
@@ -363,9 +649,65 @@ func (c *Client) AddFriend(ctx context.Context, accessToken, inviteCode, idem st
 	return out, err
 }
 
-func (c *Client) RemoveFriend(ctx context.Context, accessToken, inviteCode string) (map[string]any, error) {
+func (c *Client) RemoveFriend(ctx context.Context, accessToken, inviteCode, idem string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodDelete, "/v1/friends/"+url.PathEscape(inviteCode), accessToken, nil, &out, idem)
+	return out, err
+}
+
+func (c *Client) ListWatch(ctx context.Context, accessToken string) (map[string]any, error) {
 	var out map[string]any
-	err := c.jsonRequest(ctx, http.MethodDelete, "/v1/friends/"+url.PathEscape(inviteCode), accessToken, nil, &out, "")
+	err := c.jsonRequest(ctx, http.MethodGet, "/v1/watchlist", accessToken, nil, &out, "")
+	return out, err
+}
+
+func (c *Client) AddWatch(ctx context.Context, accessToken, symbol, idem string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, "/v1/watchlist", accessToken, map[string]any{
+		"symbol": symbol,
+	}, &out, idem)
+	return out, err
+}
+
+func (c *Client) RemoveWatch(ctx context.Context, accessToken, symbol, idem string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodDelete, "/v1/watchlist/"+url.PathEscape(symbol), accessToken, nil, &out, idem)
+	return out, err
+}
+
+func (c *Client) TriggeredAlerts(ctx context.Context, accessToken string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, "/v1/alerts", accessToken, nil, &out, "")
+	return out, err
+}
+
+func (c *Client) SetPriceAlert(ctx context.Context, accessToken, symbol, direction string, targetPriceMicros int64, idem string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, "/v1/alerts", accessToken, map[string]any{
+		"symbol":              symbol,
+		"direction":           direction,
+		"target_price_micros": targetPriceMicros,
+	}, &out, idem)
+	return out, err
+}
+
+func (c *Client) AcknowledgeAlerts(ctx context.Context, accessToken string, ids []int64, idem string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodPost, "/v1/alerts/ack", accessToken, map[string]any{
+		"ids": ids,
+	}, &out, idem)
+	return out, err
+}
+
+func (c *Client) PlayerProfile(ctx context.Context, accessToken, inviteCode string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, "/v1/players/"+url.PathEscape(inviteCode), accessToken, nil, &out, "")
+	return out, err
+}
+
+func (c *Client) RankHistory(ctx context.Context, accessToken, inviteCode string) (map[string]any, error) {
+	var out map[string]any
+	err := c.jsonRequest(ctx, http.MethodGet, "/v1/players/"+url.PathEscape(inviteCode)+"/rank-history", accessToken, nil, &out, "")
 	return out, err
 }
 
@@ -384,20 +726,68 @@ func (c *Client) Do(ctx context.Context, method, path, accessToken string, body
 }
 
 func (c *Client) jsonRequest(ctx context.Context, method, path, accessToken string, in any, out any, idem string) error {
-	var body io.Reader
+	var raw []byte
 	if in != nil {
-		raw, err := json.Marshal(in)
+		var err error
+		raw, err = json.Marshal(in)
 		if err != nil {
 			return err
 		}
+	}
+	accessToken = c.currentAccessToken(accessToken)
+	resp, err := c.send(ctx, method, path, accessToken, in != nil, raw, idem)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && accessToken != "" && c.RefreshAccessToken != nil {
+		// The auth middleware rejects the request before any handler runs,
+		// so replaying it with the same idempotency key is safe.
+		resp.Body.Close()
+		fresh, err := c.refreshAccessToken(ctx, accessToken)
+		if err != nil {
+			return fmt.Errorf("api status %d: session expired and refresh failed (%v); run `stk login`", http.StatusUnauthorized, err)
+		}
+		resp, err = c.send(ctx, method, path, fresh, in != nil, raw, idem)
+		if err != nil {
+			return err
+		}
+	}
+	defer resp.Body.Close()
+	// Setting Accept-Encoding ourselves turns off the transport's automatic
+	// decompression, so gzip bodies are unwrapped here.
+	respBody := io.Reader(resp.Body)
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		respBody = gz
+	}
+	if resp.StatusCode >= 300 {
+		raw, _ := io.ReadAll(io.LimitReader(respBody, 4096))
+		return fmt.Errorf("api status %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+	if out == nil {
+		return nil
+	}
+	dec := json.NewDecoder(respBody)
+	dec.UseNumber()
+	return dec.Decode(out)
+}
+
+func (c *Client) send(ctx context.Context, method, path, accessToken string, hasBody bool, raw []byte, idem string) (*http.Response, error) {
+	var body io.Reader
+	if hasBody {
 		body = bytes.NewReader(raw)
 	}
 	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
-	if in != nil {
+	req.Header.Set("Accept-Encoding", "gzip")
+	if hasBody {
 		req.Header.Set("Content-Type", "application/json")
 	}
 	if accessToken != "" {
@@ -406,19 +796,56 @@ func (c *Client) jsonRequest(ctx context.Context, method, path, accessToken stri
 	if idem != "" {
 		req.Header.Set("Idempotency-Key", idem)
 	}
-	resp, err := c.HTTP.Do(req)
-	if err != nil {
-		return err
+	return c.HTTP.Do(req)
+}
+
+func (c *Client) currentAccessToken(accessToken string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if fresh, ok := c.refreshed[accessToken]; ok {
+		return fresh
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 300 {
-		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
-		return fmt.Errorf("api status %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	return accessToken
+}
+
+// refreshAccessToken runs RefreshAccessToken at most once per stale token;
+// concurrent callers holding the same token wait and share the result.
+func (c *Client) refreshAccessToken(ctx context.Context, stale string) (string, error) {
+	c.mu.Lock()
+	if fresh, ok := c.refreshed[stale]; ok {
+		c.mu.Unlock()
+		return fresh, nil
 	}
-	if out == nil {
-		return nil
+	if call, ok := c.refreshing[stale]; ok {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.token, call.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
 	}
-	dec := json.NewDecoder(resp.Body)
-	dec.UseNumber()
-	return dec.Decode(out)
+	call := &tokenRefresh{done: make(chan struct{})}
+	if c.refreshing == nil {
+		c.refreshing = map[string]*tokenRefresh{}
+	}
+	c.refreshing[stale] = call
+	c.mu.Unlock()
+
+	call.token, call.err = c.RefreshAccessToken(ctx)
+
+	c.mu.Lock()
+	delete(c.refreshing, stale)
+	if call.err == nil {
+		if c.refreshed == nil {
+			c.refreshed = map[string]string{}
+		}
+		c.refreshed[stale] = call.token
+	}
+	c.mu.Unlock()
+	close(call.done)
+	if call.err != nil {
+		return "", call.err
+	}
+	return call.token, nil
 }
@@ -1,13 +1,20 @@
 package cli
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
+// ErrSessionExpired is returned by SessionTimeLeft once the saved access
+// token is past its exp claim.
+var ErrSessionExpired = errors.New("session expired, run `stk login`")
+
 type Session struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
@@ -79,3 +86,41 @@ func ClearSession() error {
 	}
 	return os.Remove(path)
 }
+
+// TokenExpiry reads the exp claim from a JWT access token without verifying
+// the signature. It is only meant for local diagnostics.
+func TokenExpiry(token string) (time.Time, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("access token is not a jwt")
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("decode jwt payload: %w", err)
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("decode jwt claims: %w", err)
+	}
+	if claims.Exp <= 0 {
+		return time.Time{}, fmt.Errorf("access token has no exp claim")
+	}
+	return time.Unix(claims.Exp, 0), nil
+}
+
+// SessionTimeLeft reports how long the session's access token remains valid
+// at now. Tokens whose exp cannot be read return the decode error so callers
+// can fall back to letting the API decide.
+func SessionTimeLeft(s Session, now time.Time) (time.Duration, error) {
+	exp, err := TokenExpiry(s.AccessToken)
+	if err != nil {
+		return 0, err
+	}
+	left := exp.Sub(now)
+	if left <= 0 {
+		return 0, ErrSessionExpired
+	}
+	return left, nil
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTokenExpiry(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"u1","exp":1700000000}`))
+	got, err := TokenExpiry("h." + payload + ".sig")
+	if err != nil {
+		t.Fatalf("TokenExpiry returned error: %v", err)
+	}
+	if got.Unix() != 1700000000 {
+		t.Fatalf("TokenExpiry = %d, want 1700000000", got.Unix())
+	}
+
+	if _, err := TokenExpiry("not-a-jwt"); err == nil {
+		t.Fatalf("expected error for malformed token")
+	}
+	noExp := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"u1"}`))
+	if _, err := TokenExpiry("h." + noExp + ".sig"); err == nil {
+		t.Fatalf("expected error for token without exp")
+	}
+}
+
+func TestSessionTimeLeft(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"u1","exp":1700000000}`))
+	sess := Session{AccessToken: "h." + payload + ".sig"}
+
+	left, err := SessionTimeLeft(sess, time.Unix(1700000000-120, 0))
+	if err != nil {
+		t.Fatalf("SessionTimeLeft returned error: %v", err)
+	}
+	if left != 2*time.Minute {
+		t.Fatalf("SessionTimeLeft = %s, want 2m", left)
+	}
+
+	if _, err := SessionTimeLeft(sess, time.Unix(1700000000, 0)); !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("expected ErrSessionExpired at exp, got %v", err)
+	}
+	if _, err := SessionTimeLeft(Session{AccessToken: "opaque"}, time.Now()); err == nil || errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("expected decode error for opaque token, got %v", err)
+	}
+}
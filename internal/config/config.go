@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -11,6 +12,7 @@ import (
 type APIConfig struct {
 	Addr              string
 	DatabaseURL       string
+	DatabaseReplica   string
 	AdminUsername     string
 	AdminPassword     string
 	MarketTickEvery   time.Duration
@@ -20,10 +22,44 @@ type APIConfig struct {
 	MarketVolatility  string
 	InterestAPR       float64
 	StartupSeedStocks bool
+	Symbol            SymbolFormat
+	DevSeed           bool
+	DailyBonusMicros  int64
+	// GzipMinBytes is the smallest response body the API gzips for clients
+	// that accept it; 0 disables compression.
+	GzipMinBytes int
+	// InviteOnly requires a valid invite code from an existing player to
+	// sign up.
+	InviteOnly bool
+	// MaxFriends caps how many players one account may follow; 0 means
+	// unlimited.
+	MaxFriends int
 }
 
 type CLIConfig struct {
 	APIBaseURL string
+	Symbol     SymbolFormat
+	// SyncQueueMax caps the local offline queue; 0 disables the cap.
+	SyncQueueMax int
+	// SessionWarnMinutes is how close to token expiry commands start warning;
+	// 0 disables the warning.
+	SessionWarnMinutes int
+	// DisplayDecimals is how many decimal places stonky amounts render with.
+	DisplayDecimals int
+	// PennyDecimals, when above DisplayDecimals, is used for prices under one
+	// stonky so sub-cent moves near the price floor stay visible.
+	PennyDecimals int
+	// Timezone renders CLI timestamps in this IANA zone ("UTC", "Asia/Tokyo");
+	// empty means the system local zone.
+	Timezone string
+}
+
+// SymbolFormat describes the ticker symbols a deployment accepts.
+// The default is exactly 6 uppercase letters.
+type SymbolFormat struct {
+	MinLen      int
+	MaxLen      int
+	AllowDigits bool
 }
 
 type DiscordBotConfig struct {
@@ -51,6 +87,7 @@ func LoadAPIFromEnv() (APIConfig, error) {
 	cfg := APIConfig{
 		Addr:              addr,
 		DatabaseURL:       strings.TrimSpace(os.Getenv("DATABASE_URL")),
+		DatabaseReplica:   strings.TrimSpace(os.Getenv("STANKS_DATABASE_REPLICA_URL")),
 		AdminUsername:     strings.TrimSpace(os.Getenv("ADMIN_USRN")),
 		AdminPassword:     strings.TrimSpace(os.Getenv("ADMIN_PASS")),
 		MarketTickEvery:   envDurationDefault("STANKS_MARKET_TICK_EVERY", 5*time.Minute),
@@ -60,6 +97,12 @@ func LoadAPIFromEnv() (APIConfig, error) {
 		MarketVolatility:  envVolatilityDefault(),
 		InterestAPR:       envFloatDefault("STANKS_INTEREST_APR", 0.18),
 		StartupSeedStocks: envBoolDefault("STANKS_STARTUP_SEED_STOCKS", true),
+		Symbol:            loadSymbolFormat(),
+		DevSeed:           envBoolDefault("STANKS_DEV_SEED", false),
+		DailyBonusMicros:  int64(math.Round(envFloatDefault("STANKS_DAILY_BONUS_STONKY", 0) * 1_000_000)),
+		GzipMinBytes:      envIntDefaultAlias([]string{"STANKS_GZIP_MIN_BYTES"}, 1024),
+		InviteOnly:        envBoolDefault("STANKS_INVITE_ONLY", false),
+		MaxFriends:        envIntDefaultAlias([]string{"STANKS_MAX_FRIENDS"}, 0),
 	}
 	if cfg.EmployeePerTick < 0 {
 		cfg.EmployeePerTick = 0
@@ -67,6 +110,15 @@ func LoadAPIFromEnv() (APIConfig, error) {
 	if cfg.NewStocksPerTick < 0 {
 		cfg.NewStocksPerTick = 0
 	}
+	if cfg.DailyBonusMicros < 0 {
+		cfg.DailyBonusMicros = 0
+	}
+	if cfg.GzipMinBytes < 0 {
+		cfg.GzipMinBytes = 0
+	}
+	if cfg.MaxFriends < 0 {
+		cfg.MaxFriends = 0
+	}
 	if cfg.DatabaseURL == "" {
 		return cfg, fmt.Errorf("DATABASE_URL is required")
 	}
@@ -75,7 +127,21 @@ func LoadAPIFromEnv() (APIConfig, error) {
 
 func LoadCLIFromEnv() CLIConfig {
 	return CLIConfig{
-		APIBaseURL: normalizeCLIBaseURL(envDefault("STK_API_BASE_URL", "https://stonks.pikapp.in")),
+		APIBaseURL:         normalizeCLIBaseURL(envDefault("STK_API_BASE_URL", "https://stonks.pikapp.in")),
+		Symbol:             loadSymbolFormat(),
+		SyncQueueMax:       envIntDefaultAlias([]string{"STK_SYNC_QUEUE_MAX"}, 200),
+		SessionWarnMinutes: envIntDefaultAlias([]string{"STK_SESSION_WARN_MINUTES"}, 5),
+		DisplayDecimals:    envIntDefaultAlias([]string{"STK_DISPLAY_DECIMALS"}, 2),
+		PennyDecimals:      envIntDefaultAlias([]string{"STK_PENNY_DECIMALS"}, 0),
+		Timezone:           envDefault("STK_TIMEZONE", ""),
+	}
+}
+
+func loadSymbolFormat() SymbolFormat {
+	return SymbolFormat{
+		MinLen:      envIntDefaultAlias([]string{"STANKS_SYMBOL_MIN_LEN"}, 6),
+		MaxLen:      envIntDefaultAlias([]string{"STANKS_SYMBOL_MAX_LEN"}, 6),
+		AllowDigits: envBoolDefault("STANKS_SYMBOL_ALLOW_DIGITS", false),
 	}
 }
 
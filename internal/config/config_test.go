@@ -95,3 +95,196 @@ func TestParseFlexibleDuration(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadAPIFromEnvDatabaseReplica(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://example")
+	t.Setenv("STANKS_DATABASE_REPLICA_URL", " postgres://replica ")
+
+	cfg, err := LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if cfg.DatabaseReplica != "postgres://replica" {
+		t.Fatalf("LoadAPIFromEnv().DatabaseReplica = %q, want %q", cfg.DatabaseReplica, "postgres://replica")
+	}
+}
+
+func TestLoadAPIFromEnvSymbolFormat(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://example")
+
+	cfg, err := LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if want := (SymbolFormat{MinLen: 6, MaxLen: 6}); cfg.Symbol != want {
+		t.Fatalf("default Symbol = %+v, want %+v", cfg.Symbol, want)
+	}
+
+	t.Setenv("STANKS_SYMBOL_MIN_LEN", "3")
+	t.Setenv("STANKS_SYMBOL_MAX_LEN", "5")
+	t.Setenv("STANKS_SYMBOL_ALLOW_DIGITS", "true")
+	cfg, err = LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if want := (SymbolFormat{MinLen: 3, MaxLen: 5, AllowDigits: true}); cfg.Symbol != want {
+		t.Fatalf("Symbol = %+v, want %+v", cfg.Symbol, want)
+	}
+}
+
+func TestLoadAPIFromEnvDevSeed(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://example")
+
+	cfg, err := LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if cfg.DevSeed {
+		t.Fatalf("DevSeed should default to false")
+	}
+
+	t.Setenv("STANKS_DEV_SEED", "true")
+	cfg, err = LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if !cfg.DevSeed {
+		t.Fatalf("DevSeed = false, want true")
+	}
+}
+
+func TestLoadAPIFromEnvDailyBonus(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://example")
+
+	cfg, err := LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if cfg.DailyBonusMicros != 0 {
+		t.Fatalf("DailyBonusMicros = %d, want disabled by default", cfg.DailyBonusMicros)
+	}
+
+	t.Setenv("STANKS_DAILY_BONUS_STONKY", "250.5")
+	cfg, err = LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if cfg.DailyBonusMicros != 250_500_000 {
+		t.Fatalf("DailyBonusMicros = %d, want 250500000", cfg.DailyBonusMicros)
+	}
+}
+
+func TestLoadCLIFromEnvSyncQueueMax(t *testing.T) {
+	if got := LoadCLIFromEnv().SyncQueueMax; got != 200 {
+		t.Fatalf("SyncQueueMax = %d, want 200", got)
+	}
+	t.Setenv("STK_SYNC_QUEUE_MAX", "25")
+	if got := LoadCLIFromEnv().SyncQueueMax; got != 25 {
+		t.Fatalf("SyncQueueMax = %d, want 25", got)
+	}
+}
+
+func TestLoadCLIFromEnvSessionWarnMinutes(t *testing.T) {
+	if got := LoadCLIFromEnv().SessionWarnMinutes; got != 5 {
+		t.Fatalf("SessionWarnMinutes = %d, want 5", got)
+	}
+	t.Setenv("STK_SESSION_WARN_MINUTES", "0")
+	if got := LoadCLIFromEnv().SessionWarnMinutes; got != 0 {
+		t.Fatalf("SessionWarnMinutes = %d, want 0", got)
+	}
+}
+
+func TestLoadCLIFromEnvDisplayDecimals(t *testing.T) {
+	cfg := LoadCLIFromEnv()
+	if cfg.DisplayDecimals != 2 || cfg.PennyDecimals != 0 {
+		t.Fatalf("decimals = %d/%d, want 2/0", cfg.DisplayDecimals, cfg.PennyDecimals)
+	}
+	t.Setenv("STK_DISPLAY_DECIMALS", "4")
+	t.Setenv("STK_PENNY_DECIMALS", "6")
+	cfg = LoadCLIFromEnv()
+	if cfg.DisplayDecimals != 4 || cfg.PennyDecimals != 6 {
+		t.Fatalf("decimals = %d/%d, want 4/6", cfg.DisplayDecimals, cfg.PennyDecimals)
+	}
+}
+
+func TestLoadCLIFromEnvTimezone(t *testing.T) {
+	if got := LoadCLIFromEnv().Timezone; got != "" {
+		t.Fatalf("Timezone = %q, want empty (system local)", got)
+	}
+	t.Setenv("STK_TIMEZONE", " UTC ")
+	if got := LoadCLIFromEnv().Timezone; got != "UTC" {
+		t.Fatalf("Timezone = %q, want UTC", got)
+	}
+}
+
+func TestLoadAPIFromEnvGzipMinBytes(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://example")
+
+	cfg, err := LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if cfg.GzipMinBytes != 1024 {
+		t.Fatalf("GzipMinBytes = %d, want 1024", cfg.GzipMinBytes)
+	}
+
+	t.Setenv("STANKS_GZIP_MIN_BYTES", "0")
+	cfg, err = LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if cfg.GzipMinBytes != 0 {
+		t.Fatalf("GzipMinBytes = %d, want 0 (disabled)", cfg.GzipMinBytes)
+	}
+}
+
+func TestLoadAPIFromEnvInviteOnly(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://example")
+
+	cfg, err := LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if cfg.InviteOnly {
+		t.Fatal("InviteOnly should default to false")
+	}
+
+	t.Setenv("STANKS_INVITE_ONLY", "true")
+	cfg, err = LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if !cfg.InviteOnly {
+		t.Fatal("InviteOnly = false, want true")
+	}
+}
+
+func TestLoadAPIFromEnvMaxFriends(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://example")
+
+	cfg, err := LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if cfg.MaxFriends != 0 {
+		t.Fatalf("MaxFriends = %d, want unlimited by default", cfg.MaxFriends)
+	}
+
+	t.Setenv("STANKS_MAX_FRIENDS", "-4")
+	cfg, err = LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if cfg.MaxFriends != 0 {
+		t.Fatalf("MaxFriends = %d, want negative values treated as unlimited", cfg.MaxFriends)
+	}
+
+	t.Setenv("STANKS_MAX_FRIENDS", "50")
+	cfg, err = LoadAPIFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIFromEnv() error = %v", err)
+	}
+	if cfg.MaxFriends != 50 {
+		t.Fatalf("MaxFriends = %d, want 50", cfg.MaxFriends)
+	}
+}
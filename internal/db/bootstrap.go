@@ -25,11 +25,15 @@ func EnsureTables(ctx context.Context, pool *pgxpool.Pool) error {
 		ALTER TABLE auth.users
 			ADD COLUMN IF NOT EXISTS password_hash TEXT,
 			ADD COLUMN IF NOT EXISTS access_token TEXT,
+			ADD COLUMN IF NOT EXISTS refresh_token TEXT,
 			ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
 			ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
 		CREATE UNIQUE INDEX IF NOT EXISTS idx_auth_users_access_token
 			ON auth.users (access_token)
 			WHERE access_token IS NOT NULL;
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_auth_users_refresh_token
+			ON auth.users (refresh_token)
+			WHERE refresh_token IS NOT NULL;
 		CREATE TABLE IF NOT EXISTS game.discord_sessions (
 			discord_user_id TEXT PRIMARY KEY,
 			email TEXT NOT NULL,
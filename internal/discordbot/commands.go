@@ -87,7 +87,7 @@ func commandDefinitions() []*discordgo.ApplicationCommand {
 			Name:        "stock",
 			Description: "Show details and price chart for a stock",
 			Options: []*discordgo.ApplicationCommandOption{
-				{Type: discordgo.ApplicationCommandOptionString, Name: "symbol", Description: "Stock symbol (6 letters)", Required: true},
+				{Type: discordgo.ApplicationCommandOptionString, Name: "symbol", Description: "Stock symbol", Required: true},
 			},
 		},
 		{
@@ -102,11 +102,11 @@ func commandDefinitions() []*discordgo.ApplicationCommand {
 		{Name: "funds", Description: "List available mutual funds"},
 		{
 			Name:        "fund-order",
-			Description: "Buy or sell mutual fund shares",
+			Description: "Buy or sell mutual fund units",
 			Options: []*discordgo.ApplicationCommandOption{
 				{Type: discordgo.ApplicationCommandOptionString, Name: "fund", Description: "Fund code", Required: true, Choices: fundChoices},
 				{Type: discordgo.ApplicationCommandOptionString, Name: "side", Description: "Buy or sell", Required: true, Choices: sideChoices},
-				{Type: discordgo.ApplicationCommandOptionInteger, Name: "units", Description: "Number of units", Required: true},
+				{Type: discordgo.ApplicationCommandOptionNumber, Name: "units", Description: "Number of units (fractional, 0.0001 steps)", Required: true},
 			},
 		},
 		{
@@ -195,7 +195,7 @@ func commandDefinitions() []*discordgo.ApplicationCommand {
 			Description: "Take your business public via IPO",
 			Options: []*discordgo.ApplicationCommandOption{
 				{Type: discordgo.ApplicationCommandOptionInteger, Name: "business_id", Description: "Business ID", Required: true},
-				{Type: discordgo.ApplicationCommandOptionString, Name: "symbol", Description: "Stock symbol (6 uppercase letters)", Required: true},
+				{Type: discordgo.ApplicationCommandOptionString, Name: "symbol", Description: "Stock symbol", Required: true},
 				{Type: discordgo.ApplicationCommandOptionNumber, Name: "price", Description: "IPO price in stonky", Required: true},
 			},
 		},
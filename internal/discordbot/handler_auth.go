@@ -20,6 +20,9 @@ func (b *Bot) openAuthModal(s *discordgo.Session, i *discordgo.InteractionCreate
 		components = append(components, discordgo.ActionsRow{Components: []discordgo.MessageComponent{
 			discordgo.TextInput{CustomID: "username", Label: "Username", Style: discordgo.TextInputShort, Placeholder: "stonkslord", Required: true},
 		}})
+		components = append(components, discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.TextInput{CustomID: "invite_code", Label: "Invite code (if required)", Style: discordgo.TextInputShort, Required: false},
+		}})
 	}
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
@@ -36,7 +39,7 @@ func (b *Bot) handleSignupModal(ctx context.Context, s *discordgo.Session, i *di
 	password := strings.TrimSpace(values["password"])
 	username := strings.TrimSpace(values["username"])
 
-	session, err := b.client.Signup(ctx, email, password, username)
+	session, err := b.client.Signup(ctx, email, password, username, strings.TrimSpace(values["invite_code"]))
 	if err != nil {
 		return b.respondError(s, i, trimAPIError(err))
 	}
@@ -310,7 +310,7 @@ func (b *Bot) handleLoans(ctx context.Context, s *discordgo.Session, i *discordg
 
 	case "repay":
 		amountMicros := game.StonkyToMicros(amount)
-		raw, err := b.client.RepayBusinessLoan(ctx, token, businessID, amountMicros, uuid.NewString())
+		raw, err := b.client.RepayBusinessLoan(ctx, token, businessID, amountMicros, 0, uuid.NewString())
 		if err != nil {
 			return b.respondAuthAwareError(ctx, s, i, err)
 		}
@@ -454,7 +454,7 @@ func (b *Bot) handleOrder(ctx context.Context, s *discordgo.Session, i *discordg
 	}
 
 	eb := NewEmbed().Title("Order Filled").Color(colorSuccess).
-		Desc(fmt.Sprintf("%s %.4f shares of `%s`.", strings.Title(side), shares, symbol)).
+		Desc(fmt.Sprintf("%s %.4f shares of `%s`.", strings.Title(side), game.UnitsToShares(out.QuantityUnits), symbol)).
 		Field("Order ID", strconv.FormatInt(out.OrderID, 10), true).
 		Field("Price", fmtStonky(out.PriceMicros), true)
 
@@ -485,7 +485,7 @@ func (b *Bot) handleFunds(ctx context.Context, s *discordgo.Session, i *discordg
 	}
 
 	lines := []string{
-		fmt.Sprintf("%-8s %12s %-40s", "CODE", "NAV", "COMPONENTS"),
+		fmt.Sprintf("%-8s %12s %-40s", "CODE", "NAV", "NAME"),
 	}
 	for _, item := range items {
 		m, ok := item.(map[string]any)
@@ -516,7 +516,10 @@ func (b *Bot) handleFundOrder(ctx context.Context, s *discordgo.Session, i *disc
 	data := i.ApplicationCommandData()
 	fundCode := strings.TrimSpace(stringOption(data.Options, "fund", ""))
 	side := strings.ToLower(strings.TrimSpace(stringOption(data.Options, "side", "")))
-	units := integerOption(data.Options, "units", 0)
+	units, err := game.FundAmountToUnits(numberOption(data.Options, "units", 0))
+	if err != nil {
+		return b.respondError(s, i, err.Error())
+	}
 
 	var raw map[string]any
 	if side == "buy" {
@@ -530,7 +533,7 @@ func (b *Bot) handleFundOrder(ctx context.Context, s *discordgo.Session, i *disc
 
 	fields := []*discordgo.MessageEmbedField{
 		{Name: "Fund", Value: fundCode, Inline: true},
-		{Name: "Units", Value: strconv.FormatInt(units, 10), Inline: true},
+		{Name: "Units", Value: fmt.Sprintf("%.4f", game.UnitsToShares(units)), Inline: true},
 	}
 	if nav, ok := int64FromMapKeys(raw, "nav_micros"); ok {
 		fields = append(fields, &discordgo.MessageEmbedField{Name: "NAV", Value: fmtStonky(nav), Inline: true})
@@ -541,7 +544,7 @@ func (b *Bot) handleFundOrder(ctx context.Context, s *discordgo.Session, i *disc
 		fields = append(fields, spendSummaryFields(notional, fee, balance)...)
 	}
 
-	return b.respondEmbed(s, i, successEmbed("Fund Order Complete", fmt.Sprintf("%s %d units of `%s`.", strings.Title(side), units, fundCode), fields))
+	return b.respondEmbed(s, i, successEmbed("Fund Order Complete", fmt.Sprintf("%s %.4f units of `%s`.", strings.Title(side), game.UnitsToShares(units), fundCode), fields))
 }
 
 func (b *Bot) handleLeaderboard(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
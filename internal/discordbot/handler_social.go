@@ -27,7 +27,7 @@ func (b *Bot) handleFriends(ctx context.Context, s *discordgo.Session, i *discor
 		return b.respondEmbed(s, i, successEmbed("Friend Added", fmt.Sprintf("Added friend with invite code `%s`.", inviteCode), nil))
 
 	case "remove":
-		_, err = b.client.RemoveFriend(ctx, token, inviteCode)
+		_, err = b.client.RemoveFriend(ctx, token, inviteCode, uuid.NewString())
 		if err != nil {
 			return b.respondAuthAwareError(ctx, s, i, err)
 		}
@@ -0,0 +1,71 @@
+package game
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// AutomationLoanAutoRepay is the operation name for business-loan
+	// auto-repay; its ref_id is the business ID.
+	AutomationLoanAutoRepay = "loan_auto_repay"
+
+	automationSkipInsufficientFunds = "skipped_insufficient_funds"
+	automationSkipsLimit            = 50
+)
+
+// skipForInsufficientFundsTx is the shared insufficient-funds policy for
+// automated per-tick operations. When availableMicros is not positive the
+// operation is skipped: the skip is recorded (one row per unbroken streak)
+// and true is returned so the caller moves on, leaving its schedule in place
+// to retry next tick. Otherwise any open streak is resolved and false is
+// returned.
+func skipForInsufficientFundsTx(ctx context.Context, tx pgx.Tx, seasonID int64, userID, operation string, refID, neededMicros, availableMicros int64) (bool, error) {
+	if availableMicros > 0 {
+		_, err := tx.Exec(ctx, `
+			UPDATE game.automation_skips
+			SET resolved_at = now()
+			WHERE season_id = $1 AND user_id = $2 AND operation = $3 AND ref_id = $4 AND resolved_at IS NULL
+		`, seasonID, userID, operation, refID)
+		return false, err
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO game.automation_skips
+		    (season_id, user_id, operation, ref_id, reason, needed_micros, available_micros)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (season_id, user_id, operation, ref_id) WHERE resolved_at IS NULL
+		DO UPDATE SET skipped_ticks = game.automation_skips.skipped_ticks + 1,
+		              needed_micros = EXCLUDED.needed_micros,
+		              available_micros = EXCLUDED.available_micros,
+		              last_skipped_at = now()
+	`, seasonID, userID, operation, refID, automationSkipInsufficientFunds, neededMicros, availableMicros)
+	return true, err
+}
+
+// AutomationSkips lists the player's most recent skipped automated
+// operations, open streaks first.
+func (s *Service) AutomationSkips(ctx context.Context, userID string, seasonID int64) ([]AutomationSkip, error) {
+	rows, err := s.reader().Query(ctx, `
+		SELECT operation, ref_id, reason, needed_micros, available_micros,
+		       skipped_ticks, first_skipped_at, last_skipped_at, resolved_at
+		FROM game.automation_skips
+		WHERE season_id = $1 AND user_id = $2
+		ORDER BY (resolved_at IS NULL) DESC, last_skipped_at DESC
+		LIMIT $3
+	`, seasonID, userID, automationSkipsLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]AutomationSkip, 0)
+	for rows.Next() {
+		var sk AutomationSkip
+		if err := rows.Scan(&sk.Operation, &sk.RefID, &sk.Reason, &sk.NeededMicros, &sk.AvailableMicros,
+			&sk.SkippedTicks, &sk.FirstSkippedAt, &sk.LastSkippedAt, &sk.ResolvedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, sk)
+	}
+	return out, rows.Err()
+}
@@ -53,3 +53,41 @@ func rollBusinessCycle(c businessCycle, world marketWorldState, seed float64) (s
 		return "stable", 4 + int32(seed*3), clampBps(impact, -700, 700), fmt.Sprintf("%s is holding a stable cycle", label)
 	}
 }
+
+// tickMachine is one machine's state for a revenue tick's breakdown roll.
+type tickMachine struct {
+	id             int64
+	outputMicros   int64
+	upkeepMicros   int64
+	reliabilityBps int32
+	failureStreak  int32
+}
+
+// machineRepairCostMicros charges repeat breakdowns: the first failure in a
+// row is free, each further one costs upkeep times the failures before it.
+func machineRepairCostMicros(upkeepMicros int64, failureStreak int32) int64 {
+	if failureStreak < 2 || upkeepMicros <= 0 {
+		return 0
+	}
+	return upkeepMicros * int64(failureStreak-1)
+}
+
+// rollMachineBreakdowns rolls each machine against its reliability, updating
+// failure streaks in place. It returns the output lost to failed machines,
+// the repair bill, and how many machines broke down.
+func rollMachineBreakdowns(machines []tickMachine, nextFloat func() float64) (int64, int64, int) {
+	var lost, repair int64
+	broken := 0
+	for i := range machines {
+		m := &machines[i]
+		if nextFloat()*10000 < float64(clampBps(m.reliabilityBps, 0, 10000)) {
+			m.failureStreak = 0
+			continue
+		}
+		m.failureStreak++
+		broken++
+		lost = saturatingAddInt64(lost, m.outputMicros)
+		repair = saturatingAddInt64(repair, machineRepairCostMicros(m.upkeepMicros, m.failureStreak))
+	}
+	return lost, repair, broken
+}
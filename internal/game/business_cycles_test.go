@@ -29,3 +29,85 @@ func TestRollBusinessCycleCanCreateLossPeriod(t *testing.T) {
 		t.Fatalf("expected non-zero cycle impact")
 	}
 }
+
+func TestWithHiredEmployee(t *testing.T) {
+	c := businessCycle{employeeCount: 2, employeeRevenue: 100, avgRiskBps: 2000, salesCount: 1}
+	got := withHiredEmployee(c, 2, "sales", 50, 8000)
+	if got.employeeCount != 3 || got.employeeRevenue != 150 || got.salesCount != 2 {
+		t.Fatalf("unexpected hired cycle: %+v", got)
+	}
+	if got.avgRiskBps != 4000 {
+		t.Fatalf("avgRiskBps = %f, want 4000", got.avgRiskBps)
+	}
+	if c.employeeCount != 2 {
+		t.Fatalf("input cycle should not be mutated")
+	}
+
+	first := withHiredEmployee(businessCycle{}, 0, "analyst", 10, 1500)
+	if first.avgRiskBps != 1500 || first.employeeCount != 1 {
+		t.Fatalf("unexpected first hire: %+v", first)
+	}
+}
+
+func TestAddBusinessNetShares(t *testing.T) {
+	netByUser := map[string]int64{}
+	addBusinessNetShares(netByUser, "owner", 1_000, nil)
+	if netByUser["owner"] != 1_000 {
+		t.Fatalf("owner without stakes got %d, want 1000", netByUser["owner"])
+	}
+
+	netByUser = map[string]int64{}
+	addBusinessNetShares(netByUser, "owner", 1_001, []businessStakeRow{
+		{UserID: "owner", StakeBps: 6_667},
+		{UserID: "friend", StakeBps: 3_333},
+	})
+	if got := netByUser["owner"] + netByUser["friend"]; got != 1_001 {
+		t.Fatalf("shares sum to %d, want the full 1001", got)
+	}
+	if netByUser["owner"] != 667 {
+		t.Fatalf("owner share = %d, want 667", netByUser["owner"])
+	}
+}
+
+func TestRollMachineBreakdowns(t *testing.T) {
+	machines := []tickMachine{
+		{id: 1, outputMicros: 70 * MicrosPerStonky, upkeepMicros: 12 * MicrosPerStonky, reliabilityBps: 9000, failureStreak: 0},
+		{id: 2, outputMicros: 155 * MicrosPerStonky, upkeepMicros: 28 * MicrosPerStonky, reliabilityBps: 9000, failureStreak: 2},
+	}
+	rolls := []float64{0.50, 0.95}
+	next := func() float64 {
+		v := rolls[0]
+		rolls = rolls[1:]
+		return v
+	}
+	lost, repair, broken := rollMachineBreakdowns(machines, next)
+	if broken != 1 || lost != 155*MicrosPerStonky {
+		t.Fatalf("broken=%d lost=%d, want 1 machine losing 155 stonky", broken, lost)
+	}
+	if repair != 2*28*MicrosPerStonky {
+		t.Fatalf("repair = %d, want twice the upkeep on a third straight failure", repair)
+	}
+	if machines[0].failureStreak != 0 || machines[1].failureStreak != 3 {
+		t.Fatalf("streaks = %d,%d, want 0,3", machines[0].failureStreak, machines[1].failureStreak)
+	}
+	if got := machineRepairCostMicros(12*MicrosPerStonky, 1); got != 0 {
+		t.Fatalf("first failure repair = %d, want 0", got)
+	}
+}
+
+func TestProjectBusinessCycleDecaysIdleBaseRevenue(t *testing.T) {
+	base := businessCycle{baseRevenue: 10 * MicrosPerStonky, brandBps: 5000, healthBps: 5000}
+	idle := base
+	idle.idleTicks = 2
+	idle.idleDecayBps = 1000
+	if got, want := projectBusinessCycle(idle).GrossRevenueMicros, projectBusinessCycle(base).GrossRevenueMicros; got >= want {
+		t.Fatalf("idle gross = %d, want below active gross %d", got, want)
+	}
+	staffed := idle
+	staffed.employeeCount = 1
+	active := base
+	active.employeeCount = 1
+	if got, want := projectBusinessCycle(staffed).GrossRevenueMicros, projectBusinessCycle(active).GrossRevenueMicros; got != want {
+		t.Fatalf("staffed gross = %d, want undecayed %d", got, want)
+	}
+}
@@ -3,6 +3,7 @@ package game
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"sort"
@@ -29,13 +30,30 @@ var machineCatalog = []machineSpec{
 	{Type: "quantum_rig", DisplayName: "Quantum Rig", CostMicros: 40_000 * MicrosPerStonky, OutputMicros: 530 * MicrosPerStonky, UpkeepMicros: 105 * MicrosPerStonky, Reliability: 8900},
 }
 
-var fundUniverse = map[string][]string{
-	"TECH6X": {"COBOLT", "NIMBUS", "SWIFTR", "KOTLIN", "NODEON", "QUARKX"},
-	"CORE20": {"COBOLT", "NIMBUS", "RUSTIC", "PYLONS", "JAVOLT", "SWIFTR", "KOTLIN", "NODEON", "RUBYIX", "ELIXIR", "QUARKX", "VECTRA", "DATUMX", "CYBRON", "FUSION", "NEBULA", "ORBITZ", "ZENITH", "ARCANE", "LUMINA"},
-	"VOLT10": {"SWIFTR", "QUARKX", "VECTRA", "CYBRON", "ORBITZ", "ARCANE", "COBOLT", "NODEON", "ELIXIR", "FUSION"},
-	"DIVMAX": {"RUSTIC", "PYLONS", "RUBYIX", "DATUMX", "ZENITH", "LUMINA", "NIMBUS", "COBOLT"},
-	"AIEDGE": {"VECTRA", "QUARKX", "ORBITZ", "CYBRON", "ARCANE", "SWIFTR"},
-	"STABLE": {"NIMBUS", "RUSTIC", "PYLONS", "JAVOLT", "KOTLIN", "DATUMX", "LUMINA"},
+type fundSpec struct {
+	Code        string
+	DisplayName string
+	Strategy    string
+	Components  []string
+}
+
+var fundCatalog = []fundSpec{
+	{Code: "AIEDGE", DisplayName: "AI Edge", Strategy: "Concentrated bet on the AI and frontier-compute names.", Components: []string{"VECTRA", "QUARKX", "ORBITZ", "CYBRON", "ARCANE", "SWIFTR"}},
+	{Code: "CORE20", DisplayName: "Core 20", Strategy: "Broad equal-weight basket of twenty core listings; the default diversifier.", Components: []string{"COBOLT", "NIMBUS", "RUSTIC", "PYLONS", "JAVOLT", "SWIFTR", "KOTLIN", "NODEON", "RUBYIX", "ELIXIR", "QUARKX", "VECTRA", "DATUMX", "CYBRON", "FUSION", "NEBULA", "ORBITZ", "ZENITH", "ARCANE", "LUMINA"}},
+	{Code: "DIVMAX", DisplayName: "Dividend Maximizer", Strategy: "Steady dividend payers for income over growth.", Components: []string{"RUSTIC", "PYLONS", "RUBYIX", "DATUMX", "ZENITH", "LUMINA", "NIMBUS", "COBOLT"}},
+	{Code: "STABLE", DisplayName: "Stable Core", Strategy: "Low-volatility defensive names that hold up in rough regimes.", Components: []string{"NIMBUS", "RUSTIC", "PYLONS", "JAVOLT", "KOTLIN", "DATUMX", "LUMINA"}},
+	{Code: "TECH6X", DisplayName: "Tech Six", Strategy: "Six large tech platforms, equal weighted.", Components: []string{"COBOLT", "NIMBUS", "SWIFTR", "KOTLIN", "NODEON", "QUARKX"}},
+	{Code: "VOLT10", DisplayName: "Volatility 10", Strategy: "The ten swingiest listings, for momentum traders.", Components: []string{"SWIFTR", "QUARKX", "VECTRA", "CYBRON", "ORBITZ", "ARCANE", "COBOLT", "NODEON", "ELIXIR", "FUSION"}},
+}
+
+func fundByCode(code string) (fundSpec, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	for _, spec := range fundCatalog {
+		if spec.Code == code {
+			return spec, true
+		}
+	}
+	return fundSpec{}, false
 }
 
 const seededCandidatePoolSize = int(MaxBusinessEmployees)
@@ -101,7 +119,7 @@ func (s *Service) ListBusinessMachinery(ctx context.Context, userID string, seas
 		return nil, ErrUnauthorized
 	}
 	rows, err := s.db.Query(ctx, `
-		SELECT id, machine_type, level, output_bonus_micros, upkeep_micros, reliability_bps, updated_at
+		SELECT id, machine_type, level, output_bonus_micros, upkeep_micros, reliability_bps, failure_streak, updated_at
 		FROM game.business_machinery
 		WHERE business_id = $1 AND season_id = $2
 		ORDER BY machine_type
@@ -116,9 +134,9 @@ func (s *Service) ListBusinessMachinery(ctx context.Context, userID string, seas
 		var machineType string
 		var level int32
 		var output, upkeep int64
-		var reliability int32
+		var reliability, failureStreak int32
 		var updatedAt any
-		if err := rows.Scan(&id, &machineType, &level, &output, &upkeep, &reliability, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &machineType, &level, &output, &upkeep, &reliability, &failureStreak, &updatedAt); err != nil {
 			return nil, err
 		}
 		out = append(out, map[string]any{
@@ -128,6 +146,7 @@ func (s *Service) ListBusinessMachinery(ctx context.Context, userID string, seas
 			"output_bonus_micros": output,
 			"upkeep_micros":       upkeep,
 			"reliability_bps":     reliability,
+			"failure_streak":      failureStreak,
 			"updated_at":          updatedAt,
 		})
 	}
@@ -147,7 +166,7 @@ func (s *Service) BuyBusinessMachinery(ctx context.Context, in BuyMachineryInput
 	}
 	defer tx.Rollback(ctx)
 
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "buy_machinery"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "buy_machinery"); err != nil {
 		return out, err
 	}
 	var owner string
@@ -162,14 +181,13 @@ func (s *Service) BuyBusinessMachinery(ctx context.Context, in BuyMachineryInput
 	if owner != in.UserID {
 		return out, ErrUnauthorized
 	}
+	if err := checkMachineryCapTx(ctx, tx, in.BusinessID, in.SeasonID, 1); err != nil {
+		return out, err
+	}
 
 	var balance int64
-	if err := tx.QueryRow(ctx, `
-		SELECT balance_micros
-		FROM game.wallets
-		WHERE user_id = $1 AND season_id = $2
-		FOR UPDATE
-	`, in.UserID, in.SeasonID).Scan(&balance); err != nil {
+	balance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+	if err != nil {
 		return out, err
 	}
 
@@ -187,7 +205,7 @@ func (s *Service) BuyBusinessMachinery(ctx context.Context, in BuyMachineryInput
 	if err == nil {
 		nextLevel = level + 1
 	}
-	cost := int64(float64(spec.CostMicros) * (1 + 0.25*float64(nextLevel-1)))
+	cost := machineryLevelCostMicros(spec, nextLevel)
 	if !hasPositiveBalanceAfterSpend(balance, cost) {
 		return out, ErrInsufficientFunds
 	}
@@ -238,6 +256,262 @@ func (s *Service) BuyBusinessMachinery(ctx context.Context, in BuyMachineryInput
 	return out, nil
 }
 
+func checkMachineryCapTx(ctx context.Context, tx pgx.Tx, businessID, seasonID, addingLevels int64) error {
+	settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+	if err != nil {
+		return err
+	}
+	if settings.MaxMachineryLevels <= 0 {
+		return nil
+	}
+	var current int64
+	if err := tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(level), 0)
+		FROM game.business_machinery
+		WHERE business_id = $1 AND season_id = $2
+	`, businessID, seasonID).Scan(&current); err != nil {
+		return err
+	}
+	return settings.checkMachineryCap(current, addingLevels)
+}
+
+func machineryLevelCostMicros(spec machineSpec, level int32) int64 {
+	return int64(float64(spec.CostMicros) * (1 + 0.25*float64(level-1)))
+}
+
+// machineryBatchCostMicros prices count levels bought on top of
+// currentLevel, each charged at its own level's cost.
+func machineryBatchCostMicros(spec machineSpec, currentLevel int32, count int) int64 {
+	total := int64(0)
+	for i := 1; i <= count; i++ {
+		total = saturatingAddInt64(total, machineryLevelCostMicros(spec, currentLevel+int32(i)))
+	}
+	return total
+}
+
+const maxMachineryBatchLevels = 50
+
+// machineryBatchCounts validates batch items and folds them into per-type
+// level counts, returning the types in a stable order.
+func machineryBatchCounts(items []MachineryBatchItem) (map[string]int, []string, error) {
+	if len(items) == 0 {
+		return nil, nil, fmt.Errorf("at least one machine type is required")
+	}
+	counts := map[string]int{}
+	totalLevels := 0
+	for _, item := range items {
+		spec, err := machineByType(item.MachineType)
+		if err != nil {
+			return nil, nil, err
+		}
+		if item.Count <= 0 {
+			return nil, nil, fmt.Errorf("count for %s must be > 0", spec.Type)
+		}
+		counts[spec.Type] += item.Count
+		totalLevels += item.Count
+	}
+	if totalLevels > maxMachineryBatchLevels {
+		return nil, nil, fmt.Errorf("batch can add at most %d machinery levels", maxMachineryBatchLevels)
+	}
+	types := make([]string, 0, len(counts))
+	for machineType := range counts {
+		types = append(types, machineType)
+	}
+	sort.Strings(types)
+	return counts, types, nil
+}
+
+// QuoteBusinessMachineryBatch prices a machinery batch against the
+// business's current levels without buying anything.
+func (s *Service) QuoteBusinessMachineryBatch(ctx context.Context, in BuyMachineryBatchInput) (map[string]any, error) {
+	out := map[string]any{}
+	counts, types, err := machineryBatchCounts(in.Items)
+	if err != nil {
+		return out, err
+	}
+	var owner string
+	if err := s.reader().QueryRow(ctx, `
+		SELECT owner_user_id
+		FROM game.businesses
+		WHERE id = $1 AND season_id = $2
+	`, in.BusinessID, in.SeasonID).Scan(&owner); err != nil {
+		return out, err
+	}
+	if owner != in.UserID {
+		return out, ErrUnauthorized
+	}
+	results := make([]map[string]any, 0, len(types))
+	totalCost := int64(0)
+	for _, machineType := range types {
+		spec, _ := machineByType(machineType)
+		var level int32
+		err := s.reader().QueryRow(ctx, `
+			SELECT level
+			FROM game.business_machinery
+			WHERE business_id = $1 AND season_id = $2 AND machine_type = $3
+		`, in.BusinessID, in.SeasonID, spec.Type).Scan(&level)
+		if err != nil && err != pgx.ErrNoRows {
+			return out, err
+		}
+		cost := machineryBatchCostMicros(spec, level, counts[machineType])
+		totalCost = saturatingAddInt64(totalCost, cost)
+		results = append(results, map[string]any{
+			"machine_type": spec.Type,
+			"added_levels": counts[machineType],
+			"new_level":    level + int32(counts[machineType]),
+			"cost_micros":  cost,
+		})
+	}
+	out["machinery"] = results
+	out["total_cost_micros"] = totalCost
+	return out, nil
+}
+
+func (s *Service) BuyBusinessMachineryBatch(ctx context.Context, in BuyMachineryBatchInput) (map[string]any, error) {
+	out := map[string]any{}
+	counts, types, err := machineryBatchCounts(in.Items)
+	if err != nil {
+		return out, err
+	}
+	totalLevels := 0
+	for _, count := range counts {
+		totalLevels += count
+	}
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return out, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "buy_machinery_batch"); err != nil {
+		return out, err
+	}
+	var owner string
+	if err := tx.QueryRow(ctx, `
+		SELECT owner_user_id
+		FROM game.businesses
+		WHERE id = $1 AND season_id = $2
+		FOR UPDATE
+	`, in.BusinessID, in.SeasonID).Scan(&owner); err != nil {
+		return out, err
+	}
+	if owner != in.UserID {
+		return out, ErrUnauthorized
+	}
+	if err := checkMachineryCapTx(ctx, tx, in.BusinessID, in.SeasonID, int64(totalLevels)); err != nil {
+		return out, err
+	}
+
+	var balance int64
+	balance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+	if err != nil {
+		return out, err
+	}
+
+	type machineryPlan struct {
+		spec        machineSpec
+		exists      bool
+		level       int32
+		output      int64
+		upkeep      int64
+		reliability int32
+		cost        int64
+	}
+	plans := make([]machineryPlan, 0, len(types))
+	totalCost := int64(0)
+	for _, machineType := range types {
+		spec, _ := machineByType(machineType)
+		plan := machineryPlan{spec: spec}
+		err := tx.QueryRow(ctx, `
+			SELECT level, output_bonus_micros, upkeep_micros, reliability_bps
+			FROM game.business_machinery
+			WHERE business_id = $1 AND season_id = $2 AND machine_type = $3
+			FOR UPDATE
+		`, in.BusinessID, in.SeasonID, spec.Type).Scan(&plan.level, &plan.output, &plan.upkeep, &plan.reliability)
+		if err != nil && err != pgx.ErrNoRows {
+			return out, err
+		}
+		plan.exists = err == nil
+		plan.cost = machineryBatchCostMicros(spec, plan.level, counts[machineType])
+		for i := 0; i < counts[machineType]; i++ {
+			if plan.level == 0 {
+				plan.level = 1
+				plan.output = spec.OutputMicros
+				plan.upkeep = spec.UpkeepMicros
+				plan.reliability = spec.Reliability
+			} else {
+				plan.level++
+				plan.output = int64(math.Round(float64(plan.output) * 1.22))
+				plan.upkeep = int64(math.Round(float64(plan.upkeep) * 1.18))
+				if plan.reliability-40 > 7000 {
+					plan.reliability -= 40
+				} else {
+					plan.reliability = 7000
+				}
+			}
+		}
+		totalCost = saturatingAddInt64(totalCost, plan.cost)
+		plans = append(plans, plan)
+	}
+	if !hasPositiveBalanceAfterSpend(balance, totalCost) {
+		return out, ErrInsufficientFunds
+	}
+
+	results := make([]map[string]any, 0, len(plans))
+	for _, plan := range plans {
+		if plan.exists {
+			_, err = tx.Exec(ctx, `
+				UPDATE game.business_machinery
+				SET level = $1,
+				    output_bonus_micros = $2,
+				    upkeep_micros = $3,
+				    reliability_bps = $4,
+				    updated_at = now()
+				WHERE business_id = $5 AND season_id = $6 AND machine_type = $7
+			`, plan.level, plan.output, plan.upkeep, plan.reliability, in.BusinessID, in.SeasonID, plan.spec.Type)
+		} else {
+			_, err = tx.Exec(ctx, `
+				INSERT INTO game.business_machinery
+				    (business_id, season_id, machine_type, level, output_bonus_micros, upkeep_micros, reliability_bps)
+				VALUES
+				    ($1, $2, $3, $4, $5, $6, $7)
+			`, in.BusinessID, in.SeasonID, plan.spec.Type, plan.level, plan.output, plan.upkeep, plan.reliability)
+		}
+		if err != nil {
+			return out, err
+		}
+		results = append(results, map[string]any{
+			"machine_type": plan.spec.Type,
+			"added_levels": counts[plan.spec.Type],
+			"new_level":    plan.level,
+			"cost_micros":  plan.cost,
+		})
+	}
+	balance -= totalCost
+	if _, err := tx.Exec(ctx, `
+		UPDATE game.wallets
+		SET balance_micros = $1, updated_at = now()
+		WHERE user_id = $2 AND season_id = $3
+	`, balance, in.UserID, in.SeasonID); err != nil {
+		return out, err
+	}
+	if err := appendLedgerEntries(ctx, tx, in.UserID, in.SeasonID, "machinery_buy_batch", totalCost, 0); err != nil {
+		return out, err
+	}
+	if err := s.updatePeakNetWorthTx(ctx, tx, in.UserID, in.SeasonID); err != nil {
+		return out, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return out, err
+	}
+	out["ok"] = true
+	out["machinery"] = results
+	out["total_cost_micros"] = totalCost
+	out["new_balance_micros"] = balance
+	return out, nil
+}
+
 func (s *Service) TrainProfessional(ctx context.Context, in TrainProfessionalInput) (map[string]any, error) {
 	out := map[string]any{}
 	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
@@ -246,7 +520,7 @@ func (s *Service) TrainProfessional(ctx context.Context, in TrainProfessionalInp
 	}
 	defer tx.Rollback(ctx)
 
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "train_professional"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "train_professional"); err != nil {
 		return out, err
 	}
 	var owner string
@@ -275,12 +549,8 @@ func (s *Service) TrainProfessional(ctx context.Context, in TrainProfessionalInp
 	cost := int64(math.Round(float64(curRevenue) * 1.8))
 
 	var balance int64
-	if err := tx.QueryRow(ctx, `
-		SELECT balance_micros
-		FROM game.wallets
-		WHERE user_id = $1 AND season_id = $2
-		FOR UPDATE
-	`, in.UserID, in.SeasonID).Scan(&balance); err != nil {
+	balance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+	if err != nil {
 		return out, err
 	}
 	if !hasPositiveBalanceAfterSpend(balance, cost) {
@@ -332,7 +602,7 @@ func (s *Service) TakeBusinessLoan(ctx context.Context, in BusinessLoanInput) (m
 		return out, err
 	}
 	defer tx.Rollback(ctx)
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "take_business_loan"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "take_business_loan"); err != nil {
 		return out, err
 	}
 
@@ -349,11 +619,16 @@ func (s *Service) TakeBusinessLoan(ctx context.Context, in BusinessLoanInput) (m
 		return out, ErrUnauthorized
 	}
 
-	netWorth, err := netWorthTx(ctx, tx, in.UserID, in.SeasonID)
+	settings, err := loadSeasonSettingsTx(ctx, tx, in.SeasonID)
+	if err != nil {
+		return out, err
+	}
+	cash, holdings, err := netWorthPartsTx(ctx, tx, in.UserID, in.SeasonID)
 	if err != nil {
 		return out, err
 	}
-	maxLoan := int64(math.Round(float64(netWorth) * 0.45))
+	collateral := settings.loanCollateralMicros(cash, holdings)
+	maxLoan := int64(math.Round(float64(collateral) * 0.45))
 	var outstanding int64
 	if err := tx.QueryRow(ctx, `
 		SELECT COALESCE(SUM(outstanding_micros), 0)
@@ -368,12 +643,8 @@ func (s *Service) TakeBusinessLoan(ctx context.Context, in BusinessLoanInput) (m
 
 	interestBps := int32(65 + int32(math.Round(s.nextFloat()*95)))
 	var balance int64
-	if err := tx.QueryRow(ctx, `
-		SELECT balance_micros
-		FROM game.wallets
-		WHERE user_id = $1 AND season_id = $2
-		FOR UPDATE
-	`, in.UserID, in.SeasonID).Scan(&balance); err != nil {
+	balance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+	if err != nil {
 		return out, err
 	}
 	balance += in.AmountMicros
@@ -418,7 +689,7 @@ func (s *Service) RepayBusinessLoan(ctx context.Context, in BusinessLoanInput) (
 		return out, err
 	}
 	defer tx.Rollback(ctx)
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "repay_business_loan"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "repay_business_loan"); err != nil {
 		return out, err
 	}
 	var owner string
@@ -428,18 +699,17 @@ func (s *Service) RepayBusinessLoan(ctx context.Context, in BusinessLoanInput) (
 		WHERE id = $1 AND season_id = $2
 		FOR UPDATE
 	`, in.BusinessID, in.SeasonID).Scan(&owner); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out, ErrUnauthorized
+		}
 		return out, err
 	}
 	if owner != in.UserID {
 		return out, ErrUnauthorized
 	}
 	var balance int64
-	if err := tx.QueryRow(ctx, `
-		SELECT balance_micros
-		FROM game.wallets
-		WHERE user_id = $1 AND season_id = $2
-		FOR UPDATE
-	`, in.UserID, in.SeasonID).Scan(&balance); err != nil {
+	balance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+	if err != nil {
 		return out, err
 	}
 	if !hasPositiveBalanceAfterSpend(balance, in.AmountMicros) {
@@ -450,9 +720,10 @@ func (s *Service) RepayBusinessLoan(ctx context.Context, in BusinessLoanInput) (
 		SELECT id, outstanding_micros
 		FROM game.business_loans
 		WHERE business_id = $1 AND season_id = $2 AND status = 'open'
+		  AND ($3::bigint = 0 OR id = $3)
 		ORDER BY id
 		FOR UPDATE
-	`, in.BusinessID, in.SeasonID)
+	`, in.BusinessID, in.SeasonID, in.LoanID)
 	if err != nil {
 		return out, err
 	}
@@ -471,6 +742,9 @@ func (s *Service) RepayBusinessLoan(ctx context.Context, in BusinessLoanInput) (
 	}
 	rows.Close()
 	if len(loans) == 0 {
+		if in.LoanID > 0 {
+			return out, fmt.Errorf("%w: loan %d is not an open loan of business %d", ErrLoanNotFound, in.LoanID, in.BusinessID)
+		}
 		return out, fmt.Errorf("no open business loans")
 	}
 
@@ -522,9 +796,187 @@ func (s *Service) RepayBusinessLoan(ctx context.Context, in BusinessLoanInput) (
 	out["ok"] = true
 	out["repaid_micros"] = repaid
 	out["balance_micros"] = balance
+	if in.LoanID > 0 {
+		out["loan_id"] = in.LoanID
+	}
 	return out, nil
 }
 
+func (s *Service) SetBusinessLoanAutoRepay(ctx context.Context, in BusinessLoanAutoRepayInput) (map[string]any, error) {
+	out := map[string]any{}
+	if in.BufferMicros < 0 {
+		return out, fmt.Errorf("buffer must be >= 0")
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return out, err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "set_business_loan_auto_repay"); err != nil {
+		return out, err
+	}
+	cmd, err := tx.Exec(ctx, `
+		UPDATE game.businesses
+		SET loan_auto_repay = $1, loan_auto_repay_buffer_micros = $2, updated_at = now()
+		WHERE id = $3 AND season_id = $4 AND owner_user_id = $5
+	`, in.Enabled, in.BufferMicros, in.BusinessID, in.SeasonID, in.UserID)
+	if err != nil {
+		return out, err
+	}
+	if cmd.RowsAffected() == 0 {
+		return out, ErrUnauthorized
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return out, err
+	}
+	out["ok"] = true
+	out["business_id"] = in.BusinessID
+	out["auto_repay"] = in.Enabled
+	out["buffer_micros"] = in.BufferMicros
+	return out, nil
+}
+
+// autoRepayAmount is how much of outstanding debt can be paid from balance
+// while keeping buffer untouched in the wallet.
+func autoRepayAmount(balance, buffer, outstanding int64) int64 {
+	surplus := saturatingSubInt64(balance, buffer)
+	if surplus <= 0 || outstanding <= 0 {
+		return 0
+	}
+	if surplus > outstanding {
+		return outstanding
+	}
+	return surplus
+}
+
+// applyLoanAutoRepayTx pays down open loans for businesses that opted in,
+// using only wallet balance above each business's buffer. It runs before
+// the regular debt-service step so opted-in players avoid late fees. A tick
+// with nothing above the buffer is recorded as a skip and retried next tick.
+func applyLoanAutoRepayTx(ctx context.Context, tx pgx.Tx, seasonID int64) error {
+	rows, err := tx.Query(ctx, `
+		SELECT b.id, b.owner_user_id, b.loan_auto_repay_buffer_micros
+		FROM game.businesses b
+		WHERE b.season_id = $1
+		  AND b.loan_auto_repay
+		  AND EXISTS (
+			SELECT 1
+			FROM game.business_loans bl
+			WHERE bl.business_id = b.id AND bl.season_id = b.season_id AND bl.status = 'open'
+		  )
+		ORDER BY b.id
+	`, seasonID)
+	if err != nil {
+		return err
+	}
+	type item struct {
+		businessID int64
+		userID     string
+		buffer     int64
+	}
+	items := make([]item, 0)
+	for rows.Next() {
+		var it item
+		if err := rows.Scan(&it.businessID, &it.userID, &it.buffer); err != nil {
+			rows.Close()
+			return err
+		}
+		items = append(items, it)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, it := range items {
+		var balance int64
+		if err := tx.QueryRow(ctx, `
+			SELECT balance_micros
+			FROM game.wallets
+			WHERE season_id = $1 AND user_id = $2
+			FOR UPDATE
+		`, seasonID, it.userID).Scan(&balance); err != nil {
+			return err
+		}
+		loanRows, err := tx.Query(ctx, `
+			SELECT id, outstanding_micros
+			FROM game.business_loans
+			WHERE season_id = $1 AND business_id = $2 AND status = 'open'
+			ORDER BY id
+			FOR UPDATE
+		`, seasonID, it.businessID)
+		if err != nil {
+			return err
+		}
+		type loan struct {
+			id          int64
+			outstanding int64
+		}
+		loans := make([]loan, 0)
+		outstanding := int64(0)
+		for loanRows.Next() {
+			var l loan
+			if err := loanRows.Scan(&l.id, &l.outstanding); err != nil {
+				loanRows.Close()
+				return err
+			}
+			loans = append(loans, l)
+			outstanding = saturatingAddInt64(outstanding, l.outstanding)
+		}
+		loanRows.Close()
+		if err := loanRows.Err(); err != nil {
+			return err
+		}
+
+		remaining := autoRepayAmount(balance, it.buffer, outstanding)
+		skipped, err := skipForInsufficientFundsTx(ctx, tx, seasonID, it.userID, AutomationLoanAutoRepay, it.businessID, outstanding, saturatingSubInt64(balance, it.buffer))
+		if err != nil {
+			return err
+		}
+		if skipped {
+			continue
+		}
+		repaid := int64(0)
+		for _, l := range loans {
+			if remaining <= 0 {
+				break
+			}
+			pay := l.outstanding
+			if pay > remaining {
+				pay = remaining
+			}
+			next := l.outstanding - pay
+			status := "open"
+			if next == 0 {
+				status = "repaid"
+			}
+			if _, err := tx.Exec(ctx, `
+				UPDATE game.business_loans
+				SET outstanding_micros = $1, status = $2, missed_ticks = CASE WHEN $2 = 'repaid' THEN 0 ELSE missed_ticks END, updated_at = now()
+				WHERE id = $3
+			`, next, status, l.id); err != nil {
+				return err
+			}
+			remaining -= pay
+			repaid += pay
+		}
+		if repaid == 0 {
+			continue
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE game.wallets
+			SET balance_micros = balance_micros - $1, updated_at = now()
+			WHERE season_id = $2 AND user_id = $3
+		`, repaid, seasonID, it.userID); err != nil {
+			return err
+		}
+		if err := appendLedgerEntries(ctx, tx, it.userID, seasonID, "business_loan_auto_repay", repaid, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *Service) ListBusinessLoans(ctx context.Context, userID string, seasonID, businessID int64) ([]map[string]any, error) {
 	var owner string
 	if err := s.db.QueryRow(ctx, `
@@ -584,7 +1036,7 @@ func (s *Service) SetBusinessStrategy(ctx context.Context, in BusinessStrategyIn
 		return err
 	}
 	defer tx.Rollback(ctx)
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "set_business_strategy"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "set_business_strategy"); err != nil {
 		return err
 	}
 	cmd, err := tx.Exec(ctx, `
@@ -601,6 +1053,120 @@ func (s *Service) SetBusinessStrategy(ctx context.Context, in BusinessStrategyIn
 	return tx.Commit(ctx)
 }
 
+// SetBusinessRevenueMode switches a business between "push" (net is paid to
+// the wallet every tick) and "accrue" (positive net waits in the business
+// until ClaimBusinessRevenue). Switching back to push leaves any unclaimed
+// balance claimable.
+func (s *Service) SetBusinessRevenueMode(ctx context.Context, in BusinessRevenueModeInput) error {
+	mode := strings.ToLower(strings.TrimSpace(in.Mode))
+	if mode != "push" && mode != "accrue" {
+		return fmt.Errorf("revenue mode must be push or accrue")
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "set_business_revenue_mode"); err != nil {
+		return err
+	}
+	cmd, err := tx.Exec(ctx, `
+		UPDATE game.businesses
+		SET revenue_mode = $1, updated_at = now()
+		WHERE id = $2 AND season_id = $3 AND owner_user_id = $4
+	`, mode, in.BusinessID, in.SeasonID, in.UserID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrUnauthorized
+	}
+	return tx.Commit(ctx)
+}
+
+// ClaimBusinessRevenue sweeps a business's unclaimed revenue to its
+// stakeholders' wallets, split and taxed as a regular revenue tick would be.
+func (s *Service) ClaimBusinessRevenue(ctx context.Context, userID string, seasonID, businessID int64, idem string) (map[string]any, error) {
+	out := map[string]any{}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return out, err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, userID, seasonID, idem, "claim_business_revenue"); err != nil {
+		return out, err
+	}
+	settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+	if err != nil {
+		return out, err
+	}
+
+	var owner string
+	var unclaimed int64
+	if err := tx.QueryRow(ctx, `
+		SELECT owner_user_id, unclaimed_revenue_micros
+		FROM game.businesses
+		WHERE id = $1 AND season_id = $2
+		FOR UPDATE
+	`, businessID, seasonID).Scan(&owner, &unclaimed); err != nil {
+		return out, err
+	}
+	if owner != userID {
+		return out, ErrUnauthorized
+	}
+
+	shares, err := s.claimUnclaimedRevenueTx(ctx, tx, settings, businessID, seasonID, owner, unclaimed)
+	if err != nil {
+		return out, err
+	}
+	ownerShare := shares[userID]
+	if err := tx.Commit(ctx); err != nil {
+		return out, err
+	}
+	out["ok"] = true
+	out["business_id"] = businessID
+	out["claimed_micros"] = unclaimed
+	out["owner_share_micros"] = ownerShare
+	return out, nil
+}
+
+// claimUnclaimedRevenueTx pays a business's unclaimed accrued revenue out to
+// its stakeholders, taxed like a regular tick, and zeroes it. It returns each
+// stakeholder's net share.
+func (s *Service) claimUnclaimedRevenueTx(ctx context.Context, tx pgx.Tx, settings seasonSettings, businessID, seasonID int64, owner string, unclaimed int64) (map[string]int64, error) {
+	shares := map[string]int64{}
+	if unclaimed <= 0 {
+		return shares, nil
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE game.businesses
+		SET unclaimed_revenue_micros = 0, updated_at = now()
+		WHERE id = $1 AND season_id = $2
+	`, businessID, seasonID); err != nil {
+		return nil, err
+	}
+	stakes, err := loadBusinessStakesTx(ctx, tx, businessID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	addBusinessNetShares(shares, owner, unclaimed, stakes)
+	for shareUserID, share := range shares {
+		if share <= 0 {
+			continue
+		}
+		if err := addWalletDeltaTx(ctx, tx, seasonID, shareUserID, share); err != nil {
+			return nil, err
+		}
+		if err := recordBusinessRevenueTx(ctx, tx, settings, shareUserID, seasonID, share); err != nil {
+			return nil, err
+		}
+		if err := s.updatePeakNetWorthTx(ctx, tx, shareUserID, seasonID); err != nil {
+			return nil, err
+		}
+	}
+	return shares, nil
+}
+
 func (s *Service) BuyBusinessUpgrade(ctx context.Context, in BusinessUpgradeInput) (map[string]any, error) {
 	out := map[string]any{}
 	upgrade := strings.ToLower(strings.TrimSpace(in.Upgrade))
@@ -624,7 +1190,7 @@ func (s *Service) BuyBusinessUpgrade(ctx context.Context, in BusinessUpgradeInpu
 		return out, err
 	}
 	defer tx.Rollback(ctx)
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "buy_business_upgrade"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "buy_business_upgrade"); err != nil {
 		return out, err
 	}
 	var owner string
@@ -650,15 +1216,11 @@ func (s *Service) BuyBusinessUpgrade(ctx context.Context, in BusinessUpgradeInpu
 			step = MaxBusinessEmployees - seatCapacity
 		}
 		level := int((seatCapacity - BaseBusinessEmployeeLimit) / SeatUpgradeIncrement)
-		cost := int64(math.Round(float64((1_800+level*700)*int(MicrosPerStonky)) * (1 + float64(level)*0.18)))
+		cost := SeatUpgradeCostMicros(level)
 
 		var balance int64
-		if err := tx.QueryRow(ctx, `
-			SELECT balance_micros
-			FROM game.wallets
-			WHERE user_id = $1 AND season_id = $2
-			FOR UPDATE
-		`, in.UserID, in.SeasonID).Scan(&balance); err != nil {
+		balance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+		if err != nil {
 			return out, err
 		}
 		if !hasPositiveBalanceAfterSpend(balance, cost) {
@@ -666,7 +1228,7 @@ func (s *Service) BuyBusinessUpgrade(ctx context.Context, in BusinessUpgradeInpu
 		}
 		if _, err := tx.Exec(ctx, `
 			UPDATE game.businesses
-			SET seat_capacity = LEAST($1, seat_capacity + $2), updated_at = now()
+			SET seat_capacity = LEAST($1, seat_capacity + $2), idle_ticks = 0, updated_at = now()
 			WHERE id = $3 AND season_id = $4
 		`, MaxBusinessEmployees, step, in.BusinessID, in.SeasonID); err != nil {
 			return out, err
@@ -710,15 +1272,11 @@ func (s *Service) BuyBusinessUpgrade(ctx context.Context, in BusinessUpgradeInpu
 	if owner != in.UserID {
 		return out, ErrUnauthorized
 	}
-	cost := int64(math.Round(float64((900+int(level)*350)*int(MicrosPerStonky)) * (1 + float64(level)*0.12)))
+	cost := BusinessUpgradeCostMicros(level)
 
 	var balance int64
-	if err := tx.QueryRow(ctx, `
-		SELECT balance_micros
-		FROM game.wallets
-		WHERE user_id = $1 AND season_id = $2
-		FOR UPDATE
-	`, in.UserID, in.SeasonID).Scan(&balance); err != nil {
+	balance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+	if err != nil {
 		return out, err
 	}
 	if !hasPositiveBalanceAfterSpend(balance, cost) {
@@ -726,7 +1284,7 @@ func (s *Service) BuyBusinessUpgrade(ctx context.Context, in BusinessUpgradeInpu
 	}
 	update := fmt.Sprintf(`
 		UPDATE game.businesses
-		SET %s = %s + 1, updated_at = now()
+		SET %s = %s + 1, idle_ticks = 0, updated_at = now()
 		WHERE id = $1 AND season_id = $2
 	`, col, col)
 	if _, err := tx.Exec(ctx, update, in.BusinessID, in.SeasonID); err != nil {
@@ -757,6 +1315,55 @@ func (s *Service) BuyBusinessUpgrade(ctx context.Context, in BusinessUpgradeInpu
 	return out, nil
 }
 
+// UpgradeCosts lists the business's current upgrade levels with the price of
+// the next level, using the same curves as BuyBusinessUpgrade.
+func (s *Service) UpgradeCosts(ctx context.Context, userID string, seasonID, businessID int64) ([]BusinessUpgradeCost, error) {
+	var owner string
+	var marketing, rd, automation, compliance int32
+	var seatCapacity int64
+	if err := s.reader().QueryRow(ctx, `
+		SELECT owner_user_id, marketing_level, rd_level, automation_level, compliance_level, seat_capacity
+		FROM game.businesses
+		WHERE id = $1 AND season_id = $2
+	`, businessID, seasonID).Scan(&owner, &marketing, &rd, &automation, &compliance, &seatCapacity); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUnauthorized
+		}
+		return nil, err
+	}
+	if owner != userID {
+		return nil, ErrUnauthorized
+	}
+	out := make([]BusinessUpgradeCost, 0, 5)
+	for _, u := range []struct {
+		name  string
+		level int32
+	}{
+		{"marketing", marketing},
+		{"rd", rd},
+		{"automation", automation},
+		{"compliance", compliance},
+	} {
+		out = append(out, BusinessUpgradeCost{
+			Upgrade:        u.name,
+			Level:          int64(u.level),
+			NextCostMicros: BusinessUpgradeCostMicros(u.level),
+		})
+	}
+	seatCapacity = effectiveEmployeeLimit(seatCapacity)
+	seats := BusinessUpgradeCost{
+		Upgrade:       "seats",
+		Level:         (seatCapacity - BaseBusinessEmployeeLimit) / SeatUpgradeIncrement,
+		EmployeeLimit: seatCapacity,
+	}
+	if seatCapacity >= MaxBusinessEmployees {
+		seats.Maxed = true
+	} else {
+		seats.NextCostMicros = SeatUpgradeCostMicros(int(seats.Level))
+	}
+	return append(out, seats), nil
+}
+
 func (s *Service) BusinessReserveDeposit(ctx context.Context, in BusinessReserveInput) error {
 	if in.AmountMicros <= 0 {
 		return fmt.Errorf("amount must be > 0")
@@ -766,7 +1373,7 @@ func (s *Service) BusinessReserveDeposit(ctx context.Context, in BusinessReserve
 		return err
 	}
 	defer tx.Rollback(ctx)
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "business_reserve_deposit"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "business_reserve_deposit"); err != nil {
 		return err
 	}
 	var owner string
@@ -782,12 +1389,8 @@ func (s *Service) BusinessReserveDeposit(ctx context.Context, in BusinessReserve
 		return ErrUnauthorized
 	}
 	var balance int64
-	if err := tx.QueryRow(ctx, `
-		SELECT balance_micros
-		FROM game.wallets
-		WHERE user_id = $1 AND season_id = $2
-		FOR UPDATE
-	`, in.UserID, in.SeasonID).Scan(&balance); err != nil {
+	balance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+	if err != nil {
 		return err
 	}
 	if !hasPositiveBalanceAfterSpend(balance, in.AmountMicros) {
@@ -822,7 +1425,7 @@ func (s *Service) BusinessReserveWithdraw(ctx context.Context, in BusinessReserv
 		return err
 	}
 	defer tx.Rollback(ctx)
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "business_reserve_withdraw"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "business_reserve_withdraw"); err != nil {
 		return err
 	}
 	var owner string
@@ -861,35 +1464,28 @@ func (s *Service) BusinessReserveWithdraw(ctx context.Context, in BusinessReserv
 	return tx.Commit(ctx)
 }
 
-func (s *Service) SellBusinessToBank(ctx context.Context, userID string, seasonID, businessID int64, idem string) (map[string]any, error) {
-	out := map[string]any{}
-	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
-	if err != nil {
-		return out, err
-	}
-	defer tx.Rollback(ctx)
-	if err := claimIdempotency(ctx, tx, userID, idem, "sell_business_to_bank"); err != nil {
-		return out, err
-	}
+// The bank values a business at its operating revenue per tick times a
+// multiple, scaled by a random factor in
+// [businessSaleFactorMin, businessSaleFactorMin+businessSaleFactorSpread).
+const (
+	businessSaleFactorMin    = 0.82
+	businessSaleFactorSpread = 0.40
+)
 
-	var owner string
-	var baseRevenue int64
-	if err := tx.QueryRow(ctx, `
-		SELECT owner_user_id, base_revenue_micros
-		FROM game.businesses
-		WHERE id = $1 AND season_id = $2
-		FOR UPDATE
-	`, businessID, seasonID).Scan(&owner, &baseRevenue); err != nil {
-		return out, err
-	}
-	if owner != userID {
-		return out, ErrUnauthorized
-	}
+// businessSaleBasis is everything a bank sale valuation depends on apart
+// from the random adjustment factor. Unclaimed accrued revenue is not part
+// of it: a sale claims that first, so it is taxed like any other claim.
+type businessSaleBasis struct {
+	OperatingMicros       int64
+	Multiple              float64
+	LoanOutstandingMicros int64
+}
 
+func loadBusinessSaleBasisTx(ctx context.Context, tx pgx.Tx, businessID, seasonID, baseRevenue int64) (businessSaleBasis, error) {
+	out := businessSaleBasis{}
 	var employeeRevenue int64
 	var employeeCount int64
 	var machineryOutput, machineryUpkeep int64
-	var loanOutstanding int64
 	if err := tx.QueryRow(ctx, `
 		SELECT COALESCE(SUM(be.revenue_per_tick_micros), 0), b.employee_count
 		FROM game.businesses b
@@ -912,21 +1508,127 @@ func (s *Service) SellBusinessToBank(ctx context.Context, userID string, seasonI
 		SELECT COALESCE(SUM(outstanding_micros), 0)
 		FROM game.business_loans
 		WHERE business_id = $1 AND season_id = $2 AND status = 'open'
-	`, businessID, seasonID).Scan(&loanOutstanding); err != nil {
+	`, businessID, seasonID).Scan(&out.LoanOutstandingMicros); err != nil {
 		return out, err
 	}
-
-	operating := baseRevenue + employeeRevenue + machineryOutput - machineryUpkeep
-	if operating < 0 {
-		operating = 0
+	out.OperatingMicros = baseRevenue + employeeRevenue + machineryOutput - machineryUpkeep
+	if out.OperatingMicros < 0 {
+		out.OperatingMicros = 0
 	}
-	scale := float64(14 + employeeCount/3)
-	factor := 0.82 + (s.nextFloat() * 0.40)
-	gross := int64(math.Round(float64(operating) * scale * factor))
-	payout := gross - loanOutstanding
+	out.Multiple = float64(14 + employeeCount/3)
+	return out, nil
+}
+
+// value returns the gross valuation and the payout after loan payoff for an
+// adjustment factor.
+func (b businessSaleBasis) value(factor float64) (gross, payout int64) {
+	gross = int64(math.Round(float64(b.OperatingMicros) * b.Multiple * factor))
+	payout = gross - b.LoanOutstandingMicros
 	if payout < 0 {
 		payout = 0
 	}
+	return gross, payout
+}
+
+// PreviewBusinessSale reports the range SellBusinessToBank would pay for a
+// business, without selling it. The actual sale draws a random factor, so
+// the payout lands somewhere between the low and high figures.
+func (s *Service) PreviewBusinessSale(ctx context.Context, userID string, seasonID, businessID int64) (map[string]any, error) {
+	out := map[string]any{}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return out, err
+	}
+	defer tx.Rollback(ctx)
+
+	var owner, name string
+	var baseRevenue, unclaimed int64
+	if err := tx.QueryRow(ctx, `
+		SELECT owner_user_id, name, base_revenue_micros, unclaimed_revenue_micros
+		FROM game.businesses
+		WHERE id = $1 AND season_id = $2
+	`, businessID, seasonID).Scan(&owner, &name, &baseRevenue, &unclaimed); err != nil {
+		return out, err
+	}
+	if owner != userID {
+		return out, ErrUnauthorized
+	}
+	basis, err := loadBusinessSaleBasisTx(ctx, tx, businessID, seasonID, baseRevenue)
+	if err != nil {
+		return out, err
+	}
+	stakes, err := loadBusinessStakesTx(ctx, tx, businessID, seasonID)
+	if err != nil {
+		return out, err
+	}
+	ownerStakeBps := int32(0)
+	for _, stake := range stakes {
+		if stake.UserID == userID {
+			ownerStakeBps = stake.StakeBps
+		}
+	}
+	grossLow, payoutLow := basis.value(businessSaleFactorMin)
+	grossHigh, payoutHigh := basis.value(businessSaleFactorMin + businessSaleFactorSpread)
+	ownerShare := func(payout int64) int64 {
+		return int64(math.Round(float64(payout) * float64(ownerStakeBps) / 10000.0))
+	}
+
+	out["business_id"] = businessID
+	out["name"] = name
+	out["operating_revenue_per_tick_micros"] = basis.OperatingMicros
+	out["gross_valuation_low_micros"] = grossLow
+	out["gross_valuation_high_micros"] = grossHigh
+	out["loan_payoff_micros"] = basis.LoanOutstandingMicros
+	out["unclaimed_revenue_micros"] = unclaimed
+	out["payout_low_micros"] = payoutLow
+	out["payout_high_micros"] = payoutHigh
+	out["owner_stake_bps"] = ownerStakeBps
+	out["owner_payout_low_micros"] = ownerShare(payoutLow)
+	out["owner_payout_high_micros"] = ownerShare(payoutHigh)
+	return out, nil
+}
+
+func (s *Service) SellBusinessToBank(ctx context.Context, userID string, seasonID, businessID int64, idem string) (map[string]any, error) {
+	out := map[string]any{}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return out, err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, userID, seasonID, idem, "sell_business_to_bank"); err != nil {
+		return out, err
+	}
+
+	var owner string
+	var baseRevenue, unclaimed int64
+	if err := tx.QueryRow(ctx, `
+		SELECT owner_user_id, base_revenue_micros, unclaimed_revenue_micros
+		FROM game.businesses
+		WHERE id = $1 AND season_id = $2
+		FOR UPDATE
+	`, businessID, seasonID).Scan(&owner, &baseRevenue, &unclaimed); err != nil {
+		return out, err
+	}
+	if owner != userID {
+		return out, ErrUnauthorized
+	}
+
+	settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+	if err != nil {
+		return out, err
+	}
+	claimed, err := s.claimUnclaimedRevenueTx(ctx, tx, settings, businessID, seasonID, owner, unclaimed)
+	if err != nil {
+		return out, err
+	}
+
+	basis, err := loadBusinessSaleBasisTx(ctx, tx, businessID, seasonID, baseRevenue)
+	if err != nil {
+		return out, err
+	}
+	loanOutstanding := basis.LoanOutstandingMicros
+	factor := businessSaleFactorMin + (s.nextFloat() * businessSaleFactorSpread)
+	gross, payout := basis.value(factor)
 
 	stakes, err := loadBusinessStakesTx(ctx, tx, businessID, seasonID)
 	if err != nil {
@@ -960,6 +1662,9 @@ func (s *Service) SellBusinessToBank(ctx context.Context, userID string, seasonI
 		FROM game.wallets
 		WHERE user_id = $1 AND season_id = $2
 	`, userID, seasonID).Scan(&ownerBalance); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out, ErrWalletNotFound
+		}
 		return out, err
 	}
 	if _, err := tx.Exec(ctx, `
@@ -991,12 +1696,82 @@ func (s *Service) SellBusinessToBank(ctx context.Context, userID string, seasonI
 	out["adjustment_factor"] = factor
 	out["loan_payoff_micros"] = loanOutstanding
 	out["payout_micros"] = payout
+	out["unclaimed_revenue_micros"] = unclaimed
+	out["unclaimed_owner_share_micros"] = claimed[userID]
 	out["owner_payout_micros"] = ownerPayout
 	out["owner_stake_bps"] = ownerStakeBps
 	out["balance_micros"] = ownerBalance
 	return out, nil
 }
 
+// DeleteEmptyBusiness removes a business that was never put to use: no
+// employees, machinery, open loans, reserve cash, stock, or outside
+// stakeholders. Anything else has to go through SellBusinessToBank.
+func (s *Service) DeleteEmptyBusiness(ctx context.Context, userID string, seasonID, businessID int64, idem string) (map[string]any, error) {
+	out := map[string]any{}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return out, err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, userID, seasonID, idem, "delete_business"); err != nil {
+		return out, err
+	}
+
+	var owner, name string
+	var employeeCount, reserve, unclaimed int64
+	var listed bool
+	if err := tx.QueryRow(ctx, `
+		SELECT owner_user_id, name, employee_count, cash_reserve_micros, unclaimed_revenue_micros, is_listed
+		FROM game.businesses
+		WHERE id = $1 AND season_id = $2
+		FOR UPDATE
+	`, businessID, seasonID).Scan(&owner, &name, &employeeCount, &reserve, &unclaimed, &listed); err != nil {
+		return out, err
+	}
+	if owner != userID {
+		return out, ErrUnauthorized
+	}
+
+	var machinery, openLoans, stocks, outsideStakes int64
+	if err := tx.QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM game.business_machinery WHERE business_id = $1 AND season_id = $2),
+			(SELECT COUNT(*) FROM game.business_loans WHERE business_id = $1 AND season_id = $2 AND status = 'open'),
+			(SELECT COUNT(*) FROM game.stocks WHERE business_id = $1 AND season_id = $2),
+			(SELECT COUNT(*) FROM game.business_stakes WHERE business_id = $1 AND season_id = $2 AND user_id <> $3)
+	`, businessID, seasonID, userID).Scan(&machinery, &openLoans, &stocks, &outsideStakes); err != nil {
+		return out, err
+	}
+	switch {
+	case employeeCount > 0:
+		return out, fmt.Errorf("%w: it still has employees", ErrBusinessNotEmpty)
+	case machinery > 0:
+		return out, fmt.Errorf("%w: it owns machinery", ErrBusinessNotEmpty)
+	case openLoans > 0:
+		return out, fmt.Errorf("%w: it has open loans", ErrBusinessNotEmpty)
+	case reserve > 0:
+		return out, fmt.Errorf("%w: withdraw the cash reserve first", ErrBusinessNotEmpty)
+	case unclaimed > 0:
+		return out, fmt.Errorf("%w: claim its unclaimed revenue first", ErrBusinessNotEmpty)
+	case listed || stocks > 0:
+		return out, fmt.Errorf("%w: it has a stock", ErrBusinessNotEmpty)
+	case outsideStakes > 0:
+		return out, fmt.Errorf("%w: other players hold stakes in it", ErrBusinessNotEmpty)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM game.businesses WHERE id = $1 AND season_id = $2`, businessID, seasonID); err != nil {
+		return out, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return out, err
+	}
+	out["ok"] = true
+	out["business_id"] = businessID
+	out["name"] = name
+	return out, nil
+}
+
 type businessStakeRow struct {
 	UserID          string
 	Username        string
@@ -1041,7 +1816,7 @@ func loadBusinessStakeBpsTx(ctx context.Context, tx pgx.Tx, businessID, seasonID
 }
 
 func (s *Service) ListStakes(ctx context.Context, userID string, seasonID int64) ([]StakeView, error) {
-	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	tx, err := s.reader().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
 	if err != nil {
 		return nil, err
 	}
@@ -1124,7 +1899,7 @@ func (s *Service) TransferBusinessStake(ctx context.Context, in TransferBusiness
 		return out, err
 	}
 	defer tx.Rollback(ctx)
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "transfer_business_stake"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "transfer_business_stake"); err != nil {
 		return out, err
 	}
 
@@ -1227,7 +2002,7 @@ func (s *Service) RevokeBusinessStake(ctx context.Context, in RevokeBusinessStak
 		return out, err
 	}
 	defer tx.Rollback(ctx)
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "revoke_business_stake"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "revoke_business_stake"); err != nil {
 		return out, err
 	}
 
@@ -1333,7 +2108,7 @@ func (s *Service) TransferStonky(ctx context.Context, in WalletTransferInput) (m
 		return out, err
 	}
 	defer tx.Rollback(ctx)
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "wallet_transfer"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "wallet_transfer"); err != nil {
 		return out, err
 	}
 
@@ -1350,12 +2125,8 @@ func (s *Service) TransferStonky(ctx context.Context, in WalletTransferInput) (m
 	}
 
 	var senderBalance int64
-	if err := tx.QueryRow(ctx, `
-		SELECT balance_micros
-		FROM game.wallets
-		WHERE user_id = $1 AND season_id = $2
-		FOR UPDATE
-	`, in.UserID, in.SeasonID).Scan(&senderBalance); err != nil {
+	senderBalance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+	if err != nil {
 		return out, err
 	}
 	if senderBalance < in.AmountMicros {
@@ -1437,17 +2208,15 @@ func (s *Service) ListFunds(ctx context.Context, seasonID int64) ([]map[string]a
 	if err != nil {
 		return nil, err
 	}
-	codes := make([]string, 0, len(fundUniverse))
-	for code := range fundUniverse {
-		codes = append(codes, code)
-	}
-	sort.Strings(codes)
-	out := make([]map[string]any, 0, len(codes))
-	for _, code := range codes {
+	out := make([]map[string]any, 0, len(fundCatalog))
+	for _, spec := range fundCatalog {
 		out = append(out, map[string]any{
-			"code":       code,
-			"components": fundUniverse[code],
-			"nav_micros": navs[code],
+			"code":       spec.Code,
+			"name":       spec.DisplayName,
+			"strategy":   spec.Strategy,
+			"components": spec.Components,
+			"nav_micros": navs[spec.Code],
+			"unit_scale": FundUnitScale,
 		})
 	}
 	return out, nil
@@ -1458,12 +2227,12 @@ func (s *Service) TradeFund(ctx context.Context, in FundOrderInput) (map[string]
 	in.FundCode = strings.ToUpper(strings.TrimSpace(in.FundCode))
 	in.Side = strings.ToLower(strings.TrimSpace(in.Side))
 	if in.Units <= 0 {
-		return out, fmt.Errorf("units must be > 0")
+		return out, ErrInvalidFundUnits
 	}
 	if in.Side != "buy" && in.Side != "sell" {
 		return out, fmt.Errorf("side must be buy or sell")
 	}
-	if _, ok := fundUniverse[in.FundCode]; !ok {
+	if _, ok := fundByCode(in.FundCode); !ok {
 		return out, fmt.Errorf("unknown fund code: %s", in.FundCode)
 	}
 
@@ -1472,7 +2241,7 @@ func (s *Service) TradeFund(ctx context.Context, in FundOrderInput) (map[string]
 		return out, err
 	}
 	defer tx.Rollback(ctx)
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "fund_trade"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "fund_trade"); err != nil {
 		return out, err
 	}
 	navs, err := s.fundNAVsTx(ctx, tx, in.SeasonID)
@@ -1484,15 +2253,15 @@ func (s *Service) TradeFund(ctx context.Context, in FundOrderInput) (map[string]
 	if err != nil {
 		return out, err
 	}
-	fee := int64(math.Round(float64(notional) * 0.0010))
+	settings, err := loadSeasonSettingsTx(ctx, tx, in.SeasonID)
+	if err != nil {
+		return out, err
+	}
+	fee := settings.tradeFee(fundFeeMicros(notional))
 
 	var balance int64
-	if err := tx.QueryRow(ctx, `
-		SELECT balance_micros
-		FROM game.wallets
-		WHERE user_id = $1 AND season_id = $2
-		FOR UPDATE
-	`, in.UserID, in.SeasonID).Scan(&balance); err != nil {
+	balance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+	if err != nil {
 		return out, err
 	}
 
@@ -1587,8 +2356,201 @@ func (s *Service) TradeFund(ctx context.Context, in FundOrderInput) (map[string]
 	return out, nil
 }
 
+// SwapFunds sells Units of one fund and buys as many units of another as the
+// proceeds cover, in one transaction with a single swap fee instead of a fee
+// on each leg. Proceeds left over after the last 0.0001-unit step are
+// returned as cash.
+func (s *Service) SwapFunds(ctx context.Context, in FundSwapInput) (map[string]any, error) {
+	out := map[string]any{}
+	in.FromCode = strings.ToUpper(strings.TrimSpace(in.FromCode))
+	in.ToCode = strings.ToUpper(strings.TrimSpace(in.ToCode))
+	if in.Units <= 0 {
+		return out, ErrInvalidFundUnits
+	}
+	if _, ok := fundByCode(in.FromCode); !ok {
+		return out, fmt.Errorf("unknown fund code: %s", in.FromCode)
+	}
+	if _, ok := fundByCode(in.ToCode); !ok {
+		return out, fmt.Errorf("unknown fund code: %s", in.ToCode)
+	}
+	if in.FromCode == in.ToCode {
+		return out, fmt.Errorf("cannot swap a fund into itself")
+	}
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return out, err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "fund_swap"); err != nil {
+		return out, err
+	}
+	navs, err := s.fundNAVsTx(ctx, tx, in.SeasonID)
+	if err != nil {
+		return out, err
+	}
+	fromNav, toNav := navs[in.FromCode], navs[in.ToCode]
+	proceeds, err := notionalMicros(fromNav, in.Units)
+	if err != nil {
+		return out, err
+	}
+	settings, err := loadSeasonSettingsTx(ctx, tx, in.SeasonID)
+	if err != nil {
+		return out, err
+	}
+	fee := settings.fundSwapFee(proceeds)
+	toUnits, cashBack, err := fundSwapUnits(proceeds-fee, toNav)
+	if err != nil {
+		return out, err
+	}
+	if toUnits <= 0 {
+		return out, fmt.Errorf("swap proceeds do not cover one unit of %s", in.ToCode)
+	}
+
+	balance, err := lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+	if err != nil {
+		return out, err
+	}
+
+	var fromUnits int64
+	err = tx.QueryRow(ctx, `
+		SELECT units
+		FROM game.fund_positions
+		WHERE user_id = $1 AND season_id = $2 AND fund_code = $3
+		FOR UPDATE
+	`, in.UserID, in.SeasonID, in.FromCode).Scan(&fromUnits)
+	if err != nil && err != pgx.ErrNoRows {
+		return out, err
+	}
+	if fromUnits < in.Units {
+		return out, ErrInsufficientShares
+	}
+	if fromUnits == in.Units {
+		_, err = tx.Exec(ctx, `
+			DELETE FROM game.fund_positions
+			WHERE user_id = $1 AND season_id = $2 AND fund_code = $3
+		`, in.UserID, in.SeasonID, in.FromCode)
+	} else {
+		_, err = tx.Exec(ctx, `
+			UPDATE game.fund_positions
+			SET units = units - $1, updated_at = now()
+			WHERE user_id = $2 AND season_id = $3 AND fund_code = $4
+		`, in.Units, in.UserID, in.SeasonID, in.FromCode)
+	}
+	if err != nil {
+		return out, err
+	}
+
+	var posUnits, avgNav int64
+	err = tx.QueryRow(ctx, `
+		SELECT units, avg_nav_micros
+		FROM game.fund_positions
+		WHERE user_id = $1 AND season_id = $2 AND fund_code = $3
+		FOR UPDATE
+	`, in.UserID, in.SeasonID, in.ToCode).Scan(&posUnits, &avgNav)
+	if err != nil && err != pgx.ErrNoRows {
+		return out, err
+	}
+	newUnits := posUnits + toUnits
+	weightedOld, _ := notionalMicros(avgNav, posUnits)
+	weightedNew, _ := notionalMicros(toNav, toUnits)
+	nextAvg, err := divideMicros(weightedOld+weightedNew, newUnits)
+	if err != nil {
+		return out, err
+	}
+	if posUnits == 0 {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO game.fund_positions (user_id, season_id, fund_code, units, avg_nav_micros)
+			VALUES ($1, $2, $3, $4, $5)
+		`, in.UserID, in.SeasonID, in.ToCode, newUnits, nextAvg)
+	} else {
+		_, err = tx.Exec(ctx, `
+			UPDATE game.fund_positions
+			SET units = $1, avg_nav_micros = $2, updated_at = now()
+			WHERE user_id = $3 AND season_id = $4 AND fund_code = $5
+		`, newUnits, nextAvg, in.UserID, in.SeasonID, in.ToCode)
+	}
+	if err != nil {
+		return out, err
+	}
+
+	balance += cashBack
+	if _, err := tx.Exec(ctx, `
+		UPDATE game.wallets
+		SET balance_micros = $1, updated_at = now()
+		WHERE user_id = $2 AND season_id = $3
+	`, balance, in.UserID, in.SeasonID); err != nil {
+		return out, err
+	}
+	if err := appendLedgerEntries(ctx, tx, in.UserID, in.SeasonID, "fund_swap", cashBack, fee); err != nil {
+		return out, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return out, err
+	}
+	out["ok"] = true
+	out["from_code"] = in.FromCode
+	out["to_code"] = in.ToCode
+	out["from_units"] = in.Units
+	out["to_units"] = toUnits
+	out["from_nav_micros"] = fromNav
+	out["to_nav_micros"] = toNav
+	out["notional_micros"] = proceeds
+	out["fee_micros"] = fee
+	out["cash_back_micros"] = cashBack
+	out["balance_micros"] = balance
+	return out, nil
+}
+
+// fundSwapUnits converts swap proceeds into stored units (FundUnitScale per
+// unit) of the target fund at nav, returning the micros left over once those
+// units are paid for.
+func fundSwapUnits(proceedsMicros, navMicros int64) (units, leftoverMicros int64, err error) {
+	if proceedsMicros <= 0 || navMicros <= 0 {
+		return 0, proceedsMicros, nil
+	}
+	units, err = divideMicros(proceedsMicros, navMicros)
+	if err != nil {
+		return 0, 0, err
+	}
+	cost, err := notionalMicros(navMicros, units)
+	if err != nil {
+		return 0, 0, err
+	}
+	return units, proceedsMicros - cost, nil
+}
+
+// FundPosition returns the player's holding in one fund, ErrFundNotFound for
+// an unknown code, or ErrPositionNotFound when they hold none.
+func (s *Service) FundPosition(ctx context.Context, userID string, seasonID int64, code string) (FundPositionView, error) {
+	spec, ok := fundByCode(code)
+	if !ok {
+		return FundPositionView{}, fmt.Errorf("%w: %s", ErrFundNotFound, strings.ToUpper(strings.TrimSpace(code)))
+	}
+	out := FundPositionView{Code: spec.Code, Name: spec.DisplayName}
+	err := s.reader().QueryRow(ctx, `
+		SELECT units, avg_nav_micros
+		FROM game.fund_positions
+		WHERE user_id = $1 AND season_id = $2 AND fund_code = $3 AND units > 0
+	`, userID, seasonID, spec.Code).Scan(&out.Units, &out.AvgNavMicros)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return FundPositionView{}, fmt.Errorf("%w in %s", ErrPositionNotFound, spec.Code)
+	}
+	if err != nil {
+		return FundPositionView{}, err
+	}
+	navs, err := s.fundNAVs(ctx, seasonID)
+	if err != nil {
+		return FundPositionView{}, err
+	}
+	out.NavMicros = navs[spec.Code]
+	out.ValueMicros = notionalMicrosClamped(out.NavMicros, out.Units)
+	out.UnrealizedMicros = out.ValueMicros - notionalMicrosClamped(out.AvgNavMicros, out.Units)
+	return out, nil
+}
+
 func (s *Service) estimateFundHoldingsMicros(ctx context.Context, userID string, seasonID int64) (int64, error) {
-	rows, err := s.db.Query(ctx, `
+	rows, err := s.reader().Query(ctx, `
 		SELECT fund_code, units
 		FROM game.fund_positions
 		WHERE user_id = $1 AND season_id = $2
@@ -1616,7 +2578,7 @@ func (s *Service) estimateFundHoldingsMicros(ctx context.Context, userID string,
 }
 
 func (s *Service) fundNAVs(ctx context.Context, seasonID int64) (map[string]int64, error) {
-	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	tx, err := s.reader().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
 	if err != nil {
 		return nil, err
 	}
@@ -1654,8 +2616,9 @@ func (s *Service) fundNAVsTx(ctx context.Context, tx pgx.Tx, seasonID int64) (ma
 		return nil, err
 	}
 
-	navs := make(map[string]int64, len(fundUniverse))
-	for code, symbols := range fundUniverse {
+	navs := make(map[string]int64, len(fundCatalog))
+	for _, spec := range fundCatalog {
+		code, symbols := spec.Code, spec.Components
 		if len(symbols) == 0 {
 			navs[code] = 100 * MicrosPerStonky
 			continue
@@ -0,0 +1,66 @@
+package game
+
+import "testing"
+
+func TestMachineryBatchCostMicrosPricesEachLevel(t *testing.T) {
+	spec, err := machineByType("assembly_line")
+	if err != nil {
+		t.Fatalf("machineByType: %v", err)
+	}
+
+	// Levels 1, 2 and 3 cost 1x, 1.25x and 1.5x the base price.
+	if got, want := machineryBatchCostMicros(spec, 0, 3), int64(24_375*MicrosPerStonky); got != want {
+		t.Fatalf("cost from level 0 = %d, want %d", got, want)
+	}
+	// Levels 3 and 4 on top of an existing level 2.
+	if got, want := machineryBatchCostMicros(spec, 2, 2), int64(21_125*MicrosPerStonky); got != want {
+		t.Fatalf("cost from level 2 = %d, want %d", got, want)
+	}
+	if got := machineryBatchCostMicros(spec, 5, 0); got != 0 {
+		t.Fatalf("empty batch cost = %d, want 0", got)
+	}
+}
+
+func TestMachineryBatchCostMatchesSingleBuys(t *testing.T) {
+	spec, err := machineByType("quantum_rig")
+	if err != nil {
+		t.Fatalf("machineByType: %v", err)
+	}
+	want := int64(0)
+	for level := int32(4); level <= 9; level++ {
+		want += machineryLevelCostMicros(spec, level)
+	}
+	if got := machineryBatchCostMicros(spec, 3, 6); got != want {
+		t.Fatalf("batch cost = %d, want %d", got, want)
+	}
+}
+
+func TestMachineryBatchCountsMergesAndValidates(t *testing.T) {
+	counts, types, err := machineryBatchCounts([]MachineryBatchItem{
+		{MachineType: "robotics_cell", Count: 2},
+		{MachineType: " Assembly_Line ", Count: 1},
+		{MachineType: "robotics_cell", Count: 3},
+	})
+	if err != nil {
+		t.Fatalf("machineryBatchCounts: %v", err)
+	}
+	if len(types) != 2 || types[0] != "assembly_line" || types[1] != "robotics_cell" {
+		t.Fatalf("types = %v, want [assembly_line robotics_cell]", types)
+	}
+	if counts["robotics_cell"] != 5 || counts["assembly_line"] != 1 {
+		t.Fatalf("counts = %v", counts)
+	}
+
+	if _, _, err := machineryBatchCounts(nil); err == nil {
+		t.Fatal("expected empty batch to fail")
+	}
+	if _, _, err := machineryBatchCounts([]MachineryBatchItem{{MachineType: "warp_drive", Count: 1}}); err == nil {
+		t.Fatal("expected unknown machine type to fail")
+	}
+	if _, _, err := machineryBatchCounts([]MachineryBatchItem{{MachineType: "bio_reactor", Count: 0}}); err == nil {
+		t.Fatal("expected zero count to fail")
+	}
+	if _, _, err := machineryBatchCounts([]MachineryBatchItem{{MachineType: "bio_reactor", Count: maxMachineryBatchLevels + 1}}); err == nil {
+		t.Fatal("expected oversized batch to fail")
+	}
+}
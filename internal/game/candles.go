@@ -0,0 +1,173 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	minCandleBucket     = time.Minute
+	maxCandleBucket     = 7 * 24 * time.Hour
+	defaultCandleCount  = 48
+	maxCandlesPerWindow = 500
+)
+
+// ParseCandleBucket parses a candle width such as "5m", "1h", or "1d".
+// Go duration syntax is accepted, plus a whole-day "d" suffix.
+func ParseCandleBucket(v string) (time.Duration, error) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if v == "" {
+		return time.Hour, nil
+	}
+	var bucket time.Duration
+	if days, ok := strings.CutSuffix(v, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid candle bucket %q", v)
+		}
+		bucket = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid candle bucket %q", v)
+		}
+		bucket = d
+	}
+	if bucket < minCandleBucket || bucket > maxCandleBucket {
+		return 0, fmt.Errorf("candle bucket must be between 1m and 7d")
+	}
+	if bucket%time.Second != 0 {
+		return 0, fmt.Errorf("candle bucket must be a whole number of seconds")
+	}
+	return bucket, nil
+}
+
+// CandleWindow fills in a default window of count buckets (48 when count
+// is zero) ending now and rejects windows that would return too many
+// candles. count is ignored when from is set.
+func CandleWindow(bucket time.Duration, count int, from, to, now time.Time) (time.Time, time.Time, error) {
+	if count < 0 || count > maxCandlesPerWindow {
+		return from, to, fmt.Errorf("count must be between 1 and %d", maxCandlesPerWindow)
+	}
+	if count == 0 {
+		count = defaultCandleCount
+	}
+	if to.IsZero() {
+		to = now
+	}
+	if from.IsZero() {
+		from = to.Add(-time.Duration(count) * bucket)
+	}
+	if !from.Before(to) {
+		return from, to, fmt.Errorf("from must be before to")
+	}
+	if to.Sub(from)/bucket > maxCandlesPerWindow {
+		return from, to, fmt.Errorf("window spans more than %d candles; widen the bucket or narrow the range", maxCandlesPerWindow)
+	}
+	return from, to, nil
+}
+
+// StockCandles aggregates a stock's tick prices into OHLC candles of width
+// bucket over [from, to). Buckets are aligned to the Unix epoch. A bucket
+// without ticks carries the last close forward as a flat candle with zero
+// ticks; buckets before the stock's first tick are omitted. Zero from/to
+// default to the last 48 buckets.
+func (s *Service) StockCandles(ctx context.Context, seasonID int64, symbol string, bucket time.Duration, from, to time.Time) (StockCandles, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	out := StockCandles{Symbol: symbol, BucketSeconds: int64(bucket / time.Second)}
+	from, to, err := CandleWindow(bucket, 0, from, to, time.Now().UTC())
+	if err != nil {
+		return out, err
+	}
+	out.From, out.To = from, to
+
+	var stockID int64
+	if err := s.reader().QueryRow(ctx, `
+		SELECT id
+		FROM game.stocks
+		WHERE season_id = $1 AND symbol = $2
+	`, seasonID, symbol).Scan(&stockID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out, ErrStockNotFound
+		}
+		return out, err
+	}
+
+	rows, err := s.reader().Query(ctx, `
+		SELECT to_timestamp(floor(extract(epoch FROM tick_at) / $4) * $4) AS bucket_start,
+		       (array_agg(price_micros ORDER BY tick_at ASC))[1] AS open_micros,
+		       MAX(price_micros) AS high_micros,
+		       MIN(price_micros) AS low_micros,
+		       (array_agg(price_micros ORDER BY tick_at DESC))[1] AS close_micros,
+		       COUNT(*) AS ticks
+		FROM game.stock_prices
+		WHERE stock_id = $1 AND tick_at >= $2 AND tick_at < $3
+		GROUP BY bucket_start
+		ORDER BY bucket_start
+	`, stockID, from, to, out.BucketSeconds)
+	if err != nil {
+		return out, err
+	}
+	defer rows.Close()
+	candles := make([]Candle, 0)
+	for rows.Next() {
+		var c Candle
+		if err := rows.Scan(&c.BucketStart, &c.OpenMicros, &c.HighMicros, &c.LowMicros, &c.CloseMicros, &c.Ticks); err != nil {
+			return out, err
+		}
+		candles = append(candles, c)
+	}
+	if err := rows.Err(); err != nil {
+		return out, err
+	}
+
+	var prevClose int64
+	if err := s.reader().QueryRow(ctx, `
+		SELECT price_micros
+		FROM game.stock_prices
+		WHERE stock_id = $1 AND tick_at < $2
+		ORDER BY tick_at DESC
+		LIMIT 1
+	`, stockID, from).Scan(&prevClose); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return out, err
+	}
+	out.Candles = fillCandleGaps(candles, prevClose, bucket, from, to)
+	return out, nil
+}
+
+// fillCandleGaps returns one candle per epoch-aligned bucket in [from, to),
+// using candles where they exist and a flat zero-tick candle at the last
+// close elsewhere. prevClose is the last price before from; leading buckets
+// are skipped while there is no close to carry.
+func fillCandleGaps(candles []Candle, prevClose int64, bucket time.Duration, from, to time.Time) []Candle {
+	byStart := make(map[int64]Candle, len(candles))
+	for _, c := range candles {
+		byStart[c.BucketStart.Unix()] = c
+	}
+	secs := int64(bucket / time.Second)
+	out := make([]Candle, 0, len(candles))
+	for b := time.Unix(from.Unix()/secs*secs, 0).UTC(); b.Before(to); b = b.Add(bucket) {
+		if c, ok := byStart[b.Unix()]; ok {
+			out = append(out, c)
+			prevClose = c.CloseMicros
+			continue
+		}
+		if prevClose <= 0 {
+			continue
+		}
+		out = append(out, Candle{
+			BucketStart: b,
+			OpenMicros:  prevClose,
+			HighMicros:  prevClose,
+			LowMicros:   prevClose,
+			CloseMicros: prevClose,
+		})
+	}
+	return out
+}
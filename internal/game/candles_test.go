@@ -0,0 +1,86 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCandleBucket(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "", want: time.Hour},
+		{in: "5m", want: 5 * time.Minute},
+		{in: " 4H ", want: 4 * time.Hour},
+		{in: "1d", want: 24 * time.Hour},
+		{in: "30s", wantErr: true},
+		{in: "8d", wantErr: true},
+		{in: "xd", wantErr: true},
+		{in: "soon", wantErr: true},
+	}
+	for _, tc := range tests {
+		got, err := ParseCandleBucket(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Fatalf("ParseCandleBucket(%q) expected error", tc.in)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Fatalf("ParseCandleBucket(%q) = %v, %v; want %v", tc.in, got, err, tc.want)
+		}
+	}
+}
+
+func TestCandleWindow(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	from, to, err := CandleWindow(time.Hour, 0, time.Time{}, time.Time{}, now)
+	if err != nil {
+		t.Fatalf("default window error = %v", err)
+	}
+	if !to.Equal(now) || !from.Equal(now.Add(-defaultCandleCount*time.Hour)) {
+		t.Fatalf("default window = %v..%v", from, to)
+	}
+	if _, _, err := CandleWindow(time.Minute, 0, now.Add(-24*time.Hour), now, now); err == nil {
+		t.Fatal("expected a 1440-candle window to be rejected")
+	}
+	if _, _, err := CandleWindow(time.Hour, 0, now, now.Add(-time.Hour), now); err == nil {
+		t.Fatal("expected from after to to be rejected")
+	}
+	from, _, err = CandleWindow(24*time.Hour, 30, time.Time{}, time.Time{}, now)
+	if err != nil || !from.Equal(now.Add(-30*24*time.Hour)) {
+		t.Fatalf("count window from = %v, %v", from, err)
+	}
+	if _, _, err := CandleWindow(time.Hour, maxCandlesPerWindow+1, time.Time{}, time.Time{}, now); err == nil {
+		t.Fatal("expected count above the window cap to be rejected")
+	}
+}
+
+func TestFillCandleGapsCarriesLastClose(t *testing.T) {
+	from := time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return time.Date(2026, 3, 1, h, 0, 0, 0, time.UTC) }
+	candles := []Candle{
+		{BucketStart: at(11), OpenMicros: 100, HighMicros: 120, LowMicros: 90, CloseMicros: 110, Ticks: 4},
+		{BucketStart: at(13), OpenMicros: 108, HighMicros: 115, LowMicros: 105, CloseMicros: 112, Ticks: 2},
+	}
+
+	got := fillCandleGaps(candles, 0, time.Hour, from, to)
+	if len(got) != 4 {
+		t.Fatalf("len = %d, want 4 (10:00 skipped without a prior close): %+v", len(got), got)
+	}
+	gap := got[1]
+	if !gap.BucketStart.Equal(at(12)) || gap.Ticks != 0 || gap.OpenMicros != 110 || gap.HighMicros != 110 || gap.LowMicros != 110 || gap.CloseMicros != 110 {
+		t.Fatalf("12:00 gap candle = %+v, want flat at 110", gap)
+	}
+	if tail := got[3]; !tail.BucketStart.Equal(at(14)) || tail.CloseMicros != 112 {
+		t.Fatalf("14:00 gap candle = %+v, want flat at 112", tail)
+	}
+
+	got = fillCandleGaps(candles, 95, time.Hour, from, to)
+	if len(got) != 5 || !got[0].BucketStart.Equal(at(10)) || got[0].CloseMicros != 95 {
+		t.Fatalf("leading gap with prior close = %+v", got)
+	}
+}
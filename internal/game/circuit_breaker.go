@@ -0,0 +1,89 @@
+package game
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// priceMove is one stock's price before and after a market tick.
+type priceMove struct {
+	from, to int64
+}
+
+// indexChangeBps is the tick's move in the market index: the equal-weighted
+// average return of the given stocks, in bps.
+func indexChangeBps(moves []priceMove) int64 {
+	var sum float64
+	var n int
+	for _, m := range moves {
+		if m.from <= 0 {
+			continue
+		}
+		sum += float64(m.to-m.from) / float64(m.from)
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return int64(sum / float64(n) * 10_000)
+}
+
+// applyCircuitBreakerTx records the tick's index move and advances the
+// market-wide halt, tripping it on a large enough drop. It reports whether
+// trading is halted going into the next tick.
+func applyCircuitBreakerTx(ctx context.Context, tx pgx.Tx, seasonID int64, settings seasonSettings, changeBps int64) (bool, error) {
+	var remaining int32
+	if err := tx.QueryRow(ctx, `
+		SELECT halt_ticks_remaining
+		FROM game.market_state
+		WHERE season_id = $1
+		FOR UPDATE
+	`, seasonID).Scan(&remaining); err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	next := settings.nextHaltTicks(remaining, changeBps)
+	if _, err := tx.Exec(ctx, `
+		UPDATE game.market_state
+		SET halt_ticks_remaining = $2, index_change_bps = $3, updated_at = now()
+		WHERE season_id = $1
+	`, seasonID, next, changeBps); err != nil {
+		return false, err
+	}
+	if next > remaining {
+		headline := fmt.Sprintf("Circuit breaker tripped: market index fell %.2f%%", float64(-changeBps)/100)
+		impact := fmt.Sprintf("Stock trading is halted for %d tick(s).", next)
+		if err := recordWorldEventTx(ctx, tx, seasonID, "market", headline, impact); err != nil {
+			return false, err
+		}
+	}
+	return next > 0, nil
+}
+
+// marketHaltTx returns the ticks left on the circuit-breaker halt and the
+// last tick's index move.
+func marketHaltTx(ctx context.Context, tx pgx.Tx, seasonID int64) (int32, int64, error) {
+	var remaining int32
+	var changeBps int64
+	err := tx.QueryRow(ctx, `
+		SELECT halt_ticks_remaining, index_change_bps
+		FROM game.market_state
+		WHERE season_id = $1
+	`, seasonID).Scan(&remaining, &changeBps)
+	if err == pgx.ErrNoRows {
+		return 0, 0, nil
+	}
+	return remaining, changeBps, err
+}
+
+// withHalt adds the circuit-breaker halt to a schedule-based market state.
+func (st MarketState) withHalt(remaining int32, changeBps int64) MarketState {
+	st.Halted = remaining > 0
+	st.HaltTicksRemaining = remaining
+	st.IndexChangeBps = changeBps
+	return st
+}
@@ -0,0 +1,20 @@
+package game
+
+import "testing"
+
+func TestIndexChangeBps(t *testing.T) {
+	cases := []struct {
+		moves []priceMove
+		want  int64
+	}{
+		{nil, 0},
+		{[]priceMove{{100, 110}, {200, 180}}, 0},
+		{[]priceMove{{100, 90}, {1_000, 900}}, -1_000},
+		{[]priceMove{{100, 120}, {0, 50}}, 2_000},
+	}
+	for _, c := range cases {
+		if got := indexChangeBps(c.moves); got != c.want {
+			t.Fatalf("indexChangeBps(%v) = %d, want %d", c.moves, got, c.want)
+		}
+	}
+}
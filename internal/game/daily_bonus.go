@@ -0,0 +1,81 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// dailyBonusDue reports whether a wallet last credited at last may claim
+// again at now. Days roll over at midnight UTC.
+func dailyBonusDue(last *time.Time, now time.Time) bool {
+	if last == nil {
+		return true
+	}
+	ly, lm, ld := last.UTC().Date()
+	ny, nm, nd := now.UTC().Date()
+	return ny != ly || nm != lm || nd != ld
+}
+
+func nextDailyBonusAt(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
+
+// ClaimDailyBonus credits amountMicros once per UTC day per season wallet.
+// A second claim on the same day returns granted=false without error.
+func (s *Service) ClaimDailyBonus(ctx context.Context, userID string, seasonID, amountMicros int64) (map[string]any, error) {
+	out := map[string]any{}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return out, err
+	}
+	defer tx.Rollback(ctx)
+
+	var balance int64
+	var last *time.Time
+	if err := tx.QueryRow(ctx, `
+		SELECT balance_micros, last_daily_bonus_at
+		FROM game.wallets
+		WHERE user_id = $1 AND season_id = $2
+		FOR UPDATE
+	`, userID, seasonID).Scan(&balance, &last); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out, ErrWalletNotFound
+		}
+		return out, err
+	}
+	now := time.Now().UTC()
+	out["ok"] = true
+	out["next_available_at"] = nextDailyBonusAt(now)
+	if amountMicros <= 0 || !dailyBonusDue(last, now) {
+		out["granted"] = false
+		out["amount_micros"] = int64(0)
+		out["balance_micros"] = balance
+		return out, nil
+	}
+
+	balance = saturatingAddInt64(balance, amountMicros)
+	if _, err := tx.Exec(ctx, `
+		UPDATE game.wallets
+		SET balance_micros = $1, last_daily_bonus_at = $2, updated_at = now()
+		WHERE user_id = $3 AND season_id = $4
+	`, balance, now, userID, seasonID); err != nil {
+		return out, err
+	}
+	if err := appendLedgerEntries(ctx, tx, userID, seasonID, "daily_bonus", amountMicros, 0); err != nil {
+		return out, err
+	}
+	if err := s.updatePeakNetWorthTx(ctx, tx, userID, seasonID); err != nil {
+		return out, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return out, err
+	}
+	out["granted"] = true
+	out["amount_micros"] = amountMicros
+	out["balance_micros"] = balance
+	return out, nil
+}
@@ -0,0 +1,157 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultDemoBots = 6
+	maxDemoBots     = 20
+)
+
+type DemoSocialGraphInput struct {
+	SeasonID     int64
+	TargetUserID string
+	Bots         int
+}
+
+// SeedDemoSocialGraph creates demo bot players with wallets and a few random
+// positions, all mutually following the target account. It is meant for
+// local/dev deployments only (see STANKS_DEV_SEED) and is safe to re-run:
+// existing bots keep their wallets and positions.
+func (s *Service) SeedDemoSocialGraph(ctx context.Context, in DemoSocialGraphInput) (map[string]any, error) {
+	out := map[string]any{}
+	if in.Bots <= 0 {
+		in.Bots = defaultDemoBots
+	}
+	if in.Bots > maxDemoBots {
+		return out, fmt.Errorf("bots must be <= %d", maxDemoBots)
+	}
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return out, err
+	}
+	defer tx.Rollback(ctx)
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT true FROM users.profiles WHERE user_id = $1`, in.TargetUserID).Scan(&exists); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out, fmt.Errorf("target player not found")
+		}
+		return out, err
+	}
+
+	type demoStock struct {
+		id    int64
+		price int64
+	}
+	var stocks []demoStock
+	rows, err := tx.Query(ctx, `
+		SELECT id, current_price_micros
+		FROM game.stocks
+		WHERE season_id = $1 AND listed_public = true AND current_price_micros > 0
+		ORDER BY id
+	`, in.SeasonID)
+	if err != nil {
+		return out, err
+	}
+	for rows.Next() {
+		var st demoStock
+		if err := rows.Scan(&st.id, &st.price); err != nil {
+			rows.Close()
+			return out, err
+		}
+		stocks = append(stocks, st)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return out, err
+	}
+
+	bots := make([]map[string]any, 0, in.Bots)
+	for i := 1; i <= in.Bots; i++ {
+		userID := fmt.Sprintf("demo-bot-%02d", i)
+		username := fmt.Sprintf("demo_bot_%02d", i)
+		inviteCode, err := generateInviteCode()
+		if err != nil {
+			return out, err
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO users.profiles (user_id, email, username, invite_code)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id) DO NOTHING
+		`, userID, userID+"@demo.invalid", username, inviteCode); err != nil {
+			return out, err
+		}
+		balance := int64(5_000+math.Round(s.nextFloat()*55_000)) * MicrosPerStonky
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO game.wallets (user_id, season_id, balance_micros, peak_net_worth_micros)
+			VALUES ($1, $2, $3, $3)
+			ON CONFLICT (user_id, season_id) DO NOTHING
+		`, userID, in.SeasonID, balance); err != nil {
+			return out, err
+		}
+		if err := ensurePlayerProgressTx(ctx, tx, userID, in.SeasonID); err != nil {
+			return out, err
+		}
+
+		positions := 0
+		for n := 0; n < 3 && len(stocks) > 0; n++ {
+			st := stocks[int(s.nextFloat()*float64(len(stocks)))%len(stocks)]
+			units := int64(1+math.Round(s.nextFloat()*49)) * ShareScale
+			avg := int64(float64(st.price) * (0.8 + 0.4*s.nextFloat()))
+			if avg <= 0 {
+				avg = st.price
+			}
+			tag, err := tx.Exec(ctx, `
+				INSERT INTO game.positions (user_id, season_id, stock_id, quantity_units, avg_price_micros)
+				VALUES ($1, $2, $3, $4, $5)
+				ON CONFLICT (user_id, season_id, stock_id) DO NOTHING
+			`, userID, in.SeasonID, st.id, units, avg)
+			if err != nil {
+				return out, err
+			}
+			positions += int(tag.RowsAffected())
+		}
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO game.friend_follows (follower_user_id, followee_user_id)
+			VALUES ($1, $2), ($2, $1)
+			ON CONFLICT (follower_user_id, followee_user_id) DO NOTHING
+		`, userID, in.TargetUserID); err != nil {
+			return out, err
+		}
+
+		var storedUsername, storedInvite string
+		var storedBalance int64
+		if err := tx.QueryRow(ctx, `
+			SELECT pr.username, pr.invite_code, w.balance_micros
+			FROM users.profiles pr
+			JOIN game.wallets w ON w.user_id = pr.user_id AND w.season_id = $2
+			WHERE pr.user_id = $1
+		`, userID, in.SeasonID).Scan(&storedUsername, &storedInvite, &storedBalance); err != nil {
+			return out, err
+		}
+		bots = append(bots, map[string]any{
+			"user_id":         userID,
+			"username":        storedUsername,
+			"invite_code":     storedInvite,
+			"balance_micros":  storedBalance,
+			"positions_added": positions,
+		})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return out, err
+	}
+	out["ok"] = true
+	out["target_user_id"] = in.TargetUserID
+	out["bots"] = bots
+	return out, nil
+}
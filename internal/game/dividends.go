@@ -0,0 +1,165 @@
+package game
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// DividendEveryTicks is how often stocks not backed by a business pay
+	// their dividend_bps yield on price.
+	DividendEveryTicks = 24
+
+	// DefaultBusinessDividendBps is the share of a business's per-tick net
+	// revenue its stock pays out to holders.
+	DefaultBusinessDividendBps = 1_000
+)
+
+// seedDividendBps is the per-payout yield of a seeded stock: steady names
+// pay more, wild ones nothing. migrations/0052 backfills existing seeded
+// stocks with the same yields, bucketed by VolatilityTierName's thresholds;
+// change both together.
+func seedDividendBps(tier string) int32 {
+	switch tier {
+	case VolatilityCalm:
+		return 40
+	case VolatilityNormal:
+		return 20
+	default:
+		return 0
+	}
+}
+
+// proRataMicros is amountMicros * part / whole, rounded down and saturated.
+func proRataMicros(amountMicros, part, whole int64) int64 {
+	if amountMicros <= 0 || part <= 0 || whole <= 0 {
+		return 0
+	}
+	v := new(big.Int).Mul(big.NewInt(amountMicros), big.NewInt(part))
+	v = v.Div(v, big.NewInt(whole))
+	if !v.IsInt64() {
+		return maxBigintMicros
+	}
+	return v.Int64()
+}
+
+// businessDividendPoolMicros is what a business-backed stock pays its
+// holders for a tick: dividendBps of the business's net revenue, nothing on
+// a losing tick.
+func businessDividendPoolMicros(netMicros int64, dividendBps int32) int64 {
+	return proRataMicros(netMicros, int64(dividendBps), 10_000)
+}
+
+// businessDividendFundingTx is how much of a business's tick net its stock
+// holders will receive from applyDividendsTx this tick. The revenue split
+// takes it out of net first so dividends are paid from the business's
+// revenue rather than minted. Stocks nobody holds reserve nothing.
+func businessDividendFundingTx(ctx context.Context, tx pgx.Tx, businessID, seasonID, netMicros int64) (int64, error) {
+	if netMicros <= 0 {
+		return 0, nil
+	}
+	rows, err := tx.Query(ctx, `
+		SELECT st.dividend_bps
+		FROM game.stocks st
+		WHERE st.business_id = $1 AND st.season_id = $2 AND st.dividend_bps > 0
+		  AND EXISTS (
+		      SELECT 1 FROM game.positions p
+		      WHERE p.stock_id = st.id AND p.quantity_units > 0
+		  )
+	`, businessID, seasonID)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	pool := int64(0)
+	for rows.Next() {
+		var dividendBps int32
+		if err := rows.Scan(&dividendBps); err != nil {
+			return 0, err
+		}
+		pool = saturatingAddInt64(pool, businessDividendPoolMicros(netMicros, dividendBps))
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if pool > netMicros {
+		pool = netMicros
+	}
+	return pool, nil
+}
+
+// applyDividendsTx credits holders of dividend-paying stocks. Business-backed
+// stocks pay every tick from the business's latest net revenue, split by
+// units held; other stocks pay dividend_bps of their price every
+// DividendEveryTicks ticks. Each payout lands in the wallet as a dividend
+// ledger entry and accumulates on the position.
+func applyDividendsTx(ctx context.Context, tx pgx.Tx, seasonID int64) error {
+	var tick int64
+	if err := tx.QueryRow(ctx, `SELECT tick_count + 1 FROM game.seasons WHERE id = $1`, seasonID).Scan(&tick); err != nil {
+		return err
+	}
+	rows, err := tx.Query(ctx, `
+		SELECT p.user_id, p.stock_id, p.quantity_units, st.current_price_micros, st.dividend_bps,
+		       b.id IS NOT NULL AS linked,
+		       COALESCE(b.last_tick_net_micros, 0),
+		       SUM(p.quantity_units) OVER (PARTITION BY p.stock_id)
+		FROM game.positions p
+		JOIN game.stocks st ON st.id = p.stock_id
+		LEFT JOIN game.businesses b ON b.id = st.business_id AND b.season_id = st.season_id
+		WHERE p.season_id = $1 AND p.quantity_units > 0 AND st.dividend_bps > 0
+		ORDER BY p.stock_id, p.user_id
+	`, seasonID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	type payout struct {
+		userID  string
+		stockID int64
+		micros  int64
+	}
+	var payouts []payout
+	for rows.Next() {
+		var (
+			p                  payout
+			qty, price, netRev int64
+			dividendBps        int32
+			linked             bool
+			totalHeld          int64
+		)
+		if err := rows.Scan(&p.userID, &p.stockID, &qty, &price, &dividendBps, &linked, &netRev, &totalHeld); err != nil {
+			return err
+		}
+		switch {
+		case linked:
+			p.micros = proRataMicros(businessDividendPoolMicros(netRev, dividendBps), qty, totalHeld)
+		case tick%DividendEveryTicks == 0:
+			p.micros = proRataMicros(notionalMicrosClamped(price, qty), int64(dividendBps), 10_000)
+		}
+		if p.micros > 0 {
+			payouts = append(payouts, p)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+	for _, p := range payouts {
+		if err := addWalletDeltaTx(ctx, tx, seasonID, p.userID, p.micros); err != nil {
+			return err
+		}
+		if err := appendLedgerEntries(ctx, tx, p.userID, seasonID, "dividend", p.micros, 0); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE game.positions
+			SET dividends_micros = dividends_micros + $4
+			WHERE user_id = $1 AND season_id = $2 AND stock_id = $3
+		`, p.userID, seasonID, p.stockID, p.micros); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package game
+
+import "testing"
+
+func TestBusinessDividendPoolMicros(t *testing.T) {
+	if got := businessDividendPoolMicros(5_000_000, DefaultBusinessDividendBps); got != 500_000 {
+		t.Fatalf("pool = %d, want 500000", got)
+	}
+	if got := businessDividendPoolMicros(-5_000_000, DefaultBusinessDividendBps); got != 0 {
+		t.Fatalf("pool on a loss = %d, want 0", got)
+	}
+}
+
+func TestProRataMicrosSplitsByUnitsHeld(t *testing.T) {
+	pool := int64(1_000_000)
+	a := proRataMicros(pool, 3*ShareScale, 4*ShareScale)
+	b := proRataMicros(pool, 1*ShareScale, 4*ShareScale)
+	if a != 750_000 || b != 250_000 {
+		t.Fatalf("split = %d/%d, want 750000/250000", a, b)
+	}
+	if got := proRataMicros(maxBigintMicros, 2, 1); got != maxBigintMicros {
+		t.Fatalf("overflow = %d, want saturation", got)
+	}
+}
+
+func TestSeedDividendBpsByTier(t *testing.T) {
+	if seedDividendBps(VolatilityCalm) <= seedDividendBps(VolatilityNormal) {
+		t.Fatal("calm stocks should yield more than normal ones")
+	}
+	if seedDividendBps(VolatilityWild) != 0 {
+		t.Fatal("wild stocks should not pay dividends")
+	}
+}
+
+// migrations/0052 backfills seeded yields with these exact numbers.
+func TestSeedDividendBpsMatchesMigration(t *testing.T) {
+	cases := []struct {
+		volatilityBps int32
+		want          int32
+	}{
+		{volatilityBps: 7_999, want: 40},
+		{volatilityBps: 8_000, want: 20},
+		{volatilityBps: 13_000, want: 20},
+		{volatilityBps: 13_001, want: 0},
+	}
+	for _, tc := range cases {
+		if got := seedDividendBps(VolatilityTierName(tc.volatilityBps)); got != tc.want {
+			t.Fatalf("seedDividendBps at %d bps = %d, want %d", tc.volatilityBps, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// idempotencyTx emulates the (user_id, season_id, key) primary key of
+// game.idempotency_keys for claimIdempotency.
+type idempotencyTx struct {
+	pgx.Tx
+	claimed map[string]bool
+}
+
+func (tx *idempotencyTx) Exec(_ context.Context, _ string, args ...any) (pgconn.CommandTag, error) {
+	k := fmt.Sprint(args[0], "|", args[1], "|", args[2])
+	if tx.claimed[k] {
+		return pgconn.NewCommandTag("INSERT 0 0"), nil
+	}
+	tx.claimed[k] = true
+	return pgconn.NewCommandTag("INSERT 0 1"), nil
+}
+
+func TestClaimIdempotencyIsSeasonScoped(t *testing.T) {
+	ctx := context.Background()
+	tx := &idempotencyTx{claimed: map[string]bool{}}
+
+	if err := claimIdempotency(ctx, tx, "u1", 1, "key-1", "order"); err != nil {
+		t.Fatalf("first claim error = %v", err)
+	}
+	if err := claimIdempotency(ctx, tx, "u1", 1, "key-1", "order"); !errors.Is(err, ErrDuplicateIdempotency) {
+		t.Fatalf("repeat claim in same season error = %v, want ErrDuplicateIdempotency", err)
+	}
+	if err := claimIdempotency(ctx, tx, "u1", 2, "key-1", "order"); err != nil {
+		t.Fatalf("same key in a new season error = %v", err)
+	}
+	if err := claimIdempotency(ctx, tx, "u1", 2, "  ", "order"); err == nil {
+		t.Fatalf("expected blank key to be rejected")
+	}
+}
@@ -0,0 +1,198 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	MinLiquidationPriority = -100
+	MaxLiquidationPriority = 100
+)
+
+// SetLiquidationPriority stores how early a holding is sold when positions
+// are force-sold. Higher priorities go first; negative values protect it.
+func (s *Service) SetLiquidationPriority(ctx context.Context, userID string, seasonID int64, symbol string, priority int32, idem string) error {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if err := ValidateSymbol(symbol); err != nil {
+		return err
+	}
+	if priority < MinLiquidationPriority || priority > MaxLiquidationPriority {
+		return fmt.Errorf("priority must be between %d and %d", MinLiquidationPriority, MaxLiquidationPriority)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, userID, seasonID, idem, "liquidation_priority"); err != nil {
+		return err
+	}
+	var stockID int64
+	if err := tx.QueryRow(ctx, `
+		SELECT id FROM game.stocks WHERE season_id = $1 AND symbol = $2
+	`, seasonID, symbol).Scan(&stockID); err != nil {
+		if err == pgx.ErrNoRows {
+			return ErrStockNotFound
+		}
+		return err
+	}
+	tag, err := tx.Exec(ctx, `
+		UPDATE game.positions
+		SET liquidation_priority = $4, updated_at = now()
+		WHERE user_id = $1 AND season_id = $2 AND stock_id = $3
+	`, userID, seasonID, stockID, priority)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: no %s position", ErrInsufficientShares, symbol)
+	}
+	return tx.Commit(ctx)
+}
+
+// LiquidationOrder lists the player's long positions in the order a forced
+// sale would sell them. Shorts are never force-sold here.
+func (s *Service) LiquidationOrder(ctx context.Context, userID string, seasonID int64) ([]LiquidationEntry, error) {
+	rows, err := s.reader().Query(ctx, `
+		SELECT st.symbol, p.quantity_units, st.current_price_micros, p.liquidation_priority
+		FROM game.positions p
+		JOIN game.stocks st ON st.id = p.stock_id
+		WHERE p.user_id = $1 AND p.season_id = $2 AND p.quantity_units > 0
+	`, userID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]LiquidationEntry, 0)
+	for rows.Next() {
+		var e LiquidationEntry
+		if err := rows.Scan(&e.Symbol, &e.QuantityUnits, &e.PriceMicros, &e.Priority); err != nil {
+			return nil, err
+		}
+		e.ValueMicros = notionalMicrosClamped(e.PriceMicros, e.QuantityUnits)
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	orderForLiquidation(out)
+	return out, nil
+}
+
+// orderForLiquidation sorts positions into forced-sale order: highest
+// priority first, then largest market value, then symbol for stability.
+func orderForLiquidation(entries []LiquidationEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if a.ValueMicros != b.ValueMicros {
+			return a.ValueMicros > b.ValueMicros
+		}
+		return a.Symbol < b.Symbol
+	})
+}
+
+// forcedSaleUnits is the smallest slice of a held position whose proceeds
+// cover shortfallMicros, or the whole position when even that falls short.
+func forcedSaleUnits(shortfallMicros, heldUnits int64, proceeds func(qtyUnits int64) int64) int64 {
+	if heldUnits <= 0 || proceeds(heldUnits) <= shortfallMicros {
+		return heldUnits
+	}
+	lo, hi := int64(1), heldUnits
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if proceeds(mid) >= shortfallMicros {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// applyForcedSalesTx sells long positions of players who end the tick with a
+// negative balance, in their liquidation order, until the debt is covered or
+// nothing is left to sell. Whatever debt remains is cleared by
+// clampNegativeBalancesTx.
+func applyForcedSalesTx(ctx context.Context, tx pgx.Tx, seasonID int64, settings seasonSettings) error {
+	rows, err := tx.Query(ctx, `
+		SELECT w.user_id, w.balance_micros, p.stock_id, st.symbol, p.quantity_units,
+		       st.current_price_micros, p.liquidation_priority
+		FROM game.wallets w
+		JOIN game.positions p ON p.user_id = w.user_id AND p.season_id = w.season_id
+		JOIN game.stocks st ON st.id = p.stock_id
+		WHERE w.season_id = $1 AND w.balance_micros < 0
+		  AND p.quantity_units > 0 AND st.current_price_micros > 0
+		ORDER BY w.user_id
+		FOR UPDATE OF w, p
+	`, seasonID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	type debtor struct {
+		userID    string
+		shortfall int64
+		holdings  []LiquidationEntry
+	}
+	var debtors []*debtor
+	for rows.Next() {
+		var (
+			userID  string
+			balance int64
+			e       LiquidationEntry
+		)
+		if err := rows.Scan(&userID, &balance, &e.stockID, &e.Symbol, &e.QuantityUnits, &e.PriceMicros, &e.Priority); err != nil {
+			return err
+		}
+		e.ValueMicros = notionalMicrosClamped(e.PriceMicros, e.QuantityUnits)
+		if len(debtors) == 0 || debtors[len(debtors)-1].userID != userID {
+			debtors = append(debtors, &debtor{userID: userID, shortfall: -balance})
+		}
+		d := debtors[len(debtors)-1]
+		d.holdings = append(d.holdings, e)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, d := range debtors {
+		orderForLiquidation(d.holdings)
+		for _, h := range d.holdings {
+			if d.shortfall <= 0 {
+				break
+			}
+			proceeds := func(qty int64) int64 {
+				notional := notionalMicrosClamped(h.PriceMicros, qty)
+				return notional - settings.tradeFee(tradeFeeMicros(notional))
+			}
+			qty := forcedSaleUnits(d.shortfall, h.QuantityUnits, proceeds)
+			notional := notionalMicrosClamped(h.PriceMicros, qty)
+			fee := settings.tradeFee(tradeFeeMicros(notional))
+			realized, err := applySellPosition(ctx, tx, d.userID, seasonID, h.stockID, qty, notional, fee)
+			if err != nil {
+				return err
+			}
+			if err := addWalletDeltaTx(ctx, tx, seasonID, d.userID, notional-fee); err != nil {
+				return err
+			}
+			if err := appendLedgerEntries(ctx, tx, d.userID, seasonID, "forced_sale", notional, fee); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(ctx, `
+				INSERT INTO game.orders (user_id, season_id, stock_id, side, quantity_units, price_micros, fee_micros, realized_pl_micros)
+				VALUES ($1, $2, $3, 'sell', $4, $5, $6, $7)
+			`, d.userID, seasonID, h.stockID, qty, h.PriceMicros, fee, realized); err != nil {
+				return err
+			}
+			d.shortfall -= notional - fee
+		}
+	}
+	return nil
+}
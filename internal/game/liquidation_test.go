@@ -0,0 +1,33 @@
+package game
+
+import "testing"
+
+func TestOrderForLiquidation(t *testing.T) {
+	entries := []LiquidationEntry{
+		{Symbol: "COBOLT", ValueMicros: 9_000, Priority: -50},
+		{Symbol: "NIMBUS", ValueMicros: 2_000},
+		{Symbol: "VECTRA", ValueMicros: 1_000, Priority: 10},
+		{Symbol: "RUSTIC", ValueMicros: 5_000},
+		{Symbol: "PYLONS", ValueMicros: 5_000},
+	}
+	orderForLiquidation(entries)
+	want := []string{"VECTRA", "PYLONS", "RUSTIC", "NIMBUS", "COBOLT"}
+	for i, sym := range want {
+		if entries[i].Symbol != sym {
+			t.Fatalf("position %d = %s, want %s (order %v)", i, entries[i].Symbol, sym, entries)
+		}
+	}
+}
+
+func TestForcedSaleUnitsCoversShortfall(t *testing.T) {
+	proceeds := func(qty int64) int64 { return qty * 100 }
+	if got := forcedSaleUnits(250, 10, proceeds); got != 3 {
+		t.Fatalf("units for 250 = %d, want 3", got)
+	}
+	if got := forcedSaleUnits(300, 10, proceeds); got != 3 {
+		t.Fatalf("units for 300 = %d, want 3", got)
+	}
+	if got := forcedSaleUnits(5_000, 10, proceeds); got != 10 {
+		t.Fatalf("units for oversized shortfall = %d, want whole position", got)
+	}
+}
@@ -17,9 +17,22 @@ const (
 
 	MinDebtLimitMicros = int64(5_000) * MicrosPerStonky
 	MaxDebtLimitMicros = int64(100_000) * MicrosPerStonky
+	DebtLimitPeakBps   = int32(3_500)
 
 	ShareScale = int64(10_000) // 1 share = 10_000 units.
 
+	// FundUnitScale is ShareScale for funds: fund holdings are fractional
+	// down to 0.0001 of a unit, NAV is quoted per whole unit, and one whole
+	// unit is stored as FundUnitScale.
+	FundUnitScale = ShareScale
+
+	TradeFeeBps = int64(15) // charged on both buys and sells.
+	FundFeeBps  = int64(10) // charged on fund buys and sells.
+
+	// ScarcityPremiumMaxBps is the buy premium over the last price once a
+	// fixed-supply stock is fully held.
+	ScarcityPremiumMaxBps = int64(2_000)
+
 	BaseBusinessEmployeeLimit = int64(60_000)
 	SeatUpgradeIncrement      = int64(10_000)
 	MaxBusinessEmployees      = int64(250_000)
@@ -28,22 +41,82 @@ const (
 )
 
 var (
-	ErrInvalidSymbol        = errors.New("symbol must be exactly 6 uppercase letters")
+	ErrInvalidSymbol        = errors.New("invalid symbol")
 	ErrStockNotFound        = errors.New("stock not found")
+	ErrPlayerNotFound       = errors.New("player not found")
+	ErrPositionNotFound     = errors.New("no position held")
+	ErrLoanNotFound         = errors.New("business loan not found")
+	ErrFundNotFound         = errors.New("unknown fund code")
+	ErrWalletNotFound       = errors.New("no wallet for this season: log in again to join it")
+	ErrStockNotListed       = errors.New("stock is not listed publicly: it can only be traded after its business IPOs")
+	ErrSymbolTaken          = errors.New("symbol already taken this season")
+	ErrNameTaken            = errors.New("business name already taken this season")
+	ErrOutsideShareholders  = errors.New("other players still hold this stock")
+	ErrSupplyExhausted      = errors.New("not enough shares outstanding")
 	ErrDuplicateIdempotency = errors.New("duplicate idempotency key")
 	ErrInsufficientFunds    = errors.New("not enough balance")
 	ErrInsufficientShares   = errors.New("insufficient shares")
 	ErrBusinessLocked       = errors.New("business feature locked: net worth below requirement")
 	ErrUnauthorized         = errors.New("unauthorized")
 	ErrEmployeeLimitReached = errors.New("employee limit reached")
+	ErrMachineryLimit       = errors.New("machinery level cap reached")
+	ErrFriendLimit          = errors.New("friend limit reached")
+	ErrNotFollowing         = errors.New("follow this player to see their rank history")
+	ErrInvalidFundUnits     = errors.New("fund units must be > 0 (stored in 0.0001-unit steps)")
+	ErrInvalidAlert         = errors.New("alert needs a direction of above or below and a positive target price")
+	ErrBusinessNotEmpty     = errors.New("business is not empty")
+	ErrMarketClosed         = errors.New("market is closed")
+	ErrMarketHalted         = errors.New("trading halted by the market circuit breaker")
+	ErrInviteRequired       = errors.New("a valid invite code from an existing player is required")
 	ErrTxConflict           = errors.New("transaction conflict: please retry")
 )
 
-var symbolRE = regexp.MustCompile(`^[A-Z]{6}$`)
+// MaxSymbolLength bounds configurable ticker symbols to the stock symbol
+// column width.
+const MaxSymbolLength = 10
+
+// SeededSymbolLength is the length of the letter-only symbols given to
+// seeded and generated stocks. Every configured format must accept it or
+// those stocks could no longer be traded.
+const SeededSymbolLength = 6
+
+var (
+	symbolRE = regexp.MustCompile(`^[A-Z]{6}$`)
+	// symbolRule describes symbolRE in ValidateSymbol's errors.
+	symbolRule = "symbol must be exactly 6 uppercase letters"
+)
 
+// ConfigureSymbolFormat rebuilds the ticker rule used by ValidateSymbol.
+// Symbols always start with a letter; allowDigits permits 0-9 after it.
+// The range must include SeededSymbolLength. Call it once at startup,
+// before any symbol is validated.
+func ConfigureSymbolFormat(minLen, maxLen int, allowDigits bool) error {
+	if minLen < 1 || maxLen < minLen || maxLen > MaxSymbolLength {
+		return fmt.Errorf("symbol length must satisfy 1 <= min <= max <= %d (got %d..%d)", MaxSymbolLength, minLen, maxLen)
+	}
+	if minLen > SeededSymbolLength || maxLen < SeededSymbolLength {
+		return fmt.Errorf("symbol length range %d..%d must include %d so seeded stocks stay tradeable", minLen, maxLen, SeededSymbolLength)
+	}
+	rest := "[A-Z]"
+	kind := "uppercase letters"
+	if allowDigits {
+		rest = "[A-Z0-9]"
+		kind = "uppercase letters or digits, starting with a letter"
+	}
+	symbolRE = regexp.MustCompile(fmt.Sprintf(`^[A-Z]%s{%d,%d}$`, rest, minLen-1, maxLen-1))
+	if minLen == maxLen {
+		symbolRule = fmt.Sprintf("symbol must be exactly %d %s", minLen, kind)
+	} else {
+		symbolRule = fmt.Sprintf("symbol must be %d-%d %s", minLen, maxLen, kind)
+	}
+	return nil
+}
+
+// ValidateSymbol checks symbol against the configured ticker rule. Failures
+// wrap ErrInvalidSymbol and spell out the rule.
 func ValidateSymbol(symbol string) error {
 	if !symbolRE.MatchString(strings.TrimSpace(symbol)) {
-		return ErrInvalidSymbol
+		return fmt.Errorf("%w: %s", ErrInvalidSymbol, symbolRule)
 	}
 	return nil
 }
@@ -56,28 +129,94 @@ func MicrosToStonky(v int64) float64 {
 	return float64(v) / float64(MicrosPerStonky)
 }
 
+// SharesToUnits rounds a typed share amount half-away-from-zero to the
+// nearest unit (0.0001 share). Amounts that round to zero units are rejected.
 func SharesToUnits(v float64) (int64, error) {
 	if v <= 0 {
 		return 0, fmt.Errorf("shares must be > 0")
 	}
-	return int64(math.Round(v * float64(ShareScale))), nil
+	units := int64(math.Round(v * float64(ShareScale)))
+	if units <= 0 {
+		return 0, fmt.Errorf("shares must be at least %.4f", UnitsToShares(1))
+	}
+	return units, nil
+}
+
+// SharesRounded reports whether converting v to units changed the amount.
+func SharesRounded(v float64, units int64) bool {
+	return math.Abs(v*float64(ShareScale)-float64(units)) > 1e-6
+}
+
+// FundAmountToUnits converts a typed fund amount to stored units with the
+// same rounding as SharesToUnits.
+func FundAmountToUnits(v float64) (int64, error) {
+	if v <= 0 {
+		return 0, fmt.Errorf("%w: got %g", ErrInvalidFundUnits, v)
+	}
+	units := int64(math.Round(v * float64(FundUnitScale)))
+	if units <= 0 {
+		return 0, fmt.Errorf("%w: %g is below the smallest amount, %.4f", ErrInvalidFundUnits, v, 1/float64(FundUnitScale))
+	}
+	return units, nil
 }
 
 func UnitsToShares(v int64) float64 {
 	return float64(v) / float64(ShareScale)
 }
 
+func tradeFeeMicros(notionalMicros int64) int64 {
+	return int64(math.Round(float64(notionalMicros) * float64(TradeFeeBps) / 10_000))
+}
+
+func fundFeeMicros(notionalMicros int64) int64 {
+	return int64(math.Round(float64(notionalMicros) * float64(FundFeeBps) / 10_000))
+}
+
 func DebtLimitFromPeak(peakNetWorthMicros int64) int64 {
-	limit := int64(math.Round(float64(peakNetWorthMicros) * 0.35))
-	if limit < MinDebtLimitMicros {
-		return MinDebtLimitMicros
+	return debtLimitFromPeak(peakNetWorthMicros, MinDebtLimitMicros, MaxDebtLimitMicros, DebtLimitPeakBps)
+}
+
+// debtLimitFromPeak takes peakBps of peak net worth, clamped to
+// [minMicros, maxMicros].
+func debtLimitFromPeak(peakNetWorthMicros, minMicros, maxMicros int64, peakBps int32) int64 {
+	limit := int64(math.Round(float64(peakNetWorthMicros) * float64(peakBps) / 10000.0))
+	if limit < minMicros {
+		return minMicros
 	}
-	if limit > MaxDebtLimitMicros {
-		return MaxDebtLimitMicros
+	if limit > maxMicros {
+		return maxMicros
 	}
 	return limit
 }
 
+// BusinessUpgradeCostMicros is the price of the next marketing, rd,
+// automation, or compliance level when the business is at level.
+func BusinessUpgradeCostMicros(level int32) int64 {
+	return int64(math.Round(float64((900+int(level)*350)*int(MicrosPerStonky)) * (1 + float64(level)*0.12)))
+}
+
+// SeatUpgradeCostMicros is the price of the next seat upgrade after level
+// seat upgrades have been bought.
+func SeatUpgradeCostMicros(level int) int64 {
+	return int64(math.Round(float64((1_800+level*700)*int(MicrosPerStonky)) * (1 + float64(level)*0.18)))
+}
+
+// leaderboardTopBps turns "ahead of me" and season size into a "top N%"
+// figure in bps, rounded up so the leader of 1000 players is top 0.10%.
+func leaderboardTopBps(ahead, players int64) int64 {
+	if players <= 0 {
+		return 0
+	}
+	if ahead < 0 {
+		ahead = 0
+	}
+	top := ((ahead+1)*10_000 + players - 1) / players
+	if top > 10_000 {
+		return 10_000
+	}
+	return top
+}
+
 func hasPositiveBalanceAfterSpend(balanceMicros, spendMicros int64) bool {
 	if spendMicros <= 0 {
 		return true
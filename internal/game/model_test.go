@@ -1,6 +1,14 @@
 package game
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
 
 func TestValidateSymbol(t *testing.T) {
 	valid := []string{"ABCDEF", "NIMBUS", "COBOLT"}
@@ -18,6 +26,37 @@ func TestValidateSymbol(t *testing.T) {
 	}
 }
 
+func TestConfigureSymbolFormat(t *testing.T) {
+	defer func() {
+		if err := ConfigureSymbolFormat(6, 6, false); err != nil {
+			t.Fatalf("restore default format: %v", err)
+		}
+	}()
+
+	if err := ConfigureSymbolFormat(3, 7, true); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	for _, s := range []string{"ABC", "AB1", "X2Y3Z", "COBOLT"} {
+		if err := ValidateSymbol(s); err != nil {
+			t.Fatalf("expected symbol %q to be valid: %v", s, err)
+		}
+	}
+	for _, s := range []string{"AB", "1ABC", "ABCDEFGH", "ab1"} {
+		if err := ValidateSymbol(s); !errors.Is(err, ErrInvalidSymbol) {
+			t.Fatalf("ValidateSymbol(%q) = %v, want ErrInvalidSymbol", s, err)
+		}
+	}
+	if err := ValidateSymbol("AB"); !strings.Contains(err.Error(), "3-7 uppercase letters or digits") {
+		t.Fatalf("error %q should describe the configured rule", err)
+	}
+
+	for _, bad := range [][2]int{{0, 6}, {5, 4}, {1, MaxSymbolLength + 1}, {3, 5}, {7, 8}} {
+		if err := ConfigureSymbolFormat(bad[0], bad[1], false); err == nil {
+			t.Fatalf("expected %d..%d to be rejected", bad[0], bad[1])
+		}
+	}
+}
+
 func TestDebtLimitFromPeak(t *testing.T) {
 	tests := []struct {
 		peak int64
@@ -35,6 +74,21 @@ func TestDebtLimitFromPeak(t *testing.T) {
 	}
 }
 
+func TestUpgradeCostCurves(t *testing.T) {
+	if got := BusinessUpgradeCostMicros(0); got != 900*MicrosPerStonky {
+		t.Fatalf("level 0 upgrade = %d, want %d", got, 900*MicrosPerStonky)
+	}
+	if got, want := BusinessUpgradeCostMicros(2), int64(1_984)*MicrosPerStonky; got != want {
+		t.Fatalf("level 2 upgrade = %d, want %d", got, want)
+	}
+	if got := SeatUpgradeCostMicros(0); got != 1_800*MicrosPerStonky {
+		t.Fatalf("first seat upgrade = %d, want %d", got, 1_800*MicrosPerStonky)
+	}
+	if SeatUpgradeCostMicros(3) <= SeatUpgradeCostMicros(2) {
+		t.Fatalf("seat upgrade cost should grow with level")
+	}
+}
+
 func TestNotionalMicros(t *testing.T) {
 	price := int64(150 * MicrosPerStonky)
 	qty := int64(25 * ShareScale / 10) // 2.5 shares
@@ -55,6 +109,36 @@ func TestNotionalMicrosClamped(t *testing.T) {
 	}
 }
 
+func TestScarcityPriceMicros(t *testing.T) {
+	if got, err := scarcityPriceMicros(1_000_000, 5*ShareScale, ShareScale, 0); err != nil || got != 1_000_000 {
+		t.Fatalf("unlimited supply = %d, %v; want the last price", got, err)
+	}
+	// Half the supply held after the fill: 20% * 0.25 = 5% premium.
+	got, err := scarcityPriceMicros(1_000_000, 4*ShareScale, ShareScale, 10*ShareScale)
+	if err != nil || got != 1_050_000 {
+		t.Fatalf("half held = %d, %v; want 1050000", got, err)
+	}
+	got, err = scarcityPriceMicros(1_000_000, 9*ShareScale, ShareScale, 10*ShareScale)
+	if err != nil || got != 1_200_000 {
+		t.Fatalf("fully held = %d, %v; want 1200000", got, err)
+	}
+	if _, err := scarcityPriceMicros(1_000_000, 9*ShareScale, 2*ShareScale, 10*ShareScale); !errors.Is(err, ErrSupplyExhausted) {
+		t.Fatalf("err = %v, want ErrSupplyExhausted", err)
+	}
+}
+
+func TestBusinessSaleBasisValue(t *testing.T) {
+	basis := businessSaleBasis{OperatingMicros: 1_000_000, Multiple: 14, LoanOutstandingMicros: 5_000_000}
+	gross, payout := basis.value(1)
+	if gross != 14_000_000 || payout != 9_000_000 {
+		t.Fatalf("value(1) = %d, %d; want 14000000, 9000000", gross, payout)
+	}
+	basis.LoanOutstandingMicros = 20_000_000
+	if _, payout := basis.value(1); payout != 0 {
+		t.Fatalf("underwater payout = %d, want 0", payout)
+	}
+}
+
 func TestValidateEntityName(t *testing.T) {
 	if err := validateEntityName("Acme Labs"); err != nil {
 		t.Fatalf("expected valid entity name: %v", err)
@@ -131,3 +215,178 @@ func TestEmployeeCostHelpersPositive(t *testing.T) {
 		t.Fatalf("maintenance should be positive")
 	}
 }
+
+func TestSharesToUnitsRoundingPolicy(t *testing.T) {
+	units, err := SharesToUnits(1.23455)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if units != 12346 {
+		t.Fatalf("units = %d, want 12346", units)
+	}
+	if !SharesRounded(1.23455, units) {
+		t.Fatalf("expected 1.23455 to be reported as rounded")
+	}
+	if SharesRounded(2.5, 25_000) {
+		t.Fatalf("expected 2.5 shares to convert exactly")
+	}
+	if _, err := SharesToUnits(0.00001); err == nil {
+		t.Fatalf("expected amount rounding to zero units to fail")
+	}
+}
+
+func TestFundAmountToUnitsMatchesShareScale(t *testing.T) {
+	for _, v := range []float64{1, 2.5, 1.23455, 0.0001} {
+		funds, err := FundAmountToUnits(v)
+		if err != nil {
+			t.Fatalf("FundAmountToUnits(%g): %v", v, err)
+		}
+		shares, _ := SharesToUnits(v)
+		if funds != shares {
+			t.Fatalf("FundAmountToUnits(%g) = %d, SharesToUnits = %d", v, funds, shares)
+		}
+	}
+	for _, v := range []float64{0, -1, 0.00001} {
+		if _, err := FundAmountToUnits(v); !errors.Is(err, ErrInvalidFundUnits) {
+			t.Fatalf("FundAmountToUnits(%g) err = %v, want ErrInvalidFundUnits", v, err)
+		}
+	}
+}
+
+func TestAutoRepayAmountHonorsBuffer(t *testing.T) {
+	tests := []struct {
+		balance, buffer, outstanding, want int64
+	}{
+		{balance: 1_000, buffer: 200, outstanding: 5_000, want: 800},
+		{balance: 1_000, buffer: 200, outstanding: 300, want: 300},
+		{balance: 150, buffer: 200, outstanding: 300, want: 0},
+		{balance: -50, buffer: 0, outstanding: 300, want: 0},
+	}
+	for _, tc := range tests {
+		if got := autoRepayAmount(tc.balance, tc.buffer, tc.outstanding); got != tc.want {
+			t.Fatalf("autoRepayAmount(%d, %d, %d) = %d, want %d", tc.balance, tc.buffer, tc.outstanding, got, tc.want)
+		}
+	}
+}
+
+func TestLeaderboardTopBps(t *testing.T) {
+	tests := []struct {
+		ahead, players, want int64
+	}{
+		{ahead: 0, players: 0, want: 0},
+		{ahead: 0, players: 1, want: 10_000},
+		{ahead: 0, players: 1000, want: 10},
+		{ahead: 49, players: 1000, want: 500},
+		{ahead: 2346, players: 50_000, want: 470},
+		{ahead: 999, players: 1000, want: 10_000},
+	}
+	for _, tc := range tests {
+		if got := leaderboardTopBps(tc.ahead, tc.players); got != tc.want {
+			t.Fatalf("leaderboardTopBps(%d, %d) = %d, want %d", tc.ahead, tc.players, got, tc.want)
+		}
+	}
+}
+
+func TestVolatilityTiers(t *testing.T) {
+	for _, tier := range volatilityTiers {
+		bps, ok := VolatilityTierBps(tier)
+		if !ok {
+			t.Fatalf("VolatilityTierBps(%q) not recognised", tier)
+		}
+		if got := VolatilityTierName(bps); got != tier {
+			t.Fatalf("VolatilityTierName(%d) = %q, want %q", bps, got, tier)
+		}
+	}
+	if _, ok := VolatilityTierBps("extreme"); ok {
+		t.Fatalf("expected unknown tier to be rejected")
+	}
+
+	base := volatilityParams("normal")
+	if got := base.forStock(10_000); got != base {
+		t.Fatalf("normal multiplier should leave params unchanged")
+	}
+	calm := base.forStock(6_000)
+	wild := base.forStock(16_000)
+	if !(calm.NoiseScale < base.NoiseScale && base.NoiseScale < wild.NoiseScale) {
+		t.Fatalf("noise scale not ordered: calm=%f base=%f wild=%f", calm.NoiseScale, base.NoiseScale, wild.NoiseScale)
+	}
+	if !(calm.ShockProb < base.ShockProb && base.ShockProb < wild.ShockProb) {
+		t.Fatalf("shock prob not ordered: calm=%f base=%f wild=%f", calm.ShockProb, base.ShockProb, wild.ShockProb)
+	}
+	if wild.MaxDropPerTick != base.MaxDropPerTick || wild.MeanReversion != base.MeanReversion {
+		t.Fatalf("multiplier should not change drop cap or mean reversion")
+	}
+}
+
+func TestDailyBonusDue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 0, 30, 0, 0, time.UTC)
+	if !dailyBonusDue(nil, now) {
+		t.Fatalf("first claim should be due")
+	}
+	sameDay := time.Date(2026, 3, 10, 0, 0, 1, 0, time.UTC)
+	if dailyBonusDue(&sameDay, now) {
+		t.Fatalf("claim on the same UTC day should not be due")
+	}
+	yesterday := time.Date(2026, 3, 9, 23, 59, 59, 0, time.UTC)
+	if !dailyBonusDue(&yesterday, now) {
+		t.Fatalf("claim after UTC midnight should be due")
+	}
+	if got := nextDailyBonusAt(now); !got.Equal(time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("nextDailyBonusAt = %v", got)
+	}
+}
+
+func TestBetaFromSeries(t *testing.T) {
+	// Stock 1 moves twice as much as stock 2 each tick; the market is their
+	// average, so holding only stock 1 should give beta > 1.
+	s1 := []int64{1000, 1040, 1000, 1080, 1040, 1120, 1080}
+	s2 := []int64{1000, 1020, 1000, 1040, 1020, 1060, 1040}
+	prices := map[int64][]int64{1: s1, 2: s2}
+
+	beta, ok := betaFromSeries(prices, map[int64]float64{1: 500}, len(s1))
+	if !ok || beta <= 1 {
+		t.Fatalf("aggressive holding beta = %f ok=%v, want > 1", beta, ok)
+	}
+	beta, ok = betaFromSeries(prices, map[int64]float64{2: 500}, len(s1))
+	if !ok || beta >= 1 || beta <= 0 {
+		t.Fatalf("defensive holding beta = %f ok=%v, want between 0 and 1", beta, ok)
+	}
+	beta, ok = betaFromSeries(prices, map[int64]float64{1: 100, 2: 100}, len(s1))
+	if !ok || beta < 0.999 || beta > 1.001 {
+		t.Fatalf("market-weighted holding beta = %f, want 1", beta)
+	}
+
+	if _, ok := betaFromSeries(prices, map[int64]float64{1: 1}, 3); ok {
+		t.Fatalf("expected too few samples to be rejected")
+	}
+	if _, ok := betaFromSeries(prices, map[int64]float64{99: 1}, len(s1)); ok {
+		t.Fatalf("expected unrelated holdings to be rejected")
+	}
+}
+
+func TestGenerateInviteCode(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		code, err := generateInviteCode()
+		if err != nil {
+			t.Fatalf("generateInviteCode: %v", err)
+		}
+		if len(code) != inviteCodeLength || strings.ContainsAny(code, "01IO") {
+			t.Fatalf("code %q: want %d characters without 0, 1, I, or O", code, inviteCodeLength)
+		}
+		if seen[code] {
+			t.Fatalf("duplicate code %q", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestIsInviteCodeConflict(t *testing.T) {
+	err := fmt.Errorf("insert profile: %w", &pgconn.PgError{Code: "23505", ConstraintName: "profiles_invite_code_key"})
+	if !isInviteCodeConflict(err) {
+		t.Fatal("expected an invite code conflict")
+	}
+	if isInviteCodeConflict(&pgconn.PgError{Code: "23505", ConstraintName: "profiles_username_key"}) {
+		t.Fatal("a username conflict is not an invite code conflict")
+	}
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	DefaultNewsLimit = 30
+	MaxNewsLimit     = 200
+	// newsRetention is how many of the latest entries a season keeps.
+	newsRetention = 1_000
+	// newsMoveBps is the one-tick move that puts a listed stock in the news.
+	newsMoveBps = 1_500
+)
+
+// News entry types.
+const (
+	NewsRegime    = "regime"
+	NewsStockMove = "stock_move"
+	NewsIPO       = "ipo"
+	NewsViral     = "viral"
+	NewsCrisis    = "crisis"
+	NewsDefault   = "default"
+	NewsLeader    = "leader"
+)
+
+// News lists the season's latest news entries, newest first.
+func (s *Service) News(ctx context.Context, seasonID int64, limit int) ([]NewsItem, error) {
+	if limit <= 0 {
+		limit = DefaultNewsLimit
+	}
+	if limit > MaxNewsLimit {
+		limit = MaxNewsLimit
+	}
+	rows, err := s.reader().Query(ctx, `
+		SELECT id, type, summary, created_at
+		FROM game.news
+		WHERE season_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`, seasonID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]NewsItem, 0, limit)
+	for rows.Next() {
+		var n NewsItem
+		if err := rows.Scan(&n.ID, &n.Type, &n.Summary, &n.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+	return out, rows.Err()
+}
+
+func recordNewsTx(ctx context.Context, tx pgx.Tx, seasonID int64, newsType, summary string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO game.news (season_id, type, summary)
+		VALUES ($1, $2, $3)
+	`, seasonID, newsType, summary)
+	return err
+}
+
+func trimNewsTx(ctx context.Context, tx pgx.Tx, seasonID int64) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM game.news
+		WHERE season_id = $1
+		  AND id < (
+		      SELECT MIN(id) FROM (
+		          SELECT id FROM game.news
+		          WHERE season_id = $1
+		          ORDER BY id DESC
+		          LIMIT $2
+		      ) kept
+		  )
+	`, seasonID, newsRetention)
+	return err
+}
+
+// stockMoveNews describes a listed stock's tick move, and reports false when
+// the move is too small to be news.
+func stockMoveNews(symbol string, m priceMove) (string, bool) {
+	if m.from <= 0 {
+		return "", false
+	}
+	// Float math, as in indexChangeBps: the move times 10,000 can overflow
+	// int64 near the price cap.
+	change := float64(m.to-m.from) / float64(m.from)
+	if math.Abs(change)*10_000 < newsMoveBps {
+		return "", false
+	}
+	verb := "jumps"
+	if change < 0 {
+		verb = "plunges"
+	}
+	return fmt.Sprintf("%s %s %.2f%% to %.2f stonky", symbol, verb, math.Abs(change)*100, MicrosToStonky(m.to)), true
+}
+
+func regimeNews(previous, regime string) string {
+	return fmt.Sprintf("Market turns %s after a %s stretch", regime, previous)
+}
+
+func ipoNews(symbol, displayName string, priceMicros int64) string {
+	return fmt.Sprintf("%s (%s) lists publicly at %.2f stonky", symbol, displayName, MicrosToStonky(priceMicros))
+}
+
+// businessNewsName hides the name of a private business.
+func businessNewsName(name, visibility string) string {
+	if visibility == "public" && name != "" {
+		return name
+	}
+	return "A private business"
+}
+
+// recordLeaderChangeNewsTx reports a new leaderboard leader, comparing the
+// snapshot just taken for the running tick with the previous tick's.
+func recordLeaderChangeNewsTx(ctx context.Context, tx pgx.Tx, seasonID int64) error {
+	var leader, previous, username string
+	err := tx.QueryRow(ctx, `
+		SELECT cur.user_id, COALESCE(prev.user_id, ''), COALESCE(pr.username, '')
+		FROM game.seasons s
+		JOIN game.leaderboard_snapshots cur
+		  ON cur.season_id = s.id AND cur.tick = s.tick_count + 1 AND cur.rank = 1
+		LEFT JOIN game.leaderboard_snapshots prev
+		  ON prev.season_id = s.id AND prev.tick = s.tick_count AND prev.rank = 1
+		LEFT JOIN users.profiles pr ON pr.user_id = cur.user_id
+		WHERE s.id = $1
+	`, seasonID).Scan(&leader, &previous, &username)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if previous == "" || previous == leader {
+		return nil
+	}
+	if username == "" {
+		username = "A new player"
+	}
+	return recordNewsTx(ctx, tx, seasonID, NewsLeader, username+" takes the lead on the global leaderboard")
+}
@@ -0,0 +1,40 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStockMoveNews(t *testing.T) {
+	cases := []struct {
+		move priceMove
+		want string
+		ok   bool
+	}{
+		{priceMove{10 * MicrosPerStonky, 11 * MicrosPerStonky}, "", false},
+		{priceMove{10 * MicrosPerStonky, 8_600_000}, "", false},
+		{priceMove{10 * MicrosPerStonky, 11_500_000}, "COBOLT jumps 15.00% to 11.50 stonky", true},
+		{priceMove{8 * MicrosPerStonky, 6 * MicrosPerStonky}, "COBOLT plunges 25.00% to 6.00 stonky", true},
+		{priceMove{0, MicrosPerStonky}, "", false},
+	}
+	for _, c := range cases {
+		got, ok := stockMoveNews("COBOLT", c.move)
+		if got != c.want || ok != c.ok {
+			t.Fatalf("stockMoveNews(%v) = %q, %v; want %q, %v", c.move, got, ok, c.want, c.ok)
+		}
+	}
+	// A move near the price cap would overflow int64 basis points.
+	got, ok := stockMoveNews("COBOLT", priceMove{MicrosPerStonky, 1_000_000_000_000 * MicrosPerStonky})
+	if !ok || !strings.HasPrefix(got, "COBOLT jumps 999999999") {
+		t.Fatalf("huge move = %q, %v; want a positive jump", got, ok)
+	}
+}
+
+func TestBusinessNewsNameHidesPrivateBusinesses(t *testing.T) {
+	if got := businessNewsName("Rocket Labs", "public"); got != "Rocket Labs" {
+		t.Fatalf("public name = %q", got)
+	}
+	if got := businessNewsName("Rocket Labs", "private"); got != "A private business" {
+		t.Fatalf("private name = %q", got)
+	}
+}
@@ -0,0 +1,51 @@
+package game
+
+import "context"
+
+const (
+	DefaultOrderPageSize = 50
+	MaxOrderPageSize     = 200
+)
+
+// ListOrders pages through the player's trade log for the season, newest
+// first. Closing trades carry the P/L stored on them when they were placed.
+func (s *Service) ListOrders(ctx context.Context, userID string, seasonID int64, limit, offset int) (OrderPage, error) {
+	if limit <= 0 {
+		limit = DefaultOrderPageSize
+	}
+	if limit > MaxOrderPageSize {
+		limit = MaxOrderPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	out := OrderPage{Orders: []OrderView{}, Limit: limit, Offset: offset}
+	if err := s.reader().QueryRow(ctx, `
+		SELECT COUNT(1)
+		FROM game.orders
+		WHERE user_id = $1 AND season_id = $2
+	`, userID, seasonID).Scan(&out.Total); err != nil {
+		return out, err
+	}
+	rows, err := s.reader().Query(ctx, `
+		SELECT o.id, st.symbol, o.side, o.quantity_units, o.price_micros, o.fee_micros, o.short, o.realized_pl_micros, o.created_at
+		FROM game.orders o
+		JOIN game.stocks st ON st.id = o.stock_id
+		WHERE o.user_id = $1 AND o.season_id = $2
+		ORDER BY o.created_at DESC, o.id DESC
+		LIMIT $3 OFFSET $4
+	`, userID, seasonID, limit, offset)
+	if err != nil {
+		return out, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var o OrderView
+		if err := rows.Scan(&o.ID, &o.Symbol, &o.Side, &o.QuantityUnits, &o.PriceMicros, &o.FeeMicros, &o.Short, &o.RealizedPLMicros, &o.CreatedAt); err != nil {
+			return out, err
+		}
+		o.NotionalMicros = notionalMicrosClamped(o.PriceMicros, o.QuantityUnits)
+		out.Orders = append(out.Orders, o)
+	}
+	return out, rows.Err()
+}
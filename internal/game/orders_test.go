@@ -0,0 +1,17 @@
+package game
+
+import "testing"
+
+func TestRealizedPLMicrosProratesCostBasis(t *testing.T) {
+	// 10 shares held at 2.00 average; selling 4 at 3.00 with a 0.05 fee.
+	avg := int64(2_000_000)
+	qty := int64(4 * ShareScale)
+	proceeds := notionalMicrosClamped(3_000_000, qty)
+	got := realizedPLMicros(avg, qty, proceeds, 50_000)
+	if want := int64(12_000_000 - 8_000_000 - 50_000); got != want {
+		t.Fatalf("realizedPLMicros = %d, want %d", got, want)
+	}
+	if loss := realizedPLMicros(avg, qty, notionalMicrosClamped(1_000_000, qty), 0); loss != -4_000_000 {
+		t.Fatalf("realizedPLMicros at a loss = %d, want -4000000", loss)
+	}
+}
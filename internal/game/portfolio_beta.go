@@ -0,0 +1,144 @@
+package game
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// portfolioBetaWindow is the number of recent market ticks the beta
+	// regression looks back over.
+	portfolioBetaWindow = 30
+	minBetaSamples      = 5
+)
+
+// PortfolioBeta estimates how strongly the player's stock positions move
+// with the market. The market is the equal-weighted average return of all
+// listed stocks per tick; the portfolio return weights each held stock by
+// its current market value. ok is false when there are no positions or too
+// little history for a meaningful estimate.
+func (s *Service) PortfolioBeta(ctx context.Context, userID string, seasonID int64) (float64, bool, error) {
+	weights := map[int64]float64{}
+	rows, err := s.reader().Query(ctx, `
+		SELECT p.stock_id, p.quantity_units, s.current_price_micros
+		FROM game.positions p
+		JOIN game.stocks s ON s.id = p.stock_id
+		WHERE p.user_id = $1 AND p.season_id = $2 AND p.quantity_units > 0
+	`, userID, seasonID)
+	if err != nil {
+		return 0, false, err
+	}
+	for rows.Next() {
+		var stockID, qty, price int64
+		if err := rows.Scan(&stockID, &qty, &price); err != nil {
+			rows.Close()
+			return 0, false, err
+		}
+		weights[stockID] = float64(notionalMicrosClamped(price, qty))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, false, err
+	}
+	if len(weights) == 0 {
+		return 0, false, nil
+	}
+
+	// Market ticks write one price row per stock at the same timestamp;
+	// single-row timestamps come from IPOs and are skipped.
+	rows, err = s.reader().Query(ctx, `
+		WITH ticks AS (
+			SELECT sp.tick_at
+			FROM game.stock_prices sp
+			JOIN game.stocks s ON s.id = sp.stock_id
+			WHERE s.season_id = $1
+			GROUP BY sp.tick_at
+			HAVING COUNT(*) > 1
+			ORDER BY sp.tick_at DESC
+			LIMIT $2
+		)
+		SELECT sp.stock_id, sp.tick_at, sp.price_micros
+		FROM game.stock_prices sp
+		JOIN game.stocks s ON s.id = sp.stock_id
+		JOIN ticks t ON t.tick_at = sp.tick_at
+		WHERE s.season_id = $1 AND s.listed_public = true
+		ORDER BY sp.tick_at
+	`, seasonID, portfolioBetaWindow+1)
+	if err != nil {
+		return 0, false, err
+	}
+	defer rows.Close()
+	tickIndex := map[time.Time]int{}
+	prices := map[int64][]int64{}
+	for rows.Next() {
+		var stockID, price int64
+		var tickAt time.Time
+		if err := rows.Scan(&stockID, &tickAt, &price); err != nil {
+			return 0, false, err
+		}
+		idx, ok := tickIndex[tickAt]
+		if !ok {
+			idx = len(tickIndex)
+			tickIndex[tickAt] = idx
+		}
+		series := prices[stockID]
+		for len(series) <= idx {
+			series = append(series, 0)
+		}
+		series[idx] = price
+		prices[stockID] = series
+	}
+	if err := rows.Err(); err != nil {
+		return 0, false, err
+	}
+	beta, ok := betaFromSeries(prices, weights, len(tickIndex))
+	return beta, ok, nil
+}
+
+// betaFromSeries regresses portfolio returns on market returns. prices holds
+// one tick-aligned series per stock with 0 marking a missing price.
+func betaFromSeries(prices map[int64][]int64, weights map[int64]float64, ticks int) (float64, bool) {
+	var market, portfolio []float64
+	for i := 1; i < ticks; i++ {
+		var mSum float64
+		var mN int
+		var pSum, pWeight float64
+		for stockID, series := range prices {
+			if i >= len(series) || series[i-1] <= 0 || series[i] <= 0 {
+				continue
+			}
+			ret := float64(series[i])/float64(series[i-1]) - 1
+			mSum += ret
+			mN++
+			if w := weights[stockID]; w > 0 {
+				pSum += ret * w
+				pWeight += w
+			}
+		}
+		if mN == 0 || pWeight == 0 {
+			continue
+		}
+		market = append(market, mSum/float64(mN))
+		portfolio = append(portfolio, pSum/pWeight)
+	}
+	if len(market) < minBetaSamples {
+		return 0, false
+	}
+	var mMean, pMean float64
+	for i := range market {
+		mMean += market[i]
+		pMean += portfolio[i]
+	}
+	mMean /= float64(len(market))
+	pMean /= float64(len(market))
+	var cov, variance float64
+	for i := range market {
+		dm := market[i] - mMean
+		cov += dm * (portfolio[i] - pMean)
+		variance += dm * dm
+	}
+	if variance <= 1e-18 {
+		return 0, false
+	}
+	return cov / variance, true
+}
@@ -0,0 +1,159 @@
+package game
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SetPriceAlert stores an alert that fires once the stock's price crosses
+// the target in the given direction. A symbol can have any number of
+// pending alerts.
+func (s *Service) SetPriceAlert(ctx context.Context, in PriceAlertInput) (PriceAlert, error) {
+	in.Symbol = strings.ToUpper(strings.TrimSpace(in.Symbol))
+	in.Direction = strings.ToLower(strings.TrimSpace(in.Direction))
+	out := PriceAlert{Symbol: in.Symbol, Direction: in.Direction, TargetPriceMicros: in.TargetPriceMicros}
+	if err := ValidateSymbol(in.Symbol); err != nil {
+		return out, err
+	}
+	if !validAlertDirection(in.Direction) || in.TargetPriceMicros <= 0 {
+		return out, ErrInvalidAlert
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return out, err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "price_alert"); err != nil {
+		return out, err
+	}
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO game.price_alerts (user_id, season_id, stock_id, direction, target_price_micros)
+		SELECT $1, $2, id, $4, $5
+		FROM game.stocks
+		WHERE season_id = $2 AND symbol = $3
+		RETURNING id, created_at
+	`, in.UserID, in.SeasonID, in.Symbol, in.Direction, in.TargetPriceMicros).Scan(&out.ID, &out.CreatedAt); err != nil {
+		if err == pgx.ErrNoRows {
+			return out, ErrStockNotFound
+		}
+		return out, err
+	}
+	return out, tx.Commit(ctx)
+}
+
+// TriggeredAlerts returns the player's alerts that have fired but not yet
+// been acknowledged, oldest first.
+func (s *Service) TriggeredAlerts(ctx context.Context, userID string, seasonID int64) ([]PriceAlert, error) {
+	rows, err := s.reader().Query(ctx, `
+		SELECT a.id, st.symbol, a.direction, a.target_price_micros, a.created_at,
+		       a.triggered_at, a.triggered_price_micros
+		FROM game.price_alerts a
+		JOIN game.stocks st ON st.id = a.stock_id
+		WHERE a.user_id = $1 AND a.season_id = $2
+		  AND a.triggered_at IS NOT NULL AND a.acknowledged_at IS NULL
+		ORDER BY a.triggered_at, a.id
+	`, userID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]PriceAlert, 0)
+	for rows.Next() {
+		var a PriceAlert
+		if err := rows.Scan(&a.ID, &a.Symbol, &a.Direction, &a.TargetPriceMicros, &a.CreatedAt,
+			&a.TriggeredAt, &a.TriggeredPriceMicros); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// AcknowledgeAlerts clears triggered alerts by id so they are not returned
+// again. Ids that are not the player's, not triggered, or already
+// acknowledged are ignored.
+func (s *Service) AcknowledgeAlerts(ctx context.Context, userID string, seasonID int64, ids []int64, idem string) error {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, userID, seasonID, idem, "alert_ack"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE game.price_alerts
+		SET acknowledged_at = now()
+		WHERE user_id = $1 AND season_id = $2 AND id = ANY($3)
+		  AND triggered_at IS NOT NULL AND acknowledged_at IS NULL
+	`, userID, seasonID, ids); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func validAlertDirection(direction string) bool {
+	return direction == "above" || direction == "below"
+}
+
+// alertCrossed reports whether priceMicros has reached an alert's target:
+// at or above it for "above", at or below it for "below".
+func alertCrossed(direction string, targetMicros, priceMicros int64) bool {
+	switch direction {
+	case "above":
+		return priceMicros >= targetMicros
+	case "below":
+		return priceMicros <= targetMicros
+	}
+	return false
+}
+
+// applyPriceAlertsTx marks pending alerts whose stock crossed the target on
+// this tick as triggered, recording the price that set them off. now() is
+// the tick's transaction time, the same tick_at as its stock_prices rows.
+func applyPriceAlertsTx(ctx context.Context, tx pgx.Tx, seasonID int64) error {
+	rows, err := tx.Query(ctx, `
+		SELECT a.id, a.direction, a.target_price_micros, st.current_price_micros
+		FROM game.price_alerts a
+		JOIN game.stocks st ON st.id = a.stock_id
+		WHERE a.season_id = $1 AND a.triggered_at IS NULL
+		FOR UPDATE OF a
+	`, seasonID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	type fired struct {
+		id          int64
+		priceMicros int64
+	}
+	var items []fired
+	for rows.Next() {
+		var (
+			id                  int64
+			direction           string
+			target, priceMicros int64
+		)
+		if err := rows.Scan(&id, &direction, &target, &priceMicros); err != nil {
+			return err
+		}
+		if alertCrossed(direction, target, priceMicros) {
+			items = append(items, fired{id: id, priceMicros: priceMicros})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, f := range items {
+		if _, err := tx.Exec(ctx, `
+			UPDATE game.price_alerts
+			SET triggered_at = now(), triggered_price_micros = $2
+			WHERE id = $1
+		`, f.id, f.priceMicros); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package game
+
+import "testing"
+
+func TestAlertCrossed(t *testing.T) {
+	cases := []struct {
+		direction     string
+		target, price int64
+		want          bool
+	}{
+		{"above", 100_000_000, 99_990_000, false},
+		{"above", 100_000_000, 100_000_000, true},
+		{"above", 100_000_000, 120_000_000, true},
+		{"below", 100_000_000, 100_010_000, false},
+		{"below", 100_000_000, 100_000_000, true},
+		{"below", 100_000_000, 80_000_000, true},
+		{"sideways", 100_000_000, 100_000_000, false},
+	}
+	for _, c := range cases {
+		if got := alertCrossed(c.direction, c.target, c.price); got != c.want {
+			t.Fatalf("alertCrossed(%q, %d, %d) = %v, want %v", c.direction, c.target, c.price, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// MaxRankHistoryPoints caps how many of the latest snapshots RankHistory
+// returns.
+const MaxRankHistoryPoints = 500
+
+// recordLeaderboardSnapshotTx stores every player's rank as of the tick
+// being run, using the same ordering as GlobalLeaderboard.
+func recordLeaderboardSnapshotTx(ctx context.Context, tx pgx.Tx, seasonID int64) error {
+	_, err := tx.Exec(ctx, `
+		WITH`+leaderboardRankedCTE+`
+		INSERT INTO game.leaderboard_snapshots (season_id, tick, user_id, rank, players, net_worth_micros)
+		SELECT $1, (SELECT tick_count + 1 FROM game.seasons WHERE id = $1),
+		       r.user_id, r.rank, COUNT(*) OVER (), r.net_worth_micros
+		FROM ranked r
+		ON CONFLICT (season_id, tick, user_id) DO NOTHING
+	`, seasonID, ShareScale)
+	return err
+}
+
+// RankHistory returns the rank-over-time of the player with inviteCode,
+// oldest snapshot first. viewerID must follow that player (or be them).
+func (s *Service) RankHistory(ctx context.Context, viewerID string, seasonID int64, inviteCode string) (RankHistory, error) {
+	out := RankHistory{Points: []RankPoint{}}
+	var userID string
+	var following bool
+	inviteCode = strings.ToUpper(strings.TrimSpace(inviteCode))
+	if err := s.reader().QueryRow(ctx, `
+		SELECT pr.user_id, pr.username, pr.invite_code,
+		       EXISTS (SELECT 1 FROM game.friend_follows WHERE follower_user_id = $2 AND followee_user_id = pr.user_id)
+		FROM users.profiles pr
+		WHERE pr.invite_code = $1
+	`, inviteCode, viewerID).Scan(&userID, &out.Username, &out.InviteCode, &following); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out, ErrPlayerNotFound
+		}
+		return out, err
+	}
+	if !following && userID != viewerID {
+		return out, ErrNotFollowing
+	}
+	rows, err := s.reader().Query(ctx, `
+		SELECT tick, rank, players, net_worth_micros, recorded_at
+		FROM (
+			SELECT tick, rank, players, net_worth_micros, recorded_at
+			FROM game.leaderboard_snapshots
+			WHERE season_id = $1 AND user_id = $2
+			ORDER BY tick DESC
+			LIMIT $3
+		) latest
+		ORDER BY tick
+	`, seasonID, userID, MaxRankHistoryPoints)
+	if err != nil {
+		return out, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var p RankPoint
+		if err := rows.Scan(&p.Tick, &p.Rank, &p.Players, &p.NetWorthMicros, &p.RecordedAt); err != nil {
+			return out, err
+		}
+		out.Points = append(out.Points, p)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// recordRegimeChangeTx logs a regime switch taking effect on the tick being
+// run. Callers only invoke it when the regime actually changed.
+func recordRegimeChangeTx(ctx context.Context, tx pgx.Tx, seasonID int64, previous, regime string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO game.regime_history (season_id, regime, previous_regime, tick)
+		SELECT $1, $2, $3, tick_count + 1
+		FROM game.seasons
+		WHERE id = $1
+	`, seasonID, regime, previous)
+	return err
+}
+
+// RegimeHistory lists the season's market regimes in order, starting with
+// the regime the season opened in. The last period is still running and
+// has no end.
+func (s *Service) RegimeHistory(ctx context.Context, seasonID int64) ([]RegimePeriod, error) {
+	var startsAt time.Time
+	var current string
+	if err := s.reader().QueryRow(ctx, `
+		SELECT s.starts_at, COALESCE(ms.regime, 'neutral')
+		FROM game.seasons s
+		LEFT JOIN game.market_state ms ON ms.season_id = s.id
+		WHERE s.id = $1
+	`, seasonID).Scan(&startsAt, &current); err != nil {
+		return nil, err
+	}
+	rows, err := s.reader().Query(ctx, `
+		SELECT regime, previous_regime, tick, started_at
+		FROM game.regime_history
+		WHERE season_id = $1
+		ORDER BY started_at, id
+	`, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	changes := make([]regimeChange, 0)
+	for rows.Next() {
+		var c regimeChange
+		if err := rows.Scan(&c.Regime, &c.Previous, &c.Tick, &c.At); err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return regimePeriods(current, startsAt, changes), nil
+}
+
+type regimeChange struct {
+	Regime   string
+	Previous string
+	Tick     int64
+	At       time.Time
+}
+
+// regimePeriods turns recorded switches into consecutive periods. The
+// opening regime is the first switch's previous regime, or current when the
+// regime never changed.
+func regimePeriods(current string, startsAt time.Time, changes []regimeChange) []RegimePeriod {
+	opening := current
+	if len(changes) > 0 {
+		opening = changes[0].Previous
+	}
+	out := []RegimePeriod{{Regime: opening, StartTick: 0, StartedAt: startsAt}}
+	for _, c := range changes {
+		prev := &out[len(out)-1]
+		endedAt := c.At
+		prev.EndedAt = &endedAt
+		prev.Ticks = c.Tick - prev.StartTick
+		out = append(out, RegimePeriod{Regime: c.Regime, StartTick: c.Tick, StartedAt: c.At})
+	}
+	return out
+}
@@ -0,0 +1,31 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRegimePeriods(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := regimePeriods("bull", start, nil); len(got) != 1 || got[0].Regime != "bull" || got[0].EndedAt != nil {
+		t.Fatalf("no switches = %+v, want one open bull period", got)
+	}
+
+	changes := []regimeChange{
+		{Regime: "bear", Previous: "neutral", Tick: 12, At: start.Add(time.Hour)},
+		{Regime: "bull", Previous: "bear", Tick: 30, At: start.Add(2 * time.Hour)},
+	}
+	got := regimePeriods("bull", start, changes)
+	if len(got) != 3 {
+		t.Fatalf("periods = %d, want 3", len(got))
+	}
+	if got[0].Regime != "neutral" || got[0].Ticks != 12 || got[0].EndedAt == nil {
+		t.Fatalf("opening period = %+v, want 12 ticks of neutral", got[0])
+	}
+	if got[1].Regime != "bear" || got[1].StartTick != 12 || got[1].Ticks != 18 {
+		t.Fatalf("second period = %+v, want bear from tick 12 for 18 ticks", got[1])
+	}
+	if got[2].Regime != "bull" || got[2].EndedAt != nil {
+		t.Fatalf("last period = %+v, want an open bull period", got[2])
+	}
+}
@@ -163,7 +163,7 @@ func (s *Service) PlayRush(ctx context.Context, in RushPlayInput) (map[string]an
 		return out, err
 	}
 	defer tx.Rollback(ctx)
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "play_rush"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "play_rush"); err != nil {
 		return out, err
 	}
 	if err := ensureRushProgressTx(ctx, tx, in.UserID, in.SeasonID); err != nil {
@@ -176,12 +176,8 @@ func (s *Service) PlayRush(ctx context.Context, in RushPlayInput) (map[string]an
 	}
 
 	var balance int64
-	if err := tx.QueryRow(ctx, `
-		SELECT balance_micros
-		FROM game.wallets
-		WHERE user_id = $1 AND season_id = $2
-		FOR UPDATE
-	`, in.UserID, in.SeasonID).Scan(&balance); err != nil {
+	balance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+	if err != nil {
 		return out, err
 	}
 	if !hasPositiveBalanceAfterSpend(balance, in.AmountMicros) {
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CompleteSeasonIfTickLimit closes seasonID once its tick count reaches the
+// season's end_after_ticks and opens the next season with the same settings,
+// so a tick-limited format keeps running. With anti-snipe enabled, a change
+// of leaderboard leader on the deciding tick pushes the close back one tick,
+// up to the season's extension cap. It reports the new season ID, or 0 when
+// the season is still in play.
+func (s *Service) CompleteSeasonIfTickLimit(ctx context.Context, seasonID int64) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var status, lastLeader string
+	var tickCount int64
+	var extensions int32
+	if err := tx.QueryRow(ctx, `
+		SELECT status, tick_count, anti_snipe_extensions, last_leader_user_id
+		FROM game.seasons
+		WHERE id = $1
+		FOR UPDATE
+	`, seasonID).Scan(&status, &tickCount, &extensions, &lastLeader); err != nil {
+		return 0, err
+	}
+	settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+	if err != nil {
+		return 0, err
+	}
+	if status != "active" {
+		return 0, nil
+	}
+
+	leaderChanged := false
+	if settings.AntiSnipeMaxExtensions > 0 && settings.EndAfterTicks > 0 {
+		var leader string
+		err := tx.QueryRow(ctx, `
+			WITH`+leaderboardRankedCTE+`
+			SELECT user_id FROM ranked WHERE rank = 1
+		`, seasonID, ShareScale).Scan(&leader)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return 0, err
+		}
+		leaderChanged = lastLeader != "" && leader != lastLeader
+		if leader != lastLeader {
+			if _, err := tx.Exec(ctx, `
+				UPDATE game.seasons
+				SET last_leader_user_id = $2
+				WHERE id = $1
+			`, seasonID, leader); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	// Each extension already granted pushes the limit back one tick.
+	if !settings.tickLimitReached(tickCount - int64(extensions)) {
+		return 0, tx.Commit(ctx)
+	}
+	if settings.antiSnipeExtends(extensions, leaderChanged) {
+		if _, err := tx.Exec(ctx, `
+			UPDATE game.seasons
+			SET anti_snipe_extensions = anti_snipe_extensions + 1
+			WHERE id = $1
+		`, seasonID); err != nil {
+			return 0, err
+		}
+		return 0, tx.Commit(ctx)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE game.seasons
+		SET status = 'completed', ends_at = now()
+		WHERE id = $1
+	`, seasonID); err != nil {
+		return 0, err
+	}
+	var nextID int64
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO game.seasons (name, status, starts_at, ends_at)
+		SELECT 'Season ' || (COUNT(*) + 1), 'active', now(), now() + interval '90 days'
+		FROM game.seasons
+		RETURNING id
+	`).Scan(&nextID); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO game.season_settings
+		SELECT (jsonb_populate_record(NULL::game.season_settings, to_jsonb(ss) || jsonb_build_object('season_id', $2::bigint, 'updated_at', now()))).*
+		FROM game.season_settings ss
+		WHERE ss.season_id = $1
+	`, seasonID, nextID); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return nextID, nil
+}
@@ -0,0 +1,43 @@
+package game
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ActiveSeasonInfo returns the active season's schedule, rules, and current
+// market hours.
+func (s *Service) ActiveSeasonInfo(ctx context.Context) (SeasonInfo, error) {
+	seasonID, err := s.ActiveSeasonID(ctx)
+	if err != nil {
+		return SeasonInfo{}, err
+	}
+	tx, err := s.reader().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return SeasonInfo{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	out := SeasonInfo{ID: seasonID}
+	if err := tx.QueryRow(ctx, `
+		SELECT name, status, starts_at, ends_at, tick_count, anti_snipe_extensions
+		FROM game.seasons
+		WHERE id = $1
+	`, seasonID).Scan(&out.Name, &out.Status, &out.StartsAt, &out.EndsAt, &out.TickCount, &out.Extensions); err != nil {
+		return SeasonInfo{}, err
+	}
+	settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+	if err != nil {
+		return SeasonInfo{}, err
+	}
+	out.EndAfterTicks = settings.EndAfterTicks
+	out.Rules = settings.rules()
+	halt, changeBps, err := marketHaltTx(ctx, tx, seasonID)
+	if err != nil {
+		return SeasonInfo{}, err
+	}
+	out.Market = settings.marketState(time.Now()).withHalt(halt, changeBps)
+	return out, nil
+}
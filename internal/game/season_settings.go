@@ -0,0 +1,662 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// seasonSettings holds per-season tuning knobs. Seasons without a row in
+// game.season_settings run with defaultSeasonSettings.
+type seasonSettings struct {
+	ViralBaseChance  float64
+	CrisisBaseChance float64
+	ViralBonusMin    float64
+	ViralBonusMax    float64
+	CrisisHitMin     float64
+	CrisisHitMax     float64
+	// CollateralHoldingsBps is the share of stock holdings value that counts
+	// toward business-loan borrowing capacity (10000 = full value).
+	CollateralHoldingsBps int32
+	// MaxMachineryLevels caps the sum of machinery levels per business.
+	// Zero means unlimited.
+	MaxMachineryLevels int32
+	// MarketOpenTime and MarketCloseTime are "HH:MM" in MarketTimezone.
+	// Leaving either empty keeps the market open around the clock.
+	MarketOpenTime  string
+	MarketCloseTime string
+	MarketTimezone  string
+	// MaxBusinessTickNetMicros and MaxBusinessTickNetBaseMultiple cap a
+	// business's positive net per tick, absolutely or as a multiple of its
+	// base revenue. Zero leaves that limit off.
+	MaxBusinessTickNetMicros       int64
+	MaxBusinessTickNetBaseMultiple float64
+	// ReserveYieldBaseMultiple limits the yield-eligible cash reserve to a
+	// multiple of base revenue; MaxReserveYieldMicros caps yield per tick.
+	// Zero leaves that bound off.
+	ReserveYieldBaseMultiple float64
+	MaxReserveYieldMicros    int64
+	// EndAfterTicks completes the season once that many market ticks have
+	// run. Zero leaves the season on its wall-clock schedule.
+	EndAfterTicks int64
+	// BusinessTaxBps taxes a player's per-tick business income above
+	// BusinessTaxThresholdMicros. Zero bps means no tax.
+	BusinessTaxThresholdMicros int64
+	BusinessTaxBps             int32
+	// BusinessPriceWeight blends a linked business's revenue change into its
+	// stock's anchor drift (0 = pure random walk, 1 = fundamentals only).
+	BusinessPriceWeight float64
+	// PriceTickMicros is the minimum stock price increment applied on each
+	// market tick. 1 (or less) keeps full micro precision.
+	PriceTickMicros int64
+	// PeakDecayBps moves peak net worth this share of the way down toward
+	// current net worth each market tick. Zero keeps the all-time peak.
+	PeakDecayBps int32
+	// UniqueBusinessNames rejects a business name already used (ignoring
+	// case) by another business in the season.
+	UniqueBusinessNames bool
+	// LoanCompoundEveryTicks compounds business-loan interest once every
+	// that many market ticks, charging interest_bps for each tick in the
+	// period. 1 compounds every tick.
+	LoanCompoundEveryTicks int32
+	// DebtLimitMinMicros, DebtLimitMaxMicros, and DebtLimitPeakBps shape the
+	// peak-based debt limit; defaults match DebtLimitFromPeak.
+	DebtLimitMinMicros int64
+	DebtLimitMaxMicros int64
+	DebtLimitPeakBps   int32
+	// IdleRevenueDecayBps shrinks base revenue by this share per consecutive
+	// tick a business has no employees and no machinery. Zero disables it.
+	IdleRevenueDecayBps int32
+	// MinTradeFeeMicros floors the fee on every stock and fund order so
+	// splitting a trade into tiny pieces cannot round the fee away.
+	MinTradeFeeMicros int64
+	// DefaultPayoutBps is the share of the last price paid to outside
+	// shareholders when a business defaults and its stock is closed out.
+	DefaultPayoutBps int32
+	// FundSwapFeeBps is the single fee charged on a fund-to-fund swap, in
+	// bps of the sold leg. 0 makes rebalancing between funds free.
+	FundSwapFeeBps int32
+	// AntiSnipeMaxExtensions lets a tick-limited season run one more tick
+	// when the leaderboard leader changes on the deciding tick, at most this
+	// many times. Zero closes the season on schedule.
+	AntiSnipeMaxExtensions int32
+	// SharesOutstanding is the supply, in whole shares, given to stocks
+	// seeded or listed this season. Zero leaves supply unlimited.
+	SharesOutstanding int64
+	// MaxNegativeBalanceMicros is a hard floor of -value on wallet balances
+	// for debt interest and business losses; the part of a charge that
+	// would breach it is dropped and logged as uncovered_loss. Zero means
+	// no floor.
+	MaxNegativeBalanceMicros int64
+	// FeeDiscountFundCode names a "membership" fund: players holding at
+	// least FeeDiscountMinUnits whole units of it pay FeeDiscountBps less
+	// on stock order fees. An empty code or zero bps disables the discount.
+	FeeDiscountFundCode string
+	FeeDiscountBps      int32
+	FeeDiscountMinUnits int64
+	// CircuitBreakerDropBps trips a market-wide trading halt of
+	// CircuitBreakerHaltTicks ticks when the index falls at least this far
+	// in one tick. Zero in either disables the breaker.
+	CircuitBreakerDropBps   int32
+	CircuitBreakerHaltTicks int32
+	// HireCostHeadcountBps adds this share of a candidate's hire cost for
+	// every employee the business already has, on top of the built-in hire
+	// cost curve. Zero leaves hire costs on the curve alone.
+	HireCostHeadcountBps int32
+}
+
+func defaultSeasonSettings() seasonSettings {
+	return seasonSettings{
+		ViralBaseChance:  0.020,
+		CrisisBaseChance: 0.018,
+		ViralBonusMin:    0.08,
+		ViralBonusMax:    0.23,
+		CrisisHitMin:     0.10,
+		CrisisHitMax:     0.30,
+
+		CollateralHoldingsBps:  10000,
+		MarketTimezone:         "UTC",
+		PriceTickMicros:        1,
+		LoanCompoundEveryTicks: 1,
+		DebtLimitMinMicros:     MinDebtLimitMicros,
+		DebtLimitMaxMicros:     MaxDebtLimitMicros,
+		DebtLimitPeakBps:       DebtLimitPeakBps,
+		DefaultPayoutBps:       10000,
+	}
+}
+
+func loadSeasonSettingsTx(ctx context.Context, tx pgx.Tx, seasonID int64) (seasonSettings, error) {
+	out := defaultSeasonSettings()
+	err := tx.QueryRow(ctx, `
+		SELECT viral_base_chance,
+		       crisis_base_chance,
+		       viral_bonus_min,
+		       viral_bonus_max,
+		       crisis_hit_min,
+		       crisis_hit_max,
+		       collateral_holdings_bps,
+		       max_machinery_levels,
+		       market_open_time,
+		       market_close_time,
+		       market_timezone,
+		       max_business_tick_net_micros,
+		       max_business_tick_net_base_multiple,
+		       reserve_yield_base_multiple,
+		       max_reserve_yield_micros,
+		       end_after_ticks,
+		       business_tax_threshold_micros,
+		       business_tax_bps,
+		       business_price_weight,
+		       price_tick_micros,
+		       peak_decay_bps,
+		       unique_business_names,
+		       loan_compound_every_ticks,
+		       debt_limit_min_micros,
+		       debt_limit_max_micros,
+		       debt_limit_peak_bps,
+		       idle_revenue_decay_bps,
+		       min_trade_fee_micros,
+		       default_payout_bps,
+		       fund_swap_fee_bps,
+		       anti_snipe_max_extensions,
+		       shares_outstanding,
+		       max_negative_balance_micros,
+		       fee_discount_fund_code,
+		       fee_discount_bps,
+		       fee_discount_min_units,
+		       circuit_breaker_drop_bps,
+		       circuit_breaker_halt_ticks,
+		       hire_cost_headcount_bps
+		FROM game.season_settings
+		WHERE season_id = $1
+	`, seasonID).Scan(
+		&out.ViralBaseChance,
+		&out.CrisisBaseChance,
+		&out.ViralBonusMin,
+		&out.ViralBonusMax,
+		&out.CrisisHitMin,
+		&out.CrisisHitMax,
+		&out.CollateralHoldingsBps,
+		&out.MaxMachineryLevels,
+		&out.MarketOpenTime,
+		&out.MarketCloseTime,
+		&out.MarketTimezone,
+		&out.MaxBusinessTickNetMicros,
+		&out.MaxBusinessTickNetBaseMultiple,
+		&out.ReserveYieldBaseMultiple,
+		&out.MaxReserveYieldMicros,
+		&out.EndAfterTicks,
+		&out.BusinessTaxThresholdMicros,
+		&out.BusinessTaxBps,
+		&out.BusinessPriceWeight,
+		&out.PriceTickMicros,
+		&out.PeakDecayBps,
+		&out.UniqueBusinessNames,
+		&out.LoanCompoundEveryTicks,
+		&out.DebtLimitMinMicros,
+		&out.DebtLimitMaxMicros,
+		&out.DebtLimitPeakBps,
+		&out.IdleRevenueDecayBps,
+		&out.MinTradeFeeMicros,
+		&out.DefaultPayoutBps,
+		&out.FundSwapFeeBps,
+		&out.AntiSnipeMaxExtensions,
+		&out.SharesOutstanding,
+		&out.MaxNegativeBalanceMicros,
+		&out.FeeDiscountFundCode,
+		&out.FeeDiscountBps,
+		&out.FeeDiscountMinUnits,
+		&out.CircuitBreakerDropBps,
+		&out.CircuitBreakerHaltTicks,
+		&out.HireCostHeadcountBps,
+	)
+	if err == pgx.ErrNoRows {
+		return defaultSeasonSettings(), nil
+	}
+	return out, err
+}
+
+// viralEventChance returns the per-tick viral chance for a business. A zero
+// base chance disables the event regardless of marketing or team bonuses.
+func (cfg seasonSettings) viralEventChance(marketingLevel int32, teamBonus float64) float64 {
+	if cfg.ViralBaseChance <= 0 {
+		return 0
+	}
+	return cfg.ViralBaseChance + float64(marketingLevel)*0.0012 + teamBonus
+}
+
+// crisisEventChance returns the per-tick crisis chance for a business. A zero
+// base chance disables the event regardless of risk, team, or region drag.
+func (cfg seasonSettings) crisisEventChance(riskFactor, teamBonus, regionDrag float64) float64 {
+	if cfg.CrisisBaseChance <= 0 {
+		return 0
+	}
+	return cfg.CrisisBaseChance + riskFactor*0.07 + teamBonus + regionDrag*0.6
+}
+
+func (cfg seasonSettings) viralBonusFraction(seed float64) float64 {
+	return cfg.ViralBonusMin + seed*maxFloat(0, cfg.ViralBonusMax-cfg.ViralBonusMin)
+}
+
+func (cfg seasonSettings) crisisHitFraction(seed float64) float64 {
+	return cfg.CrisisHitMin + seed*maxFloat(0, cfg.CrisisHitMax-cfg.CrisisHitMin)
+}
+
+// loanCollateralMicros values cash plus haircut holdings for borrowing
+// capacity, so a market dip cannot retroactively over-leverage a player.
+func (cfg seasonSettings) loanCollateralMicros(balanceMicros, holdingsMicros int64) int64 {
+	bps := int64(clampBps(cfg.CollateralHoldingsBps, 0, 10000))
+	if holdingsMicros <= 0 || bps == 10000 {
+		return saturatingAddInt64(balanceMicros, holdingsMicros)
+	}
+	haircut := int64(math.Round(float64(holdingsMicros) * float64(bps) / 10000.0))
+	return saturatingAddInt64(balanceMicros, haircut)
+}
+
+// checkMachineryCap rejects purchases that would push a business's total
+// machinery levels past MaxMachineryLevels.
+func (cfg seasonSettings) checkMachineryCap(currentLevels, addingLevels int64) error {
+	if cfg.MaxMachineryLevels <= 0 {
+		return nil
+	}
+	limit := int64(cfg.MaxMachineryLevels)
+	if currentLevels+addingLevels > limit {
+		return fmt.Errorf("%w: business has %d of %d levels, cannot add %d", ErrMachineryLimit, currentLevels, limit, addingLevels)
+	}
+	return nil
+}
+
+// capBusinessTickNet clamps a positive per-tick business net to the season's
+// limits. Losses pass through unchanged.
+func (cfg seasonSettings) capBusinessTickNet(net, baseRevenueMicros int64) int64 {
+	if net <= 0 {
+		return net
+	}
+	if cfg.MaxBusinessTickNetMicros > 0 && net > cfg.MaxBusinessTickNetMicros {
+		net = cfg.MaxBusinessTickNetMicros
+	}
+	if cfg.MaxBusinessTickNetBaseMultiple > 0 && baseRevenueMicros > 0 {
+		limit := float64(baseRevenueMicros) * cfg.MaxBusinessTickNetBaseMultiple
+		if float64(net) > limit {
+			net = int64(math.Round(limit))
+		}
+	}
+	return net
+}
+
+// reserveYieldMicros pays rate on the yield-eligible part of a business's
+// cash reserve, within the season's bounds.
+func (cfg seasonSettings) reserveYieldMicros(reserveMicros, baseRevenueMicros int64, rate float64) int64 {
+	eligible := float64(reserveMicros)
+	if cfg.ReserveYieldBaseMultiple > 0 {
+		eligible = math.Min(eligible, math.Max(0, float64(baseRevenueMicros))*cfg.ReserveYieldBaseMultiple)
+	}
+	yield := int64(math.Round(eligible * rate))
+	if cfg.MaxReserveYieldMicros > 0 && yield > cfg.MaxReserveYieldMicros {
+		yield = cfg.MaxReserveYieldMicros
+	}
+	return yield
+}
+
+// businessTaxMicros is the tax owed on a player's combined business income
+// for one tick. Only the part above the threshold is taxed; losses never are.
+func (cfg seasonSettings) businessTaxMicros(incomeMicros int64) int64 {
+	bps := int64(clampBps(cfg.BusinessTaxBps, 0, 10000))
+	taxable := incomeMicros - cfg.BusinessTaxThresholdMicros
+	if bps == 0 || taxable <= 0 {
+		return 0
+	}
+	return int64(math.Round(float64(taxable) * float64(bps) / 10000.0))
+}
+
+// maxFundamentalAnchorMove bounds the anchor return a single revenue swing
+// can contribute, so one freak tick cannot rerate a stock on its own.
+const maxFundamentalAnchorMove = 0.05
+
+// blendBusinessAnchorReturn mixes the random anchor return with the linked
+// business's revenue change between its last two ticks, scaled by the larger
+// of the previous net and base revenue.
+func (cfg seasonSettings) blendBusinessAnchorReturn(randomRet float64, lastNet, prevNet, baseRevenueMicros int64) float64 {
+	w := math.Max(0, math.Min(1, cfg.BusinessPriceWeight))
+	if w == 0 {
+		return randomRet
+	}
+	scale := math.Max(1, math.Max(math.Abs(float64(prevNet)), float64(baseRevenueMicros)))
+	change := math.Max(-1, math.Min(1, (float64(lastNet)-float64(prevNet))/scale))
+	return (1-w)*randomRet + w*change*maxFundamentalAnchorMove
+}
+
+// roundToPriceTick rounds a price to the nearest PriceTickMicros multiple,
+// never below the smallest multiple at or above floorMicros and never above
+// the largest multiple at or below ceilingMicros.
+func (cfg seasonSettings) roundToPriceTick(priceMicros, floorMicros, ceilingMicros int64) int64 {
+	tick := cfg.PriceTickMicros
+	if tick <= 1 {
+		return priceMicros
+	}
+	rounded := ((priceMicros + tick/2) / tick) * tick
+	if rounded < floorMicros {
+		rounded = ((floorMicros + tick - 1) / tick) * tick
+	}
+	if rounded > ceilingMicros {
+		rounded = (ceilingMicros / tick) * tick
+	}
+	return rounded
+}
+
+// loanInterestPeriods returns how many ticks of loan interest to capitalize
+// on the tick after tickCount completed ticks: the full period on a
+// compounding tick, otherwise 0.
+func (cfg seasonSettings) loanInterestPeriods(tickCount int64) int64 {
+	every := int64(cfg.LoanCompoundEveryTicks)
+	if every <= 1 {
+		return 1
+	}
+	if (tickCount+1)%every != 0 {
+		return 0
+	}
+	return every
+}
+
+// debtLimitFromPeak is DebtLimitFromPeak with the season's bounds and ratio.
+func (cfg seasonSettings) debtLimitFromPeak(peakNetWorthMicros int64) int64 {
+	return debtLimitFromPeak(peakNetWorthMicros, cfg.DebtLimitMinMicros, cfg.DebtLimitMaxMicros, cfg.DebtLimitPeakBps)
+}
+
+// idleBaseRevenue is idleBaseRevenueMicros with the season's decay rate.
+func (cfg seasonSettings) idleBaseRevenue(baseRevenueMicros int64, idleTicks int32) int64 {
+	return idleBaseRevenueMicros(baseRevenueMicros, idleTicks, cfg.IdleRevenueDecayBps)
+}
+
+// idleBaseRevenueMicros decays base revenue by decayBps per tick for a
+// business that has been idle for idleTicks ticks in a row. It snaps back
+// once idleTicks resets to zero.
+func idleBaseRevenueMicros(baseRevenueMicros int64, idleTicks, decayBps int32) int64 {
+	bps := clampBps(decayBps, 0, 10000)
+	if bps == 0 || idleTicks <= 0 || baseRevenueMicros <= 0 {
+		return baseRevenueMicros
+	}
+	keep := math.Pow(1-float64(bps)/10000.0, float64(idleTicks))
+	return int64(math.Round(float64(baseRevenueMicros) * keep))
+}
+
+// tradeFee applies the season's minimum to a proportional order fee.
+func (cfg seasonSettings) tradeFee(proportionalMicros int64) int64 {
+	if proportionalMicros < cfg.MinTradeFeeMicros {
+		return cfg.MinTradeFeeMicros
+	}
+	return proportionalMicros
+}
+
+// feeDiscountEnabled reports whether holding the membership fund can lower
+// order fees this season.
+func (cfg seasonSettings) feeDiscountEnabled() bool {
+	return cfg.FeeDiscountFundCode != "" && clampBps(cfg.FeeDiscountBps, 0, 10000) > 0
+}
+
+// feeDiscount is how much of feeMicros a holder of heldUnits of the
+// membership fund is let off.
+func (cfg seasonSettings) feeDiscount(feeMicros, heldUnits int64) int64 {
+	if !cfg.feeDiscountEnabled() || feeMicros <= 0 || heldUnits <= 0 {
+		return 0
+	}
+	if cfg.FeeDiscountMinUnits > 0 && heldUnits/FundUnitScale < cfg.FeeDiscountMinUnits {
+		return 0
+	}
+	bps := int64(clampBps(cfg.FeeDiscountBps, 0, 10000))
+	return int64(math.Round(float64(feeMicros) * float64(bps) / 10000.0))
+}
+
+// orderFee is the fee PlaceOrder charges on an order of notionalMicros to a
+// player holding fundUnits of the membership fund, net of the discount, and
+// the discount itself.
+func (cfg seasonSettings) orderFee(notionalMicros, fundUnits int64) (int64, int64) {
+	fee := cfg.tradeFee(tradeFeeMicros(notionalMicros))
+	discount := cfg.feeDiscount(fee, fundUnits)
+	return fee - discount, discount
+}
+
+// breakEvenPrice is the lowest per-share sell price at which a long position
+// of qtyUnits bought at avgPriceMicros gets back its cost and the fees on
+// both legs, as orderFee charges them. The minimum fee makes small
+// positions need a higher price than large ones.
+func (cfg seasonSettings) breakEvenPrice(avgPriceMicros, qtyUnits, fundUnits int64) int64 {
+	if avgPriceMicros <= 0 || qtyUnits <= 0 {
+		return 0
+	}
+	entry := notionalMicrosClamped(avgPriceMicros, qtyUnits)
+	entryFee, _ := cfg.orderFee(entry, fundUnits)
+	cost := saturatingAddInt64(entry, entryFee)
+	recovers := func(priceMicros int64) bool {
+		proceeds := notionalMicrosClamped(priceMicros, qtyUnits)
+		fee, _ := cfg.orderFee(proceeds, fundUnits)
+		return proceeds-fee >= cost
+	}
+	hi := avgPriceMicros
+	for !recovers(hi) {
+		if hi > maxBigintMicros/2 {
+			return maxBigintMicros
+		}
+		hi *= 2
+	}
+	// Proceeds never shrink as the price rises, so bisect down to the
+	// lowest price that still recovers the cost.
+	lo := int64(0)
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if recovers(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi
+}
+
+// shortBreakEvenPrice is the highest per-share buy-back price at which a
+// short of qtyUnits opened at avgPriceMicros still covers both legs' fees,
+// as orderFee charges them. It is zero when the fees alone eat the short's
+// proceeds.
+func (cfg seasonSettings) shortBreakEvenPrice(avgPriceMicros, qtyUnits, fundUnits int64) int64 {
+	if avgPriceMicros <= 0 || qtyUnits <= 0 {
+		return 0
+	}
+	entry := notionalMicrosClamped(avgPriceMicros, qtyUnits)
+	entryFee, _ := cfg.orderFee(entry, fundUnits)
+	proceeds := entry - entryFee
+	covers := func(priceMicros int64) bool {
+		cost := notionalMicrosClamped(priceMicros, qtyUnits)
+		fee, _ := cfg.orderFee(cost, fundUnits)
+		return saturatingAddInt64(cost, fee) <= proceeds
+	}
+	if !covers(0) {
+		return 0
+	}
+	if covers(avgPriceMicros) {
+		return avgPriceMicros
+	}
+	// Buy-back cost never shrinks as the price rises, so bisect up to the
+	// highest price that still covers.
+	lo, hi := int64(0), avgPriceMicros
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if covers(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// hireCostMicros is what hiring a candidate with baseCost costs when the
+// business already has currentEmployees plus hireIndex earlier picks from the
+// same batch.
+func (cfg seasonSettings) hireCostMicros(baseCost, currentEmployees int64, hireIndex int) int64 {
+	cost := scaledHireCostMicros(baseCost, currentEmployees, hireIndex)
+	headcount := currentEmployees + int64(hireIndex)
+	if cfg.HireCostHeadcountBps <= 0 || cost <= 0 || headcount <= 0 {
+		return cost
+	}
+	multiplier := 1 + float64(cfg.HireCostHeadcountBps)*float64(headcount)/10000.0
+	scaled := float64(cost) * multiplier
+	if scaled >= float64(maxBigintMicros) {
+		return maxBigintMicros
+	}
+	return int64(math.Round(scaled))
+}
+
+// nextHaltTicks is the halt left after a tick whose index moved
+// indexChangeBps: a running halt counts down by one, and a drop of at least
+// CircuitBreakerDropBps restarts it at CircuitBreakerHaltTicks (never
+// shortening a longer halt already in place).
+func (cfg seasonSettings) nextHaltTicks(remaining int32, indexChangeBps int64) int32 {
+	if remaining > 0 {
+		remaining--
+	}
+	if cfg.CircuitBreakerDropBps <= 0 || cfg.CircuitBreakerHaltTicks <= 0 {
+		return remaining
+	}
+	if indexChangeBps <= -int64(clampBps(cfg.CircuitBreakerDropBps, 0, 10000)) {
+		return max(remaining, cfg.CircuitBreakerHaltTicks)
+	}
+	return remaining
+}
+
+// defaultPayoutPrice is the per-share price paid to outside holders when a
+// defaulted business's stock is closed out.
+func (cfg seasonSettings) defaultPayoutPrice(lastPriceMicros int64) int64 {
+	bps := int64(clampBps(cfg.DefaultPayoutBps, 0, 10000))
+	if lastPriceMicros <= 0 || bps == 10000 {
+		return lastPriceMicros
+	}
+	return int64(math.Round(float64(lastPriceMicros) * float64(bps) / 10000.0))
+}
+
+// flooredDebit splits a negative wallet delta into the part the balance
+// can absorb without dropping below the season's floor and the uncovered
+// remainder. Credits and seasons without a floor pass through untouched.
+func (cfg seasonSettings) flooredDebit(balanceMicros, deltaMicros int64) (applied, uncovered int64) {
+	if deltaMicros >= 0 || cfg.MaxNegativeBalanceMicros <= 0 {
+		return deltaMicros, 0
+	}
+	room := saturatingAddInt64(balanceMicros, cfg.MaxNegativeBalanceMicros)
+	if room <= 0 {
+		return 0, -deltaMicros
+	}
+	if -deltaMicros <= room {
+		return deltaMicros, 0
+	}
+	return -room, -deltaMicros - room
+}
+
+// fundSwapFee is the fee on a fund swap whose sold leg is worth notional.
+func (cfg seasonSettings) fundSwapFee(notionalMicros int64) int64 {
+	bps := int64(clampBps(cfg.FundSwapFeeBps, 0, 10000))
+	if notionalMicros <= 0 || bps == 0 {
+		return 0
+	}
+	return int64(math.Round(float64(notionalMicros) * float64(bps) / 10000.0))
+}
+
+// rules reports the settings clients can see through SeasonInfo.
+func (cfg seasonSettings) rules() SeasonRules {
+	return SeasonRules{
+		StarterBalanceMicros:       StarterBalanceMicros,
+		SignupBonusMicros:          SignupBonusMicros,
+		BusinessUnlockMicros:       BusinessUnlockMicros,
+		TradeFeeBps:                TradeFeeBps,
+		FundFeeBps:                 FundFeeBps,
+		MinTradeFeeMicros:          cfg.MinTradeFeeMicros,
+		FundSwapFeeBps:             cfg.FundSwapFeeBps,
+		DebtLimitMinMicros:         cfg.DebtLimitMinMicros,
+		DebtLimitMaxMicros:         cfg.DebtLimitMaxMicros,
+		DebtLimitPeakBps:           cfg.DebtLimitPeakBps,
+		CollateralHoldingsBps:      cfg.CollateralHoldingsBps,
+		LoanCompoundEveryTicks:     cfg.LoanCompoundEveryTicks,
+		BusinessTaxBps:             cfg.BusinessTaxBps,
+		BusinessTaxThresholdMicros: cfg.BusinessTaxThresholdMicros,
+		MaxMachineryLevels:         cfg.MaxMachineryLevels,
+		IdleRevenueDecayBps:        cfg.IdleRevenueDecayBps,
+		DefaultPayoutBps:           cfg.DefaultPayoutBps,
+		PeakDecayBps:               cfg.PeakDecayBps,
+		PriceTickMicros:            cfg.PriceTickMicros,
+		UniqueBusinessNames:        cfg.UniqueBusinessNames,
+		ViralBaseChance:            cfg.ViralBaseChance,
+		CrisisBaseChance:           cfg.CrisisBaseChance,
+		AntiSnipeMaxExtensions:     cfg.AntiSnipeMaxExtensions,
+		SharesOutstanding:          cfg.SharesOutstanding,
+		MaxNegativeBalanceMicros:   cfg.MaxNegativeBalanceMicros,
+		FeeDiscountFundCode:        cfg.FeeDiscountFundCode,
+		FeeDiscountBps:             cfg.FeeDiscountBps,
+		FeeDiscountMinUnits:        cfg.FeeDiscountMinUnits,
+		CircuitBreakerDropBps:      cfg.CircuitBreakerDropBps,
+		CircuitBreakerHaltTicks:    cfg.CircuitBreakerHaltTicks,
+		HireCostHeadcountBps:       cfg.HireCostHeadcountBps,
+	}
+}
+
+func (cfg seasonSettings) tickLimitReached(tickCount int64) bool {
+	return cfg.EndAfterTicks > 0 && tickCount >= cfg.EndAfterTicks
+}
+
+// sharesOutstandingUnits converts the season's share supply to units.
+func (cfg seasonSettings) sharesOutstandingUnits() int64 {
+	if cfg.SharesOutstanding <= 0 || cfg.SharesOutstanding > maxBigintMicros/ShareScale {
+		return 0
+	}
+	return cfg.SharesOutstanding * ShareScale
+}
+
+// antiSnipeExtends reports whether a season that reached its tick limit
+// should run one more tick because the leader just changed.
+func (cfg seasonSettings) antiSnipeExtends(extensions int32, leaderChanged bool) bool {
+	return leaderChanged && extensions < cfg.AntiSnipeMaxExtensions
+}
+
+func parseMarketClock(v string) (int, bool) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+// marketState reports whether trading is open at now and, for scheduled
+// markets, when that next changes. An unknown timezone falls back to UTC.
+func (cfg seasonSettings) marketState(now time.Time) MarketState {
+	loc, err := time.LoadLocation(cfg.MarketTimezone)
+	if err != nil || cfg.MarketTimezone == "" {
+		loc = time.UTC
+	}
+	out := MarketState{Open: true, Timezone: loc.String()}
+	openMin, okOpen := parseMarketClock(cfg.MarketOpenTime)
+	closeMin, okClose := parseMarketClock(cfg.MarketCloseTime)
+	if !okOpen || !okClose || openMin == closeMin {
+		return out
+	}
+	out.Scheduled = true
+	out.OpenTime = cfg.MarketOpenTime
+	out.CloseTime = cfg.MarketCloseTime
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	if openMin < closeMin {
+		out.Open = minute >= openMin && minute < closeMin
+	} else {
+		out.Open = minute >= openMin || minute < closeMin
+	}
+	next := openMin
+	if out.Open {
+		next = closeMin
+	}
+	at := time.Date(local.Year(), local.Month(), local.Day(), next/60, next%60, 0, 0, loc)
+	if !at.After(local) {
+		at = at.AddDate(0, 0, 1)
+	}
+	out.NextChangeAt = &at
+	return out
+}
@@ -0,0 +1,508 @@
+package game
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDefaultSeasonSettingsMatchLegacyEventChances(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got, want := cfg.viralEventChance(5, 0.01), 0.020+5*0.0012+0.01; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("viralEventChance = %f, want %f", got, want)
+	}
+	if got, want := cfg.crisisEventChance(0.4, 0.02, 0.1), 0.018+0.4*0.07+0.02+0.1*0.6; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("crisisEventChance = %f, want %f", got, want)
+	}
+	if got := cfg.viralBonusFraction(1); math.Abs(got-0.23) > 1e-12 {
+		t.Fatalf("viralBonusFraction(1) = %f, want 0.23", got)
+	}
+	if got := cfg.crisisHitFraction(0); math.Abs(got-0.10) > 1e-12 {
+		t.Fatalf("crisisHitFraction(0) = %f, want 0.10", got)
+	}
+}
+
+func TestSeasonSettingsZeroChanceDisablesEvents(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	cfg.ViralBaseChance = 0
+	cfg.CrisisBaseChance = 0
+	if got := cfg.viralEventChance(20, 0.05); got != 0 {
+		t.Fatalf("expected viral events disabled, got chance %f", got)
+	}
+	if got := cfg.crisisEventChance(1, 0.05, 0.3); got != 0 {
+		t.Fatalf("expected crisis events disabled, got chance %f", got)
+	}
+}
+
+func TestLoanCollateralHaircutsHoldings(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got := cfg.loanCollateralMicros(1_000, 2_000); got != 3_000 {
+		t.Fatalf("default collateral = %d, want 3000", got)
+	}
+	cfg.CollateralHoldingsBps = 7000
+	if got := cfg.loanCollateralMicros(1_000, 2_000); got != 2_400 {
+		t.Fatalf("haircut collateral = %d, want 2400", got)
+	}
+	if got := cfg.loanCollateralMicros(1_000, -500); got != 500 {
+		t.Fatalf("negative holdings should not be haircut, got %d", got)
+	}
+}
+
+func TestMachineryCap(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if err := cfg.checkMachineryCap(1_000, 50); err != nil {
+		t.Fatalf("default should be unlimited, got %v", err)
+	}
+	cfg.MaxMachineryLevels = 10
+	if err := cfg.checkMachineryCap(8, 2); err != nil {
+		t.Fatalf("reaching the cap should be allowed, got %v", err)
+	}
+	if err := cfg.checkMachineryCap(8, 3); !errors.Is(err, ErrMachineryLimit) {
+		t.Fatalf("expected ErrMachineryLimit, got %v", err)
+	}
+}
+
+func TestMarketStateHours(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	noon := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+	if st := cfg.marketState(noon); !st.Open || st.Scheduled {
+		t.Fatalf("default market should be always open, got %+v", st)
+	}
+
+	cfg.MarketOpenTime = "09:30"
+	cfg.MarketCloseTime = "16:00"
+	if st := cfg.marketState(noon); !st.Open || !st.NextChangeAt.Equal(time.Date(2026, 5, 4, 16, 0, 0, 0, time.UTC)) {
+		t.Fatalf("midday state = %+v", st)
+	}
+	evening := time.Date(2026, 5, 4, 20, 0, 0, 0, time.UTC)
+	if st := cfg.marketState(evening); st.Open || !st.NextChangeAt.Equal(time.Date(2026, 5, 5, 9, 30, 0, 0, time.UTC)) {
+		t.Fatalf("evening state = %+v", st)
+	}
+
+	// Overnight window spanning midnight.
+	cfg.MarketOpenTime = "22:00"
+	cfg.MarketCloseTime = "06:00"
+	if !cfg.marketState(time.Date(2026, 5, 4, 23, 0, 0, 0, time.UTC)).Open {
+		t.Fatalf("overnight market should be open at 23:00")
+	}
+	if cfg.marketState(noon).Open {
+		t.Fatalf("overnight market should be closed at noon")
+	}
+
+	// Hours are interpreted in the configured timezone.
+	cfg.MarketOpenTime = "09:00"
+	cfg.MarketCloseTime = "17:00"
+	cfg.MarketTimezone = "Asia/Kolkata"
+	if !cfg.marketState(time.Date(2026, 5, 4, 5, 0, 0, 0, time.UTC)).Open {
+		t.Fatalf("10:30 IST should be open")
+	}
+	if cfg.marketState(noon).Open {
+		t.Fatalf("17:30 IST should be closed")
+	}
+}
+
+func TestCapBusinessTickNet(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got := cfg.capBusinessTickNet(9_000_000, 100); got != 9_000_000 {
+		t.Fatalf("default should be uncapped, got %d", got)
+	}
+
+	cfg.MaxBusinessTickNetMicros = 1_000
+	if got := cfg.capBusinessTickNet(1_000, 0); got != 1_000 {
+		t.Fatalf("net at the cap = %d, want 1000", got)
+	}
+	if got := cfg.capBusinessTickNet(1_001, 0); got != 1_000 {
+		t.Fatalf("net just over the cap = %d, want 1000", got)
+	}
+	if got := cfg.capBusinessTickNet(-5_000, 0); got != -5_000 {
+		t.Fatalf("losses should not be capped, got %d", got)
+	}
+
+	cfg.MaxBusinessTickNetBaseMultiple = 2.5
+	if got := cfg.capBusinessTickNet(900, 200); got != 500 {
+		t.Fatalf("multiple cap should win when tighter, got %d", got)
+	}
+	if got := cfg.capBusinessTickNet(5_000, 1_000); got != 1_000 {
+		t.Fatalf("absolute cap should win when tighter, got %d", got)
+	}
+}
+
+func TestReserveYieldBounds(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got := cfg.reserveYieldMicros(1_000_000_000, 1_000, 0.001); got != 1_000_000 {
+		t.Fatalf("default reserve yield = %d, want uncapped 1000000", got)
+	}
+
+	cfg.ReserveYieldBaseMultiple = 100
+	if got := cfg.reserveYieldMicros(1_000_000_000, 1_000, 0.001); got != 100 {
+		t.Fatalf("eligible reserve should be 100x base revenue, got yield %d", got)
+	}
+	if got := cfg.reserveYieldMicros(50_000, 1_000, 0.001); got != 50 {
+		t.Fatalf("reserve under the cap should earn in full, got %d", got)
+	}
+
+	cfg.ReserveYieldBaseMultiple = 0
+	cfg.MaxReserveYieldMicros = 250
+	if got := cfg.reserveYieldMicros(1_000_000_000, 1_000, 0.001); got != 250 {
+		t.Fatalf("per-tick yield cap = %d, want 250", got)
+	}
+}
+
+func TestTickLimitReached(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if cfg.tickLimitReached(1_000_000) {
+		t.Fatalf("default season should never end on ticks")
+	}
+	cfg.EndAfterTicks = 500
+	if cfg.tickLimitReached(499) {
+		t.Fatalf("season should still run at tick 499")
+	}
+	if !cfg.tickLimitReached(500) {
+		t.Fatalf("season should end at tick 500")
+	}
+}
+
+func TestLoanInterestPeriods(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	for tick := int64(0); tick < 3; tick++ {
+		if got := cfg.loanInterestPeriods(tick); got != 1 {
+			t.Fatalf("default periods at tick %d = %d, want 1", tick, got)
+		}
+	}
+	cfg.LoanCompoundEveryTicks = 4
+	var total int64
+	for tick := int64(0); tick < 12; tick++ {
+		got := cfg.loanInterestPeriods(tick)
+		if (tick+1)%4 == 0 && got != 4 {
+			t.Fatalf("periods at compounding tick %d = %d, want 4", tick, got)
+		}
+		total += got
+	}
+	if total != 12 {
+		t.Fatalf("interest ticks over 12 ticks = %d, want 12", total)
+	}
+}
+
+func TestSeasonDebtLimitFromPeak(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	for _, peak := range []int64{0, 10_000 * MicrosPerStonky, 100_000 * MicrosPerStonky, 1_000_000 * MicrosPerStonky} {
+		if got, want := cfg.debtLimitFromPeak(peak), DebtLimitFromPeak(peak); got != want {
+			t.Fatalf("default season limit for peak %d = %d, want %d", peak, got, want)
+		}
+	}
+
+	cfg.DebtLimitMinMicros = 0
+	cfg.DebtLimitPeakBps = 1000
+	if got := cfg.debtLimitFromPeak(0); got != 0 {
+		t.Fatalf("new player limit = %d, want 0", got)
+	}
+	if got := cfg.debtLimitFromPeak(50_000 * MicrosPerStonky); got != 5_000*MicrosPerStonky {
+		t.Fatalf("10%% of 50k peak = %d, want 5000 stonky", got)
+	}
+}
+
+func TestIdleBaseRevenue(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got := cfg.idleBaseRevenue(18_000_000, 50); got != 18_000_000 {
+		t.Fatalf("default decay should leave base revenue alone, got %d", got)
+	}
+	cfg.IdleRevenueDecayBps = 1000
+	if got := cfg.idleBaseRevenue(18_000_000, 0); got != 18_000_000 {
+		t.Fatalf("active business should earn full base revenue, got %d", got)
+	}
+	if got := cfg.idleBaseRevenue(18_000_000, 1); got != 16_200_000 {
+		t.Fatalf("one idle tick = %d, want 16200000", got)
+	}
+	if got := cfg.idleBaseRevenue(18_000_000, 2); got != 14_580_000 {
+		t.Fatalf("two idle ticks = %d, want 14580000", got)
+	}
+}
+
+func TestTradeFeeMinimum(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got := cfg.tradeFee(0); got != 0 {
+		t.Fatalf("default minimum fee = %d, want 0", got)
+	}
+	cfg.MinTradeFeeMicros = 10_000
+	if got := cfg.tradeFee(tradeFeeMicros(100)); got != 10_000 {
+		t.Fatalf("tiny trade fee = %d, want the 10000 floor", got)
+	}
+	if got := cfg.tradeFee(150_000); got != 150_000 {
+		t.Fatalf("fee above the floor = %d, want 150000", got)
+	}
+}
+
+func TestBreakEvenPriceRecoversBothFees(t *testing.T) {
+	avg := int64(100) * MicrosPerStonky
+	discounted := defaultSeasonSettings()
+	discounted.FeeDiscountFundCode = "TECH"
+	discounted.FeeDiscountBps = 5_000
+	floored := defaultSeasonSettings()
+	floored.MinTradeFeeMicros = MicrosPerStonky
+	for name, tc := range map[string]struct {
+		cfg       seasonSettings
+		fundUnits int64
+	}{
+		"default":  {cfg: defaultSeasonSettings()},
+		"discount": {cfg: discounted, fundUnits: 10 * FundUnitScale},
+		"min fee":  {cfg: floored},
+	} {
+		for _, qty := range []int64{ShareScale, 3 * ShareScale / 2, 37} {
+			be := tc.cfg.breakEvenPrice(avg, qty, tc.fundUnits)
+			if be <= avg {
+				t.Fatalf("%s qty=%d: break-even %d should exceed avg %d", name, qty, be, avg)
+			}
+			entry := notionalMicrosClamped(avg, qty)
+			entryFee, _ := tc.cfg.orderFee(entry, tc.fundUnits)
+			net := func(price int64) int64 {
+				proceeds := notionalMicrosClamped(price, qty)
+				fee, _ := tc.cfg.orderFee(proceeds, tc.fundUnits)
+				return proceeds - fee - entry - entryFee
+			}
+			if net(be) < 0 || net(be-1) >= 0 {
+				t.Fatalf("%s qty=%d: break-even %d is not the lowest recovering price", name, qty, be)
+			}
+		}
+	}
+	// The fee floor weighs far more on a tiny position than a large one.
+	if small, large := floored.breakEvenPrice(avg, 37, 0), floored.breakEvenPrice(avg, 100*ShareScale, 0); small <= large {
+		t.Fatalf("min-fee break-even small=%d should exceed large=%d", small, large)
+	}
+	// The discount lowers the target for a holder of the membership fund.
+	if with, without := discounted.breakEvenPrice(avg, ShareScale, 10*FundUnitScale), discounted.breakEvenPrice(avg, ShareScale, 0); with >= without {
+		t.Fatalf("discounted break-even %d should be below %d", with, without)
+	}
+	if got := defaultSeasonSettings().breakEvenPrice(0, ShareScale, 0); got != 0 {
+		t.Fatalf("zero avg should yield zero break-even, got %d", got)
+	}
+}
+
+func TestDefaultPayoutPrice(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got := cfg.defaultPayoutPrice(12_500_000); got != 12_500_000 {
+		t.Fatalf("default payout price = %d, want the full last price", got)
+	}
+	cfg.DefaultPayoutBps = 2500
+	if got := cfg.defaultPayoutPrice(12_500_000); got != 3_125_000 {
+		t.Fatalf("quarter payout price = %d, want 3125000", got)
+	}
+	cfg.DefaultPayoutBps = 0
+	if got := cfg.defaultPayoutPrice(12_500_000); got != 0 {
+		t.Fatalf("zero payout price = %d, want 0", got)
+	}
+}
+
+func TestFundSwapFee(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got := cfg.fundSwapFee(1_000_000_000); got != 0 {
+		t.Fatalf("default swap fee = %d, want 0", got)
+	}
+	cfg.FundSwapFeeBps = 5
+	if got := cfg.fundSwapFee(1_000_000_000); got != 500_000 {
+		t.Fatalf("5 bps swap fee = %d, want 500000", got)
+	}
+}
+
+func TestFundSwapUnits(t *testing.T) {
+	units, leftover, err := fundSwapUnits(250*MicrosPerStonky, 100*MicrosPerStonky)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if units != 2*ShareScale+ShareScale/2 || leftover != 0 {
+		t.Fatalf("units=%d leftover=%d, want 2.5 shares and no leftover", units, leftover)
+	}
+	units, leftover, err = fundSwapUnits(1_000, 3*MicrosPerStonky)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if units != 3 || leftover != 100 {
+		t.Fatalf("units=%d leftover=%d, want 3 units and 100 micros back", units, leftover)
+	}
+}
+
+func TestSeasonRulesDefaults(t *testing.T) {
+	rules := defaultSeasonSettings().rules()
+	if rules.StarterBalanceMicros != StarterBalanceMicros || rules.TradeFeeBps != TradeFeeBps || rules.FundFeeBps != FundFeeBps {
+		t.Fatalf("rules = %+v, want the economy constants", rules)
+	}
+	if rules.DebtLimitPeakBps != DebtLimitPeakBps || rules.DefaultPayoutBps != 10000 || rules.LoanCompoundEveryTicks != 1 {
+		t.Fatalf("rules = %+v, want default season settings", rules)
+	}
+}
+
+func TestAntiSnipeExtends(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if cfg.antiSnipeExtends(0, true) {
+		t.Fatal("anti-snipe should be off by default")
+	}
+	cfg.AntiSnipeMaxExtensions = 2
+	if cfg.antiSnipeExtends(0, false) {
+		t.Fatal("no extension expected when the leader held")
+	}
+	if !cfg.antiSnipeExtends(1, true) {
+		t.Fatal("expected an extension when the leader changed under the cap")
+	}
+	if cfg.antiSnipeExtends(2, true) {
+		t.Fatal("no extension expected once the cap is used up")
+	}
+}
+
+func TestSharesOutstandingUnits(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got := cfg.sharesOutstandingUnits(); got != 0 {
+		t.Fatalf("default supply = %d, want 0 (unlimited)", got)
+	}
+	cfg.SharesOutstanding = 1_000
+	if got := cfg.sharesOutstandingUnits(); got != 1_000*ShareScale {
+		t.Fatalf("supply = %d, want %d", got, 1_000*ShareScale)
+	}
+	cfg.SharesOutstanding = maxBigintMicros
+	if got := cfg.sharesOutstandingUnits(); got != 0 {
+		t.Fatalf("overflowing supply = %d, want 0", got)
+	}
+}
+
+func TestFlooredDebit(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if applied, uncovered := cfg.flooredDebit(-50_000_000, -10_000_000); applied != -10_000_000 || uncovered != 0 {
+		t.Fatalf("no floor = %d, %d; want the full debit", applied, uncovered)
+	}
+	cfg.MaxNegativeBalanceMicros = 20_000_000
+	if applied, uncovered := cfg.flooredDebit(-5_000_000, -10_000_000); applied != -10_000_000 || uncovered != 0 {
+		t.Fatalf("within floor = %d, %d; want the full debit", applied, uncovered)
+	}
+	if applied, uncovered := cfg.flooredDebit(-15_000_000, -10_000_000); applied != -5_000_000 || uncovered != 5_000_000 {
+		t.Fatalf("breaching floor = %d, %d; want -5000000, 5000000", applied, uncovered)
+	}
+	if applied, uncovered := cfg.flooredDebit(-25_000_000, -10_000_000); applied != 0 || uncovered != 10_000_000 {
+		t.Fatalf("below floor = %d, %d; want 0, 10000000", applied, uncovered)
+	}
+	if applied, uncovered := cfg.flooredDebit(-25_000_000, 10_000_000); applied != 10_000_000 || uncovered != 0 {
+		t.Fatalf("credit = %d, %d; want it untouched", applied, uncovered)
+	}
+}
+
+func TestFeeDiscount(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got := cfg.feeDiscount(100_000, 50*ShareScale); got != 0 {
+		t.Fatalf("disabled discount = %d, want 0", got)
+	}
+	cfg.FeeDiscountFundCode = "TECH"
+	cfg.FeeDiscountBps = 2_500
+	cfg.FeeDiscountMinUnits = 10
+	if got := cfg.feeDiscount(100_000, 9*ShareScale); got != 0 {
+		t.Fatalf("below threshold = %d, want 0", got)
+	}
+	if got := cfg.feeDiscount(100_000, 10*ShareScale); got != 25_000 {
+		t.Fatalf("at threshold = %d, want 25000", got)
+	}
+	cfg.FeeDiscountBps = 20_000
+	if got := cfg.feeDiscount(100_000, 10*ShareScale); got != 100_000 {
+		t.Fatalf("over-100%% discount = %d, want the whole fee", got)
+	}
+}
+
+func TestNextHaltTicks(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got := cfg.nextHaltTicks(0, -5_000); got != 0 {
+		t.Fatalf("disabled breaker tripped: %d", got)
+	}
+	cfg.CircuitBreakerDropBps = 700
+	cfg.CircuitBreakerHaltTicks = 3
+	cases := []struct {
+		remaining int32
+		change    int64
+		want      int32
+	}{
+		{0, -699, 0},
+		{0, -700, 3},
+		{0, -1_500, 3},
+		{3, 0, 2},
+		{1, 250, 0},
+		{1, -800, 3},
+		{5, -800, 4},
+	}
+	for _, c := range cases {
+		if got := cfg.nextHaltTicks(c.remaining, c.change); got != c.want {
+			t.Fatalf("nextHaltTicks(%d, %d) = %d, want %d", c.remaining, c.change, got, c.want)
+		}
+	}
+}
+
+func TestHireCostMicrosScalesWithHeadcount(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	base := int64(100) * MicrosPerStonky
+	for _, n := range []int64{0, 5, 40} {
+		if got, want := cfg.hireCostMicros(base, n, 0), scaledHireCostMicros(base, n, 0); got != want {
+			t.Fatalf("disabled surcharge at %d employees = %d, want curve %d", n, got, want)
+		}
+	}
+	cfg.HireCostHeadcountBps = 500
+	if got := cfg.hireCostMicros(base, 0, 0); got != base {
+		t.Fatalf("first hire = %d, want %d", got, base)
+	}
+	curve := scaledHireCostMicros(base, 10, 0)
+	if got, want := cfg.hireCostMicros(base, 10, 0), curve*3/2; got != want {
+		t.Fatalf("10 employees at 5%% each = %d, want %d", got, want)
+	}
+	if got, want := cfg.hireCostMicros(base, 8, 2), cfg.hireCostMicros(base, 10, 0); got != want {
+		t.Fatalf("batch pick = %d, want same as single hire at that headcount %d", got, want)
+	}
+	if got := cfg.hireCostMicros(0, 10, 0); got != 0 {
+		t.Fatalf("free candidate = %d, want 0", got)
+	}
+}
+
+func TestBusinessTaxMicros(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got := cfg.businessTaxMicros(10_000_000); got != 0 {
+		t.Fatalf("default tax = %d, want 0", got)
+	}
+	cfg.BusinessTaxThresholdMicros = 1_000_000
+	cfg.BusinessTaxBps = 2_000
+	if got := cfg.businessTaxMicros(1_000_000); got != 0 {
+		t.Fatalf("income at threshold taxed %d, want 0", got)
+	}
+	if got := cfg.businessTaxMicros(1_500_000); got != 100_000 {
+		t.Fatalf("tax on 0.5 over threshold = %d, want 100000", got)
+	}
+	if got := cfg.businessTaxMicros(-3_000_000); got != 0 {
+		t.Fatalf("losses taxed %d, want 0", got)
+	}
+}
+
+func TestBlendBusinessAnchorReturn(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got := cfg.blendBusinessAnchorReturn(0.01, 5_000_000, 1_000_000, 1_000_000); got != 0.01 {
+		t.Fatalf("default blend = %f, want the random return", got)
+	}
+	cfg.BusinessPriceWeight = 0.5
+	if got, want := cfg.blendBusinessAnchorReturn(0.01, 1_500_000, 1_000_000, 1_000_000), 0.5*0.01+0.5*0.5*maxFundamentalAnchorMove; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("growth blend = %f, want %f", got, want)
+	}
+	if got, want := cfg.blendBusinessAnchorReturn(0, -9_000_000, 1_000_000, 1_000_000), -0.5*maxFundamentalAnchorMove; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("crisis blend = %f, want capped %f", got, want)
+	}
+}
+
+func TestRoundToPriceTick(t *testing.T) {
+	cfg := defaultSeasonSettings()
+	if got := cfg.roundToPriceTick(94_237_651, 10_000, 1_000_000_000); got != 94_237_651 {
+		t.Fatalf("default tick = %d, want full precision", got)
+	}
+	cfg.PriceTickMicros = 10_000
+	if got := cfg.roundToPriceTick(94_237_651, 10_000, 1_000_000_000); got != 94_240_000 {
+		t.Fatalf("rounded up = %d, want 94240000", got)
+	}
+	if got := cfg.roundToPriceTick(94_234_999, 10_000, 1_000_000_000); got != 94_230_000 {
+		t.Fatalf("rounded down = %d, want 94230000", got)
+	}
+	cfg.PriceTickMicros = 30_000
+	if got := cfg.roundToPriceTick(10_000, 10_000, 1_000_000_000); got != 30_000 {
+		t.Fatalf("floor = %d, want 30000", got)
+	}
+	if got := cfg.roundToPriceTick(1_000_000_000, 10_000, 1_000_000_000); got != 999_990_000 {
+		t.Fatalf("ceiling = %d, want 999990000", got)
+	}
+}
@@ -0,0 +1,60 @@
+package game
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const (
+	// SentimentWindow is how far back order flow counts toward a stock's
+	// sentiment.
+	SentimentWindow = 24 * time.Hour
+
+	// Buy share of traded volume at or beyond which sentiment turns
+	// bullish, and at or below which it turns bearish.
+	sentimentBullishBps = 6_000
+	sentimentBearishBps = 4_000
+
+	SentimentBullish = "bullish"
+	SentimentBearish = "bearish"
+	SentimentNeutral = "neutral"
+)
+
+// StockSentiment gauges crowd behavior on a stock from the buy and sell
+// volume players traded over the last SentimentWindow.
+func (s *Service) StockSentiment(ctx context.Context, seasonID int64, symbol string) (StockSentiment, error) {
+	out := StockSentiment{WindowSeconds: int64(SentimentWindow / time.Second)}
+	if err := s.reader().QueryRow(ctx, `
+		SELECT COALESCE(SUM(o.quantity_units) FILTER (WHERE o.side = 'buy'), 0)::bigint,
+		       COALESCE(SUM(o.quantity_units) FILTER (WHERE o.side = 'sell'), 0)::bigint,
+		       COUNT(o.id)
+		FROM game.stocks st
+		LEFT JOIN game.orders o
+		  ON o.stock_id = st.id
+		 AND o.created_at >= now() - make_interval(secs => $3)
+		WHERE st.season_id = $1 AND st.symbol = $2
+	`, seasonID, strings.ToUpper(symbol), float64(out.WindowSeconds)).Scan(&out.BuyUnits, &out.SellUnits, &out.Orders); err != nil {
+		return out, err
+	}
+	out.Label, out.BuyShareBps = sentimentFromVolume(out.BuyUnits, out.SellUnits)
+	return out, nil
+}
+
+// sentimentFromVolume labels order flow by the buy share of traded volume,
+// in bps. No volume reads as neutral at an even split.
+func sentimentFromVolume(buyUnits, sellUnits int64) (string, int64) {
+	total := buyUnits + sellUnits
+	if buyUnits < 0 || sellUnits < 0 || total <= 0 {
+		return SentimentNeutral, 5_000
+	}
+	bps := int64(float64(buyUnits) / float64(total) * 10_000)
+	switch {
+	case bps >= sentimentBullishBps:
+		return SentimentBullish, bps
+	case bps <= sentimentBearishBps:
+		return SentimentBearish, bps
+	default:
+		return SentimentNeutral, bps
+	}
+}
@@ -0,0 +1,24 @@
+package game
+
+import "testing"
+
+func TestSentimentFromVolume(t *testing.T) {
+	cases := []struct {
+		buy, sell int64
+		label     string
+		bps       int64
+	}{
+		{0, 0, SentimentNeutral, 5_000},
+		{7, 3, SentimentBullish, 7_000},
+		{6, 4, SentimentBullish, 6_000},
+		{5, 5, SentimentNeutral, 5_000},
+		{4, 6, SentimentBearish, 4_000},
+		{0, 9, SentimentBearish, 0},
+	}
+	for _, c := range cases {
+		label, bps := sentimentFromVolume(c.buy, c.sell)
+		if label != c.label || bps != c.bps {
+			t.Fatalf("sentimentFromVolume(%d, %d) = %s, %d; want %s, %d", c.buy, c.sell, label, bps, c.label, c.bps)
+		}
+	}
+}
@@ -35,10 +35,11 @@ var blockedNameFragments = []string{
 }
 
 type Service struct {
-	db   *pgxpool.Pool
-	log  *slog.Logger
-	mu   sync.Mutex
-	rand *mathrand.Rand
+	db      *pgxpool.Pool
+	replica *pgxpool.Pool
+	log     *slog.Logger
+	mu      sync.Mutex
+	rand    *mathrand.Rand
 }
 
 func NewService(db *pgxpool.Pool, logger *slog.Logger) *Service {
@@ -52,6 +53,20 @@ func NewService(db *pgxpool.Pool, logger *slog.Logger) *Service {
 	}
 }
 
+// UseReadReplica routes read-only views (stock lists, leaderboards, dashboard)
+// to a replica pool. Mutations and anything that lazily inserts rows keep
+// using the primary pool.
+func (s *Service) UseReadReplica(replica *pgxpool.Pool) {
+	s.replica = replica
+}
+
+func (s *Service) reader() *pgxpool.Pool {
+	if s.replica != nil {
+		return s.replica
+	}
+	return s.db
+}
+
 func (s *Service) ActiveSeasonID(ctx context.Context) (int64, error) {
 	var seasonID int64
 	err := s.db.QueryRow(ctx, `
@@ -70,16 +85,51 @@ func (s *Service) ActiveSeasonID(ctx context.Context) (int64, error) {
 
 	err = s.db.QueryRow(ctx, `
 		INSERT INTO game.seasons (name, status, starts_at, ends_at)
-		VALUES ($1, 'active', now(), now() + interval '90 days')
+		SELECT 'Season ' || (COUNT(*) + 1), 'active', now(), now() + interval '90 days'
+		FROM game.seasons
 		RETURNING id
-	`, "Season 1").Scan(&seasonID)
+	`).Scan(&seasonID)
 	if err != nil {
 		return 0, err
 	}
 	return seasonID, nil
 }
 
-func (s *Service) EnsurePlayer(ctx context.Context, userID, email, username string) error {
+// ResolveInviter returns the user who owns inviteCode, or ErrInviteRequired
+// when the code is blank or unknown.
+func (s *Service) ResolveInviter(ctx context.Context, inviteCode string) (string, error) {
+	inviteCode = strings.ToUpper(strings.TrimSpace(inviteCode))
+	if inviteCode == "" {
+		return "", ErrInviteRequired
+	}
+	var userID string
+	if err := s.db.QueryRow(ctx, `SELECT user_id FROM users.profiles WHERE invite_code = $1`, inviteCode).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrInviteRequired
+		}
+		return "", err
+	}
+	return userID, nil
+}
+
+// PlayerExists reports whether userID already has a profile.
+func (s *Service) PlayerExists(ctx context.Context, userID string) (bool, error) {
+	var exists bool
+	err := s.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users.profiles WHERE user_id = $1)`, userID).Scan(&exists)
+	return exists, err
+}
+
+const (
+	// inviteCodeLength gives 32^10 (about 10^15) possible invite codes.
+	// Codes issued when they were 8 characters long stay valid.
+	inviteCodeLength = 10
+	// ensurePlayerAttempts bounds the retries on invite code collisions.
+	ensurePlayerAttempts = 5
+)
+
+// EnsurePlayer creates the profile and active-season wallet for userID if
+// missing. invitedBy is recorded only when the profile is first created.
+func (s *Service) EnsurePlayer(ctx context.Context, userID, email, username, invitedBy string) error {
 	seasonID, err := s.ActiveSeasonID(ctx)
 	if err != nil {
 		return err
@@ -91,11 +141,22 @@ func (s *Service) EnsurePlayer(ctx context.Context, userID, email, username stri
 	if !usernameRE.MatchString(username) {
 		username = sanitizeUsername(usernameFromEmail(email))
 	}
-	inviteCode, err := generateInviteCode()
-	if err != nil {
-		return err
+	// A fresh invite code colliding with an existing one is rare but would
+	// otherwise fail the login outright, so draw a new code and try again.
+	for attempt := 1; ; attempt++ {
+		inviteCode, err := generateInviteCode()
+		if err != nil {
+			return err
+		}
+		err = s.ensurePlayerTx(ctx, seasonID, userID, email, username, inviteCode, invitedBy)
+		if err == nil || !isInviteCodeConflict(err) || attempt == ensurePlayerAttempts {
+			return err
+		}
+		s.log.Warn("invite code collision, retrying", "user_id", userID, "attempt", attempt)
 	}
+}
 
+func (s *Service) ensurePlayerTx(ctx context.Context, seasonID int64, userID, email, username, inviteCode, invitedBy string) error {
 	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
 	if err != nil {
 		return err
@@ -103,10 +164,10 @@ func (s *Service) EnsurePlayer(ctx context.Context, userID, email, username stri
 	defer tx.Rollback(ctx)
 
 	_, err = tx.Exec(ctx, `
-		INSERT INTO users.profiles (user_id, email, username, invite_code)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO users.profiles (user_id, email, username, invite_code, invited_by_user_id)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
 		ON CONFLICT (user_id) DO NOTHING
-	`, userID, email, username, inviteCode)
+	`, userID, email, username, inviteCode, invitedBy)
 	if err != nil {
 		return err
 	}
@@ -133,27 +194,28 @@ func (s *Service) SeedDefaults(ctx context.Context, seasonID int64) error {
 		Symbol string
 		Name   string
 		Price  int64
+		Tier   string
 	}{
-		{"COBOLT", "Cobalt Dynamics", 130 * MicrosPerStonky},
-		{"NIMBUS", "Nimbus Labs", 95 * MicrosPerStonky},
-		{"RUSTIC", "Rustic Systems", 115 * MicrosPerStonky},
-		{"PYLONS", "Pylon Networks", 80 * MicrosPerStonky},
-		{"JAVOLT", "Javolt Cloud", 105 * MicrosPerStonky},
-		{"SWIFTR", "Swiftr Mobile", 150 * MicrosPerStonky},
-		{"KOTLIN", "Kotlin Forge", 90 * MicrosPerStonky},
-		{"NODEON", "Nodeon Runtime", 120 * MicrosPerStonky},
-		{"RUBYIX", "Rubyix Core", 70 * MicrosPerStonky},
-		{"ELIXIR", "Elixir Ops", 125 * MicrosPerStonky},
-		{"QUARKX", "Quarkx Compute", 135 * MicrosPerStonky},
-		{"VECTRA", "Vectra AI", 165 * MicrosPerStonky},
-		{"DATUMX", "Datumx Data", 85 * MicrosPerStonky},
-		{"CYBRON", "Cybron Secure", 140 * MicrosPerStonky},
-		{"FUSION", "Fusion Grid", 110 * MicrosPerStonky},
-		{"NEBULA", "Nebula Energy", 92 * MicrosPerStonky},
-		{"ORBITZ", "Orbitz Space", 180 * MicrosPerStonky},
-		{"ZENITH", "Zenith Retail", 75 * MicrosPerStonky},
-		{"ARCANE", "Arcane Finance", 145 * MicrosPerStonky},
-		{"LUMINA", "Lumina Health", 102 * MicrosPerStonky},
+		{"COBOLT", "Cobalt Dynamics", 130 * MicrosPerStonky, VolatilityNormal},
+		{"NIMBUS", "Nimbus Labs", 95 * MicrosPerStonky, VolatilityNormal},
+		{"RUSTIC", "Rustic Systems", 115 * MicrosPerStonky, VolatilityNormal},
+		{"PYLONS", "Pylon Networks", 80 * MicrosPerStonky, VolatilityNormal},
+		{"JAVOLT", "Javolt Cloud", 105 * MicrosPerStonky, VolatilityNormal},
+		{"SWIFTR", "Swiftr Mobile", 150 * MicrosPerStonky, VolatilityNormal},
+		{"KOTLIN", "Kotlin Forge", 90 * MicrosPerStonky, VolatilityNormal},
+		{"NODEON", "Nodeon Runtime", 120 * MicrosPerStonky, VolatilityNormal},
+		{"RUBYIX", "Rubyix Core", 70 * MicrosPerStonky, VolatilityNormal},
+		{"ELIXIR", "Elixir Ops", 125 * MicrosPerStonky, VolatilityNormal},
+		{"QUARKX", "Quarkx Compute", 135 * MicrosPerStonky, VolatilityWild},
+		{"VECTRA", "Vectra AI", 165 * MicrosPerStonky, VolatilityWild},
+		{"DATUMX", "Datumx Data", 85 * MicrosPerStonky, VolatilityNormal},
+		{"CYBRON", "Cybron Secure", 140 * MicrosPerStonky, VolatilityNormal},
+		{"FUSION", "Fusion Grid", 110 * MicrosPerStonky, VolatilityCalm},
+		{"NEBULA", "Nebula Energy", 92 * MicrosPerStonky, VolatilityCalm},
+		{"ORBITZ", "Orbitz Space", 180 * MicrosPerStonky, VolatilityWild},
+		{"ZENITH", "Zenith Retail", 75 * MicrosPerStonky, VolatilityCalm},
+		{"ARCANE", "Arcane Finance", 145 * MicrosPerStonky, VolatilityWild},
+		{"LUMINA", "Lumina Health", 102 * MicrosPerStonky, VolatilityCalm},
 	}
 
 	tx, err := s.db.Begin(ctx)
@@ -163,11 +225,16 @@ func (s *Service) SeedDefaults(ctx context.Context, seasonID int64) error {
 	defer tx.Rollback(ctx)
 
 	if count == 0 {
+		settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+		if err != nil {
+			return err
+		}
 		for _, row := range seed {
+			volBps, _ := VolatilityTierBps(row.Tier)
 			_, err := tx.Exec(ctx, `
-				INSERT INTO game.stocks (season_id, symbol, display_name, listed_public, current_price_micros, anchor_price_micros, created_by_user_id)
-				VALUES ($1, $2, $3, true, $4, $4, NULL)
-			`, seasonID, row.Symbol, row.Name, row.Price)
+				INSERT INTO game.stocks (season_id, symbol, display_name, listed_public, current_price_micros, anchor_price_micros, created_by_user_id, volatility_bps, shares_outstanding_units, dividend_bps)
+				VALUES ($1, $2, $3, true, $4, $4, NULL, $5, $6, $7)
+			`, seasonID, row.Symbol, row.Name, row.Price, volBps, settings.sharesOutstandingUnits(), seedDividendBps(row.Tier))
 			if err != nil {
 				return err
 			}
@@ -217,6 +284,8 @@ type businessCycle struct {
 	brandBps            int32
 	healthBps           int32
 	reserveMicros       int64
+	revenueMode         string
+	unclaimedMicros     int64
 	employeeRevenue     int64
 	employeeCount       int64
 	avgRiskBps          float64
@@ -232,10 +301,11 @@ type businessCycle struct {
 	machineOutput       int64
 	machineUpkeep       int64
 	loanOutstanding     int64
-	loanInterest        int64
 	stockID             *int64
 	stockPrice          int64
 	stockAnchorPrice    int64
+	idleTicks           int32
+	idleDecayBps        int32
 }
 
 type businessProjection struct {
@@ -275,6 +345,8 @@ func loadBusinessCyclesTx(ctx context.Context, tx pgx.Tx, seasonID int64, ownerU
 		       b.brand_bps,
 		       b.operational_health_bps,
 		       b.cash_reserve_micros,
+		       b.revenue_mode,
+		       b.unclaimed_revenue_micros,
 		       COALESCE(be.employee_revenue, 0) AS employee_revenue,
 		       b.employee_count AS employee_count,
 		       COALESCE(be.avg_risk_bps, 0) AS avg_risk_bps,
@@ -290,10 +362,10 @@ func loadBusinessCyclesTx(ctx context.Context, tx pgx.Tx, seasonID int64, ownerU
 		       COALESCE(m.output_bonus, 0) AS machine_output,
 		       COALESCE(m.upkeep, 0) AS machine_upkeep,
 		       COALESCE(l.loan_outstanding, 0) AS loan_outstanding,
-		       COALESCE(l.loan_interest, 0) AS loan_interest,
 		       bs.id,
 		       COALESCE(bs.current_price_micros, 0),
-		       COALESCE(bs.anchor_price_micros, 0)
+		       COALESCE(bs.anchor_price_micros, 0),
+		       b.idle_ticks
 		FROM game.businesses b
 		JOIN users.profiles owner ON owner.user_id = b.owner_user_id
 		LEFT JOIN LATERAL (
@@ -318,8 +390,7 @@ func loadBusinessCyclesTx(ctx context.Context, tx pgx.Tx, seasonID int64, ownerU
 			WHERE bm.business_id = b.id AND bm.season_id = b.season_id
 		) m ON TRUE
 		LEFT JOIN LATERAL (
-			SELECT COALESCE(SUM(bl.outstanding_micros), 0) AS loan_outstanding,
-			       COALESCE(SUM(((bl.outstanding_micros::numeric * bl.interest_bps::numeric) / 10000)::bigint), 0) AS loan_interest
+			SELECT COALESCE(SUM(bl.outstanding_micros), 0) AS loan_outstanding
 			FROM game.business_loans bl
 			WHERE bl.business_id = b.id AND bl.season_id = b.season_id AND bl.status = 'open'
 		) l ON TRUE
@@ -346,6 +417,10 @@ func loadBusinessCyclesTx(ctx context.Context, tx pgx.Tx, seasonID int64, ownerU
 	}
 	query += " ORDER BY b.id"
 
+	settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+	if err != nil {
+		return nil, err
+	}
 	rows, err := tx.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
@@ -359,14 +434,15 @@ func loadBusinessCyclesTx(ctx context.Context, tx pgx.Tx, seasonID int64, ownerU
 		if err := rows.Scan(
 			&c.businessID, &c.userID, &c.name, &c.controllerUsername, &c.visibility, &c.isListed, &c.stockSymbol, &c.primaryRegion, &c.narrativeArc, &c.narrativeFocus, &c.narrativePressure, &c.cyclePhase, &c.cycleTicksRemaining, &c.cycleImpactBps, &c.employeeLimit, &c.strategy,
 			&c.baseRevenue, &c.lastEvent, &c.marketingLevel, &c.rdLevel, &c.automationLevel, &c.complianceLevel,
-			&c.brandBps, &c.healthBps, &c.reserveMicros,
+			&c.brandBps, &c.healthBps, &c.reserveMicros, &c.revenueMode, &c.unclaimedMicros,
 			&c.employeeRevenue, &c.employeeCount, &c.avgRiskBps,
 			&c.opsCount, &c.engineerCount, &c.productCount, &c.salesCount, &c.growthCount, &c.financeCount, &c.legalCount, &c.designCount,
-			&c.machineryCount, &c.machineOutput, &c.machineUpkeep, &c.loanOutstanding, &c.loanInterest,
-			&stockID, &c.stockPrice, &c.stockAnchorPrice,
+			&c.machineryCount, &c.machineOutput, &c.machineUpkeep, &c.loanOutstanding,
+			&stockID, &c.stockPrice, &c.stockAnchorPrice, &c.idleTicks,
 		); err != nil {
 			return nil, err
 		}
+		c.idleDecayBps = settings.IdleRevenueDecayBps
 		if stockID.Valid {
 			id := stockID.Int64
 			c.stockID = &id
@@ -411,7 +487,15 @@ func projectBusinessCycle(c businessCycle) businessProjection {
 	machineOutput := int64(math.Round(float64(c.machineOutput) * autoBoost))
 	machineUpkeep := int64(math.Round(float64(c.machineUpkeep) * (1 - upkeepCut) * team.MachineUpkeepFactor))
 
-	preMultiplierGross := c.baseRevenue + employeeRevenue + machineOutput
+	// Mirror the tick: a business with no staff and no machines decays for
+	// one more idle tick.
+	idleTicks := int32(0)
+	if c.employeeCount == 0 && c.machineryCount == 0 {
+		idleTicks = c.idleTicks + 1
+	}
+	baseRevenue := idleBaseRevenueMicros(c.baseRevenue, idleTicks, c.idleDecayBps)
+
+	preMultiplierGross := baseRevenue + employeeRevenue + machineOutput
 	gross := int64(math.Round(float64(preMultiplierGross) * marketingBoost * rdBoost * brandBoost * healthBoost * team.RevenueMultiplier))
 	gross = int64(math.Round(float64(gross) * businessCycleRevenueMultiplier(c)))
 	if c.visibility == "public" {
@@ -456,7 +540,7 @@ func projectBusinessCycle(c businessCycle) businessProjection {
 	maintenanceCost = int64(math.Round(float64(maintenanceCost) * costMultiplier))
 	machineryMaintenance := machineUpkeep
 	upgradeBurn := int64((int64(c.marketingLevel)*5 + int64(c.rdLevel)*5 + int64(c.automationLevel)*4 + int64(c.complianceLevel)*4) * MicrosPerStonky)
-	totalCosts := salaryCost + maintenanceCost + machineryMaintenance + c.loanInterest + upgradeBurn + riskPenalty
+	totalCosts := salaryCost + maintenanceCost + machineryMaintenance + upgradeBurn + riskPenalty
 
 	return businessProjection{
 		GrossRevenueMicros:   gross,
@@ -469,6 +553,36 @@ func projectBusinessCycle(c businessCycle) businessProjection {
 	}
 }
 
+// withHiredEmployee returns the cycle as it would look after adding one
+// employee. rosterSize is the number of employee rows averaged into avgRiskBps.
+func withHiredEmployee(c businessCycle, rosterSize int64, role string, revenue int64, risk int32) businessCycle {
+	if rosterSize < 0 {
+		rosterSize = 0
+	}
+	c.avgRiskBps = (c.avgRiskBps*float64(rosterSize) + float64(risk)) / float64(rosterSize+1)
+	c.employeeRevenue += revenue
+	c.employeeCount++
+	switch role {
+	case "ops":
+		c.opsCount++
+	case "engineer":
+		c.engineerCount++
+	case "product":
+		c.productCount++
+	case "sales":
+		c.salesCount++
+	case "growth":
+		c.growthCount++
+	case "finance":
+		c.financeCount++
+	case "legal":
+		c.legalCount++
+	case "design":
+		c.designCount++
+	}
+	return c
+}
+
 func estimateBusinessValuationMicros(c businessCycle, p businessProjection) int64 {
 	operating := p.RevenuePerTickMicros
 	if operating < 0 {
@@ -556,48 +670,86 @@ func effectiveEmployeeLimit(limit int64) int64 {
 	return limit
 }
 
-func (s *Service) Dashboard(ctx context.Context, userID string, seasonID int64) (Dashboard, error) {
-	var out Dashboard
-	out.SeasonID = seasonID
+// Positions returns just the player's stock positions, without the business
+// and stake joins Dashboard does.
+func (s *Service) Positions(ctx context.Context, userID string, seasonID int64) ([]PositionView, error) {
+	out, _, err := s.positionViews(ctx, userID, seasonID)
+	return out, err
+}
 
-	err := s.db.QueryRow(ctx, `
-		SELECT balance_micros, peak_net_worth_micros, active_business_id
-		FROM game.wallets
-		WHERE user_id = $1 AND season_id = $2
-	`, userID, seasonID).Scan(&out.BalanceMicros, &out.PeakNetWorthMicros, &out.ActiveBusinessID)
+// positionViews loads the player's positions with unrealized P/L and returns
+// their total market value alongside.
+func (s *Service) positionViews(ctx context.Context, userID string, seasonID int64) ([]PositionView, int64, error) {
+	tx, err := s.reader().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
 	if err != nil {
-		return out, err
+		return nil, 0, err
 	}
-
-	rows, err := s.db.Query(ctx, `
-		SELECT s.symbol, s.display_name, p.quantity_units, p.avg_price_micros, s.current_price_micros
+	defer tx.Rollback(ctx)
+	// Break-even prices follow the season's fees and this player's discount.
+	settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+	if err != nil {
+		return nil, 0, err
+	}
+	fundUnits, err := feeDiscountUnitsTx(ctx, tx, settings, userID, seasonID)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, err := tx.Query(ctx, `
+		SELECT s.symbol, s.display_name, p.quantity_units, p.avg_price_micros, s.current_price_micros, p.dividends_micros
 		FROM game.positions p
 		JOIN game.stocks s ON s.id = p.stock_id
 		WHERE p.user_id = $1 AND p.season_id = $2
 		ORDER BY s.symbol
 	`, userID, seasonID)
 	if err != nil {
-		return out, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
+	var out []PositionView
 	var holdings int64
 	for rows.Next() {
 		var pos PositionView
-		if err := rows.Scan(&pos.Symbol, &pos.DisplayName, &pos.QuantityUnits, &pos.AvgPriceMicros, &pos.CurrentPriceMicros); err != nil {
-			return out, err
+		if err := rows.Scan(&pos.Symbol, &pos.DisplayName, &pos.QuantityUnits, &pos.AvgPriceMicros, &pos.CurrentPriceMicros, &pos.DividendsMicros); err != nil {
+			return nil, 0, err
 		}
 		marketValue := notionalMicrosClamped(pos.CurrentPriceMicros, pos.QuantityUnits)
 		costValue := notionalMicrosClamped(pos.AvgPriceMicros, pos.QuantityUnits)
 		pos.UnrealizedMicros = saturatingSubInt64(marketValue, costValue)
+		pos.BreakEvenMicros = settings.breakEvenPrice(pos.AvgPriceMicros, pos.QuantityUnits, fundUnits)
+		if pos.QuantityUnits < 0 {
+			pos.BreakEvenMicros = settings.shortBreakEvenPrice(pos.AvgPriceMicros, -pos.QuantityUnits, fundUnits)
+		}
 		holdings = saturatingAddInt64(holdings, marketValue)
-		out.Positions = append(out.Positions, pos)
+		out = append(out, pos)
 	}
-	if err := rows.Err(); err != nil {
+	return out, holdings, rows.Err()
+}
+
+func (s *Service) Dashboard(ctx context.Context, userID string, seasonID int64) (Dashboard, error) {
+	var out Dashboard
+	out.SeasonID = seasonID
+
+	err := s.reader().QueryRow(ctx, `
+		SELECT w.balance_micros, w.peak_net_worth_micros, w.active_business_id, se.starts_at
+		FROM game.wallets w
+		JOIN game.seasons se ON se.id = w.season_id
+		WHERE w.user_id = $1 AND w.season_id = $2
+	`, userID, seasonID).Scan(&out.BalanceMicros, &out.PeakNetWorthMicros, &out.ActiveBusinessID, &out.SeasonStartsAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return out, ErrWalletNotFound
+	}
+	if err != nil {
 		return out, err
 	}
 
-	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	positions, holdings, err := s.positionViews(ctx, userID, seasonID)
+	if err != nil {
+		return out, err
+	}
+	out.Positions = positions
+
+	tx, err := s.reader().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
 	if err != nil {
 		return out, err
 	}
@@ -644,6 +796,8 @@ func (s *Service) Dashboard(ctx context.Context, userID string, seasonID int64)
 			BrandBps:              c.brandBps,
 			OperationalHealthBps:  c.healthBps,
 			CashReserveMicros:     c.reserveMicros,
+			RevenueMode:           c.revenueMode,
+			UnclaimedMicros:       c.unclaimedMicros,
 			LastEvent:             c.lastEvent,
 			OwnedStakeBps:         ownedStakeBps,
 		})
@@ -668,6 +822,35 @@ func (s *Service) Dashboard(ctx context.Context, userID string, seasonID int64)
 	netWorth = saturatingAddInt64(netWorth, fundHoldings)
 	netWorth = saturatingAddInt64(netWorth, stakeHoldings)
 	out.NetWorthMicros = netWorth
+	ahead, players, err := s.leaderboardStanding(ctx, userID, seasonID)
+	if err != nil {
+		return out, err
+	}
+	out.LeaderboardTopBps = leaderboardTopBps(ahead, players)
+	out.LeaderboardPlayers = players
+	if beta, ok, err := s.PortfolioBeta(ctx, userID, seasonID); err != nil {
+		return out, err
+	} else if ok {
+		out.PortfolioBeta = &beta
+	}
+	out.DividendsReceivedMicros, out.DividendsReinvestedMicros, err = s.dividendTotals(ctx, userID, seasonID)
+	if err != nil {
+		return out, err
+	}
+	if err := s.reader().QueryRow(ctx, `
+		SELECT COALESCE(SUM(realized_pl_micros), 0)::bigint
+		FROM game.orders
+		WHERE user_id = $1 AND season_id = $2
+	`, userID, seasonID).Scan(&out.RealizedPLMicros); err != nil {
+		return out, err
+	}
+	if err := s.reader().QueryRow(ctx, `
+		SELECT COALESCE(SUM(realized_micros), 0)::bigint
+		FROM game.realized_pnl
+		WHERE user_id = $1
+	`, userID).Scan(&out.LifetimeRealizedPLMicros); err != nil {
+		return out, err
+	}
 	out.Progression, err = s.playerProgress(ctx, userID, seasonID)
 	if err != nil {
 		return out, err
@@ -687,6 +870,56 @@ func (s *Service) WalletSummary(ctx context.Context, userID string, seasonID int
 		FROM game.wallets
 		WHERE user_id = $1 AND season_id = $2
 	`, userID, seasonID).Scan(&out.BalanceMicros, &out.PeakNetWorthMicros, &out.ActiveBusinessID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out, ErrWalletNotFound
+		}
+		return out, err
+	}
+	return out, nil
+}
+
+// dividendTotals sums the user's dividend ledger entries for the season.
+// Amounts are taken as absolute values so a reinvestment counts the same
+// whichever way round its wallet leg was booked.
+func (s *Service) dividendTotals(ctx context.Context, userID string, seasonID int64) (received, reinvested int64, err error) {
+	err = s.reader().QueryRow(ctx, `
+		SELECT COALESCE(SUM(ABS(delta_micros)) FILTER (WHERE metadata->>'action' IN ('dividend', 'dividend_reinvest')), 0)::bigint,
+		       COALESCE(SUM(ABS(delta_micros)) FILTER (WHERE metadata->>'action' = 'dividend_reinvest'), 0)::bigint
+		FROM game.ledger_entries
+		WHERE user_id = $1 AND season_id = $2 AND account = 'wallet'
+	`, userID, seasonID).Scan(&received, &reinvested)
+	return received, reinvested, err
+}
+
+func (s *Service) MyCosts(ctx context.Context, userID string, seasonID int64) (CostBreakdown, error) {
+	out := CostBreakdown{SeasonID: seasonID}
+	if err := s.reader().QueryRow(ctx, `
+		SELECT COALESCE(SUM(-delta_micros) FILTER (WHERE account = 'fees'), 0),
+		       COALESCE(SUM(-delta_micros) FILTER (WHERE account = 'wallet' AND metadata->>'action' = 'debt_interest'), 0),
+		       COALESCE(SUM(-delta_micros) FILTER (WHERE account = 'wallet' AND metadata->>'action' = 'business_loan_late_fee'), 0),
+		       COALESCE(SUM(-delta_micros) FILTER (WHERE account = 'wallet' AND metadata->>'action' = 'business_cycle_loss'), 0),
+		       COALESCE(SUM(-delta_micros) FILTER (WHERE account = 'wallet' AND metadata->>'action' = 'business_tax'), 0)
+		FROM game.ledger_entries
+		WHERE user_id = $1 AND season_id = $2
+	`, userID, seasonID).Scan(&out.TradeFeesMicros, &out.DebtInterestMicros, &out.LoanLateFeesMicros, &out.BusinessLossesMicros, &out.BusinessTaxMicros); err != nil {
+		return out, err
+	}
+	out.TotalMicros = saturatingAddInt64(out.TradeFeesMicros, out.DebtInterestMicros)
+	out.TotalMicros = saturatingAddInt64(out.TotalMicros, out.LoanLateFeesMicros)
+	out.TotalMicros = saturatingAddInt64(out.TotalMicros, out.BusinessLossesMicros)
+	out.TotalMicros = saturatingAddInt64(out.TotalMicros, out.BusinessTaxMicros)
+	return out, nil
+}
+
+func (s *Service) PlayerProfile(ctx context.Context, userID string, seasonID int64) (PlayerProfile, error) {
+	var out PlayerProfile
+	out.UserID = userID
+	out.SeasonID = seasonID
+	if err := s.db.QueryRow(ctx, `
+		SELECT email, username, invite_code, created_at
+		FROM users.profiles
+		WHERE user_id = $1
+	`, userID).Scan(&out.Email, &out.Username, &out.InviteCode, &out.CreatedAt); err != nil {
 		return out, err
 	}
 	return out, nil
@@ -694,7 +927,10 @@ func (s *Service) WalletSummary(ctx context.Context, userID string, seasonID int
 
 func (s *Service) ListStocks(ctx context.Context, seasonID int64, includeUnlisted bool) ([]StockView, error) {
 	query := `
-		SELECT symbol, display_name, current_price_micros, listed_public
+		SELECT symbol, display_name, current_price_micros, listed_public, volatility_bps, shares_outstanding_units,
+		       CASE WHEN shares_outstanding_units > 0 THEN GREATEST(0, shares_outstanding_units - COALESCE((
+		           SELECT SUM(p.quantity_units) FROM game.positions p WHERE p.stock_id = game.stocks.id AND p.quantity_units > 0
+		       ), 0)) ELSE 0 END
 		FROM game.stocks
 		WHERE season_id = $1
 	`
@@ -702,7 +938,7 @@ func (s *Service) ListStocks(ctx context.Context, seasonID int64, includeUnliste
 		query += " AND listed_public = true"
 	}
 	query += " ORDER BY symbol"
-	rows, err := s.db.Query(ctx, query, seasonID)
+	rows, err := s.reader().Query(ctx, query, seasonID)
 	if err != nil {
 		return nil, err
 	}
@@ -710,23 +946,57 @@ func (s *Service) ListStocks(ctx context.Context, seasonID int64, includeUnliste
 	var out []StockView
 	for rows.Next() {
 		var s StockView
-		if err := rows.Scan(&s.Symbol, &s.DisplayName, &s.CurrentPriceMicros, &s.ListedPublic); err != nil {
+		if err := rows.Scan(&s.Symbol, &s.DisplayName, &s.CurrentPriceMicros, &s.ListedPublic, &s.VolatilityBps, &s.SharesOutstandingUnits, &s.AvailableUnits); err != nil {
 			return nil, err
 		}
+		s.VolatilityTier = VolatilityTierName(s.VolatilityBps)
 		out = append(out, s)
 	}
 	return out, rows.Err()
 }
 
+// RecentIPOs lists stocks that went public within the last sinceTicks market
+// ticks, newest first.
+func (s *Service) RecentIPOs(ctx context.Context, seasonID, sinceTicks int64) ([]RecentIPO, error) {
+	rows, err := s.reader().Query(ctx, `
+		SELECT st.symbol, st.display_name, st.business_id, COALESCE(b.name, ''),
+		       st.ipo_price_micros, st.current_price_micros, st.listed_at,
+		       se.tick_count - st.listed_tick
+		FROM game.stocks st
+		JOIN game.seasons se ON se.id = st.season_id
+		LEFT JOIN game.businesses b ON b.id = st.business_id
+		WHERE st.season_id = $1
+		  AND st.listed_public = true
+		  AND st.listed_tick IS NOT NULL
+		  AND st.listed_tick > se.tick_count - $2
+		ORDER BY st.listed_at DESC, st.symbol
+	`, seasonID, sinceTicks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []RecentIPO
+	for rows.Next() {
+		var r RecentIPO
+		if err := rows.Scan(&r.Symbol, &r.DisplayName, &r.BusinessID, &r.BusinessName,
+			&r.IPOPriceMicros, &r.CurrentPriceMicros, &r.ListedAt, &r.TicksSinceListing); err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
 func (s *Service) StockDetail(ctx context.Context, seasonID int64, symbol string) (StockDetail, error) {
 	var out StockDetail
 	if err := s.db.QueryRow(ctx, `
-		SELECT symbol, display_name, current_price_micros, listed_public
+		SELECT symbol, display_name, current_price_micros, listed_public, volatility_bps
 		FROM game.stocks
 		WHERE season_id = $1 AND symbol = $2
-	`, seasonID, strings.ToUpper(symbol)).Scan(&out.Symbol, &out.DisplayName, &out.CurrentPriceMicros, &out.ListedPublic); err != nil {
+	`, seasonID, strings.ToUpper(symbol)).Scan(&out.Symbol, &out.DisplayName, &out.CurrentPriceMicros, &out.ListedPublic, &out.VolatilityBps); err != nil {
 		return out, err
 	}
+	out.VolatilityTier = VolatilityTierName(out.VolatilityBps)
 
 	rows, err := s.db.Query(ctx, `
 		SELECT tick_at, price_micros
@@ -747,22 +1017,156 @@ func (s *Service) StockDetail(ctx context.Context, seasonID int64, symbol string
 		}
 		out.Series = append(out.Series, p)
 	}
-	return out, rows.Err()
+	if err := rows.Err(); err != nil {
+		return out, err
+	}
+	out.Sentiment, err = s.StockSentiment(ctx, seasonID, symbol)
+	return out, err
 }
 
-func (s *Service) PlaceOrder(ctx context.Context, in OrderInput) (OrderResult, error) {
-	var out OrderResult
+// normalizeOrderInput canonicalizes the symbol and side and rejects orders
+// PlaceOrder could never fill.
+func normalizeOrderInput(in *OrderInput) error {
 	in.Symbol = strings.ToUpper(strings.TrimSpace(in.Symbol))
 	in.Side = strings.ToLower(strings.TrimSpace(in.Side))
 	if err := ValidateSymbol(in.Symbol); err != nil {
-		return out, err
+		return err
 	}
 	if in.QuantityUnits <= 0 {
-		return out, fmt.Errorf("quantity must be > 0")
+		return fmt.Errorf("quantity must be > 0")
 	}
 	if in.Side != "buy" && in.Side != "sell" {
-		return out, fmt.Errorf("side must be buy or sell")
+		return fmt.Errorf("side must be buy or sell")
+	}
+	if in.Short && in.Side != "sell" {
+		return fmt.Errorf("only sell orders can open a short")
+	}
+	return nil
+}
+
+// pricedOrder is what an order fills at, before any balance moves.
+type pricedOrder struct {
+	OrderQuote
+	stockID int64
+}
+
+// priceOrderTx prices an order the way PlaceOrder fills it: the scarcity
+// premium on buys that take new supply, then the season's fee less the
+// membership discount. lock takes the stock row lock that keeps concurrent
+// buys from both claiming the last of the supply.
+func priceOrderTx(ctx context.Context, tx pgx.Tx, settings seasonSettings, in OrderInput, lock bool) (pricedOrder, error) {
+	var out pricedOrder
+	var outstanding int64
+	var listed bool
+	if err := tx.QueryRow(ctx, `
+		SELECT id, current_price_micros, listed_public, shares_outstanding_units
+		FROM game.stocks
+		WHERE season_id = $1 AND symbol = $2
+	`, in.SeasonID, in.Symbol).Scan(&out.stockID, &out.PriceMicros, &listed, &outstanding); err != nil {
+		if err == pgx.ErrNoRows {
+			return out, ErrStockNotFound
+		}
+		return out, err
+	}
+	if !listed {
+		return out, ErrStockNotListed
+	}
+	if in.Side == "buy" && outstanding > 0 {
+		// Shorts are not supply, and a buy that covers a short hands shares
+		// back rather than taking new ones, so it skips the scarcity gate
+		// and premium.
+		query := `
+			SELECT COALESCE((
+			           SELECT SUM(p.quantity_units)
+			           FROM game.positions p
+			           WHERE p.stock_id = st.id AND p.quantity_units > 0
+			       ), 0),
+			       COALESCE((
+			           SELECT p.quantity_units < 0
+			           FROM game.positions p
+			           WHERE p.stock_id = st.id AND p.user_id = $2 AND p.season_id = $3
+			       ), false)
+			FROM game.stocks st
+			WHERE st.id = $1
+		`
+		if lock {
+			query += " FOR UPDATE OF st"
+		}
+		var owned int64
+		var covering bool
+		if err := tx.QueryRow(ctx, query, out.stockID, in.UserID, in.SeasonID).Scan(&owned, &covering); err != nil {
+			return out, err
+		}
+		if !covering {
+			var err error
+			out.PriceMicros, err = scarcityPriceMicros(out.PriceMicros, owned, in.QuantityUnits, outstanding)
+			if err != nil {
+				return out, err
+			}
+		}
+	}
+	notional, err := notionalMicros(out.PriceMicros, in.QuantityUnits)
+	if err != nil {
+		return out, err
+	}
+	fundUnits, err := feeDiscountUnitsTx(ctx, tx, settings, in.UserID, in.SeasonID)
+	if err != nil {
+		return out, err
+	}
+	out.NotionalMicros = notional
+	out.FeeMicros, out.FeeDiscountMicros = settings.orderFee(notional, fundUnits)
+	if in.Side == "buy" {
+		out.TotalMicros = saturatingAddInt64(notional, out.FeeMicros)
+	} else {
+		out.TotalMicros = saturatingSubInt64(notional, out.FeeMicros)
+	}
+	return out, nil
+}
+
+// feeDiscountUnitsTx is how many units of the season's membership fund the
+// player holds, or zero when the season has no fee discount.
+func feeDiscountUnitsTx(ctx context.Context, tx pgx.Tx, settings seasonSettings, userID string, seasonID int64) (int64, error) {
+	if !settings.feeDiscountEnabled() {
+		return 0, nil
+	}
+	var units int64
+	err := tx.QueryRow(ctx, `
+		SELECT COALESCE((
+			SELECT units FROM game.fund_positions
+			WHERE user_id = $1 AND season_id = $2 AND fund_code = $3
+		), 0)
+	`, userID, seasonID, settings.FeeDiscountFundCode).Scan(&units)
+	return units, err
+}
+
+// QuoteOrder prices an order as PlaceOrder would fill it right now, without
+// placing it or checking the market hours and the player's balance.
+func (s *Service) QuoteOrder(ctx context.Context, in OrderInput) (OrderQuote, error) {
+	if err := normalizeOrderInput(&in); err != nil {
+		return OrderQuote{}, err
+	}
+	tx, err := s.reader().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return OrderQuote{}, err
+	}
+	defer tx.Rollback(ctx)
+	settings, err := loadSeasonSettingsTx(ctx, tx, in.SeasonID)
+	if err != nil {
+		return OrderQuote{}, err
+	}
+	priced, err := priceOrderTx(ctx, tx, settings, in, false)
+	if err != nil {
+		return OrderQuote{}, err
+	}
+	return priced.OrderQuote, nil
+}
+
+func (s *Service) PlaceOrder(ctx context.Context, in OrderInput) (OrderResult, error) {
+	var out OrderResult
+	if err := normalizeOrderInput(&in); err != nil {
+		return out, err
 	}
+	out.QuantityUnits = in.QuantityUnits
 
 	const maxAttempts = 8
 	retryDelay := 75 * time.Millisecond
@@ -774,56 +1178,72 @@ func (s *Service) PlaceOrder(ctx context.Context, in OrderInput) (OrderResult, e
 		err = func() error {
 			defer tx.Rollback(ctx)
 
-			if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "order"); err != nil {
+			if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "order"); err != nil {
 				return err
 			}
-
-			var stockID int64
-			var listed bool
-			if err := tx.QueryRow(ctx, `
-				SELECT id, current_price_micros, listed_public
-				FROM game.stocks
-				WHERE season_id = $1 AND symbol = $2
-			`, in.SeasonID, in.Symbol).Scan(&stockID, &out.PriceMicros, &listed); err != nil {
-				if err == pgx.ErrNoRows {
-					return ErrStockNotFound
-				}
+			settings, err := loadSeasonSettingsTx(ctx, tx, in.SeasonID)
+			if err != nil {
 				return err
 			}
-			if !listed {
-				return fmt.Errorf("stock is not listed publicly")
+			if !settings.marketState(time.Now()).Open {
+				return ErrMarketClosed
+			}
+			if halt, _, err := marketHaltTx(ctx, tx, in.SeasonID); err != nil {
+				return err
+			} else if halt > 0 {
+				return fmt.Errorf("%w: %d tick(s) remaining", ErrMarketHalted, halt)
 			}
-			notional, err := notionalMicros(out.PriceMicros, in.QuantityUnits)
+
+			priced, err := priceOrderTx(ctx, tx, settings, in, true)
 			if err != nil {
 				return err
 			}
-			fee := int64(math.Round(float64(notional) * 0.0015))
+			stockID := priced.stockID
+			notional, fee := priced.NotionalMicros, priced.FeeMicros
+			out.PriceMicros = priced.PriceMicros
 			out.NotionalMicros = notional
 			out.FeeMicros = fee
+			out.FeeDiscountMicros = priced.FeeDiscountMicros
 
 			var balance int64
-			if err := tx.QueryRow(ctx, `
-				SELECT balance_micros
-				FROM game.wallets
-				WHERE user_id = $1 AND season_id = $2
-				FOR UPDATE
-			`, in.UserID, in.SeasonID).Scan(&balance); err != nil {
+			balance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+			if err != nil {
 				return err
 			}
 
+			action := in.Side
+			short := in.Short
+			// realized stays nil for orders that open or add to a position.
+			var realized *int64
 			switch in.Side {
 			case "buy":
 				nextBalance := balance - notional - fee
 				if nextBalance <= 0 {
 					return ErrInsufficientFunds
 				}
-				if err := upsertBuyPosition(ctx, tx, in.UserID, in.SeasonID, stockID, in.QuantityUnits, out.PriceMicros); err != nil {
+				covered, pl, err := applyCoverTx(ctx, tx, in.UserID, in.SeasonID, stockID, in.QuantityUnits, notional, fee)
+				if err != nil {
+					return err
+				}
+				if covered {
+					short = true
+					realized = &pl
+				} else if err := upsertBuyPosition(ctx, tx, in.UserID, in.SeasonID, stockID, in.QuantityUnits, out.PriceMicros); err != nil {
 					return err
 				}
 				balance = nextBalance
 			case "sell":
-				if err := applySellPosition(ctx, tx, in.UserID, in.SeasonID, stockID, in.QuantityUnits); err != nil {
-					return err
+				if short {
+					if err := applyShortSellTx(ctx, tx, settings, in.UserID, in.SeasonID, stockID, in.QuantityUnits, out.PriceMicros); err != nil {
+						return err
+					}
+					action = "short_sell"
+				} else {
+					pl, err := applySellPosition(ctx, tx, in.UserID, in.SeasonID, stockID, in.QuantityUnits, notional, fee)
+					if err != nil {
+						return err
+					}
+					realized = &pl
 				}
 				balance = balance + notional - fee
 			}
@@ -836,15 +1256,15 @@ func (s *Service) PlaceOrder(ctx context.Context, in OrderInput) (OrderResult, e
 				return err
 			}
 
-			if err := appendLedgerEntries(ctx, tx, in.UserID, in.SeasonID, in.Side, notional, fee); err != nil {
+			if err := appendLedgerEntries(ctx, tx, in.UserID, in.SeasonID, action, notional, fee); err != nil {
 				return err
 			}
 
 			err = tx.QueryRow(ctx, `
-				INSERT INTO game.orders (user_id, season_id, stock_id, side, quantity_units, price_micros, fee_micros)
-				VALUES ($1, $2, $3, $4, $5, $6, $7)
+				INSERT INTO game.orders (user_id, season_id, stock_id, side, quantity_units, price_micros, fee_micros, short, realized_pl_micros)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 				RETURNING id
-			`, in.UserID, in.SeasonID, stockID, in.Side, in.QuantityUnits, out.PriceMicros, fee).Scan(&out.OrderID)
+			`, in.UserID, in.SeasonID, stockID, in.Side, in.QuantityUnits, out.PriceMicros, fee, short, realized).Scan(&out.OrderID)
 			if err != nil {
 				return err
 			}
@@ -892,7 +1312,7 @@ func (s *Service) CreateBusiness(ctx context.Context, in CreateBusinessInput) (i
 	}
 	defer tx.Rollback(ctx)
 
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "create_business"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "create_business"); err != nil {
 		return 0, err
 	}
 
@@ -903,6 +1323,13 @@ func (s *Service) CreateBusiness(ctx context.Context, in CreateBusinessInput) (i
 	if netWorth < BusinessUnlockMicros {
 		return 0, ErrBusinessLocked
 	}
+	cfg, err := loadSeasonSettingsTx(ctx, tx, in.SeasonID)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkBusinessNameTx(ctx, tx, in.SeasonID, cfg.UniqueBusinessNames, in.Name); err != nil {
+		return 0, err
+	}
 	region, arc, focus := businessNarrativeSeed(s.nextFloat())
 
 	err = tx.QueryRow(ctx, `
@@ -936,6 +1363,27 @@ func (s *Service) CreateBusiness(ctx context.Context, in CreateBusinessInput) (i
 	return id, nil
 }
 
+// checkBusinessNameTx returns ErrNameTaken when unique is set and another
+// business this season already uses name.
+func checkBusinessNameTx(ctx context.Context, tx pgx.Tx, seasonID int64, unique bool, name string) error {
+	if !unique {
+		return nil
+	}
+	var taken bool
+	if err := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM game.businesses
+			WHERE season_id = $1 AND lower(name) = lower($2)
+		)
+	`, seasonID, name).Scan(&taken); err != nil {
+		return err
+	}
+	if taken {
+		return ErrNameTaken
+	}
+	return nil
+}
+
 func (s *Service) BusinessState(ctx context.Context, userID string, seasonID, businessID int64) (BusinessView, error) {
 	var out BusinessView
 	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
@@ -988,6 +1436,8 @@ func (s *Service) BusinessState(ctx context.Context, userID string, seasonID, bu
 		BrandBps:              c.brandBps,
 		OperationalHealthBps:  c.healthBps,
 		CashReserveMicros:     c.reserveMicros,
+		RevenueMode:           c.revenueMode,
+		UnclaimedMicros:       c.unclaimedMicros,
 		LastEvent:             c.lastEvent,
 		OwnedStakeBps:         ownedStakeBps,
 	}
@@ -1013,15 +1463,17 @@ func (s *Service) SetBusinessVisibility(ctx context.Context, userID string, seas
 	return nil
 }
 
-func (s *Service) HireEmployee(ctx context.Context, in HireEmployeeInput) error {
+// HireEmployee hires a candidate into the business and returns the hire cost
+// actually charged, which grows with the business's current headcount.
+func (s *Service) HireEmployee(ctx context.Context, in HireEmployeeInput) (int64, error) {
 	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer tx.Rollback(ctx)
 
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "hire_employee"); err != nil {
-		return err
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "hire_employee"); err != nil {
+		return 0, err
 	}
 
 	var ownerID string
@@ -1032,14 +1484,14 @@ func (s *Service) HireEmployee(ctx context.Context, in HireEmployeeInput) error
 		WHERE id = $1 AND season_id = $2
 		FOR UPDATE
 	`, in.BusinessID, in.SeasonID).Scan(&ownerID, &employeeLimit, &currentEmployees); err != nil {
-		return err
+		return 0, err
 	}
 	if ownerID != in.UserID {
-		return ErrUnauthorized
+		return 0, ErrUnauthorized
 	}
 	employeeLimit = effectiveEmployeeLimit(employeeLimit)
 	if currentEmployees >= employeeLimit {
-		return ErrEmployeeLimitReached
+		return 0, ErrEmployeeLimitReached
 	}
 
 	var candidateName, role, trait string
@@ -1050,21 +1502,21 @@ func (s *Service) HireEmployee(ctx context.Context, in HireEmployeeInput) error
 		FROM game.employee_candidates
 		WHERE id = $1 AND season_id = $2
 	`, in.CandidateID, in.SeasonID).Scan(&candidateName, &role, &trait, &cost, &revenue, &risk); err != nil {
-		return err
+		return 0, err
 	}
 
 	var balance int64
-	if err := tx.QueryRow(ctx, `
-		SELECT balance_micros
-		FROM game.wallets
-		WHERE user_id = $1 AND season_id = $2
-		FOR UPDATE
-	`, in.UserID, in.SeasonID).Scan(&balance); err != nil {
-		return err
+	balance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+	if err != nil {
+		return 0, err
+	}
+	settings, err := loadSeasonSettingsTx(ctx, tx, in.SeasonID)
+	if err != nil {
+		return 0, err
 	}
-	cost = scaledHireCostMicros(cost, currentEmployees, 0)
+	cost = settings.hireCostMicros(cost, currentEmployees, 0)
 	if !hasPositiveBalanceAfterSpend(balance, cost) {
-		return ErrInsufficientFunds
+		return 0, ErrInsufficientFunds
 	}
 
 	_, err = tx.Exec(ctx, `
@@ -1074,14 +1526,14 @@ func (s *Service) HireEmployee(ctx context.Context, in HireEmployeeInput) error
 		    ($1, $2, $3, $4, $5, $6, $7, $8)
 	`, in.BusinessID, in.SeasonID, in.CandidateID, candidateName, role, trait, revenue, risk)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if _, err := tx.Exec(ctx, `
 		UPDATE game.businesses
 		SET employee_count = employee_count + 1, updated_at = now()
 		WHERE id = $1 AND season_id = $2
 	`, in.BusinessID, in.SeasonID); err != nil {
-		return err
+		return 0, err
 	}
 
 	balance -= cost
@@ -1090,15 +1542,18 @@ func (s *Service) HireEmployee(ctx context.Context, in HireEmployeeInput) error
 		SET balance_micros = $1, updated_at = now()
 		WHERE user_id = $2 AND season_id = $3
 	`, balance, in.UserID, in.SeasonID); err != nil {
-		return err
+		return 0, err
 	}
 	if err := appendLedgerEntries(ctx, tx, in.UserID, in.SeasonID, "employee_hire", cost, 0); err != nil {
-		return err
+		return 0, err
 	}
 	if err := s.updatePeakNetWorthTx(ctx, tx, in.UserID, in.SeasonID); err != nil {
-		return err
+		return 0, err
 	}
-	return tx.Commit(ctx)
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return cost, nil
 }
 
 func (s *Service) HireEmployeesBulk(ctx context.Context, in BulkHireEmployeesInput) (map[string]any, error) {
@@ -1124,7 +1579,7 @@ func (s *Service) HireEmployeesBulk(ctx context.Context, in BulkHireEmployeesInp
 		err = func() error {
 			defer tx.Rollback(ctx)
 
-			if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "hire_employee_batch"); err != nil {
+			if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "hire_employee_batch"); err != nil {
 				return err
 			}
 
@@ -1155,12 +1610,8 @@ func (s *Service) HireEmployeesBulk(ctx context.Context, in BulkHireEmployeesInp
 			}
 
 			var balance int64
-			if err := tx.QueryRow(ctx, `
-				SELECT balance_micros
-				FROM game.wallets
-				WHERE user_id = $1 AND season_id = $2
-				FOR UPDATE
-			`, in.UserID, in.SeasonID).Scan(&balance); err != nil {
+			balance, err = lockWalletBalanceTx(ctx, tx, in.UserID, in.SeasonID)
+			if err != nil {
 				return err
 			}
 			shortlist, err := selectHireShortlistTx(ctx, tx, in.BusinessID, in.SeasonID, currentEmployees, selectionLimit, orderBy, balance)
@@ -1316,6 +1767,9 @@ func (s *Service) QuoteHireEmployeesBulk(ctx context.Context, in BulkHireEmploye
 		FROM game.wallets
 		WHERE user_id = $1 AND season_id = $2
 	`, in.UserID, in.SeasonID).Scan(&balance); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out, ErrWalletNotFound
+		}
 		return out, err
 	}
 
@@ -1330,10 +1784,12 @@ func (s *Service) QuoteHireEmployeesBulk(ctx context.Context, in BulkHireEmploye
 		previewLimit = len(shortlist)
 	}
 	previewNames := make([]string, 0, previewLimit)
+	previewIDs := make([]int64, 0, previewLimit)
 	for i, pick := range shortlist {
 		estimatedCost += pick.Cost
 		if i < previewLimit {
 			previewNames = append(previewNames, pick.Name)
+			previewIDs = append(previewIDs, pick.ID)
 		}
 	}
 
@@ -1351,6 +1807,7 @@ func (s *Service) QuoteHireEmployeesBulk(ctx context.Context, in BulkHireEmploye
 	}
 	if len(previewNames) > 0 {
 		out["candidate_name_preview"] = previewNames
+		out["candidate_id_preview"] = previewIDs
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -1359,19 +1816,96 @@ func (s *Service) QuoteHireEmployeesBulk(ctx context.Context, in BulkHireEmploye
 	return out, nil
 }
 
-type hirePick struct {
-	ID       int64
-	Name     string
-	Role     string
-	Trait    string
-	BaseCost int64
-	Cost     int64
-	Revenue  int64
-	Risk     int32
-}
+// PreviewHire projects a business's revenue per tick and average employee
+// risk after hiring the candidate, without mutating anything.
+func (s *Service) PreviewHire(ctx context.Context, userID string, seasonID, businessID, candidateID int64) (map[string]any, error) {
+	out := map[string]any{}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return out, err
+	}
+	defer tx.Rollback(ctx)
 
-func selectHireShortlistTx(ctx context.Context, tx pgx.Tx, businessID, seasonID int64, currentEmployees int64, selectionLimit int, orderBy string, balance int64) ([]hirePick, error) {
-	rows, err := tx.Query(ctx, `
+	cycles, err := loadBusinessCyclesTx(ctx, tx, seasonID, "", &businessID)
+	if err != nil {
+		return out, err
+	}
+	if len(cycles) == 0 {
+		return out, pgx.ErrNoRows
+	}
+	c := cycles[0]
+	if c.userID != userID {
+		return out, ErrUnauthorized
+	}
+	if c.employeeCount >= effectiveEmployeeLimit(c.employeeLimit) {
+		return out, ErrEmployeeLimitReached
+	}
+
+	var name, role, trait string
+	var cost, revenue int64
+	var risk int32
+	if err := tx.QueryRow(ctx, `
+		SELECT full_name, role, trait, hire_cost_micros, revenue_per_tick_micros, risk_bps
+		FROM game.employee_candidates
+		WHERE id = $1 AND season_id = $2
+	`, candidateID, seasonID).Scan(&name, &role, &trait, &cost, &revenue, &risk); err != nil {
+		return out, err
+	}
+	var rosterSize int64
+	if err := tx.QueryRow(ctx, `
+		SELECT COUNT(1)
+		FROM game.business_employees
+		WHERE business_id = $1 AND season_id = $2
+	`, businessID, seasonID).Scan(&rosterSize); err != nil {
+		return out, err
+	}
+
+	settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+	if err != nil {
+		return out, err
+	}
+
+	before := projectBusinessCycle(c)
+	hired := withHiredEmployee(c, rosterSize, role, revenue, risk)
+	after := projectBusinessCycle(hired)
+
+	out = map[string]any{
+		"ok":                                true,
+		"business_id":                       businessID,
+		"candidate_id":                      candidateID,
+		"full_name":                         name,
+		"role":                              role,
+		"trait":                             trait,
+		"hire_cost_micros":                  settings.hireCostMicros(cost, c.employeeCount, 0),
+		"candidate_revenue_micros":          revenue,
+		"candidate_risk_bps":                risk,
+		"employee_count":                    c.employeeCount,
+		"current_revenue_per_tick_micros":   before.RevenuePerTickMicros,
+		"projected_revenue_per_tick_micros": after.RevenuePerTickMicros,
+		"revenue_delta_micros":              after.RevenuePerTickMicros - before.RevenuePerTickMicros,
+		"current_avg_risk_bps":              int64(math.Round(c.avgRiskBps)),
+		"projected_avg_risk_bps":            int64(math.Round(hired.avgRiskBps)),
+	}
+	return out, tx.Commit(ctx)
+}
+
+type hirePick struct {
+	ID       int64
+	Name     string
+	Role     string
+	Trait    string
+	BaseCost int64
+	Cost     int64
+	Revenue  int64
+	Risk     int32
+}
+
+func selectHireShortlistTx(ctx context.Context, tx pgx.Tx, businessID, seasonID int64, currentEmployees int64, selectionLimit int, orderBy string, balance int64) ([]hirePick, error) {
+	settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, `
 		SELECT ec.id, ec.full_name, ec.role, ec.trait, ec.hire_cost_micros, ec.revenue_per_tick_micros, ec.risk_bps
 		FROM game.employee_candidates ec
 		LEFT JOIN game.business_employees be
@@ -1396,7 +1930,7 @@ func selectHireShortlistTx(ctx context.Context, tx pgx.Tx, businessID, seasonID
 		if err := rows.Scan(&pick.ID, &pick.Name, &pick.Role, &pick.Trait, &pick.BaseCost, &pick.Revenue, &pick.Risk); err != nil {
 			return nil, err
 		}
-		pick.Cost = scaledHireCostMicros(pick.BaseCost, currentEmployees, hireIndex)
+		pick.Cost = settings.hireCostMicros(pick.BaseCost, currentEmployees, hireIndex)
 		if !hasPositiveBalanceAfterSpend(balance-runningCost, pick.Cost) {
 			continue
 		}
@@ -1513,7 +2047,7 @@ func (s *Service) CreateCustomStock(ctx context.Context, in CreateStockInput) er
 	}
 	defer tx.Rollback(ctx)
 
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "create_stock"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "create_stock"); err != nil {
 		return err
 	}
 
@@ -1529,15 +2063,19 @@ func (s *Service) CreateCustomStock(ctx context.Context, in CreateStockInput) er
 		return ErrUnauthorized
 	}
 
-	_, err = tx.Exec(ctx, `
+	tag, err := tx.Exec(ctx, `
 		INSERT INTO game.stocks
-		    (season_id, symbol, display_name, listed_public, current_price_micros, anchor_price_micros, created_by_user_id, business_id)
+		    (season_id, symbol, display_name, listed_public, current_price_micros, anchor_price_micros, created_by_user_id, business_id, dividend_bps)
 		VALUES
-		    ($1, $2, $3, false, $4, $4, $5, $6)
-	`, in.SeasonID, in.Symbol, in.DisplayName, 100*MicrosPerStonky, in.UserID, in.BusinessID)
+		    ($1, $2, $3, false, $4, $4, $5, $6, $7)
+		ON CONFLICT (season_id, symbol) DO NOTHING
+	`, in.SeasonID, in.Symbol, in.DisplayName, 100*MicrosPerStonky, in.UserID, in.BusinessID, DefaultBusinessDividendBps)
 	if err != nil {
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		return ErrSymbolTaken
+	}
 	return tx.Commit(ctx)
 }
 
@@ -1556,7 +2094,7 @@ func (s *Service) IPOStock(ctx context.Context, in IPOInput) error {
 	}
 	defer tx.Rollback(ctx)
 
-	if err := claimIdempotency(ctx, tx, in.UserID, in.IdempotencyKey, "ipo_stock"); err != nil {
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "ipo_stock"); err != nil {
 		return err
 	}
 
@@ -1583,11 +2121,17 @@ func (s *Service) IPOStock(ctx context.Context, in IPOInput) error {
 		SET listed_public = true,
 		    current_price_micros = $1,
 		    anchor_price_micros = $1,
+		    listed_at = now(),
+		    listed_tick = (SELECT tick_count FROM game.seasons WHERE id = season_id),
+		    ipo_price_micros = $1,
 		    updated_at = now()
 		WHERE id = $2
 	`, in.PriceMicros, stockID); err != nil {
 		return err
 	}
+	if err := setSeasonSharesOutstandingTx(ctx, tx, in.SeasonID, stockID); err != nil {
+		return err
+	}
 	if _, err := tx.Exec(ctx, `
 		INSERT INTO game.stock_prices (stock_id, tick_at, price_micros)
 		VALUES ($1, now(), $2)
@@ -1595,6 +2139,13 @@ func (s *Service) IPOStock(ctx context.Context, in IPOInput) error {
 		return err
 	}
 	_, _ = tx.Exec(ctx, `UPDATE game.businesses SET is_listed = true WHERE id = (SELECT business_id FROM game.stocks WHERE id = $1)`, stockID)
+	var displayName string
+	if err := tx.QueryRow(ctx, `SELECT display_name FROM game.stocks WHERE id = $1`, stockID).Scan(&displayName); err != nil {
+		return err
+	}
+	if err := recordNewsTx(ctx, tx, in.SeasonID, NewsIPO, ipoNews(in.Symbol, displayName, in.PriceMicros)); err != nil {
+		return err
+	}
 
 	return tx.Commit(ctx)
 }
@@ -1613,7 +2164,7 @@ func (s *Service) BusinessIPO(ctx context.Context, userID string, seasonID, busi
 	}
 	defer tx.Rollback(ctx)
 
-	if err := claimIdempotency(ctx, tx, userID, idem, "business_ipo"); err != nil {
+	if err := claimIdempotency(ctx, tx, userID, seasonID, idem, "business_ipo"); err != nil {
 		return err
 	}
 
@@ -1634,15 +2185,46 @@ func (s *Service) BusinessIPO(ctx context.Context, userID string, seasonID, busi
 	}
 	display := businessDisplayName(name)
 
-	_, err = tx.Exec(ctx, `
+	tag, err := tx.Exec(ctx, `
 		INSERT INTO game.stocks
-		    (season_id, symbol, display_name, listed_public, current_price_micros, anchor_price_micros, created_by_user_id, business_id)
-		VALUES ($1, $2, $3, true, $4, $4, $5, $6)
+		    (season_id, symbol, display_name, listed_public, current_price_micros, anchor_price_micros, created_by_user_id, business_id,
+		     listed_at, listed_tick, ipo_price_micros, dividend_bps)
+		VALUES ($1, $2, $3, true, $4, $4, $5, $6,
+		        now(), (SELECT tick_count FROM game.seasons WHERE id = $1), $4, $7)
 		ON CONFLICT (season_id, symbol) DO NOTHING
-	`, seasonID, symbol, display, priceMicros, userID, businessID)
+	`, seasonID, symbol, display, priceMicros, userID, businessID, DefaultBusinessDividendBps)
 	if err != nil {
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		// The symbol already exists: only this business's own (unlisted)
+		// stock may be listed under it; anything else would mis-link.
+		var existingBusinessID *int64
+		if err := tx.QueryRow(ctx, `
+			SELECT business_id
+			FROM game.stocks
+			WHERE season_id = $1 AND symbol = $2
+			FOR UPDATE
+		`, seasonID, symbol).Scan(&existingBusinessID); err != nil {
+			return err
+		}
+		if existingBusinessID == nil || *existingBusinessID != businessID {
+			return ErrSymbolTaken
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE game.stocks
+			SET listed_public = true,
+			    current_price_micros = $3,
+			    anchor_price_micros = $3,
+			    listed_at = now(),
+			    listed_tick = (SELECT tick_count FROM game.seasons WHERE id = $1),
+			    ipo_price_micros = $3,
+			    updated_at = now()
+			WHERE season_id = $1 AND symbol = $2
+		`, seasonID, symbol, priceMicros); err != nil {
+			return err
+		}
+	}
 	_, err = tx.Exec(ctx, `
 		UPDATE game.businesses
 		SET is_listed = true, stock_symbol = $1, updated_at = now()
@@ -1657,6 +2239,9 @@ func (s *Service) BusinessIPO(ctx context.Context, userID string, seasonID, busi
 	`, seasonID, symbol).Scan(&stockID); err != nil {
 		return err
 	}
+	if err := setSeasonSharesOutstandingTx(ctx, tx, seasonID, stockID); err != nil {
+		return err
+	}
 	_, err = tx.Exec(ctx, `
 		INSERT INTO game.stock_prices (stock_id, tick_at, price_micros)
 		VALUES ($1, now(), $2)
@@ -1664,56 +2249,214 @@ func (s *Service) BusinessIPO(ctx context.Context, userID string, seasonID, busi
 	if err != nil {
 		return err
 	}
+	if err := recordNewsTx(ctx, tx, seasonID, NewsIPO, ipoNews(symbol, display, priceMicros)); err != nil {
+		return err
+	}
 	return tx.Commit(ctx)
 }
 
-func (s *Service) AddFriend(ctx context.Context, userID, inviteCode string) error {
+// DelistBusinessStock takes a business's stock off the public market. Only
+// the owner may do it, and only while no other player holds or is short its
+// shares: an unlisted stock cannot be traded, so those positions could never
+// be closed.
+func (s *Service) DelistBusinessStock(ctx context.Context, userID string, seasonID, businessID int64, idem string) (map[string]any, error) {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := claimIdempotency(ctx, tx, userID, seasonID, idem, "business_delist"); err != nil {
+		return nil, err
+	}
+
+	var ownerID string
+	if err := tx.QueryRow(ctx, `
+		SELECT owner_user_id
+		FROM game.businesses
+		WHERE id = $1 AND season_id = $2
+		FOR UPDATE
+	`, businessID, seasonID).Scan(&ownerID); err != nil {
+		return nil, err
+	}
+	if ownerID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	var stockID int64
+	var symbol string
+	err = tx.QueryRow(ctx, `
+		SELECT id, symbol
+		FROM game.stocks
+		WHERE season_id = $1 AND business_id = $2 AND listed_public = true
+		FOR UPDATE
+	`, seasonID, businessID).Scan(&stockID, &symbol)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrStockNotListed
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var outsideHolders int64
+	if err := tx.QueryRow(ctx, `
+		SELECT COUNT(1)
+		FROM game.positions
+		WHERE season_id = $1 AND stock_id = $2 AND user_id <> $3 AND quantity_units <> 0
+	`, seasonID, stockID, userID).Scan(&outsideHolders); err != nil {
+		return nil, err
+	}
+	if outsideHolders > 0 {
+		return nil, fmt.Errorf("%w: %d other player(s) hold or are short %s", ErrOutsideShareholders, outsideHolders, symbol)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE game.stocks
+		SET listed_public = false, updated_at = now()
+		WHERE id = $1
+	`, stockID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE game.businesses
+		SET is_listed = false, updated_at = now()
+		WHERE id = $1 AND season_id = $2
+	`, businessID, seasonID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return map[string]any{"ok": true, "business_id": businessID, "symbol": symbol}, nil
+}
+
+// AddFriend follows the player with inviteCode. maxFollows caps how many
+// players userID may follow; zero means unlimited. Re-following an existing
+// friend never counts against the cap.
+func (s *Service) AddFriend(ctx context.Context, userID string, seasonID int64, inviteCode, idem string, maxFollows int) error {
 	inviteCode = strings.ToUpper(strings.TrimSpace(inviteCode))
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, userID, seasonID, idem, "friend_add"); err != nil {
+		return err
+	}
 	var followee string
-	if err := s.db.QueryRow(ctx, `SELECT user_id FROM users.profiles WHERE invite_code = $1`, inviteCode).Scan(&followee); err != nil {
+	if err := tx.QueryRow(ctx, `SELECT user_id FROM users.profiles WHERE invite_code = $1`, inviteCode).Scan(&followee); err != nil {
 		return err
 	}
 	if followee == userID {
 		return fmt.Errorf("cannot follow yourself")
 	}
-	_, err := s.db.Exec(ctx, `
+	if maxFollows > 0 {
+		// Lock the follower's profile so concurrent adds cannot both pass
+		// the count check.
+		if _, err := tx.Exec(ctx, `SELECT 1 FROM users.profiles WHERE user_id = $1 FOR UPDATE`, userID); err != nil {
+			return err
+		}
+		var following int
+		var already bool
+		if err := tx.QueryRow(ctx, `
+			SELECT COUNT(*),
+			       COALESCE(BOOL_OR(followee_user_id = $2), false)
+			FROM game.friend_follows
+			WHERE follower_user_id = $1
+		`, userID, followee).Scan(&following, &already); err != nil {
+			return err
+		}
+		if !already && following >= maxFollows {
+			return fmt.Errorf("%w: already following %d of %d players", ErrFriendLimit, following, maxFollows)
+		}
+	}
+	if _, err := tx.Exec(ctx, `
 		INSERT INTO game.friend_follows (follower_user_id, followee_user_id)
 		VALUES ($1, $2)
 		ON CONFLICT (follower_user_id, followee_user_id) DO NOTHING
-	`, userID, followee)
-	return err
+	`, userID, followee); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
-func (s *Service) RemoveFriend(ctx context.Context, userID, inviteCode string) error {
+func (s *Service) RemoveFriend(ctx context.Context, userID string, seasonID int64, inviteCode, idem string) error {
 	inviteCode = strings.ToUpper(strings.TrimSpace(inviteCode))
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, userID, seasonID, idem, "friend_remove"); err != nil {
+		return err
+	}
 	var followee string
-	if err := s.db.QueryRow(ctx, `SELECT user_id FROM users.profiles WHERE invite_code = $1`, inviteCode).Scan(&followee); err != nil {
+	if err := tx.QueryRow(ctx, `SELECT user_id FROM users.profiles WHERE invite_code = $1`, inviteCode).Scan(&followee); err != nil {
 		return err
 	}
-	_, err := s.db.Exec(ctx, `
+	if _, err := tx.Exec(ctx, `
 		DELETE FROM game.friend_follows
 		WHERE follower_user_id = $1 AND followee_user_id = $2
-	`, userID, followee)
-	return err
+	`, userID, followee); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
-func (s *Service) GlobalLeaderboard(ctx context.Context, seasonID int64, limit int) ([]LeaderboardRow, error) {
-	rows, err := s.db.Query(ctx, `
-		WITH holdings AS (
+// leaderboardRankedCTE ranks every wallet in season $1 (holdings scaled by
+// $2) by net worth, then by the season's leaderboard_tie_break, then by
+// user_id, so equal net worths never swap places between ticks. Holdings are
+// summed in numeric, flooring each position like notionalMicros, so whale
+// positions cannot overflow bigint; net worth is clamped to the bigint range
+// only when reported.
+const leaderboardRankedCTE = `
+		holdings AS (
 			SELECT p.user_id,
-			       COALESCE(SUM((p.quantity_units * st.current_price_micros) / $2), 0) AS holdings_micros
+			       COALESCE(SUM(FLOOR(p.quantity_units::numeric * st.current_price_micros::numeric / $2::numeric)), 0) AS holdings_micros
 			FROM game.positions p
 			JOIN game.stocks st ON st.id = p.stock_id
 			WHERE p.season_id = $1
 			GROUP BY p.user_id
-		)
-		SELECT pr.username, pr.invite_code,
-		       (w.balance_micros + COALESCE(h.holdings_micros, 0)) AS net_worth_micros
-		FROM game.wallets w
-		JOIN users.profiles pr ON pr.user_id = w.user_id
-		LEFT JOIN holdings h ON h.user_id = w.user_id
-		WHERE w.season_id = $1
-		ORDER BY net_worth_micros DESC
+		), owned AS (
+			SELECT owner_user_id AS user_id,
+			       COUNT(*) AS business_count,
+			       COALESCE(SUM(last_tick_net_micros), 0) AS revenue_micros
+			FROM game.businesses
+			WHERE season_id = $1
+			GROUP BY owner_user_id
+		), tie AS (
+			SELECT COALESCE(
+			           (SELECT leaderboard_tie_break FROM game.season_settings WHERE season_id = $1),
+			           'business_count'
+			       ) AS mode
+		), ranked AS (
+			SELECT w.user_id,
+			       LEAST(
+			           9223372036854775807::numeric,
+			           GREATEST(-9223372036854775808::numeric, w.balance_micros::numeric + COALESCE(h.holdings_micros, 0))
+			       )::bigint AS net_worth_micros,
+			       ROW_NUMBER() OVER (
+			           ORDER BY (w.balance_micros::numeric + COALESCE(h.holdings_micros, 0)) DESC,
+			                    CASE (SELECT mode FROM tie)
+			                        WHEN 'business_count' THEN COALESCE(o.business_count, 0)::numeric
+			                        WHEN 'business_revenue' THEN COALESCE(o.revenue_micros, 0)::numeric
+			                        ELSE 0::numeric
+			                    END DESC,
+			                    w.user_id
+			       ) AS rank
+			FROM game.wallets w
+			LEFT JOIN holdings h ON h.user_id = w.user_id
+			LEFT JOIN owned o ON o.user_id = w.user_id
+			WHERE w.season_id = $1
+		)`
+
+func (s *Service) GlobalLeaderboard(ctx context.Context, seasonID int64, limit int) ([]LeaderboardRow, error) {
+	rows, err := s.reader().Query(ctx, `
+		WITH`+leaderboardRankedCTE+`
+		SELECT r.user_id, pr.username, pr.invite_code, r.net_worth_micros
+		FROM ranked r
+		JOIN users.profiles pr ON pr.user_id = r.user_id
+		ORDER BY r.rank
 		LIMIT $3
 	`, seasonID, ShareScale, limit)
 	if err != nil {
@@ -1725,7 +2468,7 @@ func (s *Service) GlobalLeaderboard(ctx context.Context, seasonID int64, limit i
 	var rank int64 = 1
 	for rows.Next() {
 		var r LeaderboardRow
-		if err := rows.Scan(&r.Username, &r.InviteCode, &r.NetWorthMicros); err != nil {
+		if err := rows.Scan(&r.UserID, &r.Username, &r.InviteCode, &r.NetWorthMicros); err != nil {
 			return nil, err
 		}
 		r.Rank = rank
@@ -1735,30 +2478,77 @@ func (s *Service) GlobalLeaderboard(ctx context.Context, seasonID int64, limit i
 	return out, rows.Err()
 }
 
+// leaderboardStanding counts wallets ranked above the player and the season
+// total, using the same ordering as GlobalLeaderboard.
+func (s *Service) leaderboardStanding(ctx context.Context, userID string, seasonID int64) (int64, int64, error) {
+	var ahead, players int64
+	err := s.reader().QueryRow(ctx, `
+		WITH`+leaderboardRankedCTE+`
+		SELECT COALESCE((SELECT rank FROM ranked WHERE user_id = $3), 1) - 1,
+		       COUNT(*)
+		FROM ranked
+	`, seasonID, ShareScale, userID).Scan(&ahead, &players)
+	return ahead, players, err
+}
+
+// PublicProfile looks up a player by invite code for viewerID. Having the
+// invite code is what grants access, the same as following them.
+func (s *Service) PublicProfile(ctx context.Context, viewerID string, seasonID int64, inviteCode string) (PublicProfile, error) {
+	var out PublicProfile
+	var userID string
+	inviteCode = strings.ToUpper(strings.TrimSpace(inviteCode))
+	if err := s.reader().QueryRow(ctx, `
+		SELECT user_id, username, invite_code, created_at
+		FROM users.profiles
+		WHERE invite_code = $1
+	`, inviteCode).Scan(&userID, &out.Username, &out.InviteCode, &out.JoinedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out, ErrPlayerNotFound
+		}
+		return out, err
+	}
+
+	var netWorth *int64
+	var ahead int64
+	err := s.reader().QueryRow(ctx, `
+		WITH`+leaderboardRankedCTE+`, target AS (
+			SELECT net_worth_micros, rank FROM ranked WHERE user_id = $3
+		)
+		SELECT (SELECT net_worth_micros FROM target),
+		       COALESCE((SELECT rank FROM target), 1) - 1,
+		       COUNT(*),
+		       (SELECT COUNT(*) FROM game.businesses WHERE owner_user_id = $3 AND season_id = $1),
+		       EXISTS (SELECT 1 FROM game.friend_follows WHERE follower_user_id = $4 AND followee_user_id = $3),
+		       EXISTS (SELECT 1 FROM game.friend_follows WHERE follower_user_id = $3 AND followee_user_id = $4)
+		FROM ranked
+	`, seasonID, ShareScale, userID, viewerID).Scan(&netWorth, &ahead, &out.Players, &out.BusinessCount, &out.Following, &out.FollowsYou)
+	if err != nil {
+		return out, err
+	}
+	if netWorth == nil {
+		// No wallet this season yet: the player exists but is unranked.
+		return out, nil
+	}
+	out.NetWorthMicros = *netWorth
+	out.Rank = ahead + 1
+	out.LeaderboardTopBps = leaderboardTopBps(ahead, out.Players)
+	return out, nil
+}
+
 func (s *Service) FriendsLeaderboard(ctx context.Context, seasonID int64, userID string, limit int) ([]LeaderboardRow, error) {
-	rows, err := s.db.Query(ctx, `
-		WITH social AS (
+	rows, err := s.reader().Query(ctx, `
+		WITH`+leaderboardRankedCTE+`, social AS (
 			SELECT $3::text AS user_id
 			UNION
 			SELECT followee_user_id
 			FROM game.friend_follows
 			WHERE follower_user_id = $3
-		),
-		holdings AS (
-			SELECT p.user_id,
-			       COALESCE(SUM((p.quantity_units * st.current_price_micros) / $2), 0) AS holdings_micros
-			FROM game.positions p
-			JOIN game.stocks st ON st.id = p.stock_id
-			WHERE p.season_id = $1
-			GROUP BY p.user_id
 		)
-		SELECT pr.username, pr.invite_code,
-		       (w.balance_micros + COALESCE(h.holdings_micros, 0)) AS net_worth_micros
+		SELECT r.user_id, pr.username, pr.invite_code, r.net_worth_micros
 		FROM social so
-		JOIN game.wallets w ON w.user_id = so.user_id AND w.season_id = $1
-		JOIN users.profiles pr ON pr.user_id = w.user_id
-		LEFT JOIN holdings h ON h.user_id = w.user_id
-		ORDER BY net_worth_micros DESC
+		JOIN ranked r ON r.user_id = so.user_id
+		JOIN users.profiles pr ON pr.user_id = r.user_id
+		ORDER BY r.rank
 		LIMIT $4
 	`, seasonID, ShareScale, userID, limit)
 	if err != nil {
@@ -1770,7 +2560,7 @@ func (s *Service) FriendsLeaderboard(ctx context.Context, seasonID int64, userID
 	var rank int64 = 1
 	for rows.Next() {
 		var r LeaderboardRow
-		if err := rows.Scan(&r.Username, &r.InviteCode, &r.NetWorthMicros); err != nil {
+		if err := rows.Scan(&r.UserID, &r.Username, &r.InviteCode, &r.NetWorthMicros); err != nil {
 			return nil, err
 		}
 		r.Rank = rank
@@ -1798,6 +2588,25 @@ func (s *Service) ReplaySync(ctx context.Context, userID string, seasonID int64,
 	return results, nil
 }
 
+// MarketState reports the season's trading hours and whether the market is
+// open right now. The worker skips ticks and orders are rejected while closed.
+func (s *Service) MarketState(ctx context.Context, seasonID int64) (MarketState, error) {
+	tx, err := s.reader().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return MarketState{}, err
+	}
+	defer tx.Rollback(ctx)
+	settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+	if err != nil {
+		return MarketState{}, err
+	}
+	halt, changeBps, err := marketHaltTx(ctx, tx, seasonID)
+	if err != nil {
+		return MarketState{}, err
+	}
+	return settings.marketState(time.Now()).withHalt(halt, changeBps), nil
+}
+
 func (s *Service) RunMarketTick(ctx context.Context, seasonID int64, tickEvery time.Duration, employeePerTick, newStocksPerTick int, interestAPR float64, volatility string) error {
 	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
 	if err != nil {
@@ -1805,13 +2614,13 @@ func (s *Service) RunMarketTick(ctx context.Context, seasonID int64, tickEvery t
 	}
 	defer tx.Rollback(ctx)
 
-	params := volatilityParams(volatility)
+	marketParams := volatilityParams(volatility)
 	world, err := s.evolveWorldStateTx(ctx, tx, seasonID)
 	if err != nil {
 		return err
 	}
 	regime := world.Regime
-	if s.nextFloat() < params.RegimeSwitchProb {
+	if s.nextFloat() < marketParams.RegimeSwitchProb {
 		regime = randomRegime(s.nextFloat())
 		if _, err := tx.Exec(ctx, `
 			UPDATE game.market_state
@@ -1820,28 +2629,53 @@ func (s *Service) RunMarketTick(ctx context.Context, seasonID int64, tickEvery t
 		`, seasonID, regime); err != nil {
 			return err
 		}
+		if regime != world.Regime {
+			if err := recordRegimeChangeTx(ctx, tx, seasonID, world.Regime, regime); err != nil {
+				return err
+			}
+			if err := recordNewsTx(ctx, tx, seasonID, NewsRegime, regimeNews(world.Regime, regime)); err != nil {
+				return err
+			}
+		}
 		world.Regime = regime
 	}
 
+	settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+	if err != nil {
+		return err
+	}
+
 	rows, err := tx.Query(ctx, `
-		SELECT id, symbol, current_price_micros, anchor_price_micros
-		FROM game.stocks
-		WHERE season_id = $1
-		FOR UPDATE
+		SELECT s.id, s.symbol, s.current_price_micros, s.anchor_price_micros, s.volatility_bps,
+		       s.listed_public,
+		       b.id IS NOT NULL AS linked,
+		       COALESCE(b.last_tick_net_micros, 0),
+		       COALESCE(b.prev_tick_net_micros, 0),
+		       COALESCE(b.base_revenue_micros, 0)
+		FROM game.stocks s
+		LEFT JOIN game.businesses b ON b.id = s.business_id AND b.season_id = s.season_id
+		WHERE s.season_id = $1
+		FOR UPDATE OF s
 	`, seasonID)
 	if err != nil {
 		return err
 	}
 	type row struct {
-		id     int64
-		symbol string
-		price  int64
-		anchor int64
+		id          int64
+		symbol      string
+		price       int64
+		anchor      int64
+		volBps      int32
+		listed      bool
+		linked      bool
+		lastNet     int64
+		prevNet     int64
+		baseRevenue int64
 	}
 	var stocks []row
 	for rows.Next() {
 		var r row
-		if err := rows.Scan(&r.id, &r.symbol, &r.price, &r.anchor); err != nil {
+		if err := rows.Scan(&r.id, &r.symbol, &r.price, &r.anchor, &r.volBps, &r.listed, &r.linked, &r.lastNet, &r.prevNet, &r.baseRevenue); err != nil {
 			rows.Close()
 			return err
 		}
@@ -1854,7 +2688,9 @@ func (s *Service) RunMarketTick(ctx context.Context, seasonID int64, tickEvery t
 
 	const minPriceMicros = int64(10_000)                // 0.01 stonky
 	const maxPriceMicros = int64(2_000_000_000_000_000) // 2 trillion stonky
+	var indexMoves []priceMove
 	for _, st := range stocks {
+		params := marketParams.forStock(st.volBps)
 		region := stockRegion(st.symbol)
 		sector := stockSector(st.symbol)
 		anchorRet := (0.30 * regimeDrift(regime)) + params.AnchorNoiseScale*normalish(s.nextFloat())
@@ -1863,6 +2699,9 @@ func (s *Service) RunMarketTick(ctx context.Context, seasonID int64, tickEvery t
 		if s.nextFloat() < params.ShockProb*0.20 {
 			anchorRet += signedShock(s.nextFloat(), s.nextFloat(), params.ShockScale*0.40)
 		}
+		if st.linked {
+			anchorRet = settings.blendBusinessAnchorReturn(anchorRet, st.lastNet, st.prevNet, st.baseRevenue)
+		}
 		nextAnchor := evolvePrice(st.anchor, anchorRet, params.MaxDropPerTick)
 		if nextAnchor < minPriceMicros {
 			nextAnchor = minPriceMicros
@@ -1888,6 +2727,16 @@ func (s *Service) RunMarketTick(ctx context.Context, seasonID int64, tickEvery t
 		if next > maxPriceMicros {
 			next = maxPriceMicros
 		}
+		next = settings.roundToPriceTick(next, minPriceMicros, maxPriceMicros)
+		if st.listed {
+			move := priceMove{from: st.price, to: next}
+			indexMoves = append(indexMoves, move)
+			if summary, ok := stockMoveNews(st.symbol, move); ok {
+				if err := recordNewsTx(ctx, tx, seasonID, NewsStockMove, summary); err != nil {
+					return err
+				}
+			}
+		}
 		if _, err := tx.Exec(ctx, `
 			UPDATE game.stocks
 			SET current_price_micros = $1::BIGINT,
@@ -1905,13 +2754,37 @@ func (s *Service) RunMarketTick(ctx context.Context, seasonID int64, tickEvery t
 		}
 	}
 
+	halted, err := applyCircuitBreakerTx(ctx, tx, seasonID, settings, indexChangeBps(indexMoves))
+	if err != nil {
+		return err
+	}
+	if !halted {
+		if err := applyStopLossesTx(ctx, tx, seasonID, settings); err != nil {
+			return err
+		}
+	}
+	if err := applyPriceAlertsTx(ctx, tx, seasonID); err != nil {
+		return err
+	}
 	if err := applyBusinessRevenueTx(ctx, tx, seasonID, s.nextFloat); err != nil {
 		return err
 	}
-	if err := applyBusinessLoanConsequencesTx(ctx, tx, seasonID); err != nil {
+	if err := applyDividendsTx(ctx, tx, seasonID); err != nil {
+		return err
+	}
+	if err := accrueBusinessLoanInterestTx(ctx, tx, seasonID, settings); err != nil {
+		return err
+	}
+	if err := applyLoanAutoRepayTx(ctx, tx, seasonID); err != nil {
+		return err
+	}
+	if err := applyBusinessLoanConsequencesTx(ctx, tx, seasonID, settings); err != nil {
+		return err
+	}
+	if err := applyShortBorrowFeesTx(ctx, tx, seasonID, settings, tickEvery); err != nil {
 		return err
 	}
-	if err := applyDebtInterestTx(ctx, tx, seasonID, tickEvery, interestAPR); err != nil {
+	if err := applyDebtInterestTx(ctx, tx, seasonID, settings, tickEvery, interestAPR); err != nil {
 		return err
 	}
 	if err := appendEmployeeCandidatesTx(ctx, tx, seasonID, employeePerTick); err != nil {
@@ -1920,10 +2793,21 @@ func (s *Service) RunMarketTick(ctx context.Context, seasonID int64, tickEvery t
 	if err := appendGeneratedStocksTx(ctx, tx, seasonID, newStocksPerTick, s.nextFloat); err != nil {
 		return err
 	}
+	if !halted {
+		if err := applyForcedSalesTx(ctx, tx, seasonID, settings); err != nil {
+			return err
+		}
+	}
 	if err := clampNegativeBalancesTx(ctx, tx, seasonID); err != nil {
 		return err
 	}
-	if err := updateSeasonPeakNetWorthTx(ctx, tx, seasonID); err != nil {
+	if err := updateSeasonPeakNetWorthTx(ctx, tx, seasonID, settings.PeakDecayBps); err != nil {
+		return err
+	}
+	if err := recordLeaderboardSnapshotTx(ctx, tx, seasonID); err != nil {
+		return err
+	}
+	if err := recordLeaderChangeNewsTx(ctx, tx, seasonID); err != nil {
 		return err
 	}
 	if err := s.applyPlayerProgressionTx(ctx, tx, seasonID, world); err != nil {
@@ -1935,6 +2819,19 @@ func (s *Service) RunMarketTick(ctx context.Context, seasonID int64, tickEvery t
 	if err := trimWorldEvents(ctx, tx, seasonID); err != nil {
 		return err
 	}
+	if err := trimNewsTx(ctx, tx, seasonID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE game.seasons
+		SET tick_count = tick_count + 1
+		WHERE id = $1
+	`, seasonID); err != nil {
+		return err
+	}
+	if err := notifyMarketTickTx(ctx, tx, seasonID); err != nil {
+		return err
+	}
 
 	return tx.Commit(ctx)
 }
@@ -2024,6 +2921,7 @@ func appendGeneratedStocksTx(ctx context.Context, tx pgx.Tx, seasonID int64, cou
 
 		priceMicros := int64(math.Round((1 + nextFloat()*98) * float64(MicrosPerStonky)))
 		displayName := generatedStockName(symbol)
+		volBps, _ := VolatilityTierBps(volatilityTiers[int(nextFloat()*float64(len(volatilityTiers)))%len(volatilityTiers)])
 		stockRows = append(stockRows, []any{
 			seasonID,
 			symbol,
@@ -2032,13 +2930,14 @@ func appendGeneratedStocksTx(ctx context.Context, tx pgx.Tx, seasonID int64, cou
 			priceMicros,
 			priceMicros,
 			autoGeneratedStockOwner,
+			volBps,
 		})
 	}
 
 	inserted, err := tx.CopyFrom(
 		ctx,
 		pgx.Identifier{"game", "stocks"},
-		[]string{"season_id", "symbol", "display_name", "listed_public", "current_price_micros", "anchor_price_micros", "created_by_user_id"},
+		[]string{"season_id", "symbol", "display_name", "listed_public", "current_price_micros", "anchor_price_micros", "created_by_user_id", "volatility_bps"},
 		pgx.CopyFromRows(stockRows),
 	)
 	if err != nil {
@@ -2089,14 +2988,22 @@ func appendGeneratedStocksTx(ctx context.Context, tx pgx.Tx, seasonID int64, cou
 		[]string{"stock_id", "tick_at", "price_micros"},
 		pgx.CopyFromRows(stockPriceRows),
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	for _, item := range insertedStocks {
+		if err := recordNewsTx(ctx, tx, seasonID, NewsIPO, ipoNews(item.symbol, generatedStockName(item.symbol), priceBySymbol[item.symbol])); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func generatedStockSymbol(index int) string {
 	const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
-	buf := [6]byte{}
+	buf := [SeededSymbolLength]byte{}
 	n := index
-	for i := 5; i >= 0; i-- {
+	for i := SeededSymbolLength - 1; i >= 0; i-- {
 		buf[i] = letters[n%26]
 		n /= 26
 	}
@@ -2136,7 +3043,7 @@ func symbolsFromRows(rows [][]any) []string {
 
 const autoGeneratedStockOwner = "__AUTO_MARKET__"
 
-func applyDebtInterestTx(ctx context.Context, tx pgx.Tx, seasonID int64, tickEvery time.Duration, apr float64) error {
+func applyDebtInterestTx(ctx context.Context, tx pgx.Tx, seasonID int64, settings seasonSettings, tickEvery time.Duration, apr float64) error {
 	if apr <= 0 {
 		return nil
 	}
@@ -2175,6 +3082,14 @@ func applyDebtInterestTx(ctx context.Context, tx pgx.Tx, seasonID int64, tickEve
 		if interest <= 0 {
 			continue
 		}
+		applied, uncovered := settings.flooredDebit(n.balance, -interest)
+		if err := recordUncoveredLossTx(ctx, tx, n.userID, seasonID, uncovered, "debt_interest"); err != nil {
+			return err
+		}
+		interest = -applied
+		if interest <= 0 {
+			continue
+		}
 		if err := addWalletDeltaTx(ctx, tx, seasonID, n.userID, -interest); err != nil {
 			return err
 		}
@@ -2182,7 +3097,20 @@ func applyDebtInterestTx(ctx context.Context, tx pgx.Tx, seasonID int64, tickEve
 			return err
 		}
 	}
-	return nil
+	return nil
+}
+
+// recordUncoveredLossTx logs the part of a charge that the season's balance
+// floor kept off the wallet. The entry has a zero wallet delta, so ledger
+// sums are unaffected.
+func recordUncoveredLossTx(ctx context.Context, tx pgx.Tx, userID string, seasonID, uncoveredMicros int64, source string) error {
+	if uncoveredMicros <= 0 {
+		return nil
+	}
+	return appendWalletDeltaEntry(ctx, tx, userID, seasonID, 0, "uncovered_loss", map[string]any{
+		"uncovered_micros": uncoveredMicros,
+		"source":           source,
+	})
 }
 
 func clampNegativeBalancesTx(ctx context.Context, tx pgx.Tx, seasonID int64) error {
@@ -2200,9 +3128,14 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 	if err != nil {
 		return err
 	}
+	settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+	if err != nil {
+		return err
+	}
 	rows, err := tx.Query(ctx, `
 		SELECT b.id,
 		       b.owner_user_id,
+		       b.name,
 		       b.base_revenue_micros,
 		       b.visibility,
 		       b.is_listed,
@@ -2221,6 +3154,9 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 		       b.brand_bps,
 		       b.operational_health_bps,
 		       b.cash_reserve_micros,
+		       b.revenue_mode,
+		       b.unclaimed_revenue_micros,
+		       b.idle_ticks,
 		       COALESCE(be.employee_revenue, 0) AS employee_revenue,
 		       b.employee_count AS employee_count,
 		       COALESCE(be.avg_risk_bps, 0) AS avg_risk_bps,
@@ -2233,8 +3169,7 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 		       COALESCE(be.legal_count, 0) AS legal_count,
 		       COALESCE(be.design_count, 0) AS design_count,
 		       COALESCE(m.output_bonus, 0) AS machine_output,
-		       COALESCE(m.upkeep, 0) AS machine_upkeep,
-		       COALESCE(l.loan_interest, 0) AS loan_interest
+		       COALESCE(m.upkeep, 0) AS machine_upkeep
 		FROM game.businesses b
 		LEFT JOIN LATERAL (
 			SELECT COALESCE(SUM(be.revenue_per_tick_micros), 0) AS employee_revenue,
@@ -2256,22 +3191,8 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 			FROM game.business_machinery bm
 			WHERE bm.business_id = b.id AND bm.season_id = b.season_id
 		) m ON TRUE
-		LEFT JOIN LATERAL (
-			SELECT COALESCE(
-				LEAST(
-					$2::numeric,
-					GREATEST(
-						0::numeric,
-						SUM((bl.outstanding_micros::numeric * bl.interest_bps::numeric) / 10000.0)
-					)
-				)::bigint,
-				0
-			) AS loan_interest
-			FROM game.business_loans bl
-			WHERE bl.business_id = b.id AND bl.season_id = b.season_id AND bl.status = 'open'
-		) l ON TRUE
 		WHERE b.season_id = $1
-	`, seasonID, maxBigintMicros)
+	`, seasonID)
 	if err != nil {
 		return err
 	}
@@ -2279,6 +3200,7 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 	type businessTickCycle struct {
 		businessID          int64
 		userID              string
+		name                string
 		baseRevenue         int64
 		visibility          string
 		isListed            bool
@@ -2297,6 +3219,9 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 		brandBps            int32
 		healthBps           int32
 		reserveMicros       int64
+		revenueMode         string
+		unclaimedMicros     int64
+		idleTicks           int32
 		employeeRevenue     int64
 		employeeCount       int64
 		avgRiskBps          float64
@@ -2310,18 +3235,17 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 		designCount         int64
 		machineOutput       int64
 		machineUpkeep       int64
-		loanInterest        int64
 	}
 	cycles := make([]businessTickCycle, 0)
 	for rows.Next() {
 		var c businessTickCycle
 		if err := rows.Scan(
-			&c.businessID, &c.userID, &c.baseRevenue,
+			&c.businessID, &c.userID, &c.name, &c.baseRevenue,
 			&c.visibility, &c.isListed, &c.primaryRegion, &c.narrativeArc, &c.narrativeFocus, &c.narrativePressure, &c.cyclePhase, &c.cycleTicksRemaining, &c.cycleImpactBps, &c.strategy, &c.marketingLevel, &c.rdLevel, &c.automationLevel, &c.complianceLevel,
-			&c.brandBps, &c.healthBps, &c.reserveMicros,
+			&c.brandBps, &c.healthBps, &c.reserveMicros, &c.revenueMode, &c.unclaimedMicros, &c.idleTicks,
 			&c.employeeRevenue, &c.employeeCount, &c.avgRiskBps,
 			&c.opsCount, &c.engineerCount, &c.productCount, &c.salesCount, &c.growthCount, &c.financeCount, &c.legalCount, &c.designCount,
-			&c.machineOutput, &c.machineUpkeep, &c.loanInterest,
+			&c.machineOutput, &c.machineUpkeep,
 		); err != nil {
 			return err
 		}
@@ -2331,6 +3255,31 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 		return err
 	}
 
+	machinesByBusiness := map[int64][]tickMachine{}
+	machineRows, err := tx.Query(ctx, `
+		SELECT id, business_id, output_bonus_micros, upkeep_micros, reliability_bps, failure_streak
+		FROM game.business_machinery
+		WHERE season_id = $1
+		ORDER BY business_id, id
+		FOR UPDATE
+	`, seasonID)
+	if err != nil {
+		return err
+	}
+	for machineRows.Next() {
+		var m tickMachine
+		var businessID int64
+		if err := machineRows.Scan(&m.id, &businessID, &m.outputMicros, &m.upkeepMicros, &m.reliabilityBps, &m.failureStreak); err != nil {
+			machineRows.Close()
+			return err
+		}
+		machinesByBusiness[businessID] = append(machinesByBusiness[businessID], m)
+	}
+	machineRows.Close()
+	if err := machineRows.Err(); err != nil {
+		return err
+	}
+
 	netByUser := map[string]int64{}
 	for _, c := range cycles {
 		empEfficiency := 1.0
@@ -2368,6 +3317,32 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 			}, world, nextFloat())
 		}
 
+		machines := machinesByBusiness[c.businessID]
+		idleTicks := int32(0)
+		if c.employeeCount == 0 && len(machines) == 0 {
+			idleTicks = c.idleTicks + 1
+		}
+		if idleTicks != c.idleTicks {
+			if _, err := tx.Exec(ctx, `
+				UPDATE game.businesses
+				SET idle_ticks = $1
+				WHERE id = $2 AND season_id = $3
+			`, idleTicks, c.businessID, seasonID); err != nil {
+				return err
+			}
+		}
+		baseRevenue := settings.idleBaseRevenue(c.baseRevenue, idleTicks)
+		lostOutput, repairCost, broken := rollMachineBreakdowns(machines, nextFloat)
+		for _, m := range machines {
+			if _, err := tx.Exec(ctx, `
+				UPDATE game.business_machinery
+				SET failure_streak = $1
+				WHERE id = $2 AND failure_streak <> $1
+			`, m.failureStreak, m.id); err != nil {
+				return err
+			}
+		}
+
 		autoBoost := 1.0 + float64(c.automationLevel)*0.03
 		marketingBoost := 1.0 + float64(c.marketingLevel)*0.02
 		rdBoost := 1.0 + float64(c.rdLevel)*0.015
@@ -2378,7 +3353,7 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 		if upkeepCut > 0.35 {
 			upkeepCut = 0.35
 		}
-		machineOutput := int64(math.Round(float64(c.machineOutput) * autoBoost))
+		machineOutput := int64(math.Round(float64(c.machineOutput-lostOutput) * autoBoost))
 		machineUpkeep := int64(math.Round(float64(c.machineUpkeep) * (1 - upkeepCut) * team.MachineUpkeepFactor))
 		employeeSalary := employeeSalaryCostMicros(c.employeeCount, c.avgRiskBps, c.marketingLevel, c.rdLevel, c.automationLevel, c.complianceLevel)
 		maintenanceCost := businessMaintenanceCostMicros(c.employeeCount, 0, c.reserveMicros, c.automationLevel, c.complianceLevel)
@@ -2386,7 +3361,7 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 		employeeSalary = int64(math.Round(float64(employeeSalary) * costMultiplier))
 		maintenanceCost = int64(math.Round(float64(maintenanceCost) * costMultiplier))
 
-		gross := c.baseRevenue + employeeRevenue + machineOutput - machineUpkeep
+		gross := baseRevenue + employeeRevenue + machineOutput - machineUpkeep
 		gross = int64(math.Round(float64(gross) * marketingBoost * rdBoost * brandBoost * healthBoost * team.RevenueMultiplier))
 		gross = int64(math.Round(float64(gross) * businessCycleRevenueMultiplier(businessCycle{cyclePhase: c.cyclePhase, cycleImpactBps: c.cycleImpactBps})))
 		gross = int64(math.Round(float64(gross) * (1 + regionTrend(world, c.primaryRegion)*0.35)))
@@ -2423,8 +3398,8 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 		p := nextFloat()
 		launchChance := 0.008 + team.LaunchChanceBonus
 		demandChance := 0.010 + team.DemandChanceBonus + maxFloat(0, regionTrend(world, c.primaryRegion))*0.5
-		viralChance := 0.020 + float64(c.marketingLevel)*0.0012 + team.ViralChanceBonus
-		crisisChance := 0.018 + riskFactor*0.07 + team.CrisisChanceBonus + maxFloat(0, -regionTrend(world, c.primaryRegion))*0.6
+		viralChance := settings.viralEventChance(c.marketingLevel, team.ViralChanceBonus)
+		crisisChance := settings.crisisEventChance(riskFactor, team.CrisisChanceBonus, maxFloat(0, -regionTrend(world, c.primaryRegion)))
 		if p < launchChance {
 			bonus := int64(math.Round(float64(gross) * (0.12 + nextFloat()*0.10)))
 			gross += bonus
@@ -2458,9 +3433,12 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 				return err
 			}
 		} else if p < launchChance+demandChance+viralChance {
-			bonus := int64(math.Round(float64(gross) * (0.08 + nextFloat()*0.15)))
+			bonus := int64(math.Round(float64(gross) * settings.viralBonusFraction(nextFloat())))
 			gross += bonus
 			eventTag = "Narrative breakout pushed the company into the spotlight"
+			if err := recordNewsTx(ctx, tx, seasonID, NewsViral, businessNewsName(c.name, c.visibility)+" goes viral and lands in the spotlight"); err != nil {
+				return err
+			}
 			if _, err := tx.Exec(ctx, `
 				UPDATE game.businesses
 				SET brand_bps = LEAST(20000, brand_bps + $1),
@@ -2474,12 +3452,16 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 				return err
 			}
 		} else if p < launchChance+demandChance+viralChance+crisisChance {
-			hit := int64(math.Round(float64(gross) * (0.10 + nextFloat()*0.20)))
+			hitFraction := settings.crisisHitFraction(nextFloat())
+			hit := int64(math.Round(float64(gross) * hitFraction))
 			gross -= hit
 			if gross < 0 {
 				gross = 0
 			}
 			eventTag = "Political and operating pressure triggered a company crisis"
+			if err := recordNewsTx(ctx, tx, seasonID, NewsCrisis, businessNewsName(c.name, c.visibility)+" is hit by a crisis under political and operating pressure"); err != nil {
+				return err
+			}
 			if _, err := tx.Exec(ctx, `
 				UPDATE game.businesses
 				SET brand_bps = GREATEST(5000, brand_bps - $1),
@@ -2492,6 +3474,15 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 			`, 280, 220, 420, eventTag, c.businessID, seasonID); err != nil {
 				return err
 			}
+			if c.unclaimedMicros > 0 {
+				if _, err := tx.Exec(ctx, `
+					UPDATE game.businesses
+					SET unclaimed_revenue_micros = GREATEST(0, unclaimed_revenue_micros - $1)
+					WHERE id = $2 AND season_id = $3
+				`, int64(math.Round(float64(c.unclaimedMicros)*hitFraction)), c.businessID, seasonID); err != nil {
+					return err
+				}
+			}
 		} else {
 			profitable := gross > 0
 			if _, err := tx.Exec(ctx, `
@@ -2511,6 +3502,19 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 		if nextCycleTicks < 0 {
 			nextCycleTicks = 0
 		}
+		if broken > 0 {
+			// A breakdown is reported even on an event tick, after the
+			// event itself.
+			breakdown := fmt.Sprintf("%d machine(s) broke down and produced nothing this tick", broken)
+			if repairCost > 0 {
+				breakdown += fmt.Sprintf("; repairs cost %.2f stonky", MicrosToStonky(repairCost))
+			}
+			if eventTag == "" {
+				eventTag = breakdown
+			} else {
+				eventTag += ". " + breakdown
+			}
+		}
 		if eventTag == "" && cycleMessage != "" {
 			eventTag = cycleMessage
 		}
@@ -2568,7 +3572,7 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 			}
 		}
 
-		reserveYield := int64(math.Round(float64(c.reserveMicros) * (0.00025 + float64(c.rdLevel)*0.00003) * team.ReserveYieldFactor))
+		reserveYield := settings.reserveYieldMicros(c.reserveMicros, c.baseRevenue, (0.00025+float64(c.rdLevel)*0.00003)*team.ReserveYieldFactor)
 		if reserveYield > 0 {
 			if _, err := tx.Exec(ctx, `
 				UPDATE game.businesses
@@ -2586,7 +3590,16 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 			}
 		}
 
-		net := gross - riskPenalty - employeeSalary - maintenanceCost - c.loanInterest - upgradeBurn + reserveYield
+		net := gross - riskPenalty - employeeSalary - maintenanceCost - upgradeBurn - repairCost + reserveYield
+		net = settings.capBusinessTickNet(net, c.baseRevenue)
+		if _, err := tx.Exec(ctx, `
+			UPDATE game.businesses
+			SET prev_tick_net_micros = last_tick_net_micros,
+			    last_tick_net_micros = $1
+			WHERE id = $2 AND season_id = $3
+		`, net, c.businessID, seasonID); err != nil {
+			return err
+		}
 		if net < 0 && c.reserveMicros > 0 {
 			cover := -net
 			if cover > c.reserveMicros {
@@ -2608,27 +3621,47 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 				return err
 			}
 		}
-		stakes, err := loadBusinessStakesTx(ctx, tx, c.businessID, seasonID)
+		dividendPool, err := businessDividendFundingTx(ctx, tx, c.businessID, seasonID, net)
 		if err != nil {
 			return err
 		}
-		if len(stakes) == 0 {
-			netByUser[c.userID] = saturatingAddInt64(netByUser[c.userID], net)
-		} else {
-			remaining := net
-			for idx, stake := range stakes {
-				share := int64(math.Round(float64(net) * float64(stake.StakeBps) / 10000.0))
-				if idx == len(stakes)-1 {
-					share = remaining
-				} else {
-					remaining -= share
-				}
-				netByUser[stake.UserID] = saturatingAddInt64(netByUser[stake.UserID], share)
+		net -= dividendPool
+		if c.revenueMode == "accrue" && net > 0 {
+			if _, err := tx.Exec(ctx, `
+				UPDATE game.businesses
+				SET unclaimed_revenue_micros = LEAST($1::numeric, unclaimed_revenue_micros::numeric + $2::numeric)::bigint
+				WHERE id = $3 AND season_id = $4
+			`, maxBigintMicros, net, c.businessID, seasonID); err != nil {
+				return err
 			}
+			continue
 		}
+		stakes, err := loadBusinessStakesTx(ctx, tx, c.businessID, seasonID)
+		if err != nil {
+			return err
+		}
+		addBusinessNetShares(netByUser, c.userID, net, stakes)
 	}
 
 	for userID, delta := range netByUser {
+		if delta < 0 && settings.MaxNegativeBalanceMicros > 0 {
+			var balance int64
+			if err := tx.QueryRow(ctx, `
+				SELECT balance_micros FROM game.wallets
+				WHERE season_id = $1 AND user_id = $2
+				FOR UPDATE
+			`, seasonID, userID).Scan(&balance); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					continue
+				}
+				return err
+			}
+			var uncovered int64
+			delta, uncovered = settings.flooredDebit(balance, delta)
+			if err := recordUncoveredLossTx(ctx, tx, userID, seasonID, uncovered, "business_cycle_loss"); err != nil {
+				return err
+			}
+		}
 		if delta == 0 {
 			continue
 		}
@@ -2636,7 +3669,7 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 			return err
 		}
 		if delta > 0 {
-			if err := appendLedgerEntries(ctx, tx, userID, seasonID, "business_revenue", delta, 0); err != nil {
+			if err := recordBusinessRevenueTx(ctx, tx, settings, userID, seasonID, delta); err != nil {
 				return err
 			}
 		} else {
@@ -2647,25 +3680,87 @@ func applyBusinessRevenueTx(ctx context.Context, tx pgx.Tx, seasonID int64, next
 			}
 		}
 	}
+	return nil
+}
 
-	if _, err := tx.Exec(ctx, `
+// accrueBusinessLoanInterestTx is the only place loan interest is charged:
+// it is capitalized into outstanding_micros (not taken from business net) on
+// the season's compounding ticks, at interest_bps for each tick in the period.
+func accrueBusinessLoanInterestTx(ctx context.Context, tx pgx.Tx, seasonID int64, settings seasonSettings) error {
+	var tickCount int64
+	if err := tx.QueryRow(ctx, `SELECT tick_count FROM game.seasons WHERE id = $1`, seasonID).Scan(&tickCount); err != nil {
+		return err
+	}
+	periods := settings.loanInterestPeriods(tickCount)
+	if periods <= 0 {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `
 		UPDATE game.business_loans
 		SET outstanding_micros = LEAST(
 		        $2::numeric,
 		        GREATEST(
 		            0::numeric,
-		            outstanding_micros::numeric + ((outstanding_micros::numeric * interest_bps::numeric) / 10000.0)
+		            outstanding_micros::numeric + ((outstanding_micros::numeric * interest_bps::numeric * $3::numeric) / 10000.0)
 		        )
 		    )::bigint,
 		    updated_at = now()
 		WHERE season_id = $1 AND status = 'open' AND outstanding_micros > 0
-	`, seasonID, maxBigintMicros); err != nil {
+	`, seasonID, maxBigintMicros, periods)
+	return err
+}
+
+// addBusinessNetShares splits a business's net across its stakeholders (the
+// last stake absorbs rounding), or credits the owner when there are none.
+func addBusinessNetShares(netByUser map[string]int64, ownerUserID string, net int64, stakes []businessStakeRow) {
+	if len(stakes) == 0 {
+		netByUser[ownerUserID] = saturatingAddInt64(netByUser[ownerUserID], net)
+		return
+	}
+	remaining := net
+	for idx, stake := range stakes {
+		share := int64(math.Round(float64(net) * float64(stake.StakeBps) / 10000.0))
+		if idx == len(stakes)-1 {
+			share = remaining
+		} else {
+			remaining -= share
+		}
+		netByUser[stake.UserID] = saturatingAddInt64(netByUser[stake.UserID], share)
+	}
+}
+
+// recordBusinessRevenueTx books business income already added to the wallet
+// and withholds any season business tax on it.
+func recordBusinessRevenueTx(ctx context.Context, tx pgx.Tx, settings seasonSettings, userID string, seasonID, amount int64) error {
+	if err := appendLedgerEntries(ctx, tx, userID, seasonID, "business_revenue", amount, 0); err != nil {
 		return err
 	}
-	return nil
+	tax := settings.businessTaxMicros(amount)
+	if tax <= 0 {
+		return nil
+	}
+	balance, err := lockWalletBalanceTx(ctx, tx, userID, seasonID)
+	if errors.Is(err, ErrWalletNotFound) {
+		// addWalletDeltaTx skipped the revenue too, so there is nothing to tax.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	applied, uncovered := settings.flooredDebit(balance, -tax)
+	if err := recordUncoveredLossTx(ctx, tx, userID, seasonID, uncovered, "business_tax"); err != nil {
+		return err
+	}
+	if tax = -applied; tax <= 0 {
+		return nil
+	}
+	if err := addWalletDeltaTx(ctx, tx, seasonID, userID, -tax); err != nil {
+		return err
+	}
+	return appendLedgerEntries(ctx, tx, userID, seasonID, "business_tax", tax, 0)
 }
 
-func applyBusinessLoanConsequencesTx(ctx context.Context, tx pgx.Tx, seasonID int64) error {
+func applyBusinessLoanConsequencesTx(ctx context.Context, tx pgx.Tx, seasonID int64, settings seasonSettings) error {
 	rows, err := tx.Query(ctx, `
 		SELECT business_id, owner_user_id,
 		       COALESCE(
@@ -2788,11 +3883,17 @@ func applyBusinessLoanConsequencesTx(ctx context.Context, tx pgx.Tx, seasonID in
 		if lateFee < minLate {
 			lateFee = minLate
 		}
-		if err := addWalletDeltaTx(ctx, tx, seasonID, it.userID, -lateFee); err != nil {
+		applied, uncovered := settings.flooredDebit(balance, -lateFee)
+		if err := recordUncoveredLossTx(ctx, tx, it.userID, seasonID, uncovered, "business_loan_late_fee"); err != nil {
 			return err
 		}
-		if err := appendLedgerEntries(ctx, tx, it.userID, seasonID, "business_loan_late_fee", lateFee, 0); err != nil {
-			return err
+		if lateFee = -applied; lateFee > 0 {
+			if err := addWalletDeltaTx(ctx, tx, seasonID, it.userID, -lateFee); err != nil {
+				return err
+			}
+			if err := appendLedgerEntries(ctx, tx, it.userID, seasonID, "business_loan_late_fee", lateFee, 0); err != nil {
+				return err
+			}
 		}
 		if _, err := tx.Exec(ctx, `
 			UPDATE game.business_loans
@@ -2829,6 +3930,15 @@ func applyBusinessLoanConsequencesTx(ctx context.Context, tx pgx.Tx, seasonID in
 		}
 
 		if missed >= 9 {
+			var name, visibility string
+			if err := tx.QueryRow(ctx, `
+				SELECT name, visibility FROM game.businesses WHERE id = $1 AND season_id = $2
+			`, it.businessID, seasonID).Scan(&name, &visibility); err != nil {
+				return err
+			}
+			if err := recordNewsTx(ctx, tx, seasonID, NewsDefault, businessNewsName(name, visibility)+" defaults on its loans and is wound up"); err != nil {
+				return err
+			}
 			if _, err := tx.Exec(ctx, `
 				INSERT INTO game.business_sale_history
 				    (business_id, season_id, owner_user_id, gross_valuation_micros, adjustment_factor, loan_payoff_micros, payout_micros)
@@ -2837,6 +3947,9 @@ func applyBusinessLoanConsequencesTx(ctx context.Context, tx pgx.Tx, seasonID in
 			`, it.businessID, seasonID, it.userID, it.outstanding); err != nil {
 				return err
 			}
+			if err := closeDefaultedBusinessStockTx(ctx, tx, seasonID, it.businessID, it.userID, settings); err != nil {
+				return err
+			}
 			if _, err := tx.Exec(ctx, `
 				DELETE FROM game.businesses
 				WHERE id = $1 AND season_id = $2
@@ -2848,28 +3961,163 @@ func applyBusinessLoanConsequencesTx(ctx context.Context, tx pgx.Tx, seasonID in
 	return nil
 }
 
-func updateSeasonPeakNetWorthTx(ctx context.Context, tx pgx.Tx, seasonID int64) error {
+// closeDefaultedBusinessStockTx delists a defaulting business's stock and
+// settles every outside position at the season's default payout share of the
+// last price: holders are cashed out and short sellers are bought in, paying
+// the cover like any other tick debit. The defaulting owner's own shares are
+// written off. Without this the stock would keep trading after the business
+// row is deleted.
+func closeDefaultedBusinessStockTx(ctx context.Context, tx pgx.Tx, seasonID, businessID int64, ownerID string, settings seasonSettings) error {
+	var stockID, price int64
+	err := tx.QueryRow(ctx, `
+		SELECT id, current_price_micros
+		FROM game.stocks
+		WHERE season_id = $1 AND business_id = $2
+		FOR UPDATE
+	`, seasonID, businessID).Scan(&stockID, &price)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT user_id, quantity_units
+		FROM game.positions
+		WHERE season_id = $1 AND stock_id = $2 AND user_id <> $3 AND quantity_units <> 0
+		ORDER BY user_id
+		FOR UPDATE
+	`, seasonID, stockID, ownerID)
+	if err != nil {
+		return err
+	}
+	type holding struct {
+		userID string
+		units  int64
+	}
+	holders := make([]holding, 0)
+	for rows.Next() {
+		var h holding
+		if err := rows.Scan(&h.userID, &h.units); err != nil {
+			rows.Close()
+			return err
+		}
+		holders = append(holders, h)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	payoutPrice := settings.defaultPayoutPrice(price)
+	for _, h := range holders {
+		if h.units < 0 {
+			if err := coverDefaultedShortTx(ctx, tx, settings, h.userID, seasonID, stockID, -h.units, payoutPrice); err != nil {
+				return err
+			}
+			continue
+		}
+		payout, err := notionalMicros(payoutPrice, h.units)
+		if err != nil {
+			return err
+		}
+		if payout <= 0 {
+			continue
+		}
+		if err := addWalletDeltaTx(ctx, tx, seasonID, h.userID, payout); err != nil {
+			return err
+		}
+		if err := appendLedgerEntries(ctx, tx, h.userID, seasonID, "business_default_payout", payout, 0); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM game.positions
+		WHERE season_id = $1 AND stock_id = $2
+	`, seasonID, stockID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE game.stocks
+		SET listed_public = false, updated_at = now()
+		WHERE id = $1
+	`, stockID)
+	return err
+}
+
+// coverDefaultedShortTx buys in a short on a defaulting business's stock at
+// payoutPrice. The cover is booked like a fee-free buy, with its order row
+// and realized P/L, but the debit is floored like other tick debits.
+func coverDefaultedShortTx(ctx context.Context, tx pgx.Tx, settings seasonSettings, userID string, seasonID, stockID, units, payoutPrice int64) error {
+	cost, err := notionalMicros(payoutPrice, units)
+	if err != nil {
+		return err
+	}
+	_, realized, err := applyCoverTx(ctx, tx, userID, seasonID, stockID, units, cost, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO game.orders (user_id, season_id, stock_id, side, quantity_units, price_micros, fee_micros, short, realized_pl_micros)
+		VALUES ($1, $2, $3, 'buy', $4, $5, 0, true, $6)
+	`, userID, seasonID, stockID, units, payoutPrice, realized); err != nil {
+		return err
+	}
+	if cost <= 0 {
+		return nil
+	}
+	balance, err := lockWalletBalanceTx(ctx, tx, userID, seasonID)
+	if err != nil {
+		return err
+	}
+	applied, uncovered := settings.flooredDebit(balance, -cost)
+	if err := recordUncoveredLossTx(ctx, tx, userID, seasonID, uncovered, "business_default_cover"); err != nil {
+		return err
+	}
+	if applied == 0 {
+		return nil
+	}
+	if err := addWalletDeltaTx(ctx, tx, seasonID, userID, applied); err != nil {
+		return err
+	}
+	return appendLedgerEntries(ctx, tx, userID, seasonID, "business_default_cover", -applied, 0)
+}
+
+// updateSeasonPeakNetWorthTx raises each wallet's peak to its current net
+// worth and, when decayBps is set, lets a peak above current net worth decay
+// that share of the gap toward it.
+func updateSeasonPeakNetWorthTx(ctx context.Context, tx pgx.Tx, seasonID int64, decayBps int32) error {
 	_, err := tx.Exec(ctx, `
 		UPDATE game.wallets w
 		SET peak_net_worth_micros = GREATEST(
-		        w.peak_net_worth_micros,
-		        LEAST(
-		            $2::numeric,
-		            GREATEST(
-		                $3::numeric,
-		                w.balance_micros::numeric + COALESCE((
-		                    SELECT SUM((p.quantity_units::numeric * s.current_price_micros::numeric) / $4::numeric)
-		                    FROM game.positions p
-		                    JOIN game.stocks s ON s.id = p.stock_id
-		                    WHERE p.user_id = w.user_id
-		                      AND p.season_id = w.season_id
-		                ), 0::numeric)
-		            )
-		        )::bigint
-		    ),
+		        nw.current_micros,
+		        w.peak_net_worth_micros::numeric - FLOOR(
+		            GREATEST(0::numeric, w.peak_net_worth_micros::numeric - nw.current_micros) * $5::numeric / 10000
+		        )
+		    )::bigint,
 		    updated_at = now()
-		WHERE w.season_id = $1
-	`, seasonID, maxBigintMicros, minBigintMicros, ShareScale)
+		FROM (
+		    SELECT cw.user_id,
+		           LEAST(
+		               $2::numeric,
+		               GREATEST(
+		                   $3::numeric,
+		                   cw.balance_micros::numeric + COALESCE((
+		                       SELECT SUM((p.quantity_units::numeric * s.current_price_micros::numeric) / $4::numeric)
+		                       FROM game.positions p
+		                       JOIN game.stocks s ON s.id = p.stock_id
+		                       WHERE p.user_id = cw.user_id
+		                         AND p.season_id = cw.season_id
+		                   ), 0::numeric)
+		               )
+		           ) AS current_micros
+		    FROM game.wallets cw
+		    WHERE cw.season_id = $1
+		) nw
+		WHERE w.season_id = $1 AND w.user_id = nw.user_id
+	`, seasonID, maxBigintMicros, minBigintMicros, ShareScale, clampBps(decayBps, 0, 10000))
 	return err
 }
 
@@ -2955,6 +4203,13 @@ func isSerializationError(err error) bool {
 	return errors.As(err, &pgErr) && pgErr.Code == "40001"
 }
 
+// isInviteCodeConflict reports a unique violation on users.profiles'
+// invite_code.
+func isInviteCodeConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && strings.Contains(pgErr.ConstraintName, "invite_code")
+}
+
 func sleepWithContext(ctx context.Context, d time.Duration) error {
 	t := time.NewTimer(d)
 	defer t.Stop()
@@ -2984,7 +4239,7 @@ func maxAffordableBuy(priceMicros, balanceMicros, debtLimitMicros int64) (maxUni
 			hi = mid - 1
 			continue
 		}
-		fee := int64(math.Round(float64(notional) * 0.0015))
+		fee := tradeFeeMicros(notional)
 		if notional+fee <= budget {
 			best = mid
 			lo = mid + 1
@@ -3050,6 +4305,61 @@ func volatilityParams(mode string) marketDynamics {
 	}
 }
 
+const (
+	VolatilityCalm   = "calm"
+	VolatilityNormal = "normal"
+	VolatilityWild   = "wild"
+)
+
+var volatilityTiers = []string{VolatilityCalm, VolatilityNormal, VolatilityWild}
+
+// VolatilityTierBps maps a named tier to the per-stock multiplier stored in
+// game.stocks.volatility_bps (10000 = the season-wide market dynamics).
+func VolatilityTierBps(tier string) (int32, bool) {
+	switch strings.ToLower(strings.TrimSpace(tier)) {
+	case VolatilityCalm:
+		return 6_000, true
+	case VolatilityNormal:
+		return 10_000, true
+	case VolatilityWild:
+		return 16_000, true
+	default:
+		return 0, false
+	}
+}
+
+// Tier boundaries for VolatilityTierName. migrations/0052 repeats them when
+// backfilling seeded dividend yields.
+const (
+	volatilityCalmBelowBps = 8_000
+	volatilityWildAboveBps = 13_000
+)
+
+func VolatilityTierName(bps int32) string {
+	switch {
+	case bps < volatilityCalmBelowBps:
+		return VolatilityCalm
+	case bps > volatilityWildAboveBps:
+		return VolatilityWild
+	default:
+		return VolatilityNormal
+	}
+}
+
+// forStock scales the noise and shock odds by a stock's volatility
+// multiplier. Drift, mean reversion, and the drop cap stay market-wide.
+func (m marketDynamics) forStock(volatilityBps int32) marketDynamics {
+	if volatilityBps <= 0 || volatilityBps == 10_000 {
+		return m
+	}
+	f := float64(volatilityBps) / 10_000
+	m.NoiseScale *= f
+	m.AnchorNoiseScale *= f
+	m.ShockProb = math.Min(m.ShockProb*f, 1)
+	m.ExtremeShockProb = math.Min(m.ExtremeShockProb*f, 1)
+	return m
+}
+
 func (s *Service) nextFloat() float64 {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -3061,10 +4371,17 @@ func appendLedgerEntries(ctx context.Context, tx pgx.Tx, userID string, seasonID
 	debit := -amountMicros
 	credit := amountMicros
 	if action == "sell" ||
+		action == "stop_loss_sell" ||
+		action == "forced_sale" ||
 		action == "business_revenue" ||
 		action == "business_loan_draw" ||
 		action == "business_sale" ||
-		action == "fund_sell" {
+		action == "daily_bonus" ||
+		action == "dividend" ||
+		action == "short_sell" ||
+		action == "fund_sell" ||
+		action == "business_default_payout" ||
+		action == "fund_swap" {
 		debit, credit = credit, debit
 	}
 	meta, _ := json.Marshal(map[string]any{"action": action})
@@ -3086,16 +4403,18 @@ func appendLedgerEntries(ctx context.Context, tx pgx.Tx, userID string, seasonID
 	return err
 }
 
-func claimIdempotency(ctx context.Context, tx pgx.Tx, userID, key, action string) error {
+// claimIdempotency records key for the user within one season, so a key
+// reused after a season rotation is treated as a new request.
+func claimIdempotency(ctx context.Context, tx pgx.Tx, userID string, seasonID int64, key, action string) error {
 	key = strings.TrimSpace(key)
 	if key == "" {
 		return fmt.Errorf("idempotency key is required")
 	}
 	cmd, err := tx.Exec(ctx, `
-		INSERT INTO game.idempotency_keys (user_id, key, action, created_at)
-		VALUES ($1, $2, $3, now())
-		ON CONFLICT (user_id, key) DO NOTHING
-	`, userID, key, action)
+		INSERT INTO game.idempotency_keys (user_id, season_id, key, action, created_at)
+		VALUES ($1, $2, $3, $4, now())
+		ON CONFLICT (user_id, season_id, key) DO NOTHING
+	`, userID, seasonID, key, action)
 	if err != nil {
 		return err
 	}
@@ -3152,21 +4471,28 @@ func upsertBuyPosition(ctx context.Context, tx pgx.Tx, userID string, seasonID,
 	return err
 }
 
-func applySellPosition(ctx context.Context, tx pgx.Tx, userID string, seasonID, stockID, qtyUnits int64) error {
-	var oldQty int64
+// applySellPosition removes qtyUnits from a position and returns the sell's
+// realized P/L against the position's average price, for the caller to store
+// on the order row. The remaining shares keep that average price.
+func applySellPosition(ctx context.Context, tx pgx.Tx, userID string, seasonID, stockID, qtyUnits, proceedsMicros, feeMicros int64) (int64, error) {
+	var oldQty, avgPrice int64
 	if err := tx.QueryRow(ctx, `
-		SELECT quantity_units
+		SELECT quantity_units, avg_price_micros
 		FROM game.positions
 		WHERE user_id = $1 AND season_id = $2 AND stock_id = $3
 		FOR UPDATE
-	`, userID, seasonID, stockID).Scan(&oldQty); err != nil {
+	`, userID, seasonID, stockID).Scan(&oldQty, &avgPrice); err != nil {
 		if err == pgx.ErrNoRows {
-			return ErrInsufficientShares
+			return 0, ErrInsufficientShares
 		}
-		return err
+		return 0, err
 	}
 	if oldQty < qtyUnits {
-		return ErrInsufficientShares
+		return 0, ErrInsufficientShares
+	}
+	realized := realizedPLMicros(avgPrice, qtyUnits, proceedsMicros, feeMicros)
+	if err := recordRealizedPLTx(ctx, tx, userID, seasonID, stockID, realized, qtyUnits); err != nil {
+		return 0, err
 	}
 	next := oldQty - qtyUnits
 	if next == 0 {
@@ -3174,16 +4500,36 @@ func applySellPosition(ctx context.Context, tx pgx.Tx, userID string, seasonID,
 			DELETE FROM game.positions
 			WHERE user_id = $1 AND season_id = $2 AND stock_id = $3
 		`, userID, seasonID, stockID)
-		return err
+		return realized, err
 	}
 	_, err := tx.Exec(ctx, `
 		UPDATE game.positions
 		SET quantity_units = $1, updated_at = now()
 		WHERE user_id = $2 AND season_id = $3 AND stock_id = $4
 	`, next, userID, seasonID, stockID)
+	return realized, err
+}
+
+// recordRealizedPLTx adds a closing trade's P/L to the player's running
+// total for the stock, which feeds the dashboard's lifetime figure.
+func recordRealizedPLTx(ctx context.Context, tx pgx.Tx, userID string, seasonID, stockID, realizedMicros, units int64) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO game.realized_pnl (user_id, season_id, stock_id, realized_micros, sold_units)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, season_id, stock_id) DO UPDATE
+		SET realized_micros = game.realized_pnl.realized_micros + EXCLUDED.realized_micros,
+		    sold_units = game.realized_pnl.sold_units + EXCLUDED.sold_units,
+		    updated_at = now()
+	`, userID, seasonID, stockID, realizedMicros, units)
 	return err
 }
 
+// realizedPLMicros is a sell's proceeds less the fee and the cost basis of
+// the shares sold, prorated from the position's average price.
+func realizedPLMicros(avgPriceMicros, qtyUnits, proceedsMicros, feeMicros int64) int64 {
+	return proceedsMicros - notionalMicrosClamped(avgPriceMicros, qtyUnits) - feeMicros
+}
+
 func (s *Service) updatePeakNetWorthTx(ctx context.Context, tx pgx.Tx, userID string, seasonID int64) error {
 	netWorth, err := netWorthTx(ctx, tx, userID, seasonID)
 	if err != nil {
@@ -3199,15 +4545,41 @@ func (s *Service) updatePeakNetWorthTx(ctx context.Context, tx pgx.Tx, userID st
 }
 
 func netWorthTx(ctx context.Context, tx pgx.Tx, userID string, seasonID int64) (int64, error) {
+	balance, holdings, err := netWorthPartsTx(ctx, tx, userID, seasonID)
+	if err != nil {
+		return 0, err
+	}
+	return saturatingAddInt64(balance, holdings), nil
+}
+
+// lockWalletBalanceTx locks the player's wallet for the season and returns
+// its balance. A missing wallet (e.g. a player acting in a season they never
+// joined) is reported as ErrWalletNotFound rather than a raw no-rows error.
+func lockWalletBalanceTx(ctx context.Context, tx pgx.Tx, userID string, seasonID int64) (int64, error) {
 	var balance int64
+	err := tx.QueryRow(ctx, `
+		SELECT balance_micros
+		FROM game.wallets
+		WHERE user_id = $1 AND season_id = $2
+		FOR UPDATE
+	`, userID, seasonID).Scan(&balance)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrWalletNotFound
+	}
+	return balance, err
+}
+
+func netWorthPartsTx(ctx context.Context, tx pgx.Tx, userID string, seasonID int64) (balance, holdings int64, err error) {
 	if err := tx.QueryRow(ctx, `
 		SELECT balance_micros
 		FROM game.wallets
 		WHERE user_id = $1 AND season_id = $2
 	`, userID, seasonID).Scan(&balance); err != nil {
-		return 0, err
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, 0, ErrWalletNotFound
+		}
+		return 0, 0, err
 	}
-	var holdings int64
 	if err := tx.QueryRow(ctx, `
 		SELECT COALESCE(
 			LEAST(
@@ -3223,9 +4595,46 @@ func netWorthTx(ctx context.Context, tx pgx.Tx, userID string, seasonID int64) (
 		JOIN game.stocks s ON s.id = p.stock_id
 		WHERE p.user_id = $1 AND p.season_id = $2
 	`, userID, seasonID, ShareScale, maxBigintMicros, minBigintMicros).Scan(&holdings); err != nil {
-		return 0, err
+		return 0, 0, err
 	}
-	return saturatingAddInt64(balance, holdings), nil
+	return balance, holdings, nil
+}
+
+// setSeasonSharesOutstandingTx gives a newly listed stock the season's
+// default supply.
+func setSeasonSharesOutstandingTx(ctx context.Context, tx pgx.Tx, seasonID, stockID int64) error {
+	settings, err := loadSeasonSettingsTx(ctx, tx, seasonID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE game.stocks
+		SET shares_outstanding_units = $2, updated_at = now()
+		WHERE id = $1
+	`, stockID, settings.sharesOutstandingUnits())
+	return err
+}
+
+// scarcityPriceMicros is the execution price for buying qtyUnits of a stock
+// with a fixed supply when ownedUnits are already held. The premium grows
+// with the square of the share of supply held after the fill, reaching
+// ScarcityPremiumMaxBps when the last share is bought. Buys beyond the
+// supply fail with ErrSupplyExhausted. outstandingUnits <= 0 means
+// unlimited supply and no premium.
+func scarcityPriceMicros(priceMicros, ownedUnits, qtyUnits, outstandingUnits int64) (int64, error) {
+	if outstandingUnits <= 0 {
+		return priceMicros, nil
+	}
+	available := outstandingUnits - ownedUnits
+	if available < 0 {
+		available = 0
+	}
+	if qtyUnits > available {
+		return 0, fmt.Errorf("%w: %.4f shares available", ErrSupplyExhausted, UnitsToShares(available))
+	}
+	held := float64(ownedUnits+qtyUnits) / float64(outstandingUnits)
+	premium := int64(math.Round(float64(priceMicros) * held * held * float64(ScarcityPremiumMaxBps) / 10_000))
+	return saturatingAddInt64(priceMicros, premium), nil
 }
 
 func notionalMicros(priceMicros, qtyUnits int64) (int64, error) {
@@ -3265,9 +4674,11 @@ func divideMicros(totalMicros, qtyUnits int64) (int64, error) {
 	return v.Int64(), nil
 }
 
+// generateInviteCode draws inviteCodeLength characters from a 32-letter
+// alphabet; 256 is a multiple of 32, so every letter is equally likely.
 func generateInviteCode() (string, error) {
 	const letters = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
-	buf := make([]byte, 8)
+	buf := make([]byte, inviteCodeLength)
 	if _, err := rand.Read(buf); err != nil {
 		return "", err
 	}
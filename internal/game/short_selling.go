@@ -0,0 +1,186 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ShortBorrowAPR is the yearly fee on the market value of short positions,
+// charged a tick's share at a time.
+const ShortBorrowAPR = 0.08
+
+// applyShortSellTx opens or adds to a short: the position's quantity goes
+// negative by qtyUnits and its average price becomes the average entry price
+// of the short. The player's total short exposure at current prices, this
+// order included, must stay within the season's peak-based debt limit.
+func applyShortSellTx(ctx context.Context, tx pgx.Tx, settings seasonSettings, userID string, seasonID, stockID, qtyUnits, priceMicros int64) error {
+	var oldQty, oldAvg int64
+	err := tx.QueryRow(ctx, `
+		SELECT quantity_units, avg_price_micros
+		FROM game.positions
+		WHERE user_id = $1 AND season_id = $2 AND stock_id = $3
+		FOR UPDATE
+	`, userID, seasonID, stockID).Scan(&oldQty, &oldAvg)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if oldQty > 0 {
+		return fmt.Errorf("%w: sell the %.4f shares you hold before shorting", ErrInsufficientShares, UnitsToShares(oldQty))
+	}
+
+	var exposure, peak int64
+	if err := tx.QueryRow(ctx, `
+		SELECT COALESCE((
+		           SELECT LEAST($4::numeric, SUM(FLOOR(-p.quantity_units::numeric * st.current_price_micros::numeric / $3::numeric)))::bigint
+		           FROM game.positions p
+		           JOIN game.stocks st ON st.id = p.stock_id
+		           WHERE p.user_id = $1 AND p.season_id = $2 AND p.quantity_units < 0
+		       ), 0),
+		       COALESCE((SELECT peak_net_worth_micros FROM game.wallets WHERE user_id = $1 AND season_id = $2), 0)
+	`, userID, seasonID, ShareScale, maxBigintMicros).Scan(&exposure, &peak); err != nil {
+		return err
+	}
+	notional, err := notionalMicros(priceMicros, qtyUnits)
+	if err != nil {
+		return err
+	}
+	limit := settings.debtLimitFromPeak(peak)
+	if next := saturatingAddInt64(exposure, notional); next > limit {
+		return fmt.Errorf("%w: short exposure of %.2f would exceed your %.2f borrow limit",
+			ErrInsufficientFunds, float64(next)/float64(MicrosPerStonky), float64(limit)/float64(MicrosPerStonky))
+	}
+
+	shortQty := -oldQty + qtyUnits
+	cost := saturatingAddInt64(notionalMicrosClamped(oldAvg, -oldQty), notional)
+	avg, err := divideMicros(cost, shortQty)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO game.positions (user_id, season_id, stock_id, quantity_units, avg_price_micros)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, season_id, stock_id) DO UPDATE
+		SET quantity_units = EXCLUDED.quantity_units,
+		    avg_price_micros = EXCLUDED.avg_price_micros,
+		    updated_at = now()
+	`, userID, seasonID, stockID, -shortQty, avg)
+	return err
+}
+
+// applyCoverTx buys back qtyUnits of a short position and returns the P/L
+// realized against the short's average entry price, for the caller to store
+// on the order row. It reports false, leaving everything untouched, when the
+// player is not short the stock. A cover cannot buy more than is short; the
+// remainder keeps its average price.
+func applyCoverTx(ctx context.Context, tx pgx.Tx, userID string, seasonID, stockID, qtyUnits, costMicros, feeMicros int64) (bool, int64, error) {
+	var oldQty, avg int64
+	err := tx.QueryRow(ctx, `
+		SELECT quantity_units, avg_price_micros
+		FROM game.positions
+		WHERE user_id = $1 AND season_id = $2 AND stock_id = $3
+		FOR UPDATE
+	`, userID, seasonID, stockID).Scan(&oldQty, &avg)
+	if err == pgx.ErrNoRows || (err == nil && oldQty >= 0) {
+		return false, 0, nil
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	if qtyUnits > -oldQty {
+		return false, 0, fmt.Errorf("%w: buy covers at most the %.4f shares short", ErrInsufficientShares, UnitsToShares(-oldQty))
+	}
+	realized := coverPLMicros(avg, qtyUnits, costMicros, feeMicros)
+	if err := recordRealizedPLTx(ctx, tx, userID, seasonID, stockID, realized, qtyUnits); err != nil {
+		return false, 0, err
+	}
+	next := oldQty + qtyUnits
+	if next == 0 {
+		_, err = tx.Exec(ctx, `
+			DELETE FROM game.positions
+			WHERE user_id = $1 AND season_id = $2 AND stock_id = $3
+		`, userID, seasonID, stockID)
+		return true, realized, err
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE game.positions
+		SET quantity_units = $1, updated_at = now()
+		WHERE user_id = $2 AND season_id = $3 AND stock_id = $4
+	`, next, userID, seasonID, stockID)
+	return true, realized, err
+}
+
+// coverPLMicros is what buying back qtyUnits of a short realizes: the
+// prorated entry value less the buy-back cost and its fee.
+func coverPLMicros(avgPriceMicros, qtyUnits, costMicros, feeMicros int64) int64 {
+	return notionalMicrosClamped(avgPriceMicros, qtyUnits) - costMicros - feeMicros
+}
+
+// shortBorrowFeeMicros is one tick's borrow fee on shortValueMicros.
+func shortBorrowFeeMicros(shortValueMicros int64, tickEvery time.Duration) int64 {
+	if shortValueMicros <= 0 || tickEvery <= 0 {
+		return 0
+	}
+	ticksPerYear := (365 * 24 * time.Hour).Seconds() / tickEvery.Seconds()
+	return int64(math.Ceil(float64(shortValueMicros) * ShortBorrowAPR / ticksPerYear))
+}
+
+// applyShortBorrowFeesTx charges every player with short positions the
+// tick's borrow fee on their current market value, subject to the season's
+// balance floor. Shorts on unlisted stocks are not charged.
+func applyShortBorrowFeesTx(ctx context.Context, tx pgx.Tx, seasonID int64, settings seasonSettings, tickEvery time.Duration) error {
+	rows, err := tx.Query(ctx, `
+		SELECT w.user_id, w.balance_micros,
+		       LEAST($3::numeric, SUM(FLOOR(-p.quantity_units::numeric * st.current_price_micros::numeric / $2::numeric)))::bigint
+		FROM game.positions p
+		JOIN game.stocks st ON st.id = p.stock_id
+		JOIN game.wallets w ON w.user_id = p.user_id AND w.season_id = p.season_id
+		WHERE p.season_id = $1 AND p.quantity_units < 0 AND st.listed_public = true
+		GROUP BY w.user_id, w.balance_micros
+	`, seasonID, ShareScale, maxBigintMicros)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	type short struct {
+		userID  string
+		balance int64
+		value   int64
+	}
+	var items []short
+	for rows.Next() {
+		var sh short
+		if err := rows.Scan(&sh.userID, &sh.balance, &sh.value); err != nil {
+			return err
+		}
+		items = append(items, sh)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+	for _, sh := range items {
+		fee := shortBorrowFeeMicros(sh.value, tickEvery)
+		if fee <= 0 {
+			continue
+		}
+		applied, uncovered := settings.flooredDebit(sh.balance, -fee)
+		if err := recordUncoveredLossTx(ctx, tx, sh.userID, seasonID, uncovered, "short_borrow_fee"); err != nil {
+			return err
+		}
+		fee = -applied
+		if fee <= 0 {
+			continue
+		}
+		if err := addWalletDeltaTx(ctx, tx, seasonID, sh.userID, -fee); err != nil {
+			return err
+		}
+		if err := appendLedgerEntries(ctx, tx, sh.userID, seasonID, "short_borrow_fee", fee, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
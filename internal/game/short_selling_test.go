@@ -0,0 +1,58 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoverPLMicros(t *testing.T) {
+	// Short 4 shares at 5.00, bought back at 3.00 with a 0.02 fee.
+	qty := int64(4 * ShareScale)
+	if got := coverPLMicros(5_000_000, qty, notionalMicrosClamped(3_000_000, qty), 20_000); got != 7_980_000 {
+		t.Fatalf("coverPLMicros = %d, want 7980000", got)
+	}
+	if got := coverPLMicros(5_000_000, qty, notionalMicrosClamped(6_000_000, qty), 0); got != -4_000_000 {
+		t.Fatalf("coverPLMicros at a loss = %d, want -4000000", got)
+	}
+}
+
+func TestShortBorrowFeeMicros(t *testing.T) {
+	value := int64(1_000) * MicrosPerStonky
+	day := shortBorrowFeeMicros(value, 24*time.Hour)
+	want := int64(1_000*MicrosPerStonky) * 8 / 100 / 365
+	if day < want || day > want+1 {
+		t.Fatalf("daily fee = %d, want about %d", day, want)
+	}
+	if shortBorrowFeeMicros(0, time.Minute) != 0 || shortBorrowFeeMicros(value, 0) != 0 {
+		t.Fatal("no short value or tick length should mean no fee")
+	}
+}
+
+func TestShortBreakEvenBelowEntry(t *testing.T) {
+	avg := int64(10_000_000)
+	floored := defaultSeasonSettings()
+	floored.MinTradeFeeMicros = MicrosPerStonky / 10
+	for name, cfg := range map[string]seasonSettings{"default": defaultSeasonSettings(), "min fee": floored} {
+		for _, qty := range []int64{ShareScale, 20 * ShareScale} {
+			be := cfg.shortBreakEvenPrice(avg, qty, 0)
+			if be >= avg {
+				t.Fatalf("%s qty=%d: short break-even %d should be below entry %d", name, qty, be, avg)
+			}
+			entry := notionalMicrosClamped(avg, qty)
+			entryFee, _ := cfg.orderFee(entry, 0)
+			pl := func(price int64) int64 {
+				cover := notionalMicrosClamped(price, qty)
+				fee, _ := cfg.orderFee(cover, 0)
+				return entry - entryFee - cover - fee
+			}
+			if pl(be) < 0 || pl(be+1) >= 0 {
+				t.Fatalf("%s qty=%d: break-even %d is not the highest covering price", name, qty, be)
+			}
+		}
+	}
+	// Fees that swallow the whole short leave no price to break even at.
+	floored.MinTradeFeeMicros = 10 * MicrosPerStonky
+	if got := floored.shortBreakEvenPrice(avg, ShareScale, 0); got != 0 {
+		t.Fatalf("fee-swallowed short break-even = %d, want 0", got)
+	}
+}
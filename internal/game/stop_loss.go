@@ -0,0 +1,168 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SetStopLoss stores a stop on one of the player's positions: once the
+// stock's price falls below the trigger, the next market tick sells
+// QuantityUnits of it (the whole position when zero) at market. A zero
+// trigger removes the stop.
+func (s *Service) SetStopLoss(ctx context.Context, in StopLossInput) (StopLoss, error) {
+	out := StopLoss{TriggerPriceMicros: in.TriggerPriceMicros, QuantityUnits: in.QuantityUnits}
+	in.Symbol = strings.ToUpper(strings.TrimSpace(in.Symbol))
+	out.Symbol = in.Symbol
+	if err := ValidateSymbol(in.Symbol); err != nil {
+		return out, err
+	}
+	if in.TriggerPriceMicros < 0 {
+		return out, fmt.Errorf("trigger price must be >= 0")
+	}
+	if in.QuantityUnits < 0 {
+		return out, fmt.Errorf("quantity must be >= 0")
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return out, err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, in.UserID, in.SeasonID, in.IdempotencyKey, "stop_loss"); err != nil {
+		return out, err
+	}
+	var stockID int64
+	if err := tx.QueryRow(ctx, `
+		SELECT id FROM game.stocks WHERE season_id = $1 AND symbol = $2
+	`, in.SeasonID, in.Symbol).Scan(&stockID); err != nil {
+		if err == pgx.ErrNoRows {
+			return out, ErrStockNotFound
+		}
+		return out, err
+	}
+	if in.TriggerPriceMicros == 0 {
+		if _, err := tx.Exec(ctx, `
+			DELETE FROM game.stop_losses
+			WHERE user_id = $1 AND season_id = $2 AND stock_id = $3
+		`, in.UserID, in.SeasonID, stockID); err != nil {
+			return out, err
+		}
+		return out, tx.Commit(ctx)
+	}
+	var held int64
+	if err := tx.QueryRow(ctx, `
+		SELECT quantity_units
+		FROM game.positions
+		WHERE user_id = $1 AND season_id = $2 AND stock_id = $3
+		FOR UPDATE
+	`, in.UserID, in.SeasonID, stockID).Scan(&held); err != nil {
+		if err == pgx.ErrNoRows {
+			return out, fmt.Errorf("%w: no %s position", ErrPositionNotFound, in.Symbol)
+		}
+		return out, err
+	}
+	if held <= 0 {
+		return out, fmt.Errorf("%w: stops only protect long positions", ErrPositionNotFound)
+	}
+	if in.QuantityUnits > held {
+		return out, fmt.Errorf("%w: stop covers more than the %.4f shares held", ErrInsufficientShares, UnitsToShares(held))
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO game.stop_losses (user_id, season_id, stock_id, trigger_price_micros, quantity_units)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, season_id, stock_id) DO UPDATE
+		SET trigger_price_micros = EXCLUDED.trigger_price_micros,
+		    quantity_units = EXCLUDED.quantity_units,
+		    updated_at = now()
+	`, in.UserID, in.SeasonID, stockID, in.TriggerPriceMicros, in.QuantityUnits); err != nil {
+		return out, err
+	}
+	return out, tx.Commit(ctx)
+}
+
+// stopLossSellUnits is how much a triggered stop sells: its quantity, capped
+// at what is still held, or the whole position when the stop has none.
+func stopLossSellUnits(stopUnits, heldUnits int64) int64 {
+	if stopUnits <= 0 || stopUnits > heldUnits {
+		return heldUnits
+	}
+	return stopUnits
+}
+
+// applyStopLossesTx sells positions whose stock closed the tick below their
+// stop's trigger, at the tick price less the trade fee, and removes the
+// stops. Stops left without a position are dropped.
+func applyStopLossesTx(ctx context.Context, tx pgx.Tx, seasonID int64, settings seasonSettings) error {
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM game.stop_losses sl
+		WHERE sl.season_id = $1
+		  AND NOT EXISTS (
+		      SELECT 1 FROM game.positions p
+		      WHERE p.user_id = sl.user_id AND p.season_id = sl.season_id AND p.stock_id = sl.stock_id
+		  )
+	`, seasonID); err != nil {
+		return err
+	}
+	rows, err := tx.Query(ctx, `
+		SELECT sl.id, sl.user_id, sl.stock_id, sl.quantity_units, p.quantity_units, st.current_price_micros
+		FROM game.stop_losses sl
+		JOIN game.stocks st ON st.id = sl.stock_id
+		JOIN game.positions p
+		  ON p.user_id = sl.user_id AND p.season_id = sl.season_id AND p.stock_id = sl.stock_id
+		WHERE sl.season_id = $1 AND st.current_price_micros < sl.trigger_price_micros
+		ORDER BY sl.id
+		FOR UPDATE OF sl, p
+	`, seasonID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	type triggered struct {
+		id          int64
+		userID      string
+		stockID     int64
+		stopUnits   int64
+		heldUnits   int64
+		priceMicros int64
+	}
+	var items []triggered
+	for rows.Next() {
+		var t triggered
+		if err := rows.Scan(&t.id, &t.userID, &t.stockID, &t.stopUnits, &t.heldUnits, &t.priceMicros); err != nil {
+			return err
+		}
+		items = append(items, t)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, t := range items {
+		qty := stopLossSellUnits(t.stopUnits, t.heldUnits)
+		if qty > 0 && t.priceMicros > 0 {
+			notional := notionalMicrosClamped(t.priceMicros, qty)
+			fee := settings.tradeFee(tradeFeeMicros(notional))
+			realized, err := applySellPosition(ctx, tx, t.userID, seasonID, t.stockID, qty, notional, fee)
+			if err != nil {
+				return err
+			}
+			if err := addWalletDeltaTx(ctx, tx, seasonID, t.userID, notional-fee); err != nil {
+				return err
+			}
+			if err := appendLedgerEntries(ctx, tx, t.userID, seasonID, "stop_loss_sell", notional, fee); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(ctx, `
+				INSERT INTO game.orders (user_id, season_id, stock_id, side, quantity_units, price_micros, fee_micros, realized_pl_micros)
+				VALUES ($1, $2, $3, 'sell', $4, $5, $6, $7)
+			`, t.userID, seasonID, t.stockID, qty, t.priceMicros, fee, realized); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM game.stop_losses WHERE id = $1`, t.id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package game
+
+import "testing"
+
+func TestStopLossSellUnits(t *testing.T) {
+	cases := []struct {
+		stop, held, want int64
+	}{
+		{stop: 0, held: 5 * ShareScale, want: 5 * ShareScale},
+		{stop: 2 * ShareScale, held: 5 * ShareScale, want: 2 * ShareScale},
+		{stop: 8 * ShareScale, held: 5 * ShareScale, want: 5 * ShareScale},
+	}
+	for _, c := range cases {
+		if got := stopLossSellUnits(c.stop, c.held); got != c.want {
+			t.Fatalf("stopLossSellUnits(%d, %d) = %d, want %d", c.stop, c.held, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// marketTickChannel is the Postgres NOTIFY channel a market tick announces
+// itself on. Ticks run in the worker, so this is how the API learns about
+// them.
+const marketTickChannel = "stanks_market_tick"
+
+// notifyMarketTickTx queues a notification carrying the season id; Postgres
+// only delivers it if the tick transaction commits.
+func notifyMarketTickTx(ctx context.Context, tx pgx.Tx, seasonID int64) error {
+	_, err := tx.Exec(ctx, `SELECT pg_notify($1, $2)`, marketTickChannel, strconv.FormatInt(seasonID, 10))
+	return err
+}
+
+// ListenMarketTicks holds a primary connection listening for committed
+// market ticks and calls fn with each tick's season id. It blocks until ctx
+// is done or the connection fails.
+func (s *Service) ListenMarketTicks(ctx context.Context, fn func(seasonID int64)) error {
+	pooled, err := s.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// Take the connection out of the pool so a LISTEN never leaks back into
+	// it.
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+	if _, err := conn.Exec(ctx, "LISTEN "+marketTickChannel); err != nil {
+		return err
+	}
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		seasonID, err := strconv.ParseInt(n.Payload, 10, 64)
+		if err != nil {
+			continue
+		}
+		fn(seasonID)
+	}
+}
@@ -3,16 +3,31 @@ package game
 import "time"
 
 type Dashboard struct {
-	SeasonID           int64          `json:"season_id"`
-	ActiveBusinessID   *int64         `json:"active_business_id,omitempty"`
-	BalanceMicros      int64          `json:"balance_micros"`
-	NetWorthMicros     int64          `json:"net_worth_micros"`
-	PeakNetWorthMicros int64          `json:"peak_net_worth_micros"`
-	Progression        PlayerProgress `json:"progression"`
-	World              WorldView      `json:"world"`
-	Positions          []PositionView `json:"positions"`
-	Businesses         []BusinessView `json:"businesses"`
-	Stakes             []StakeView    `json:"stakes"`
+	SeasonID           int64     `json:"season_id"`
+	SeasonStartsAt     time.Time `json:"season_starts_at"`
+	ActiveBusinessID   *int64    `json:"active_business_id,omitempty"`
+	BalanceMicros      int64     `json:"balance_micros"`
+	NetWorthMicros     int64     `json:"net_worth_micros"`
+	PeakNetWorthMicros int64     `json:"peak_net_worth_micros"`
+	LeaderboardTopBps  int64     `json:"leaderboard_top_bps"`
+	LeaderboardPlayers int64     `json:"leaderboard_players"`
+	PortfolioBeta      *float64  `json:"portfolio_beta,omitempty"`
+	// DividendsReceivedMicros totals this season's dividend ledger entries,
+	// reinvested ones included; DividendsReinvestedMicros is the part that
+	// went straight back into shares.
+	DividendsReceivedMicros   int64 `json:"dividends_received_micros"`
+	DividendsReinvestedMicros int64 `json:"dividends_reinvested_micros"`
+	// RealizedPLMicros totals the P/L stored on this season's closing
+	// orders: sells and short covers.
+	RealizedPLMicros int64 `json:"realized_pl_micros"`
+	// LifetimeRealizedPLMicros totals the same P/L across every season the
+	// player has traded in.
+	LifetimeRealizedPLMicros int64          `json:"lifetime_realized_pl_micros"`
+	Progression              PlayerProgress `json:"progression"`
+	World                    WorldView      `json:"world"`
+	Positions                []PositionView `json:"positions"`
+	Businesses               []BusinessView `json:"businesses"`
+	Stakes                   []StakeView    `json:"stakes"`
 }
 
 type WalletSummary struct {
@@ -22,6 +37,29 @@ type WalletSummary struct {
 	PeakNetWorthMicros int64  `json:"peak_net_worth_micros"`
 }
 
+// CostBreakdown totals what a player has paid this season, by ledger
+// category. Business upkeep, salaries, and loan interest are netted into
+// business results, so they show up as BusinessLossesMicros only on ticks
+// where a business lost money overall.
+type CostBreakdown struct {
+	SeasonID             int64 `json:"season_id"`
+	TradeFeesMicros      int64 `json:"trade_fees_micros"`
+	DebtInterestMicros   int64 `json:"debt_interest_micros"`
+	LoanLateFeesMicros   int64 `json:"loan_late_fees_micros"`
+	BusinessLossesMicros int64 `json:"business_losses_micros"`
+	BusinessTaxMicros    int64 `json:"business_tax_micros"`
+	TotalMicros          int64 `json:"total_micros"`
+}
+
+type PlayerProfile struct {
+	UserID     string    `json:"user_id"`
+	SeasonID   int64     `json:"season_id"`
+	Email      string    `json:"email"`
+	Username   string    `json:"username"`
+	InviteCode string    `json:"invite_code"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 type PositionView struct {
 	Symbol             string `json:"symbol"`
 	DisplayName        string `json:"display_name"`
@@ -29,6 +67,84 @@ type PositionView struct {
 	AvgPriceMicros     int64  `json:"avg_price_micros"`
 	CurrentPriceMicros int64  `json:"current_price_micros"`
 	UnrealizedMicros   int64  `json:"unrealized_micros"`
+	BreakEvenMicros    int64  `json:"break_even_micros"`
+	// DividendsMicros is the dividend income this position has collected
+	// since it was opened, kept apart from its capital gain.
+	DividendsMicros int64 `json:"dividends_micros"`
+}
+
+// FundPositionView is the player's holding in one fund valued at current NAV.
+type FundPositionView struct {
+	Code             string `json:"code"`
+	Name             string `json:"name"`
+	Units            int64  `json:"units"`
+	AvgNavMicros     int64  `json:"avg_nav_micros"`
+	NavMicros        int64  `json:"nav_micros"`
+	ValueMicros      int64  `json:"value_micros"`
+	UnrealizedMicros int64  `json:"unrealized_micros"`
+}
+
+// AutomationSkip is a streak of ticks an automated operation was skipped
+// for lack of funds. ResolvedAt is set once the operation runs again.
+type AutomationSkip struct {
+	Operation       string     `json:"operation"`
+	RefID           int64      `json:"ref_id"`
+	Reason          string     `json:"reason"`
+	NeededMicros    int64      `json:"needed_micros"`
+	AvailableMicros int64      `json:"available_micros"`
+	SkippedTicks    int32      `json:"skipped_ticks"`
+	FirstSkippedAt  time.Time  `json:"first_skipped_at"`
+	LastSkippedAt   time.Time  `json:"last_skipped_at"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+}
+
+type StopLossInput struct {
+	UserID             string
+	SeasonID           int64
+	Symbol             string
+	TriggerPriceMicros int64
+	QuantityUnits      int64
+	IdempotencyKey     string
+}
+
+// StopLoss is a stored stop on a position; QuantityUnits 0 sells it all.
+type StopLoss struct {
+	Symbol             string `json:"symbol"`
+	TriggerPriceMicros int64  `json:"trigger_price_micros"`
+	QuantityUnits      int64  `json:"quantity_units"`
+}
+
+type PriceAlertInput struct {
+	UserID            string
+	SeasonID          int64
+	Symbol            string
+	Direction         string
+	TargetPriceMicros int64
+	IdempotencyKey    string
+}
+
+// PriceAlert fires when the stock's price reaches TargetPriceMicros from
+// the given direction ("above" or "below"). TriggeredAt and
+// TriggeredPriceMicros are set by the market tick that set it off.
+type PriceAlert struct {
+	ID                   int64      `json:"id"`
+	Symbol               string     `json:"symbol"`
+	Direction            string     `json:"direction"`
+	TargetPriceMicros    int64      `json:"target_price_micros"`
+	CreatedAt            time.Time  `json:"created_at"`
+	TriggeredAt          *time.Time `json:"triggered_at,omitempty"`
+	TriggeredPriceMicros *int64     `json:"triggered_price_micros,omitempty"`
+}
+
+// LiquidationEntry is one holding in forced-sale order.
+type LiquidationEntry struct {
+	Symbol        string `json:"symbol"`
+	QuantityUnits int64  `json:"quantity_units"`
+	PriceMicros   int64  `json:"price_micros"`
+	ValueMicros   int64  `json:"value_micros"`
+	Priority      int32  `json:"liquidation_priority"`
+
+	stockID int64
 }
 
 type BusinessView struct {
@@ -63,6 +179,8 @@ type BusinessView struct {
 	BrandBps              int32  `json:"brand_bps"`
 	OperationalHealthBps  int32  `json:"operational_health_bps"`
 	CashReserveMicros     int64  `json:"cash_reserve_micros"`
+	RevenueMode           string `json:"revenue_mode"`
+	UnclaimedMicros       int64  `json:"unclaimed_revenue_micros"`
 	LastEvent             string `json:"last_event"`
 	OwnedStakeBps         int32  `json:"owned_stake_bps"`
 }
@@ -93,6 +211,94 @@ type PlayerProgress struct {
 	NextStreakTarget        int32  `json:"next_streak_target"`
 }
 
+// SeasonInfo is the active season's schedule and ruleset as exposed to
+// clients. TickEverySeconds, MarketVolatility, and InterestAPR come from the
+// deployment rather than the season row and are filled in by the API.
+type SeasonInfo struct {
+	ID               int64       `json:"id"`
+	Name             string      `json:"name"`
+	Status           string      `json:"status"`
+	StartsAt         time.Time   `json:"starts_at"`
+	EndsAt           time.Time   `json:"ends_at"`
+	TickCount        int64       `json:"tick_count"`
+	EndAfterTicks    int64       `json:"end_after_ticks"`
+	Extensions       int32       `json:"anti_snipe_extensions"`
+	TickEverySeconds int64       `json:"tick_every_seconds,omitempty"`
+	MarketVolatility string      `json:"market_volatility,omitempty"`
+	InterestAPR      float64     `json:"interest_apr,omitempty"`
+	Rules            SeasonRules `json:"rules"`
+	Market           MarketState `json:"market"`
+}
+
+// SeasonRules lists the economy constants and per-season settings that
+// shape play.
+type SeasonRules struct {
+	StarterBalanceMicros       int64   `json:"starter_balance_micros"`
+	SignupBonusMicros          int64   `json:"signup_bonus_micros"`
+	BusinessUnlockMicros       int64   `json:"business_unlock_micros"`
+	TradeFeeBps                int64   `json:"trade_fee_bps"`
+	FundFeeBps                 int64   `json:"fund_fee_bps"`
+	MinTradeFeeMicros          int64   `json:"min_trade_fee_micros"`
+	FundSwapFeeBps             int32   `json:"fund_swap_fee_bps"`
+	DebtLimitMinMicros         int64   `json:"debt_limit_min_micros"`
+	DebtLimitMaxMicros         int64   `json:"debt_limit_max_micros"`
+	DebtLimitPeakBps           int32   `json:"debt_limit_peak_bps"`
+	CollateralHoldingsBps      int32   `json:"collateral_holdings_bps"`
+	LoanCompoundEveryTicks     int32   `json:"loan_compound_every_ticks"`
+	BusinessTaxBps             int32   `json:"business_tax_bps"`
+	BusinessTaxThresholdMicros int64   `json:"business_tax_threshold_micros"`
+	MaxMachineryLevels         int32   `json:"max_machinery_levels"`
+	IdleRevenueDecayBps        int32   `json:"idle_revenue_decay_bps"`
+	DefaultPayoutBps           int32   `json:"default_payout_bps"`
+	PeakDecayBps               int32   `json:"peak_decay_bps"`
+	PriceTickMicros            int64   `json:"price_tick_micros"`
+	UniqueBusinessNames        bool    `json:"unique_business_names"`
+	ViralBaseChance            float64 `json:"viral_base_chance"`
+	CrisisBaseChance           float64 `json:"crisis_base_chance"`
+	AntiSnipeMaxExtensions     int32   `json:"anti_snipe_max_extensions"`
+	SharesOutstanding          int64   `json:"shares_outstanding"`
+	MaxNegativeBalanceMicros   int64   `json:"max_negative_balance_micros"`
+	FeeDiscountFundCode        string  `json:"fee_discount_fund_code,omitempty"`
+	FeeDiscountBps             int32   `json:"fee_discount_bps"`
+	FeeDiscountMinUnits        int64   `json:"fee_discount_min_units"`
+	CircuitBreakerDropBps      int32   `json:"circuit_breaker_drop_bps"`
+	CircuitBreakerHaltTicks    int32   `json:"circuit_breaker_halt_ticks"`
+	HireCostHeadcountBps       int32   `json:"hire_cost_headcount_bps"`
+}
+
+// RegimePeriod is one stretch of the season spent in a market regime.
+// EndedAt is nil for the regime still in force; Ticks is set once it ends.
+type RegimePeriod struct {
+	Regime    string     `json:"regime"`
+	StartTick int64      `json:"start_tick"`
+	Ticks     int64      `json:"ticks,omitempty"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
+
+// NewsItem is one entry in the season's news feed.
+type NewsItem struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	Summary   string    `json:"summary"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MarketState struct {
+	Open         bool       `json:"open"`
+	Scheduled    bool       `json:"scheduled"`
+	OpenTime     string     `json:"open_time,omitempty"`
+	CloseTime    string     `json:"close_time,omitempty"`
+	Timezone     string     `json:"timezone"`
+	NextChangeAt *time.Time `json:"next_change_at,omitempty"`
+	// Halted is set while the circuit breaker has stock trading paused, for
+	// HaltTicksRemaining more market ticks. IndexChangeBps is the
+	// equal-weighted index move of the last tick.
+	Halted             bool  `json:"halted"`
+	HaltTicksRemaining int32 `json:"halt_ticks_remaining"`
+	IndexChangeBps     int64 `json:"index_change_bps"`
+}
+
 type WorldView struct {
 	Regime                 string           `json:"regime"`
 	PoliticalClimate       string           `json:"political_climate"`
@@ -123,11 +329,42 @@ type StockView struct {
 	DisplayName        string `json:"display_name"`
 	CurrentPriceMicros int64  `json:"current_price_micros"`
 	ListedPublic       bool   `json:"listed_public"`
+	VolatilityTier     string `json:"volatility_tier"`
+	VolatilityBps      int32  `json:"volatility_bps"`
+	// SharesOutstandingUnits is the stock's fixed supply (0 = unlimited);
+	// AvailableUnits is the part no player holds yet.
+	SharesOutstandingUnits int64 `json:"shares_outstanding_units,omitempty"`
+	AvailableUnits         int64 `json:"available_units,omitempty"`
+}
+
+// RecentIPO is a stock that went public recently, with the business behind
+// it when there is one.
+type RecentIPO struct {
+	Symbol             string    `json:"symbol"`
+	DisplayName        string    `json:"display_name"`
+	BusinessID         *int64    `json:"business_id,omitempty"`
+	BusinessName       string    `json:"business_name,omitempty"`
+	IPOPriceMicros     int64     `json:"ipo_price_micros"`
+	CurrentPriceMicros int64     `json:"current_price_micros"`
+	ListedAt           time.Time `json:"listed_at"`
+	TicksSinceListing  int64     `json:"ticks_since_listing"`
 }
 
 type StockDetail struct {
 	StockView
-	Series []PricePoint `json:"series"`
+	Series    []PricePoint   `json:"series"`
+	Sentiment StockSentiment `json:"sentiment"`
+}
+
+// StockSentiment is the buy/sell balance of recent player order flow.
+// BuyShareBps is buys as a share of traded volume.
+type StockSentiment struct {
+	Label         string `json:"label"`
+	BuyShareBps   int64  `json:"buy_share_bps"`
+	BuyUnits      int64  `json:"buy_units"`
+	SellUnits     int64  `json:"sell_units"`
+	Orders        int64  `json:"orders"`
+	WindowSeconds int64  `json:"window_seconds"`
 }
 
 type PricePoint struct {
@@ -135,21 +372,89 @@ type PricePoint struct {
 	PriceMicros int64     `json:"price_micros"`
 }
 
+type Candle struct {
+	BucketStart time.Time `json:"bucket_start"`
+	OpenMicros  int64     `json:"open_micros"`
+	HighMicros  int64     `json:"high_micros"`
+	LowMicros   int64     `json:"low_micros"`
+	CloseMicros int64     `json:"close_micros"`
+	Ticks       int64     `json:"ticks"`
+}
+
+type StockCandles struct {
+	Symbol        string    `json:"symbol"`
+	BucketSeconds int64     `json:"bucket_seconds"`
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	Candles       []Candle  `json:"candles"`
+}
+
 type OrderInput struct {
-	UserID         string
-	SeasonID       int64
-	Symbol         string
-	Side           string
-	QuantityUnits  int64
+	UserID        string
+	SeasonID      int64
+	Symbol        string
+	Side          string
+	QuantityUnits int64
+	// Short lets a sell take the position below zero (see applyShortSellTx).
+	Short          bool
 	IdempotencyKey string
 }
 
 type OrderResult struct {
 	OrderID        int64 `json:"order_id"`
+	QuantityUnits  int64 `json:"quantity_units"`
 	PriceMicros    int64 `json:"price_micros"`
 	NotionalMicros int64 `json:"notional_micros"`
 	FeeMicros      int64 `json:"fee_micros"`
-	BalanceMicros  int64 `json:"balance_micros"`
+	// FeeDiscountMicros is what holding the season's membership fund took
+	// off the fee; FeeMicros is already net of it.
+	FeeDiscountMicros int64 `json:"fee_discount_micros,omitempty"`
+	BalanceMicros     int64 `json:"balance_micros"`
+}
+
+// OrderQuote is what an order would fill at right now. TotalMicros is the
+// wallet debit for a buy and the credit for a sell, fee included.
+type OrderQuote struct {
+	PriceMicros       int64 `json:"price_micros"`
+	NotionalMicros    int64 `json:"notional_micros"`
+	FeeMicros         int64 `json:"fee_micros"`
+	FeeDiscountMicros int64 `json:"fee_discount_micros,omitempty"`
+	TotalMicros       int64 `json:"total_micros"`
+}
+
+// WatchItem is a stock on the player's watchlist. ChangeBps is the move
+// from AddedPriceMicros to the current price.
+type WatchItem struct {
+	Symbol             string    `json:"symbol"`
+	DisplayName        string    `json:"display_name"`
+	AddedPriceMicros   int64     `json:"added_price_micros"`
+	CurrentPriceMicros int64     `json:"current_price_micros"`
+	ChangeBps          int64     `json:"change_bps"`
+	AddedAt            time.Time `json:"added_at"`
+}
+
+// OrderView is one trade in a player's order history. Short marks sells that
+// opened a short and buys that covered one. RealizedPLMicros is set on
+// closing trades, from the figure stored when they were placed.
+type OrderView struct {
+	ID               int64     `json:"id"`
+	Symbol           string    `json:"symbol"`
+	Side             string    `json:"side"`
+	QuantityUnits    int64     `json:"quantity_units"`
+	PriceMicros      int64     `json:"price_micros"`
+	NotionalMicros   int64     `json:"notional_micros"`
+	FeeMicros        int64     `json:"fee_micros"`
+	Short            bool      `json:"short,omitempty"`
+	RealizedPLMicros *int64    `json:"realized_pl_micros,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// OrderPage is one page of order history, newest first.
+type OrderPage struct {
+	Orders []OrderView `json:"orders"`
+	Total  int64       `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
 }
 
 type CreateBusinessInput struct {
@@ -202,6 +507,19 @@ type BuyMachineryInput struct {
 	IdempotencyKey string
 }
 
+type MachineryBatchItem struct {
+	MachineType string `json:"machine_type"`
+	Count       int    `json:"count"`
+}
+
+type BuyMachineryBatchInput struct {
+	UserID         string
+	SeasonID       int64
+	BusinessID     int64
+	Items          []MachineryBatchItem
+	IdempotencyKey string
+}
+
 type TrainProfessionalInput struct {
 	UserID         string
 	SeasonID       int64
@@ -211,10 +529,22 @@ type TrainProfessionalInput struct {
 }
 
 type BusinessLoanInput struct {
+	UserID       string
+	SeasonID     int64
+	BusinessID   int64
+	AmountMicros int64
+	// LoanID targets a single open loan when repaying; zero sweeps open
+	// loans oldest first.
+	LoanID         int64
+	IdempotencyKey string
+}
+
+type BusinessLoanAutoRepayInput struct {
 	UserID         string
 	SeasonID       int64
 	BusinessID     int64
-	AmountMicros   int64
+	Enabled        bool
+	BufferMicros   int64
 	IdempotencyKey string
 }
 
@@ -226,6 +556,14 @@ type BusinessStrategyInput struct {
 	IdempotencyKey string
 }
 
+type BusinessRevenueModeInput struct {
+	UserID         string
+	SeasonID       int64
+	BusinessID     int64
+	Mode           string
+	IdempotencyKey string
+}
+
 type BusinessUpgradeInput struct {
 	UserID         string
 	SeasonID       int64
@@ -234,6 +572,17 @@ type BusinessUpgradeInput struct {
 	IdempotencyKey string
 }
 
+// BusinessUpgradeCost is one upgrade track's current level and the price of
+// its next level. Seats also report the employee limit and whether they are
+// maxed out.
+type BusinessUpgradeCost struct {
+	Upgrade        string `json:"upgrade"`
+	Level          int64  `json:"level"`
+	NextCostMicros int64  `json:"next_cost_micros"`
+	Maxed          bool   `json:"maxed,omitempty"`
+	EmployeeLimit  int64  `json:"employee_limit,omitempty"`
+}
+
 type BusinessReserveInput struct {
 	UserID         string
 	SeasonID       int64
@@ -251,6 +600,16 @@ type FundOrderInput struct {
 	IdempotencyKey string
 }
 
+// FundSwapInput moves Units of FromCode into ToCode at current NAVs.
+type FundSwapInput struct {
+	UserID         string
+	SeasonID       int64
+	FromCode       string
+	ToCode         string
+	Units          int64
+	IdempotencyKey string
+}
+
 type TransferBusinessStakeInput struct {
 	UserID            string
 	SeasonID          int64
@@ -301,7 +660,41 @@ type RushStatus struct {
 	WorldMomentumBps      int32  `json:"world_momentum_bps"`
 }
 
+// PublicProfile is what other players may see about an account: season
+// standing and aggregates only, never positions or cash balance.
+type PublicProfile struct {
+	Username          string    `json:"username"`
+	InviteCode        string    `json:"invite_code"`
+	JoinedAt          time.Time `json:"joined_at"`
+	Rank              int64     `json:"rank"`
+	Players           int64     `json:"players"`
+	LeaderboardTopBps int64     `json:"leaderboard_top_bps"`
+	NetWorthMicros    int64     `json:"net_worth_micros"`
+	BusinessCount     int64     `json:"business_count"`
+	Following         bool      `json:"following"`
+	FollowsYou        bool      `json:"follows_you"`
+}
+
+// RankHistory is a player's leaderboard rank over the season, one point per
+// market tick.
+type RankHistory struct {
+	Username   string      `json:"username"`
+	InviteCode string      `json:"invite_code"`
+	Points     []RankPoint `json:"points"`
+}
+
+type RankPoint struct {
+	Tick           int64     `json:"tick"`
+	Rank           int64     `json:"rank"`
+	Players        int64     `json:"players"`
+	NetWorthMicros int64     `json:"net_worth_micros"`
+	RecordedAt     time.Time `json:"recorded_at"`
+}
+
 type LeaderboardRow struct {
+	// UserID lets callers recognize the viewer's own row; it is never
+	// serialized.
+	UserID         string `json:"-"`
 	Rank           int64  `json:"rank"`
 	Username       string `json:"username"`
 	InviteCode     string `json:"invite_code"`
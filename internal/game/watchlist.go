@@ -0,0 +1,104 @@
+package game
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// AddWatch puts a stock on the player's watchlist at its current price.
+// Watching a symbol already on the list keeps its original add price.
+func (s *Service) AddWatch(ctx context.Context, userID string, seasonID int64, symbol, idem string) error {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if err := ValidateSymbol(symbol); err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, userID, seasonID, idem, "watch_add"); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO game.watchlists (user_id, season_id, stock_id, added_price_micros)
+		SELECT $1, $2, id, current_price_micros
+		FROM game.stocks
+		WHERE season_id = $2 AND symbol = $3
+		ON CONFLICT (user_id, season_id, stock_id) DO NOTHING
+	`, userID, seasonID, symbol)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		var exists bool
+		if err := tx.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM game.stocks WHERE season_id = $1 AND symbol = $2)
+		`, seasonID, symbol).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return ErrStockNotFound
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// RemoveWatch takes a stock off the player's watchlist. Removing a symbol
+// that is not on the list is a no-op.
+func (s *Service) RemoveWatch(ctx context.Context, userID string, seasonID int64, symbol, idem string) error {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := claimIdempotency(ctx, tx, userID, seasonID, idem, "watch_remove"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM game.watchlists w
+		USING game.stocks st
+		WHERE st.id = w.stock_id
+		  AND w.user_id = $1 AND w.season_id = $2 AND st.symbol = $3
+	`, userID, seasonID, symbol); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListWatch returns the player's watchlist by symbol, with each stock's
+// move since it was added.
+func (s *Service) ListWatch(ctx context.Context, userID string, seasonID int64) ([]WatchItem, error) {
+	rows, err := s.reader().Query(ctx, `
+		SELECT st.symbol, st.display_name, w.added_price_micros, st.current_price_micros, w.created_at
+		FROM game.watchlists w
+		JOIN game.stocks st ON st.id = w.stock_id
+		WHERE w.user_id = $1 AND w.season_id = $2
+		ORDER BY st.symbol
+	`, userID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]WatchItem, 0)
+	for rows.Next() {
+		var w WatchItem
+		if err := rows.Scan(&w.Symbol, &w.DisplayName, &w.AddedPriceMicros, &w.CurrentPriceMicros, &w.AddedAt); err != nil {
+			return nil, err
+		}
+		w.ChangeBps = watchChangeBps(w.AddedPriceMicros, w.CurrentPriceMicros)
+		out = append(out, w)
+	}
+	return out, rows.Err()
+}
+
+// watchChangeBps is the price move from addedMicros to currentMicros, in bps.
+func watchChangeBps(addedMicros, currentMicros int64) int64 {
+	if addedMicros <= 0 {
+		return 0
+	}
+	return int64(float64(currentMicros-addedMicros) / float64(addedMicros) * 10_000)
+}
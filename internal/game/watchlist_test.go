@@ -0,0 +1,19 @@
+package game
+
+import "testing"
+
+func TestWatchChangeBps(t *testing.T) {
+	cases := []struct {
+		added, current, want int64
+	}{
+		{100_000_000, 110_000_000, 1_000},
+		{100_000_000, 75_000_000, -2_500},
+		{100_000_000, 100_000_000, 0},
+		{0, 5_000_000, 0},
+	}
+	for _, c := range cases {
+		if got := watchChangeBps(c.added, c.current); got != c.want {
+			t.Fatalf("watchChangeBps(%d, %d) = %d, want %d", c.added, c.current, got, c.want)
+		}
+	}
+}
@@ -2,15 +2,31 @@ package syncq
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 )
 
+const (
+	DefaultMaxSize = 200
+	// ReplayBatchSize bounds how many commands one `stk sync` run replays.
+	ReplayBatchSize = 50
+)
+
+// MaxSize caps the number of queued commands; 0 or less disables the cap.
+var MaxSize = DefaultMaxSize
+
+var ErrQueueFull = errors.New("sync queue is full")
+
 type Command struct {
 	Method         string         `json:"method"`
 	Path           string         `json:"path"`
 	Body           map[string]any `json:"body,omitempty"`
 	IdempotencyKey string         `json:"idempotency_key"`
+	// MaybeSent marks a command whose original request may have reached the
+	// API before failing, so it may already have been applied.
+	MaybeSent bool `json:"maybe_sent,omitempty"`
 }
 
 func queuePath() (string, error) {
@@ -64,6 +80,14 @@ func Push(cmd Command) error {
 	if err != nil {
 		return err
 	}
+	if MaxSize > 0 && len(commands) >= MaxSize {
+		return fmt.Errorf("%w (%d commands); run `stk sync` before queueing more", ErrQueueFull, len(commands))
+	}
 	commands = append(commands, cmd)
 	return Save(commands)
 }
+
+// Clear drops every queued command without replaying it.
+func Clear() error {
+	return Save([]Command{})
+}
@@ -0,0 +1,53 @@
+package syncq
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPushRejectsWhenFull(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	prev := MaxSize
+	MaxSize = 2
+	t.Cleanup(func() { MaxSize = prev })
+
+	for i := 0; i < 2; i++ {
+		if err := Push(Command{Method: "POST", Path: "/v1/orders"}); err != nil {
+			t.Fatalf("Push #%d error = %v", i+1, err)
+		}
+	}
+	if err := Push(Command{Method: "POST", Path: "/v1/orders"}); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("Push on full queue error = %v, want ErrQueueFull", err)
+	}
+	queue, err := Load()
+	if err != nil {
+		t.Fatalf("Load error = %v", err)
+	}
+	if len(queue) != 2 {
+		t.Fatalf("queue length = %d, want 2", len(queue))
+	}
+}
+
+func TestClearEmptiesQueue(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := Push(Command{Method: "POST", Path: "/v1/orders", IdempotencyKey: "k1", MaybeSent: true}); err != nil {
+		t.Fatalf("Push error = %v", err)
+	}
+	queue, err := Load()
+	if err != nil {
+		t.Fatalf("Load error = %v", err)
+	}
+	if len(queue) != 1 || !queue[0].MaybeSent {
+		t.Fatalf("queue = %+v, want one MaybeSent command", queue)
+	}
+	if err := Clear(); err != nil {
+		t.Fatalf("Clear error = %v", err)
+	}
+	queue, err = Load()
+	if err != nil {
+		t.Fatalf("Load after Clear error = %v", err)
+	}
+	if len(queue) != 0 {
+		t.Fatalf("queue length after Clear = %d, want 0", len(queue))
+	}
+}
@@ -8,15 +8,24 @@ import (
 	"go.mau.fi/whatsmeow/types"
 )
 
+// signupInvitePrefix marks the optional trailing invite code argument, so it
+// cannot be mistaken for the last word of a password with spaces.
+const signupInvitePrefix = "invite:"
+
 func (b *Bot) handleSignup(ctx context.Context, chat, sender types.JID, args []string) error {
+	inviteCode := ""
+	if n := len(args); n > 0 && strings.HasPrefix(strings.ToLower(args[n-1]), signupInvitePrefix) {
+		inviteCode = strings.TrimSpace(args[n-1][len(signupInvitePrefix):])
+		args = args[:n-1]
+	}
 	if len(args) < 3 {
-		return b.replyText(ctx, chat, "Usage: `!signup <username> <email> <password>`")
+		return b.replyText(ctx, chat, "Usage: `!signup <username> <email> <password> [invite:<code>]`")
 	}
 	username := args[0]
 	email := args[1]
 	password := strings.Join(args[2:], " ")
 
-	record, err := b.api.Signup(ctx, email, password, username)
+	record, err := b.api.Signup(ctx, email, password, username, inviteCode)
 	if err != nil {
 		return fmt.Errorf("signup failed: %v", trimAPIError(err))
 	}
@@ -71,7 +80,7 @@ func (b *Bot) handleSetup(ctx context.Context, chat, sender types.JID, args []st
 Stanks is a terminal-style economy simulation game. Here's how you play from WhatsApp:
 
 *1. Account Setup*
-- ` + "`!signup <user> <email> <password>`" + `
+- ` + "`!signup <user> <email> <password> [invite:<code>]`" + `
 - ` + "`!login <email> <password>`" + `
 
 *2. Looking Around*
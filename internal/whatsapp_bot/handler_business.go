@@ -227,7 +227,7 @@ func (b *Bot) handleLoans(ctx context.Context, chat, sender types.JID, args []st
 		}
 		return b.replyText(ctx, chat, "Successfully taken loan.")
 	} else if action == "repay" {
-		_, errResp := b.api.RepayBusinessLoan(ctx, token, id, amt, "")
+		_, errResp := b.api.RepayBusinessLoan(ctx, token, id, amt, 0, "")
 		if errResp != nil {
 			return b.replyText(ctx, chat, "Error: "+trimAPIError(errResp))
 		}
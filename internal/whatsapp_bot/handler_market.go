@@ -196,7 +196,14 @@ func (b *Bot) handleFundOrder(ctx context.Context, chat, sender types.JID, args
 	}
 	side := strings.ToLower(args[0])
 	code := strings.ToUpper(args[1])
-	units, _ := strconv.ParseInt(args[2], 10, 64)
+	amount, err := strconv.ParseFloat(args[2], 64)
+	if err != nil {
+		return b.replyText(ctx, chat, "Units must be a number, e.g. 2.5")
+	}
+	units, err := game.FundAmountToUnits(amount)
+	if err != nil {
+		return b.replyText(ctx, chat, "Error: "+err.Error())
+	}
 
 	var errResp error
 	if side == "buy" {
@@ -210,7 +217,7 @@ func (b *Bot) handleFundOrder(ctx context.Context, chat, sender types.JID, args
 	if errResp != nil {
 		return b.replyText(ctx, chat, "Error: "+trimAPIError(errResp))
 	}
-	return b.replyText(ctx, chat, fmt.Sprintf("Successfully processed %s order for %d units of fund %s.", side, units, code))
+	return b.replyText(ctx, chat, fmt.Sprintf("Successfully processed %s order for %.4f units of fund %s.", side, game.UnitsToShares(units), code))
 }
 
 func (b *Bot) handleRush(ctx context.Context, chat, sender types.JID, args []string) error {
@@ -117,7 +117,7 @@ func (b *Bot) handleFriends(ctx context.Context, chat, sender types.JID, args []
 	if action == "add" {
 		_, errResp = b.api.AddFriend(ctx, token, inviteCode, "")
 	} else if action == "remove" {
-		_, errResp = b.api.RemoveFriend(ctx, token, inviteCode)
+		_, errResp = b.api.RemoveFriend(ctx, token, inviteCode, "")
 	} else {
 		return b.replyText(ctx, chat, "Unknown action. Use add or remove.")
 	}
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS game.season_settings (
+    season_id BIGINT PRIMARY KEY REFERENCES game.seasons(id) ON DELETE CASCADE,
+    viral_base_chance DOUBLE PRECISION NOT NULL DEFAULT 0.020
+        CHECK (viral_base_chance BETWEEN 0 AND 1),
+    crisis_base_chance DOUBLE PRECISION NOT NULL DEFAULT 0.018
+        CHECK (crisis_base_chance BETWEEN 0 AND 1),
+    viral_bonus_min DOUBLE PRECISION NOT NULL DEFAULT 0.08
+        CHECK (viral_bonus_min >= 0),
+    viral_bonus_max DOUBLE PRECISION NOT NULL DEFAULT 0.23
+        CHECK (viral_bonus_max >= viral_bonus_min),
+    crisis_hit_min DOUBLE PRECISION NOT NULL DEFAULT 0.10
+        CHECK (crisis_hit_min BETWEEN 0 AND 1),
+    crisis_hit_max DOUBLE PRECISION NOT NULL DEFAULT 0.30
+        CHECK (crisis_hit_max BETWEEN crisis_hit_min AND 1),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,3 @@
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS collateral_holdings_bps INT NOT NULL DEFAULT 10000
+    CHECK (collateral_holdings_bps BETWEEN 0 AND 10000);
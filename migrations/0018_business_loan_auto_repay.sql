@@ -0,0 +1,4 @@
+ALTER TABLE game.businesses
+ADD COLUMN IF NOT EXISTS loan_auto_repay BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN IF NOT EXISTS loan_auto_repay_buffer_micros BIGINT NOT NULL DEFAULT 0
+    CHECK (loan_auto_repay_buffer_micros >= 0);
@@ -0,0 +1,5 @@
+ALTER TABLE game.stocks
+    ALTER COLUMN symbol TYPE VARCHAR(10) USING RTRIM(symbol);
+
+ALTER TABLE game.businesses
+    ALTER COLUMN stock_symbol TYPE VARCHAR(10) USING RTRIM(stock_symbol);
@@ -0,0 +1,20 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1
+        FROM information_schema.columns
+        WHERE table_schema = 'game'
+          AND table_name = 'stocks'
+          AND column_name = 'volatility_bps'
+    ) THEN
+        ALTER TABLE game.stocks
+        ADD COLUMN volatility_bps INT NOT NULL DEFAULT 10000
+            CHECK (volatility_bps BETWEEN 2500 AND 30000);
+
+        -- One-time backfill so seeded symbols in existing seasons get their tiers.
+        UPDATE game.stocks SET volatility_bps = 6000
+        WHERE symbol IN ('NEBULA', 'FUSION', 'LUMINA', 'ZENITH');
+        UPDATE game.stocks SET volatility_bps = 16000
+        WHERE symbol IN ('VECTRA', 'ORBITZ', 'QUARKX', 'ARCANE');
+    END IF;
+END $$;
@@ -0,0 +1,3 @@
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS max_machinery_levels INT NOT NULL DEFAULT 0
+    CHECK (max_machinery_levels >= 0);
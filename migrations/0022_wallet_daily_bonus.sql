@@ -0,0 +1,2 @@
+ALTER TABLE game.wallets
+ADD COLUMN IF NOT EXISTS last_daily_bonus_at TIMESTAMPTZ;
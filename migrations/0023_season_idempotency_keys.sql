@@ -0,0 +1,23 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1
+        FROM information_schema.columns
+        WHERE table_schema = 'game'
+          AND table_name = 'idempotency_keys'
+          AND column_name = 'season_id'
+    ) THEN
+        -- Keys claimed before this migration are parked in season 0.
+        ALTER TABLE game.idempotency_keys
+        ADD COLUMN season_id BIGINT NOT NULL DEFAULT 0;
+
+        ALTER TABLE game.idempotency_keys
+        DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+
+        ALTER TABLE game.idempotency_keys
+        ADD PRIMARY KEY (user_id, season_id, key);
+
+        ALTER TABLE game.idempotency_keys
+        ALTER COLUMN season_id DROP DEFAULT;
+    END IF;
+END $$;
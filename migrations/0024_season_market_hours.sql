@@ -0,0 +1,8 @@
+-- Optional trading hours per season. Empty open/close times keep the
+-- market open around the clock; close < open spans midnight.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS market_open_time TEXT NOT NULL DEFAULT ''
+    CHECK (market_open_time = '' OR market_open_time ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$'),
+ADD COLUMN IF NOT EXISTS market_close_time TEXT NOT NULL DEFAULT ''
+    CHECK (market_close_time = '' OR market_close_time ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$'),
+ADD COLUMN IF NOT EXISTS market_timezone TEXT NOT NULL DEFAULT 'UTC';
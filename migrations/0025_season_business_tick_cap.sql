@@ -0,0 +1,7 @@
+-- Optional per-tick ceiling on a business's positive net. Either limit can be
+-- used alone; when both are set the tighter one wins. Zero disables a limit.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS max_business_tick_net_micros BIGINT NOT NULL DEFAULT 0
+    CHECK (max_business_tick_net_micros >= 0),
+ADD COLUMN IF NOT EXISTS max_business_tick_net_base_multiple DOUBLE PRECISION NOT NULL DEFAULT 0
+    CHECK (max_business_tick_net_base_multiple >= 0);
@@ -0,0 +1,8 @@
+-- Optional bounds on business reserve yield. Only reserve up to
+-- reserve_yield_base_multiple x base revenue earns yield, and a single tick
+-- pays at most max_reserve_yield_micros. Zero disables a bound.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS reserve_yield_base_multiple DOUBLE PRECISION NOT NULL DEFAULT 0
+    CHECK (reserve_yield_base_multiple >= 0),
+ADD COLUMN IF NOT EXISTS max_reserve_yield_micros BIGINT NOT NULL DEFAULT 0
+    CHECK (max_reserve_yield_micros >= 0);
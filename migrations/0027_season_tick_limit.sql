@@ -0,0 +1,7 @@
+ALTER TABLE game.seasons
+ADD COLUMN IF NOT EXISTS tick_count BIGINT NOT NULL DEFAULT 0;
+
+-- Zero keeps the season on its wall-clock schedule.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS end_after_ticks BIGINT NOT NULL DEFAULT 0
+    CHECK (end_after_ticks >= 0);
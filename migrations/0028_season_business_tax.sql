@@ -0,0 +1,7 @@
+-- Optional progressive tax on a player's per-tick business income: the part
+-- above business_tax_threshold_micros is taxed at business_tax_bps.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS business_tax_threshold_micros BIGINT NOT NULL DEFAULT 0
+    CHECK (business_tax_threshold_micros >= 0),
+ADD COLUMN IF NOT EXISTS business_tax_bps INT NOT NULL DEFAULT 0
+    CHECK (business_tax_bps BETWEEN 0 AND 10000);
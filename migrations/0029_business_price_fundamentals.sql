@@ -0,0 +1,10 @@
+-- Business-linked stocks can track their company's results: the revenue tick
+-- records the last two per-tick nets, and business_price_weight blends the
+-- change between them into the stock's anchor drift.
+ALTER TABLE game.businesses
+ADD COLUMN IF NOT EXISTS last_tick_net_micros BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS prev_tick_net_micros BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS business_price_weight DOUBLE PRECISION NOT NULL DEFAULT 0
+    CHECK (business_price_weight BETWEEN 0 AND 1);
@@ -0,0 +1,4 @@
+-- Records which existing player's invite code was used at signup. Required
+-- for new accounts when the API runs with STANKS_INVITE_ONLY.
+ALTER TABLE users.profiles
+ADD COLUMN IF NOT EXISTS invited_by_user_id TEXT REFERENCES users.profiles(user_id) ON DELETE SET NULL;
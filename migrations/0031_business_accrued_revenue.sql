@@ -0,0 +1,8 @@
+-- Opt-in pull model for business income: in 'accrue' mode positive net per
+-- tick piles up in unclaimed_revenue_micros (exposed to crises) until the
+-- owner claims it.
+ALTER TABLE game.businesses
+ADD COLUMN IF NOT EXISTS revenue_mode TEXT NOT NULL DEFAULT 'push'
+    CHECK (revenue_mode IN ('push', 'accrue')),
+ADD COLUMN IF NOT EXISTS unclaimed_revenue_micros BIGINT NOT NULL DEFAULT 0
+    CHECK (unclaimed_revenue_micros >= 0);
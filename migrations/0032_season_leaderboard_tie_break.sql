@@ -0,0 +1,6 @@
+-- Players with equal net worth are ordered by leaderboard_tie_break (more
+-- businesses, or more business net on the last revenue tick) and finally by
+-- user_id, so ranks stay stable between ticks.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS leaderboard_tie_break TEXT NOT NULL DEFAULT 'business_count'
+    CHECK (leaderboard_tie_break IN ('business_count', 'business_revenue', 'user_id'));
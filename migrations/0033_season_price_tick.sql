@@ -0,0 +1,6 @@
+-- Minimum price increment for market ticks: current_price_micros is rounded
+-- to a multiple of price_tick_micros (10000 = 0.01 stonky). The default of 1
+-- keeps full micro precision.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS price_tick_micros BIGINT NOT NULL DEFAULT 1
+    CHECK (price_tick_micros > 0);
@@ -0,0 +1,7 @@
+-- Optional decay of peak net worth: each market tick moves a wallet's
+-- peak_net_worth_micros peak_decay_bps of the way down toward its current net
+-- worth, so the peak-based debt limit tracks recent standing. 0 keeps the
+-- all-time peak.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS peak_decay_bps INT NOT NULL DEFAULT 0
+    CHECK (peak_decay_bps BETWEEN 0 AND 10000);
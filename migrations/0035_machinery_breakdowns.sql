@@ -0,0 +1,6 @@
+-- Machines roll against reliability_bps every revenue tick. A failed machine
+-- produces no output that tick; failure_streak counts consecutive failures,
+-- and repeat failures are charged a repair cost.
+ALTER TABLE game.business_machinery
+ADD COLUMN IF NOT EXISTS failure_streak INT NOT NULL DEFAULT 0
+    CHECK (failure_streak >= 0);
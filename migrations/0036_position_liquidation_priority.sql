@@ -0,0 +1,6 @@
+-- Player-set order for forced sales: positions with a higher
+-- liquidation_priority are sold first, negative values protect a holding,
+-- and ties fall back to the largest market value first.
+ALTER TABLE game.positions
+ADD COLUMN IF NOT EXISTS liquidation_priority INT NOT NULL DEFAULT 0
+    CHECK (liquidation_priority BETWEEN -100 AND 100);
@@ -0,0 +1,4 @@
+-- Optional per-season rule that business names must be unique (ignoring
+-- case) among the season's businesses. Off by default.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS unique_business_names BOOLEAN NOT NULL DEFAULT false;
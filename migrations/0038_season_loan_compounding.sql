@@ -0,0 +1,7 @@
+-- Business-loan interest now accrues in one place per tick (capitalized into
+-- outstanding_micros). loan_compound_every_ticks compounds it once every N
+-- market ticks, charging interest_bps for each tick in the period. 1 keeps
+-- per-tick compounding.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS loan_compound_every_ticks INT NOT NULL DEFAULT 1
+    CHECK (loan_compound_every_ticks >= 1);
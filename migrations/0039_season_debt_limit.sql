@@ -0,0 +1,10 @@
+-- Per-season shape of the peak-based debt limit:
+-- clamp(debt_limit_min_micros, debt_limit_max_micros, peak * debt_limit_peak_bps / 10000).
+-- Defaults match the built-in 5,000 / 100,000 stonky bounds and 35% ratio.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS debt_limit_min_micros BIGINT NOT NULL DEFAULT 5000000000
+    CHECK (debt_limit_min_micros >= 0),
+ADD COLUMN IF NOT EXISTS debt_limit_max_micros BIGINT NOT NULL DEFAULT 100000000000
+    CHECK (debt_limit_max_micros >= 0),
+ADD COLUMN IF NOT EXISTS debt_limit_peak_bps INT NOT NULL DEFAULT 3500
+    CHECK (debt_limit_peak_bps BETWEEN 0 AND 10000);
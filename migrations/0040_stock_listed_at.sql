@@ -0,0 +1,10 @@
+-- When a stock went public, at what season tick, and at what price, so new
+-- issues can be listed. Seed stocks never IPO and keep NULLs.
+ALTER TABLE game.stocks
+ADD COLUMN IF NOT EXISTS listed_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS listed_tick BIGINT,
+ADD COLUMN IF NOT EXISTS ipo_price_micros BIGINT;
+
+CREATE INDEX IF NOT EXISTS idx_stocks_season_listed_tick
+    ON game.stocks (season_id, listed_tick)
+    WHERE listed_tick IS NOT NULL;
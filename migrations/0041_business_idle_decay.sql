@@ -0,0 +1,9 @@
+-- Consecutive revenue ticks a business has had no employees and no
+-- machinery, reset by investing (hiring, machinery, or an upgrade), and the
+-- optional per-season base-revenue decay applied while idle.
+ALTER TABLE game.businesses
+ADD COLUMN IF NOT EXISTS idle_ticks INT NOT NULL DEFAULT 0;
+
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS idle_revenue_decay_bps INT NOT NULL DEFAULT 0
+    CHECK (idle_revenue_decay_bps BETWEEN 0 AND 10000);
@@ -0,0 +1,6 @@
+-- Optional per-season floor on the fee charged for each stock or fund order,
+-- so splitting trades into tiny pieces cannot round the proportional fee to
+-- zero. 0 keeps purely proportional fees.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS min_trade_fee_micros BIGINT NOT NULL DEFAULT 0
+    CHECK (min_trade_fee_micros >= 0);
@@ -0,0 +1,6 @@
+-- When a business defaults on its loans its stock is delisted and outside
+-- shareholders are cashed out. default_payout_bps is the share of the last
+-- traded price they receive (10000 = full price, 0 = wiped out).
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS default_payout_bps INTEGER NOT NULL DEFAULT 10000
+    CHECK (default_payout_bps BETWEEN 0 AND 10000);
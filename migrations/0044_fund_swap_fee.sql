@@ -0,0 +1,5 @@
+-- Fee charged once on a fund-to-fund swap (POST /v1/funds/swap), in bps of
+-- the sold leg. 0 makes rebalancing between funds free.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS fund_swap_fee_bps INTEGER NOT NULL DEFAULT 0
+    CHECK (fund_swap_fee_bps BETWEEN 0 AND 10000);
@@ -0,0 +1,10 @@
+-- Anti-snipe for tick-limited seasons: when the leaderboard leader changes
+-- on the deciding tick, the season runs one more tick, at most
+-- anti_snipe_max_extensions times. 0 closes on schedule.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS anti_snipe_max_extensions INTEGER NOT NULL DEFAULT 0
+    CHECK (anti_snipe_max_extensions >= 0);
+
+ALTER TABLE game.seasons
+ADD COLUMN IF NOT EXISTS anti_snipe_extensions INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS last_leader_user_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,25 @@
+-- Automated per-tick operations (loan auto-repay today) that cannot run for
+-- lack of funds record one row per unbroken streak of skipped ticks instead
+-- of failing the tick or disappearing silently. The next successful run
+-- resolves the streak.
+CREATE TABLE IF NOT EXISTS game.automation_skips (
+    id BIGSERIAL PRIMARY KEY,
+    season_id BIGINT NOT NULL REFERENCES game.seasons(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    operation TEXT NOT NULL,
+    ref_id BIGINT NOT NULL DEFAULT 0,
+    reason TEXT NOT NULL DEFAULT 'skipped_insufficient_funds',
+    needed_micros BIGINT NOT NULL DEFAULT 0,
+    available_micros BIGINT NOT NULL DEFAULT 0,
+    skipped_ticks INTEGER NOT NULL DEFAULT 1,
+    first_skipped_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_skipped_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS automation_skips_open_idx
+    ON game.automation_skips (season_id, user_id, operation, ref_id)
+    WHERE resolved_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS automation_skips_user_idx
+    ON game.automation_skips (season_id, user_id, last_skipped_at DESC);
@@ -0,0 +1,14 @@
+-- One row per market regime switch, so players can line their P/L up with
+-- bull, bear, and neutral stretches. tick is the season tick the new regime
+-- took effect on.
+CREATE TABLE IF NOT EXISTS game.regime_history (
+    id BIGSERIAL PRIMARY KEY,
+    season_id BIGINT NOT NULL REFERENCES game.seasons(id) ON DELETE CASCADE,
+    regime TEXT NOT NULL,
+    previous_regime TEXT NOT NULL,
+    tick BIGINT NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS regime_history_season_idx
+    ON game.regime_history (season_id, started_at);
@@ -0,0 +1,10 @@
+-- Optional fixed share supply per stock. shares_outstanding_units is in
+-- share units (0 = unlimited); the season default is in whole shares and is
+-- applied when stocks are seeded or listed.
+ALTER TABLE game.stocks
+ADD COLUMN IF NOT EXISTS shares_outstanding_units BIGINT NOT NULL DEFAULT 0
+    CHECK (shares_outstanding_units >= 0);
+
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS shares_outstanding BIGINT NOT NULL DEFAULT 0
+    CHECK (shares_outstanding >= 0);
@@ -0,0 +1,5 @@
+-- Optional hard floor on negative wallet balances for debt interest and
+-- business losses, as a positive amount (0 = no floor).
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS max_negative_balance_micros BIGINT NOT NULL DEFAULT 0
+    CHECK (max_negative_balance_micros >= 0);
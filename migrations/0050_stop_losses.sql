@@ -0,0 +1,14 @@
+-- Stop-loss orders: once a stock's price falls below trigger_price_micros,
+-- the next market tick sells quantity_units of the position at market
+-- (the whole position when 0) and removes the stop.
+CREATE TABLE IF NOT EXISTS game.stop_losses (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    season_id BIGINT NOT NULL REFERENCES game.seasons(id) ON DELETE CASCADE,
+    stock_id BIGINT NOT NULL REFERENCES game.stocks(id) ON DELETE CASCADE,
+    trigger_price_micros BIGINT NOT NULL CHECK (trigger_price_micros > 0),
+    quantity_units BIGINT NOT NULL DEFAULT 0 CHECK (quantity_units >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (user_id, season_id, stock_id)
+);
@@ -0,0 +1,14 @@
+-- Realized profit/loss from stock sells: sale proceeds less the prorated
+-- cost basis (avg_price_micros at the time of the sell) and the trade fee,
+-- accumulated per user, season, and stock. Short covers add theirs too.
+CREATE TABLE IF NOT EXISTS game.realized_pnl (
+    user_id TEXT NOT NULL,
+    season_id BIGINT NOT NULL REFERENCES game.seasons(id) ON DELETE CASCADE,
+    stock_id BIGINT NOT NULL REFERENCES game.stocks(id) ON DELETE CASCADE,
+    realized_micros BIGINT NOT NULL DEFAULT 0,
+    sold_units BIGINT NOT NULL DEFAULT 0 CHECK (sold_units >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, season_id, stock_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_realized_pnl_user ON game.realized_pnl (user_id);
@@ -0,0 +1,23 @@
+-- Dividends. Stocks backed by a business pay dividend_bps of the business's
+-- net revenue to holders every tick; other stocks pay dividend_bps of their
+-- price every 24 ticks. Positions keep a running total of what they collected.
+ALTER TABLE game.stocks
+    ADD COLUMN IF NOT EXISTS dividend_bps INT NOT NULL DEFAULT 0 CHECK (dividend_bps >= 0 AND dividend_bps <= 10000);
+
+ALTER TABLE game.positions
+    ADD COLUMN IF NOT EXISTS dividends_micros BIGINT NOT NULL DEFAULT 0;
+
+UPDATE game.stocks
+SET dividend_bps = 1000
+WHERE business_id IS NOT NULL AND dividend_bps = 0;
+
+-- Mirrors seedDividendBps in internal/game/dividends.go, with the tier
+-- boundaries from VolatilityTierName (volatilityCalmBelowBps and
+-- volatilityWildAboveBps). Keep the three in sync.
+UPDATE game.stocks
+SET dividend_bps = CASE
+        WHEN volatility_bps < 8000 THEN 40
+        WHEN volatility_bps <= 13000 THEN 20
+        ELSE 0
+    END
+WHERE business_id IS NULL AND created_by_user_id IS NULL AND dividend_bps = 0;
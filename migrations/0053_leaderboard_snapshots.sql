@@ -0,0 +1,14 @@
+-- Every market tick records each player's leaderboard rank and net worth so
+-- rank-over-time can be charted. Rows go with their season.
+CREATE TABLE IF NOT EXISTS game.leaderboard_snapshots (
+    season_id BIGINT NOT NULL REFERENCES game.seasons(id) ON DELETE CASCADE,
+    tick BIGINT NOT NULL,
+    user_id TEXT NOT NULL,
+    rank BIGINT NOT NULL CHECK (rank > 0),
+    players BIGINT NOT NULL CHECK (players > 0),
+    net_worth_micros BIGINT NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (season_id, tick, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_snapshots_user ON game.leaderboard_snapshots (season_id, user_id, tick DESC);
@@ -0,0 +1,14 @@
+-- Short selling: a position's quantity goes negative while it is short, and
+-- avg_price_micros holds the average entry price of the short. Orders that
+-- open or cover a short are flagged so order history can replay them.
+ALTER TABLE game.positions
+    DROP CONSTRAINT IF EXISTS positions_quantity_units_check;
+
+ALTER TABLE game.positions
+    DROP CONSTRAINT IF EXISTS positions_quantity_units_nonzero;
+
+ALTER TABLE game.positions
+    ADD CONSTRAINT positions_quantity_units_nonzero CHECK (quantity_units <> 0);
+
+ALTER TABLE game.orders
+    ADD COLUMN IF NOT EXISTS short BOOLEAN NOT NULL DEFAULT false;
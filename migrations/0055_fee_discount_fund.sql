@@ -0,0 +1,10 @@
+-- Optional trading-fee discount for holders of a designated "membership"
+-- fund: fee_discount_bps off stock order fees for players holding at least
+-- fee_discount_min_units whole units of fee_discount_fund_code. Disabled
+-- while the code is empty or the bps is 0.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS fee_discount_fund_code TEXT NOT NULL DEFAULT '',
+ADD COLUMN IF NOT EXISTS fee_discount_bps INT NOT NULL DEFAULT 0
+    CHECK (fee_discount_bps BETWEEN 0 AND 10000),
+ADD COLUMN IF NOT EXISTS fee_discount_min_units BIGINT NOT NULL DEFAULT 0
+    CHECK (fee_discount_min_units >= 0);
@@ -0,0 +1,10 @@
+-- Personal stock watchlists. added_price_micros is the price when the symbol
+-- was added, so the list can show the move since.
+CREATE TABLE IF NOT EXISTS game.watchlists (
+    user_id TEXT NOT NULL,
+    season_id BIGINT NOT NULL REFERENCES game.seasons(id) ON DELETE CASCADE,
+    stock_id BIGINT NOT NULL REFERENCES game.stocks(id) ON DELETE CASCADE,
+    added_price_micros BIGINT NOT NULL CHECK (added_price_micros > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, season_id, stock_id)
+);
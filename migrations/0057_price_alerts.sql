@@ -0,0 +1,23 @@
+-- Price alerts. A market tick that moves a stock across target_price_micros
+-- in the alert's direction sets triggered_at; the player's client shows
+-- triggered alerts on its next sync and then acknowledges them.
+CREATE TABLE IF NOT EXISTS game.price_alerts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    season_id BIGINT NOT NULL REFERENCES game.seasons(id) ON DELETE CASCADE,
+    stock_id BIGINT NOT NULL REFERENCES game.stocks(id) ON DELETE CASCADE,
+    direction TEXT NOT NULL CHECK (direction IN ('above', 'below')),
+    target_price_micros BIGINT NOT NULL CHECK (target_price_micros > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    triggered_at TIMESTAMPTZ,
+    triggered_price_micros BIGINT,
+    acknowledged_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS price_alerts_pending_idx
+    ON game.price_alerts (season_id, stock_id)
+    WHERE triggered_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS price_alerts_unacked_idx
+    ON game.price_alerts (user_id, season_id)
+    WHERE triggered_at IS NOT NULL AND acknowledged_at IS NULL;
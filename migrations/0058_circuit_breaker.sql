@@ -0,0 +1,16 @@
+-- Market-wide circuit breaker. When the equal-weighted index of listed
+-- stocks falls by at least circuit_breaker_drop_bps in one tick, stock
+-- orders are rejected for the next circuit_breaker_halt_ticks ticks.
+-- Disabled while either setting is 0.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS circuit_breaker_drop_bps INT NOT NULL DEFAULT 0
+    CHECK (circuit_breaker_drop_bps BETWEEN 0 AND 10000),
+ADD COLUMN IF NOT EXISTS circuit_breaker_halt_ticks INT NOT NULL DEFAULT 0
+    CHECK (circuit_breaker_halt_ticks >= 0);
+
+-- halt_ticks_remaining counts down once per market tick; index_change_bps
+-- is the index move of the last tick.
+ALTER TABLE game.market_state
+ADD COLUMN IF NOT EXISTS halt_ticks_remaining INT NOT NULL DEFAULT 0
+    CHECK (halt_ticks_remaining >= 0),
+ADD COLUMN IF NOT EXISTS index_change_bps BIGINT NOT NULL DEFAULT 0;
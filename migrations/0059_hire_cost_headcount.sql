@@ -0,0 +1,6 @@
+-- Hire cost surcharge that grows with headcount. Each hire costs an extra
+-- hire_cost_headcount_bps of the candidate's cost for every employee the
+-- business already has, on top of the built-in hire cost curve. 0 = off.
+ALTER TABLE game.season_settings
+ADD COLUMN IF NOT EXISTS hire_cost_headcount_bps INT NOT NULL DEFAULT 0
+    CHECK (hire_cost_headcount_bps >= 0);
@@ -0,0 +1,13 @@
+-- Season news feed. Market ticks and IPOs write a one-line summary of each
+-- notable event: regime switches, large stock moves, new listings, business
+-- breakouts and crises, loan defaults, and leaderboard lead changes.
+CREATE TABLE IF NOT EXISTS game.news (
+    id BIGSERIAL PRIMARY KEY,
+    season_id BIGINT NOT NULL REFERENCES game.seasons(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    summary TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS news_season_created_idx
+    ON game.news (season_id, created_at DESC, id DESC);
@@ -0,0 +1,6 @@
+ALTER TABLE auth.users
+    ADD COLUMN IF NOT EXISTS refresh_token TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_auth_users_refresh_token
+ON auth.users (refresh_token)
+WHERE refresh_token IS NOT NULL;
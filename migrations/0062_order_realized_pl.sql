@@ -0,0 +1,6 @@
+-- Realized P/L is also stored on the closing order itself (sells and short
+-- covers), so order history and the dashboard's season figure agree.
+-- Orders placed before this migration keep a NULL and count as zero; the
+-- lifetime figure keeps coming from game.realized_pnl, which predates it.
+ALTER TABLE game.orders
+    ADD COLUMN IF NOT EXISTS realized_pl_micros BIGINT;